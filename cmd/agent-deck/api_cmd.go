@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/asheshgoplani/agent-deck/internal/api"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleAPI runs the local control API in the foreground until interrupted.
+func handleAPI(profile string, args []string) {
+	fs := flag.NewFlagSet("api", flag.ExitOnError)
+	socketFlag := fs.String("socket", "", "Unix socket path (overrides [api].socket_path)")
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck api [--socket path]")
+		fmt.Println()
+		fmt.Println("Serve the local control API on a unix socket (owner-only, mode 0600).")
+		fmt.Println("Requires [api] enabled = true in config.toml.")
+		fmt.Println()
+		fmt.Println("Endpoints:")
+		fmt.Println("  GET    /v1/sessions              List sessions (same shape as list --json)")
+		fmt.Println("  POST   /v1/sessions              Create and start a session")
+		fmt.Println("                                   {\"title\", \"path\", \"group\", \"tool\", \"model_id\"}")
+		fmt.Println("  DELETE /v1/sessions/{id|title}   Stop and remove a session")
+		fmt.Println("  GET    /v1/sessions/{id|title}/attach  tmux target and attach argv")
+		fmt.Println()
+		fmt.Println("Example:")
+		fmt.Println("  curl --unix-socket ~/.agent-deck/api-default.sock http://agent-deck/v1/sessions")
+	}
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	cfg, err := session.LoadUserConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	if !cfg.API.Enabled {
+		fmt.Fprintln(os.Stderr, "Error: the control API is disabled; set [api] enabled = true in config.toml")
+		os.Exit(1)
+	}
+
+	socketPath := session.ExpandPath(*socketFlag)
+	if socketPath == "" {
+		if socketPath, err = cfg.API.GetSocketPath(profile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	listener, err := api.ListenUnix(socketPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer os.Remove(socketPath)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	session.StartSessionEventWebhook(ctx, cfg.Events)
+
+	fmt.Printf("agent-deck API listening on %s\n", socketPath)
+	server := api.NewServer(api.NewStorageBackend(profile))
+	if err := server.Serve(ctx, listener); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -349,20 +348,11 @@ func ResolveSession(identifier string, instances []*session.Instance) (*session.
 // GetCurrentSessionID detects the current agent-deck session from tmux environment
 // Returns session ID or empty string if not in an agent-deck session
 func GetCurrentSessionID() string {
-	// Check if we're in tmux
-	if os.Getenv("TMUX") == "" {
-		return ""
-	}
-
-	// Get current tmux session name
-	cmd := exec.Command("tmux", "display-message", "-p", "#S")
-	output, err := cmd.Output()
+	sessionName, err := session.CurrentTmuxSession()
 	if err != nil {
 		return ""
 	}
 
-	sessionName := strings.TrimSpace(string(output))
-
 	// Parse agent-deck session name: agentdeck_<title>_<id>
 	if !strings.HasPrefix(sessionName, "agentdeck_") {
 		return ""
@@ -412,22 +402,7 @@ func StatusSymbol(status session.Status) string {
 
 // StatusString returns the string representation of a status
 func StatusString(status session.Status) string {
-	switch status {
-	case session.StatusRunning:
-		return "running"
-	case session.StatusWaiting:
-		return "waiting"
-	case session.StatusIdle:
-		return "idle"
-	case session.StatusError:
-		return "error"
-	case session.StatusStopped:
-		return "stopped"
-	case session.StatusQueued:
-		return "queued"
-	default:
-		return "unknown"
-	}
+	return session.StatusLabel(status)
 }
 
 // SubstateLabel returns a short human label for an additive Honest-Status-v2
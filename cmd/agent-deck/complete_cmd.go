@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// completionData is the payload of `agent-deck __complete --json`.
+type completionData struct {
+	Sessions []string `json:"sessions"`
+	Groups   []string `json:"groups"`
+}
+
+// collectCompletionData returns sorted, de-duplicated session titles and group
+// paths. Groups include paths only referenced by sessions so completion still
+// offers them when the group row is missing.
+func collectCompletionData(instances []*session.InstanceData, groups []*session.GroupData) completionData {
+	titles := make(map[string]bool, len(instances))
+	paths := make(map[string]bool, len(groups))
+	for _, inst := range instances {
+		if inst.Title != "" {
+			titles[inst.Title] = true
+		}
+		if inst.GroupPath != "" {
+			paths[inst.GroupPath] = true
+		}
+	}
+	for _, g := range groups {
+		if g.Path != "" {
+			paths[g.Path] = true
+		}
+	}
+	return completionData{Sessions: sortedKeys(titles), Groups: sortedKeys(paths)}
+}
+
+// writeCompletionData prints the requested kind ("sessions", "groups" or
+// "all") one entry per line. JSON mode always emits both lists.
+func writeCompletionData(w io.Writer, data completionData, kind string, jsonMode bool) error {
+	if jsonMode {
+		return json.NewEncoder(w).Encode(data)
+	}
+
+	var lines []string
+	switch kind {
+	case "sessions":
+		lines = data.Sessions
+	case "groups":
+		lines = data.Groups
+	default:
+		lines = append(append(lines, data.Sessions...), data.Groups...)
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleComplete implements the hidden `__complete` subcommand used by shell
+// completion scripts. It reads session metadata via LoadLite (no tmux calls)
+// so it stays fast enough to run on every tab press, and never prints errors:
+// a failed lookup simply yields no candidates.
+func handleComplete(profile string, args []string) {
+	fs := flag.NewFlagSet("__complete", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	kind := "all"
+	if fs.NArg() > 0 {
+		kind = fs.Arg(0)
+	}
+	switch kind {
+	case "sessions", "groups", "all":
+	default:
+		os.Exit(1)
+	}
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		return
+	}
+	defer storage.Close()
+	instances, groups, err := storage.LoadLite()
+	if err != nil {
+		return
+	}
+
+	_ = writeCompletionData(os.Stdout, collectCompletionData(instances, groups), kind, *jsonOutput)
+}
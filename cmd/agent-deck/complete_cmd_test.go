@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestCollectCompletionData_SortedAndDeduped(t *testing.T) {
+	instances := []*session.InstanceData{
+		{Title: "web", GroupPath: "work/frontend"},
+		{Title: "api", GroupPath: "work"},
+		{Title: "api", GroupPath: "work"},
+		{Title: "", GroupPath: ""},
+	}
+	groups := []*session.GroupData{{Path: "work"}, {Path: "personal"}}
+
+	data := collectCompletionData(instances, groups)
+
+	if got, want := data.Sessions, []string{"api", "web"}; !equalStrings(got, want) {
+		t.Errorf("Sessions = %v, want %v", got, want)
+	}
+	if got, want := data.Groups, []string{"personal", "work", "work/frontend"}; !equalStrings(got, want) {
+		t.Errorf("Groups = %v, want %v", got, want)
+	}
+}
+
+func TestWriteCompletionData(t *testing.T) {
+	data := completionData{Sessions: []string{"api", "my session"}, Groups: []string{"work"}}
+
+	cases := map[string]string{
+		"sessions": "api\nmy session\n",
+		"groups":   "work\n",
+		"all":      "api\nmy session\nwork\n",
+	}
+	for kind, want := range cases {
+		var buf bytes.Buffer
+		if err := writeCompletionData(&buf, data, kind, false); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != want {
+			t.Errorf("%s: got %q, want %q", kind, buf.String(), want)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeCompletionData(&buf, data, "sessions", true); err != nil {
+		t.Fatal(err)
+	}
+	var decoded completionData
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if !equalStrings(decoded.Sessions, data.Sessions) || !equalStrings(decoded.Groups, data.Groups) {
+		t.Errorf("JSON round-trip = %+v, want %+v", decoded, data)
+	}
+}
+
+func TestCompleteIsGlobalFlagSubcommand(t *testing.T) {
+	profile, rest := extractProfileFlag([]string{"-p", "work", "__complete", "sessions"})
+	if profile != "work" || len(rest) != 2 || rest[0] != "__complete" {
+		t.Fatalf("extractProfileFlag = %q, %v", profile, rest)
+	}
+}
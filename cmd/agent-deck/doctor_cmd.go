@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleDoctor validates config.toml and reports problems that would
+// otherwise make agent-deck silently misbehave (missing binaries, bad paths,
+// unknown tools). Exits 1 when any error-severity issue is found.
+func handleDoctor(args []string) {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "emit machine-readable JSON output")
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck doctor [--json]")
+		fmt.Println()
+		fmt.Println("Check config.toml for missing binaries, bad paths and unknown values.")
+	}
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, false)
+
+	cfg, err := session.LoadUserConfig()
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to load config: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	issues := cfg.Validate()
+	hasErrors := false
+	for _, issue := range issues {
+		if issue.Severity == session.ConfigIssueError {
+			hasErrors = true
+			break
+		}
+	}
+
+	configPath, _ := session.GetUserConfigPath()
+	var b strings.Builder
+	if len(issues) == 0 {
+		fmt.Fprintf(&b, "%s %s: no issues found\n", successSymbol, configPath)
+	} else {
+		fmt.Fprintf(&b, "%s: %d issue(s)\n", configPath, len(issues))
+		for _, issue := range issues {
+			symbol := bulletSymbol
+			if issue.Severity == session.ConfigIssueError {
+				symbol = errorSymbol
+			}
+			fmt.Fprintf(&b, "  %s %s\n", symbol, issue)
+		}
+	}
+	if issues == nil {
+		issues = []session.ConfigIssue{}
+	}
+	out.Print(b.String(), map[string]interface{}{
+		"config": configPath,
+		"ok":     !hasErrors,
+		"issues": issues,
+	})
+
+	if hasErrors {
+		os.Exit(1)
+	}
+}
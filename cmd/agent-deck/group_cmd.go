@@ -870,26 +870,10 @@ func handleGroupDelete(profile string, args []string) {
 		os.Exit(1)
 	}
 
-	// Determine where sessions will be moved
-	parentPath := getParentGroupPath(groupPath)
-	movedTo := parentPath
-	if movedTo == "" {
-		movedTo = session.DefaultGroupPath
-	}
-
-	// Delete the group (this also moves sessions to default group)
+	// Delete the group; its sessions move up to the parent (or default) group.
+	movedTo := session.DeleteGroupTarget(groupPath)
 	movedSessions := groupTree.DeleteGroup(groupPath)
 
-	// If we want to move to parent instead of default, do it manually
-	if parentPath != "" && len(movedSessions) > 0 {
-		// Move sessions from default to parent
-		for _, sess := range movedSessions {
-			sess.GroupPath = parentPath
-		}
-		// Re-sync the tree
-		groupTree.SyncWithInstances(groupTree.GetAllInstances())
-	}
-
 	// Save
 	if err := storage.SaveWithGroups(groupTree.GetAllInstances(), groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeNotFound)
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// importedSession is one row of `import --json` output.
+type importedSession struct {
+	ID          string `json:"id,omitempty"`
+	Title       string `json:"title"`
+	Tool        string `json:"tool"`
+	Group       string `json:"group,omitempty"`
+	Path        string `json:"path"`
+	TmuxSession string `json:"tmux_session"`
+}
+
+// handleImport adopts tmux sessions that are not in the profile's store —
+// the CLI counterpart of the TUI import key. Without a name every untracked
+// session is adopted: ones agent-deck created but lost track of (tmux names
+// with the agentdeck_ prefix) land in the "recovered" group, any other is
+// adopted ungrouped. With a name only that session is adopted, into --group
+// with --tool (guessed from the running command when omitted).
+func handleImport(profile string, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	dryRun := fs.Bool("dry-run", false, "List adoptable tmux sessions without importing them")
+	group := fs.String("group", "", "Group for the adopted session (with a tmux name)")
+	groupShort := fs.String("g", "", "Group for the adopted session (short)")
+	tool := fs.String("tool", "", "Tool of the adopted session (with a tmux name; default: guessed)")
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck import [tmux-session] [--group path] [--tool name] [--dry-run] [--json]")
+		fmt.Println()
+		fmt.Println("Adopt running tmux sessions that this profile does not manage yet.")
+		fmt.Println("Orphaned agent-deck sessions are placed in the 'recovered' group.")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck import --dry-run              # Show what would be adopted")
+		fmt.Println("  agent-deck -p work import                # Adopt into the 'work' profile")
+		fmt.Println("  agent-deck import scratch -g work/api    # Adopt one session into a group")
+	}
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	var discovered []*session.Instance
+	if name := fs.Arg(0); name != "" {
+		unmanaged, err := session.ListUnmanagedTmuxSessions(instances)
+		if err != nil {
+			out.Error(fmt.Sprintf("failed to list tmux sessions: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		if !slices.Contains(unmanaged, name) {
+			out.Error(fmt.Sprintf("no untracked tmux session named %q (see import --dry-run)", name), ErrCodeNotFound)
+			os.Exit(2)
+		}
+		groupPath := mergeFlags(*group, *groupShort)
+		inst, err := session.AdoptSession(name, groupPath, *tool)
+		if err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		discovered = []*session.Instance{inst}
+	} else {
+		if *group != "" || *groupShort != "" || *tool != "" {
+			out.Error("--group and --tool require a tmux session name", ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		discovered, err = session.DiscoverExistingTmuxSessions(instances)
+		if err != nil {
+			out.Error(fmt.Sprintf("failed to list tmux sessions: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	}
+
+	rows := make([]importedSession, 0, len(discovered))
+	for _, inst := range discovered {
+		row := importedSession{
+			Title: inst.Title,
+			Tool:  inst.Tool,
+			Group: inst.GroupPath,
+			Path:  inst.ProjectPath,
+		}
+		if ts := inst.GetTmuxSession(); ts != nil {
+			row.TmuxSession = ts.Name
+		}
+		if !*dryRun {
+			row.ID = inst.ID
+		}
+		rows = append(rows, row)
+	}
+
+	if len(discovered) > 0 && !*dryRun {
+		if err := saveSessionData(storage, append(instances, discovered...), groups); err != nil {
+			out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		session.ConfigureAdoptedSessions(discovered)
+	}
+
+	if *jsonOutput {
+		out.Print("", map[string]interface{}{
+			"success":  true,
+			"dry_run":  *dryRun,
+			"sessions": rows,
+		})
+		return
+	}
+	if len(rows) == 0 {
+		out.Success("No untracked tmux sessions found", nil)
+		return
+	}
+	var b strings.Builder
+	for _, row := range rows {
+		group := row.Group
+		if group == "" {
+			group = "-"
+		}
+		fmt.Fprintf(&b, "  %-24s %-8s %-10s %s\n", row.Title, row.Tool, group, row.TmuxSession)
+	}
+	out.Print(b.String(), nil)
+	if *dryRun {
+		out.Success(fmt.Sprintf("%d session(s) can be imported; run without --dry-run to adopt them", len(rows)), nil)
+	} else {
+		out.Success(fmt.Sprintf("Imported %d session(s) into profile '%s'", len(rows), storage.Profile()), nil)
+	}
+}
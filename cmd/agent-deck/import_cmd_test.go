@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupImportTmux points agent-deck at a private tmux server (via
+// [tmux].socket_path) that runs one unmanaged session named "scratch", so
+// import never sees or touches the developer's own sessions. A socket path
+// rather than a name keeps the server reachable from the CLI subprocess,
+// which runs without the test's isolated TMUX_TMPDIR.
+func setupImportTmux(t *testing.T, home string) (socket string) {
+	t.Helper()
+	socket = filepath.Join(t.TempDir(), "tmux.sock")
+	t.Cleanup(func() {
+		_ = exec.Command("tmux", "-S", socket, "kill-server").Run()
+	})
+
+	configDir := filepath.Join(home, ".config", "agent-deck")
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	config := fmt.Sprintf("[tmux]\nsocket_path = %q\n", socket)
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if out, err := exec.Command("tmux", "-S", socket, "new-session", "-d", "-s", "scratch", "-c", home).CombinedOutput(); err != nil {
+		t.Fatalf("tmux new-session: %v\n%s", err, out)
+	}
+	return socket
+}
+
+func tmuxMouseOption(t *testing.T, socket string) string {
+	t.Helper()
+	out, err := exec.Command("tmux", "-S", socket, "show-options", "-t", "scratch", "-v", "mouse").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestHandleImport_DryRunLeavesTmuxAndStoreUntouched(t *testing.T) {
+	if testing.Short() {
+		t.Skip("subprocess CLI test skipped in short mode")
+	}
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not available")
+	}
+	home := t.TempDir()
+	socket := setupImportTmux(t, home)
+
+	stdout, stderr, code := runAgentDeck(t, home, "import", "scratch", "--dry-run", "--json")
+	if code != 0 {
+		t.Fatalf("import --dry-run failed (exit %d)\nstdout: %s\nstderr: %s", code, stdout, stderr)
+	}
+	var resp struct {
+		DryRun   bool `json:"dry_run"`
+		Sessions []struct {
+			ID          string `json:"id"`
+			TmuxSession string `json:"tmux_session"`
+		} `json:"sessions"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &resp); err != nil {
+		t.Fatalf("parse import --json: %v\nstdout: %s", err, stdout)
+	}
+	if !resp.DryRun || len(resp.Sessions) != 1 || resp.Sessions[0].TmuxSession != "scratch" || resp.Sessions[0].ID != "" {
+		t.Fatalf("unexpected dry-run payload: %s", stdout)
+	}
+	if got := tmuxMouseOption(t, socket); got == "on" {
+		t.Error("import --dry-run must not enable mouse mode in the tmux session")
+	}
+	if list := readSessionsJSON(t, home); strings.Contains(list, "scratch") {
+		t.Errorf("import --dry-run must not save the session:\n%s", list)
+	}
+
+	stdout, stderr, code = runAgentDeck(t, home, "import", "scratch", "--json")
+	if code != 0 {
+		t.Fatalf("import failed (exit %d)\nstdout: %s\nstderr: %s", code, stdout, stderr)
+	}
+	if got := tmuxMouseOption(t, socket); got != "on" {
+		t.Errorf("import should enable mouse mode, got mouse=%q", got)
+	}
+	if list := readSessionsJSON(t, home); !strings.Contains(list, "scratch") {
+		t.Errorf("import should save the session:\n%s", list)
+	}
+}
+
+func TestHandleImport_RejectsUnknownTool(t *testing.T) {
+	if testing.Short() {
+		t.Skip("subprocess CLI test skipped in short mode")
+	}
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not available")
+	}
+	home := t.TempDir()
+	setupImportTmux(t, home)
+
+	stdout, stderr, code := runAgentDeck(t, home, "import", "scratch", "--tool", "not-a-tool")
+	if code != 1 {
+		t.Fatalf("import --tool not-a-tool: exit %d, want 1\nstdout: %s\nstderr: %s", code, stdout, stderr)
+	}
+	if !strings.Contains(stderr, `unknown tool "not-a-tool"`) {
+		t.Errorf("stderr should name the unknown tool, got: %s", stderr)
+	}
+	if list := readSessionsJSON(t, home); strings.Contains(list, "scratch") {
+		t.Errorf("a rejected import must not save the session:\n%s", list)
+	}
+}
@@ -123,7 +123,7 @@ func handleLaunch(profile string, args []string) {
 	// Socket isolation (v1.7.50+, issue #687). Same semantics as
 	// `agent-deck add --tmux-socket`: overrides `[tmux].socket_name` for
 	// this one session, captured once and persisted on the Instance.
-	tmuxSocket := fs.String("tmux-socket", "", "tmux -L socket name for this session (overrides [tmux].socket_name)")
+	tmuxSocket := fs.String("tmux-socket", "", "tmux -L socket name or -S socket path for this session (overrides [tmux].socket_name/socket_path)")
 
 	// Issue #1143: auto-stop dormant child sessions.
 	idleTimeout := fs.String("idle-timeout", "", "Auto-stop session after this duration of no tmux output (Go duration: 30m, 1h, 24h). 0 or unset = disabled")
@@ -174,11 +174,13 @@ func handleLaunch(profile string, args []string) {
 
 	// Verify path exists and is a directory
 	info, err := os.Stat(path)
-	if err != nil {
+	switch {
+	case os.IsNotExist(err) && session.MissingPathAllowed(path):
+		fmt.Fprintf(os.Stderr, "Warning: path does not exist (allowed by allow_missing_paths): %s\n", path)
+	case err != nil:
 		out.Error(fmt.Sprintf("path does not exist: %s", path), ErrCodeNotFound)
 		os.Exit(1)
-	}
-	if !info.IsDir() {
+	case !info.IsDir():
 		out.Error(fmt.Sprintf("path is not a directory: %s", path), ErrCodeInvalidOperation)
 		os.Exit(1)
 	}
@@ -300,6 +300,24 @@ func main() {
 		case "telegram-doctor":
 			handleTelegramDoctor(profile, args[1:])
 			return
+		case "doctor":
+			handleDoctor(args[1:])
+			return
+		case "__complete":
+			handleComplete(profile, args[1:])
+			return
+		case "api":
+			handleAPI(profile, args[1:])
+			return
+		case "import":
+			handleImport(profile, args[1:])
+			return
+		case "trust":
+			handleTrust(args[1:])
+			return
+		case "up":
+			handleUp(profile, args[1:])
+			return
 		case "watcher":
 			handleWatcher(profile, args[1:])
 			return
@@ -329,6 +347,9 @@ func main() {
 		case "migrate-paths":
 			handleMigratePaths(args[1:])
 			return
+		case "prune-logs":
+			handlePruneLogs(args[1:])
+			return
 		case "hook-handler":
 			handleHookHandler()
 			return
@@ -871,13 +892,14 @@ var globalFlagSubcommands = map[string]bool{
 	"rename": true, "mv": true, "status": true, "profile": true, "update": true,
 	"session": true, "mcp": true, "plugin": true, "skill": true, "mcp-proxy": true,
 	"group": true, "try": true, "launch": true, "conductor": true,
-	"telegram-doctor": true, "watcher": true, "openclaw": true, "oc": true,
+	"telegram-doctor": true, "doctor": true, "watcher": true, "openclaw": true, "oc": true,
 	"remote": true, "worktree": true, "wt": true, "costs": true, "web": true,
-	"uninstall": true, "migrate-paths": true, "hook-handler": true,
+	"uninstall": true, "migrate-paths": true, "prune-logs": true, "hook-handler": true,
 	"codex-notify": true, "hooks": true, "codex-hooks": true, "gemini-hooks": true,
 	"hermes-hooks": true, "cursor-hooks": true, "notify-daemon": true,
 	"run-task": true, "inbox": true, "feedback": true, "creds-refresh": true,
-	"debug-dump": true, "version": true, "help": true,
+	"debug-dump": true, "version": true, "help": true, "__complete": true,
+	"api": true, "import": true, "trust": true, "up": true,
 }
 
 // extractProfileFlag extracts the global -p or --profile flag from args,
@@ -1270,7 +1292,7 @@ func handleAdd(profile string, args []string) {
 	// wide `[tmux].socket_name` for this one session. Empty = fall back to
 	// config. Captured once at creation and persisted on the Instance —
 	// subsequent start/restart/revive always target the same socket.
-	tmuxSocket := fs.String("tmux-socket", "", "tmux -L socket name for this session (overrides [tmux].socket_name)")
+	tmuxSocket := fs.String("tmux-socket", "", "tmux -L socket name or -S socket path for this session (overrides [tmux].socket_name/socket_path)")
 
 	// Per-session named account slot (#924). Maps to
 	// [profiles.<account>.claude].config_dir in ~/.agent-deck/config.toml
@@ -1457,11 +1479,13 @@ func handleAdd(profile string, args []string) {
 		}
 	} else {
 		info, err := os.Stat(path)
-		if err != nil {
+		switch {
+		case os.IsNotExist(err) && session.MissingPathAllowed(path):
+			fmt.Fprintf(os.Stderr, "Warning: path does not exist (allowed by allow_missing_paths): %s\n", path)
+		case err != nil:
 			fmt.Printf("Error: path does not exist: %s\n", path)
 			os.Exit(1)
-		}
-		if !info.IsDir() {
+		case !info.IsDir():
 			fmt.Printf("Error: path is not a directory: %s\n", path)
 			os.Exit(1)
 		}
@@ -1930,61 +1954,10 @@ func handleList(profile string, args []string) {
 	}
 
 	if *jsonOutput {
-		// JSON output for scripting
-		type sessionJSON struct {
-			ID            string    `json:"id"`
-			Title         string    `json:"title"`
-			Path          string    `json:"path"`
-			Group         string    `json:"group"`
-			Tool          string    `json:"tool"`
-			Command       string    `json:"command,omitempty"`
-			ModelID       string    `json:"model_id,omitempty"`
-			Model         string    `json:"model,omitempty"`
-			ModelVersion  string    `json:"model_version,omitempty"`
-			Status        string    `json:"status"`
-			Substate      string    `json:"substate,omitempty"` // Honest Status v2: additive refinement
-			TmuxSession   string    `json:"tmux_session,omitempty"`
-			Profile       string    `json:"profile"`
-			CreatedAt     time.Time `json:"created_at"`
-			SSHHost       string    `json:"ssh_host,omitempty"`
-			SSHRemotePath string    `json:"ssh_remote_path,omitempty"`
-			Channels      []string  `json:"channels,omitempty"`
-			ExtraArgs     []string  `json:"extra_args,omitempty"`
-			Color         string    `json:"color,omitempty"` // issue #391
-		}
-		// Warm tmux pane-title cache + load hook statuses so the CLI
-		// reports the same Status the TUI and /api/menu do (issue #610).
-		session.RefreshInstancesForCLIStatus(instances)
-		sessions := make([]sessionJSON, len(instances))
-		for i, inst := range instances {
-			_ = inst.UpdateStatus()
-			sj := sessionJSON{
-				ID:            inst.ID,
-				Title:         inst.Title,
-				Path:          inst.ProjectPath,
-				Group:         inst.GroupPath,
-				Tool:          inst.Tool,
-				Command:       inst.Command,
-				Status:        StatusString(inst.Status),
-				Substate:      string(inst.Substate()),
-				Profile:       storage.Profile(),
-				CreatedAt:     inst.CreatedAt,
-				SSHHost:       inst.SSHHost,
-				SSHRemotePath: inst.SSHRemotePath,
-				Channels:      inst.Channels,
-				ExtraArgs:     inst.ExtraArgs,
-				Color:         inst.Color,
-			}
-			if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil {
-				sj.TmuxSession = tmuxSess.Name
-			}
-			if modelInfo := inst.LaunchModelInfo(); modelInfo.ModelID != "" {
-				sj.ModelID = modelInfo.ModelID
-				sj.Model = modelInfo.Model
-				sj.ModelVersion = modelInfo.Version
-			}
-			sessions[i] = sj
-		}
+		// JSON output for scripting. session.ExportSessions warms the tmux
+		// pane-title cache and hook statuses so the CLI reports the same
+		// Status the TUI and /api/menu do (issue #610).
+		sessions := session.ExportSessions(instances, storage.Profile())
 		output, err := json.MarshalIndent(sessions, "", "  ")
 		if err != nil {
 			fmt.Printf("Error: failed to format JSON output: %v\n", err)
@@ -3089,8 +3062,11 @@ func printHelp() {
 	fmt.Println("  add <path>       Add a new session")
 	fmt.Println("  launch [path]    Add, start, and optionally send a message in one step")
 	fmt.Println("  try <name>       Quick experiment (create/find dated folder + session)")
+	fmt.Println("  up <spec-file>   Create the sessions declared in a YAML/JSON spec file")
 	fmt.Println("  list, ls         List all sessions")
 	fmt.Println("  remove, rm       Remove a session")
+	fmt.Println("  import           Adopt running tmux sessions not managed yet")
+	fmt.Println("  trust            Trust a project's .agentdeck.json (env, shell_init, layout)")
 	fmt.Println("  rename, mv       Rename a session")
 	fmt.Println("  status           Show session status summary")
 	fmt.Println("  session          Manage session lifecycle")
@@ -3103,13 +3079,16 @@ func printHelp() {
 	fmt.Println("  group            Manage groups")
 	fmt.Println("  worktree, wt     Manage git worktrees")
 	fmt.Println("  web              Start TUI with web UI server running alongside")
+	fmt.Println("  api              Serve the local control API on a unix socket")
 	fmt.Println("  remote           Manage remote agent-deck instances")
 	fmt.Println("  conductor        Manage conductor meta-agent orchestration")
 	fmt.Println("  telegram-doctor  Audit channel-owning sessions for telegram drops (#1138)")
+	fmt.Println("  doctor           Check config.toml for missing binaries and bad settings")
 	fmt.Println("  profile          Manage profiles")
 	fmt.Println("  update           Check for and install updates")
 	fmt.Println("  debug-dump       Dump debug ring buffer to file for sharing")
 	fmt.Println("  migrate-paths    Copy legacy ~/.agent-deck files into XDG paths")
+	fmt.Println("  prune-logs       Delete Gemini logs, reporting the space freed (--dry-run)")
 	fmt.Println("  uninstall        Uninstall Agent Deck")
 	fmt.Println("  version          Show version")
 	fmt.Println("  help             Show this help")
@@ -3684,7 +3663,7 @@ func isNestedSession() bool {
 // to the outer tmux, not a clean shell). The guard fires only on the TUI
 // path — CLI subcommands remain usable inside tmux.
 func isOuterTmuxWithoutOptIn() bool {
-	if os.Getenv("TMUX") == "" {
+	if !session.InsideTmux() {
 		return false
 	}
 	if isNestedSession() {
@@ -3730,17 +3709,3 @@ func ensureTmuxInPath() error {
 
 	return fmt.Errorf("tmux not found in PATH or common locations")
 }
-
-// formatSize formats bytes into human-readable size
-func formatSize(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
-}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handlePruneLogs runs the maintenance worker's Gemini log prune on demand.
+// It shows how many files would go and how much space that frees before
+// asking for confirmation; --dry-run stops after the report.
+func handlePruneLogs(args []string) {
+	fs := flag.NewFlagSet("prune-logs", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "Show what would be removed without removing")
+	yes := fs.Bool("y", false, "Skip the confirmation prompt")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck prune-logs [--dry-run] [-y] [--json]")
+		fmt.Println()
+		fmt.Println("Delete the Gemini .txt logs under ~/.gemini/tmp that background")
+		fmt.Println("maintenance prunes, after reporting the space they use.")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck prune-logs --dry-run  # would remove 42 files, 310.0 MB")
+		fmt.Println("  agent-deck prune-logs -y         # Prune without prompting")
+	}
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+
+	if *jsonOutput && !*dryRun && !*yes {
+		out.Error("--json needs --dry-run or -y", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	plan := session.PlanGeminiLogPrune("")
+	if len(plan.Paths) == 0 {
+		out.Success("No Gemini logs to prune", map[string]interface{}{
+			"success": true,
+			"removed": 0,
+			"bytes":   0,
+		})
+		return
+	}
+
+	if *dryRun {
+		out.Print(fmt.Sprintf("Gemini logs: %s\n", plan.Summary()), map[string]interface{}{
+			"success": true,
+			"dry_run": true,
+			"files":   plan.Paths,
+			"bytes":   plan.Bytes,
+		})
+		return
+	}
+
+	if !*yes {
+		fmt.Printf("Gemini logs: %s\n", plan.Summary())
+		fmt.Print("Proceed? [y/N] ")
+		var response string
+		_, _ = fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" {
+			fmt.Println("Prune cancelled.")
+			return
+		}
+	}
+
+	removed := plan.Remove()
+	out.Success(
+		fmt.Sprintf("Removed %d of %d Gemini logs (%s)", removed, len(plan.Paths), session.FormatByteSize(plan.Bytes)),
+		map[string]interface{}{
+			"success": removed == len(plan.Paths),
+			"removed": removed,
+			"bytes":   plan.Bytes,
+		},
+	)
+	if removed < len(plan.Paths) {
+		os.Exit(1)
+	}
+}
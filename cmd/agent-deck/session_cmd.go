@@ -1090,6 +1090,16 @@ func handleSessionAttach(profile string, args []string) {
 		os.Exit(1)
 	}
 
+	// Already inside a client of this session's tmux server: switch that
+	// client over instead of nesting tmux in tmux.
+	if tmux.InsideServer(tmuxSession.SocketName) {
+		if err := inst.Attach(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to switch client: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Create context for attach
 	ctx := context.Background()
 
@@ -1235,6 +1245,15 @@ func handleSessionShow(profile string, args []string) {
 		}
 	}
 
+	// Gemini project hash: which ~/.gemini/tmp/<hash> the chat file was
+	// found under, to debug "sessions disappeared after moving the repo".
+	var geminiHash string
+	if inst.Tool == "gemini" && inst.GeminiSessionID != "" {
+		geminiHash = session.GeminiSessionProjectHash(inst.ProjectPath, inst.GeminiSessionID)
+		jsonData["gemini_session_id"] = inst.GeminiSessionID
+		jsonData["gemini_project_hash"] = geminiHash
+	}
+
 	if tmuxSession := inst.GetTmuxSession(); tmuxSession != nil {
 		jsonData["tmux_session"] = tmuxSession.Name
 	}
@@ -1312,6 +1331,16 @@ func handleSessionShow(profile string, args []string) {
 		}
 	}
 
+	if inst.Tool == "gemini" && inst.GeminiSessionID != "" {
+		hash := geminiHash
+		if hash == "" {
+			hash = "not found"
+		} else if len(hash) > 12 {
+			hash = hash[:12] + "..."
+		}
+		sb.WriteString(fmt.Sprintf("Gemini:  session_id=%s (project hash: %s)\n", inst.GeminiSessionID, hash))
+	}
+
 	if inst.NoTransitionNotify {
 		sb.WriteString("Notify:  transition events suppressed\n")
 	}
@@ -1365,6 +1394,7 @@ func handleSessionSet(profile string, args []string) {
 		fmt.Println("  extra-args         Extra claude CLI tokens (claude only; use `-- --flag value` for tokens starting with -; persisted plaintext — no secrets)")
 		fmt.Println("  model              Per-session model override (e.g. opus/sonnet/haiku or a gemini model); persists across restart (#1436). Empty clears it.")
 		fmt.Println("  color              Optional TUI row tint: '#RRGGBB' or ANSI '0'..'255' or '' (issue #391)")
+		fmt.Printf("  notes              Short description shown in the session list (max %d characters; '' clears)\n", session.MaxNotesLength)
 		fmt.Println("  claude-session-id  Claude conversation ID")
 		fmt.Println("  gemini-session-id  Gemini conversation ID")
 		fmt.Println("  account            Named account slot (#924) — resolves via [profiles.<account>.claude].config_dir; restart required")
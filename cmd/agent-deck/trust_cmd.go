@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleTrust marks a project's .agentdeck.json as trusted. Until then its
+// claude options, env, shell_init and layout are ignored, since a cloned
+// repository could otherwise run commands or skip permission checks in every
+// session started in it.
+func handleTrust(args []string) {
+	fs := flag.NewFlagSet("trust", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	revoke := fs.Bool("revoke", false, "Stop trusting the project")
+	list := fs.Bool("list", false, "List trusted projects")
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck trust [path] [--revoke] [--list] [--json]")
+		fmt.Println()
+		fmt.Printf("Trust the current content of a project's %s so its claude\n", session.ProjectConfigFileName)
+		fmt.Println("options, env, shell_init and layout command apply. Editing the file")
+		fmt.Println("revokes the trust.")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck trust                # Trust ./" + session.ProjectConfigFileName)
+		fmt.Println("  agent-deck trust ~/src/api --revoke")
+		fmt.Println("  agent-deck trust --list")
+	}
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	if *list {
+		dirs, err := session.TrustedProjects()
+		if err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		var b strings.Builder
+		for _, dir := range dirs {
+			fmt.Fprintf(&b, "  %s\n", dir)
+		}
+		out.Print(b.String(), map[string]interface{}{"success": true, "projects": dirs})
+		return
+	}
+
+	path := fs.Arg(0)
+	if path == "" {
+		path = "."
+	}
+	if *revoke {
+		if err := session.UntrustProjectConfig(path); err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		out.Success(fmt.Sprintf("No longer trusting %s in %s", session.ProjectConfigFileName, path), map[string]interface{}{"success": true})
+		return
+	}
+
+	cfg, err := session.LoadProjectConfig(path)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	if cfg == nil {
+		out.Error(fmt.Sprintf("no %s in %s", session.ProjectConfigFileName, path), ErrCodeNotFound)
+		os.Exit(2)
+	}
+	if !*jsonOutput {
+		out.Print(describeTrustedParts(cfg), nil)
+	}
+	if err := session.TrustProjectConfig(path); err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	out.Success(fmt.Sprintf("Trusted %s in %s", session.ProjectConfigFileName, path), map[string]interface{}{"success": true})
+}
+
+// describeTrustedParts lists what trusting cfg will let run, so the user
+// sees it before the file takes effect.
+func describeTrustedParts(cfg *session.ProjectConfig) string {
+	var b strings.Builder
+	if len(cfg.Claude) > 0 {
+		fmt.Fprintf(&b, "  claude:     %s\n", strings.Join(strings.Fields(string(cfg.Claude)), " "))
+	}
+	if len(cfg.Env) > 0 {
+		keys := make([]string, 0, len(cfg.Env))
+		for k := range cfg.Env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(&b, "  env:        %s\n", strings.Join(keys, ", "))
+	}
+	if cfg.ShellInit != "" {
+		fmt.Fprintf(&b, "  shell_init: %s\n", cfg.ShellInit)
+	}
+	if cfg.Layout != nil && cfg.Layout.Command != "" {
+		fmt.Fprintf(&b, "  layout:     %s\n", cfg.Layout.Command)
+	}
+	return b.String()
+}
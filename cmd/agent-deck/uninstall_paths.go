@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/asheshgoplani/agent-deck/internal/agentpaths"
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
 type uninstallFoundItem struct {
@@ -116,9 +117,9 @@ func describeUninstallLocation(path string, info os.FileInfo) string {
 	})
 
 	if profileCount > 0 || sessionCount > 0 {
-		return fmt.Sprintf("%d profiles, %d sessions, %s", profileCount, sessionCount, formatSize(totalSize))
+		return fmt.Sprintf("%d profiles, %d sessions, %s", profileCount, sessionCount, session.FormatByteSize(totalSize))
 	}
-	return formatSize(totalSize)
+	return session.FormatByteSize(totalSize)
 }
 
 func isUninstallDataLocation(itemType string) bool {
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// upResult is one row of `up --json` output.
+type upResult struct {
+	Title string `json:"title"`
+	ID    string `json:"id,omitempty"`
+	Group string `json:"group,omitempty"`
+	Path  string `json:"path,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// handleUp creates and starts every session declared in a spec file (see
+// session.LoadSessionSpecs) and saves each one to the profile as soon as it
+// is running. A spec that fails is rolled back and the rest still run; the
+// command exits 1 if any failed.
+func handleUp(profile string, args []string) {
+	fs := flag.NewFlagSet("up", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck up <spec-file> [--json]")
+		fmt.Println()
+		fmt.Println("Create and start the sessions declared in a YAML or JSON spec file.")
+		fmt.Println("Relative paths in the file are relative to the file itself.")
+		fmt.Println()
+		fmt.Println("Example spec (dev.yaml):")
+		fmt.Println("  group: dev")
+		fmt.Println("  sessions:")
+		fmt.Println("    - title: api")
+		fmt.Println("      path: ./api")
+		fmt.Println("      tool: claude")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck up dev.yaml")
+		fmt.Println("  agent-deck -p work up ~/src/team/sessions.json --json")
+	}
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	specPath := fs.Arg(0)
+	if specPath == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+	specs, err := session.LoadSessionSpecs(session.ExpandPath(specPath))
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	// Each session is inserted on its own, like launch does, rather than
+	// through a full saveSessionData rewrite that could drop rows a
+	// concurrent add inserted meanwhile.
+	save := func(inst *session.Instance) error {
+		tree := session.NewGroupTreeWithGroups(append(instances, inst), groups)
+		if inst.GroupPath != "" {
+			tree.CreateGroupPath(inst.GroupPath)
+		}
+		if err := storage.InsertSessionAndVerify(inst, tree); err != nil {
+			return err
+		}
+		instances = append(instances, inst)
+		return nil
+	}
+
+	results := session.CreateSessions(specs, save)
+	rows := make([]upResult, len(results))
+	failed := 0
+	var b strings.Builder
+	for i, res := range results {
+		rows[i] = upResult{Title: strings.TrimSpace(res.Spec.Title)}
+		if res.Err != nil {
+			failed++
+			rows[i].Error = res.Err.Error()
+			fmt.Fprintf(&b, "  ✗ %v\n", res.Err)
+			continue
+		}
+		rows[i].ID = res.Instance.ID
+		rows[i].Group = res.Instance.GroupPath
+		rows[i].Path = res.Instance.ProjectPath
+		fmt.Fprintf(&b, "  ✓ %s (%s)\n", res.Instance.Title, res.Instance.ProjectPath)
+	}
+
+	summary := fmt.Sprintf("Started %d of %d sessions", len(results)-failed, len(results))
+	if *jsonOutput {
+		out.Print("", map[string]interface{}{
+			"success":  failed == 0,
+			"sessions": rows,
+		})
+	} else {
+		out.Print(summary+"\n"+b.String(), nil)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
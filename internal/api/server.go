@@ -0,0 +1,195 @@
+// Package api serves a small JSON control API over a unix socket so other
+// tools can script agent-deck (list, create, delete, attach-info) without
+// driving the TUI. Access control is the socket file mode: only the owning
+// user can connect. Enabled with [api] enabled = true and started by
+// `agent-deck api`.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// Error codes in API error responses.
+const (
+	ErrCodeBadRequest    = "INVALID_REQUEST"
+	ErrCodeNotFound      = "NOT_FOUND"
+	ErrCodeInternalError = "INTERNAL_ERROR"
+)
+
+// ErrNotFound is returned by a Backend when no session matches.
+var ErrNotFound = errors.New("session not found")
+
+// AttachInfo tells a client how to attach to a session's tmux pane itself.
+type AttachInfo struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	TmuxSession string   `json:"tmux_session"`
+	TmuxSocket  string   `json:"tmux_socket,omitempty"`
+	Running     bool     `json:"running"`
+	Command     []string `json:"command"` // argv, e.g. ["tmux", "attach-session", "-t", "..."]
+}
+
+// Backend performs the session operations behind the HTTP endpoints.
+// Sessions are addressed by ID or exact title.
+type Backend interface {
+	ListSessions() ([]session.SessionExport, error)
+	CreateSession(spec session.SessionSpec) (session.SessionExport, error)
+	DeleteSession(ref string) error
+	AttachInfo(ref string) (AttachInfo, error)
+}
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type apiErrorResponse struct {
+	Error apiError `json:"error"`
+}
+
+// Server routes HTTP requests to a Backend.
+type Server struct {
+	backend Backend
+	mux     *http.ServeMux
+}
+
+// NewServer returns a Server for backend.
+func NewServer(backend Backend) *Server {
+	s := &Server{backend: backend, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /v1/sessions", s.handleList)
+	s.mux.HandleFunc("POST /v1/sessions", s.handleCreate)
+	s.mux.HandleFunc("DELETE /v1/sessions/{ref}", s.handleDelete)
+	s.mux.HandleFunc("GET /v1/sessions/{ref}/attach", s.handleAttachInfo)
+	return s
+}
+
+// Handler returns the HTTP handler, for tests and custom listeners.
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenUnix creates the unix socket at path with owner-only permissions.
+// A stale socket left by a crashed server is replaced; a live one is an
+// error so two servers never fight over the same path.
+func ListenUnix(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create socket dir: %w", err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another server is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale socket: %w", err)
+		}
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("restrict socket permissions: %w", err)
+	}
+	return l, nil
+}
+
+// Serve accepts connections on l until ctx is cancelled.
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	srv := &http.Server{Handler: s.mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	if err := srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	sessions, err := s.backend.ListSessions()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if sessions == nil {
+		sessions = []session.SessionExport{}
+	}
+	writeJSON(w, http.StatusOK, sessions)
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var spec session.SessionSpec
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&spec); err != nil {
+		writeAPIError(w, http.StatusBadRequest, ErrCodeBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	created, err := s.backend.CreateSession(spec)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if err := s.backend.DeleteSession(r.PathValue("ref")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleAttachInfo(w http.ResponseWriter, r *http.Request) {
+	info, err := s.backend.AttachInfo(r.PathValue("ref"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+// BadRequestError marks a backend error as the caller's fault (400).
+type BadRequestError struct{ Err error }
+
+func (e BadRequestError) Error() string { return e.Err.Error() }
+func (e BadRequestError) Unwrap() error { return e.Err }
+
+func writeError(w http.ResponseWriter, err error) {
+	var badReq BadRequestError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		writeAPIError(w, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	case errors.As(err, &badReq):
+		writeAPIError(w, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+	default:
+		writeAPIError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, apiErrorResponse{Error: apiError{Code: code, Message: message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+type fakeBackend struct {
+	sessions []session.SessionExport
+	created  []session.SessionSpec
+	deleted  []string
+}
+
+func (f *fakeBackend) ListSessions() ([]session.SessionExport, error) {
+	return f.sessions, nil
+}
+
+func (f *fakeBackend) CreateSession(spec session.SessionSpec) (session.SessionExport, error) {
+	if spec.Title == "" {
+		return session.SessionExport{}, BadRequestError{errors.New("title is required")}
+	}
+	f.created = append(f.created, spec)
+	return session.SessionExport{ID: "new-id", Title: spec.Title, Path: spec.Path, Status: "running"}, nil
+}
+
+func (f *fakeBackend) DeleteSession(ref string) error {
+	if ref != "abc" {
+		return fmt.Errorf("%w: %s", ErrNotFound, ref)
+	}
+	f.deleted = append(f.deleted, ref)
+	return nil
+}
+
+func (f *fakeBackend) AttachInfo(ref string) (AttachInfo, error) {
+	if ref != "abc" {
+		return AttachInfo{}, ErrNotFound
+	}
+	return AttachInfo{ID: "abc", TmuxSession: "agentdeck_x", Running: true, Command: []string{"tmux", "attach-session", "-t", "agentdeck_x"}}, nil
+}
+
+func do(t *testing.T, h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_List(t *testing.T) {
+	h := NewServer(&fakeBackend{sessions: []session.SessionExport{{ID: "abc", Title: "one", Status: "idle"}}}).Handler()
+
+	rec := do(t, h, http.MethodGet, "/v1/sessions", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var got []session.SessionExport
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "abc" || got[0].Status != "idle" {
+		t.Errorf("sessions = %+v", got)
+	}
+
+	// An empty store is [] rather than null.
+	rec = do(t, NewServer(&fakeBackend{}).Handler(), http.MethodGet, "/v1/sessions", "")
+	if strings.TrimSpace(rec.Body.String()) != "[]" {
+		t.Errorf("empty list body = %q, want []", rec.Body)
+	}
+}
+
+func TestServer_Create(t *testing.T) {
+	backend := &fakeBackend{}
+	h := NewServer(backend).Handler()
+
+	rec := do(t, h, http.MethodPost, "/v1/sessions", `{"title":"api","path":"/tmp","tool":"claude"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	if len(backend.created) != 1 || backend.created[0].Tool != "claude" {
+		t.Errorf("created = %+v", backend.created)
+	}
+
+	for name, body := range map[string]string{
+		"malformed":     `{"title":`,
+		"unknown field": `{"title":"x","path":"/tmp","colour":"red"}`,
+		"invalid spec":  `{"path":"/tmp"}`,
+	} {
+		rec := do(t, h, http.MethodPost, "/v1/sessions", body)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", name, rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), ErrCodeBadRequest) {
+			t.Errorf("%s: body = %s", name, rec.Body)
+		}
+	}
+}
+
+func TestServer_DeleteAndAttach(t *testing.T) {
+	backend := &fakeBackend{}
+	h := NewServer(backend).Handler()
+
+	if rec := do(t, h, http.MethodDelete, "/v1/sessions/abc", ""); rec.Code != http.StatusNoContent {
+		t.Errorf("delete status = %d", rec.Code)
+	}
+	if rec := do(t, h, http.MethodDelete, "/v1/sessions/missing", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("delete missing status = %d, want 404", rec.Code)
+	}
+
+	rec := do(t, h, http.MethodGet, "/v1/sessions/abc/attach", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("attach status = %d", rec.Code)
+	}
+	var info AttachInfo
+	_ = json.Unmarshal(rec.Body.Bytes(), &info)
+	if info.TmuxSession != "agentdeck_x" || len(info.Command) == 0 {
+		t.Errorf("attach info = %+v", info)
+	}
+
+	if rec := do(t, h, http.MethodPut, "/v1/sessions", ""); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("PUT status = %d, want 405", rec.Code)
+	}
+}
+
+func TestListenUnix_OwnerOnlyAndStaleSocket(t *testing.T) {
+	// Short dir: unix socket paths are limited to ~104 bytes on macOS.
+	dir, err := os.MkdirTemp("", "adapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "sub", "api.sock")
+
+	l, err := ListenUnix(path)
+	if err != nil {
+		t.Fatalf("ListenUnix: %v", err)
+	}
+	st, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := st.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket mode = %o, want 600", perm)
+	}
+
+	// A live server blocks a second listener.
+	if _, err := ListenUnix(path); err == nil {
+		t.Error("second ListenUnix on a live socket should fail")
+	}
+
+	// Serve a request end to end over the socket.
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- NewServer(&fakeBackend{}).Serve(ctx, l) }()
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}, Timeout: 5 * time.Second}
+	resp, err := client.Get("http://agent-deck/v1/sessions")
+	if err != nil {
+		t.Fatalf("GET over socket: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status over socket = %d", resp.StatusCode)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Serve: %v", err)
+	}
+
+	// Leave a stale socket file behind (listener closed without unlink).
+	stale, err := net.Listen("unix", filepath.Join(dir, "stale.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+	l2, err := ListenUnix(filepath.Join(dir, "stale.sock"))
+	if err != nil {
+		t.Fatalf("ListenUnix over stale socket: %v", err)
+	}
+	l2.Close()
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// StorageBackend is the production Backend: every call opens the profile's
+// storage, so the API sees sessions created by the TUI and CLI and they see
+// its changes, the same way concurrent CLI invocations cooperate.
+type StorageBackend struct {
+	profile string
+	mu      sync.Mutex // serializes this server's load-modify-save cycles
+}
+
+// NewStorageBackend returns a Backend for profile.
+func NewStorageBackend(profile string) *StorageBackend {
+	return &StorageBackend{profile: profile}
+}
+
+func (b *StorageBackend) load() (*session.Storage, []*session.Instance, []*session.GroupData, error) {
+	storage, err := session.NewStorageWithProfile(b.profile)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("open storage: %w", err)
+	}
+	instances, groups, err := storage.LoadWithGroups()
+	if err != nil {
+		storage.Close()
+		return nil, nil, nil, fmt.Errorf("load sessions: %w", err)
+	}
+	return storage, instances, groups, nil
+}
+
+// find resolves ref as an exact ID, then an exact title.
+func find(instances []*session.Instance, ref string) *session.Instance {
+	for _, inst := range instances {
+		if inst.ID == ref {
+			return inst
+		}
+	}
+	for _, inst := range instances {
+		if inst.Title == ref {
+			return inst
+		}
+	}
+	return nil
+}
+
+// ListSessions implements Backend.
+func (b *StorageBackend) ListSessions() ([]session.SessionExport, error) {
+	storage, instances, _, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	defer storage.Close()
+	return session.ExportSessions(instances, storage.Profile()), nil
+}
+
+// CreateSession implements Backend. The session is started right away, like
+// sessions created from the web UI. Creation is all-or-nothing: if starting
+// or saving fails, its tmux session and worktree are removed again. Only the
+// save holds b.mu, so a slow start does not block other requests.
+func (b *StorageBackend) CreateSession(spec session.SessionSpec) (session.SessionExport, error) {
+	if errs := session.ValidateSpec(spec); len(errs) > 0 {
+		return session.SessionExport{}, BadRequestError{errors.Join(errs...)}
+	}
+	if spec.MultiRepo {
+		return session.SessionExport{}, BadRequestError{errors.New("multi-repo sessions cannot be created from a spec yet")}
+	}
+
+	var txn session.CreateTxn
+	inst, err := spec.Create(&txn)
+	if err != nil {
+		_ = txn.Rollback()
+		return session.SessionExport{}, fmt.Errorf("create session: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	storage, instances, groups, err := b.load()
+	if err != nil {
+		_ = txn.Rollback()
+		return session.SessionExport{}, err
+	}
+	defer storage.Close()
+
+	instances = append(instances, inst)
+	groupTree := session.NewGroupTreeWithGroups(instances, groups)
+	if inst.GroupPath != "" {
+		groupTree.CreateGroupPath(inst.GroupPath)
+	}
+	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+		_ = txn.Rollback()
+		return session.SessionExport{}, fmt.Errorf("save session: %w", err)
+	}
+	return session.NewSessionExport(inst, storage.Profile()), nil
+}
+
+// DeleteSession implements Backend. It stops the agent gracefully, then
+// removes the session from storage. Worktree directories are left on disk;
+// use `agent-deck remove` or the TUI to clean those up.
+func (b *StorageBackend) DeleteSession(ref string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	storage, instances, groups, err := b.load()
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	inst := find(instances, ref)
+	if inst == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, ref)
+	}
+	_ = inst.Stop(session.DefaultStopTimeout)
+	_ = inst.StopServiceUnit()
+
+	remaining := make([]*session.Instance, 0, len(instances)-1)
+	for _, other := range instances {
+		if other.ID != inst.ID {
+			remaining = append(remaining, other)
+		}
+	}
+	return storage.RemoveSessionAndVerify(inst.ID, remaining, session.NewGroupTreeWithGroups(remaining, groups))
+}
+
+// AttachInfo implements Backend.
+func (b *StorageBackend) AttachInfo(ref string) (AttachInfo, error) {
+	storage, instances, _, err := b.load()
+	if err != nil {
+		return AttachInfo{}, err
+	}
+	defer storage.Close()
+
+	inst := find(instances, ref)
+	if inst == nil {
+		return AttachInfo{}, fmt.Errorf("%w: %s", ErrNotFound, ref)
+	}
+	return attachInfoFor(inst), nil
+}
+
+func attachInfoFor(inst *session.Instance) AttachInfo {
+	info := AttachInfo{ID: inst.ID, Title: inst.Title}
+	ts := inst.GetTmuxSession()
+	if ts == nil {
+		return info
+	}
+	info.TmuxSession = ts.Name
+	info.TmuxSocket = ts.SocketName
+	info.Running = ts.Exists()
+	info.Command = append(append([]string{"tmux"}, tmux.SocketArgs(ts.SocketName)...), "attach-session", "-t", ts.Name)
+	return info
+}
@@ -234,6 +234,80 @@ func IsGitRepoOrBareProjectRoot(dir string) bool {
 	return findNestedBareRepo(dir) != ""
 }
 
+// ErrInsideRepo is returned by InitRepo when dir already lies inside a git
+// repository.
+var ErrInsideRepo = errors.New("already inside a git repository")
+
+// enclosingGitDir walks up from dir and returns the first directory holding a
+// .git entry (a directory, or the file of a linked worktree), or "".
+func enclosingGitDir(dir string) string {
+	for {
+		if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// InitRepo turns dir into a git repository with an empty initial commit, so
+// worktrees can branch from HEAD right away. It refuses with ErrInsideRepo
+// when dir or one of its parents already has a .git, rather than nesting a new
+// repository inside an existing one; git itself may not report that parent
+// (e.g. one owned by another user).
+func InitRepo(dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if root := enclosingGitDir(abs); root != "" {
+		return fmt.Errorf("%w: %s", ErrInsideRepo, root)
+	}
+	if output, err := exec.Command("git", "-C", abs, "init").CombinedOutput(); err != nil {
+		return fmt.Errorf("git init failed: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return createInitialCommit(abs)
+}
+
+// HasCommits reports whether the repository at dir has at least one commit,
+// i.e. HEAD is not unborn as it is right after `git init`.
+func HasCommits(dir string) bool {
+	dir = resolveGitInvocationDir(dir)
+	cmd := exec.Command("git", "-C", dir, "rev-parse", "--verify", "--quiet", "HEAD^{commit}")
+	return cmd.Run() == nil
+}
+
+// createInitialCommit records an empty commit on the current (unborn) branch
+// so new branches have a base to start from. It is built with plumbing rather
+// than `git commit` so it works in bare repos and leaves anything already
+// staged in the index uncommitted.
+func createInitialCommit(dir string) error {
+	run := func(args ...string) (string, error) {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Stdin = strings.NewReader("")
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("initial commit failed: %s: %w", strings.TrimSpace(stderr.String()), err)
+		}
+		return strings.TrimSpace(string(output)), nil
+	}
+	tree, err := run("mktree")
+	if err != nil {
+		return err
+	}
+	commit, err := run("commit-tree", tree, "-m", "Initial commit")
+	if err != nil {
+		return err
+	}
+	_, err = run("update-ref", "HEAD", commit)
+	return err
+}
+
 // GetRepoRoot returns the root directory of the git repository containing dir
 func GetRepoRoot(dir string) (string, error) {
 	cmd := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel")
@@ -341,10 +415,8 @@ func ValidateBranchName(name string) error {
 // neither default makes sense when the project root *is* the bare repo. Custom
 // path templates still take precedence (see WorktreePath in template.go).
 func GenerateWorktreePath(repoDir, branchName, location string) string {
-	// Sanitize branch name for filesystem
-	sanitized := branchName
-	sanitized = strings.ReplaceAll(sanitized, "/", "-")
-	sanitized = strings.ReplaceAll(sanitized, " ", "-")
+	// Flat directory name; the branch keeps its real name.
+	sanitized := SanitizeBranchForPath(branchName)
 
 	// Custom path: contains "/" or starts with "~"
 	if strings.Contains(location, "/") || strings.HasPrefix(location, "~") {
@@ -391,6 +463,15 @@ func CreateWorktree(repoDir, worktreePath, branchName string) error {
 		return errors.New("not a git repository")
 	}
 
+	// A freshly initialized repo has an unborn HEAD that no branch can start
+	// from; give it an empty initial commit. Callers that want to tell the
+	// user check HasCommits beforehand.
+	if !HasCommits(repoDir) {
+		if err := createInitialCommit(repoDir); err != nil {
+			return err
+		}
+	}
+
 	resolution, err := resolveWorktreeBranch(repoDir, branchName)
 	if err != nil {
 		return err
@@ -576,6 +657,40 @@ func IsLinkedWorktree(path string) bool {
 	return filepath.Base(filepath.Dir(gitdir)) == "worktrees"
 }
 
+// WorktreePathStatus classifies what occupies a prospective worktree path.
+type WorktreePathStatus int
+
+const (
+	// WorktreePathEmpty means the path is missing or an empty directory, so
+	// `git worktree add` can use it as is.
+	WorktreePathEmpty WorktreePathStatus = iota
+	// WorktreePathWorktree means a linked worktree already lives there, e.g.
+	// one left behind by an earlier failed run.
+	WorktreePathWorktree
+	// WorktreePathForeign means a file or a non-empty directory that is not a
+	// worktree; agent-deck never reuses or removes it.
+	WorktreePathForeign
+)
+
+// WorktreePathState reports what occupies path before a worktree is created
+// there.
+func WorktreePathState(path string) WorktreePathStatus {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return WorktreePathEmpty
+	}
+	if err != nil || !info.IsDir() {
+		return WorktreePathForeign
+	}
+	if entries, err := os.ReadDir(path); err == nil && len(entries) == 0 {
+		return WorktreePathEmpty
+	}
+	if IsLinkedWorktree(path) {
+		return WorktreePathWorktree
+	}
+	return WorktreePathForeign
+}
+
 // RemoveWorktree removes a worktree from the repository.
 // If force is true, it will remove even if there are uncommitted changes.
 // When force is true and git fails (e.g. "Directory not empty" due to
@@ -741,6 +856,31 @@ func GetWorktreeBaseRoot(dir string) (string, error) {
 	return "", fmt.Errorf("not a git repository: %s", dir)
 }
 
+// BatchBranchNames returns the worktree branches for creating count sessions
+// from one branch name. Git allows a branch to be checked out in only one
+// worktree, so past the first session each gets its own suffix: "feature-1",
+// "feature-2", ... A count of 1 keeps base as is. Every name is checked with
+// ValidateBranchName.
+func BatchBranchNames(base string, count int) ([]string, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("batch size must be at least 1, got %d", count)
+	}
+	if err := ValidateBranchName(base); err != nil {
+		return nil, err
+	}
+	if count == 1 {
+		return []string{base}, nil
+	}
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s-%d", base, i+1)
+		if err := ValidateBranchName(names[i]); err != nil {
+			return nil, fmt.Errorf("branch %d of %d: %w", i+1, count, err)
+		}
+	}
+	return names, nil
+}
+
 // SanitizeBranchName converts a string to a valid branch name
 func SanitizeBranchName(name string) string {
 	// Replace common invalid characters
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -61,6 +62,14 @@ func createBranch(t *testing.T, dir, branchName string) {
 	}
 }
 
+// initEmptyRepo runs git init in dir with a committer identity but no commits.
+func initEmptyRepo(t *testing.T, dir string) {
+	t.Helper()
+	runGit(t, dir, "-c", "init.defaultBranch=main", "init")
+	runGit(t, dir, "config", "user.email", "test@test.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+}
+
 func runGit(t *testing.T, dir string, args ...string) string {
 	t.Helper()
 	cmd := exec.Command("git", args...)
@@ -72,6 +81,42 @@ func runGit(t *testing.T, dir string, args ...string) string {
 	return strings.TrimSpace(string(output))
 }
 
+func TestInitRepo(t *testing.T) {
+	for _, k := range []string{"GIT_AUTHOR", "GIT_COMMITTER"} {
+		t.Setenv(k+"_NAME", "Test User")
+		t.Setenv(k+"_EMAIL", "test@test.com")
+	}
+
+	t.Run("creates a repo worktrees can branch from", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := InitRepo(dir); err != nil {
+			t.Fatalf("InitRepo: %v", err)
+		}
+		if !IsGitRepo(dir) {
+			t.Fatal("expected a git repo after InitRepo")
+		}
+		wt := filepath.Join(t.TempDir(), "wt")
+		if err := CreateWorktree(dir, wt, "feature"); err != nil {
+			t.Fatalf("CreateWorktree after InitRepo: %v", err)
+		}
+	})
+
+	t.Run("refuses inside an existing repo", func(t *testing.T) {
+		dir := t.TempDir()
+		createTestRepo(t, dir)
+		sub := filepath.Join(dir, "sub", "project")
+		if err := os.MkdirAll(sub, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := InitRepo(sub); !errors.Is(err, ErrInsideRepo) {
+			t.Fatalf("InitRepo in a subdirectory: err = %v, want ErrInsideRepo", err)
+		}
+		if _, err := os.Stat(filepath.Join(sub, ".git")); !os.IsNotExist(err) {
+			t.Error("no nested repository may be created")
+		}
+	})
+}
+
 func TestIsGitRepo(t *testing.T) {
 	t.Run("returns true for git repo", func(t *testing.T) {
 		dir := t.TempDir()
@@ -238,6 +283,27 @@ func TestBranchExists(t *testing.T) {
 	})
 }
 
+func TestBatchBranchNames(t *testing.T) {
+	got, err := BatchBranchNames("feature/login", 3)
+	if err != nil {
+		t.Fatalf("BatchBranchNames: %v", err)
+	}
+	want := []string{"feature/login-1", "feature/login-2", "feature/login-3"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("BatchBranchNames = %v, want %v", got, want)
+	}
+
+	if got, err := BatchBranchNames("feature", 1); err != nil || len(got) != 1 || got[0] != "feature" {
+		t.Errorf("single session: BatchBranchNames = %v, %v; want [feature]", got, err)
+	}
+	if _, err := BatchBranchNames("bad..name", 2); err == nil {
+		t.Error("expected an invalid base name to be rejected")
+	}
+	if _, err := BatchBranchNames("feature", 0); err == nil {
+		t.Error("expected a zero batch size to be rejected")
+	}
+}
+
 func TestValidateBranchName(t *testing.T) {
 	t.Run("accepts valid branch names", func(t *testing.T) {
 		validNames := []string{
@@ -448,6 +514,44 @@ func TestGenerateWorktreePath(t *testing.T) {
 	})
 }
 
+func TestHasCommits(t *testing.T) {
+	dir := t.TempDir()
+	initEmptyRepo(t, dir)
+	if HasCommits(dir) {
+		t.Error("fresh git init: HasCommits = true, want false")
+	}
+	if err := createInitialCommit(dir); err != nil {
+		t.Fatalf("createInitialCommit: %v", err)
+	}
+	if !HasCommits(dir) {
+		t.Error("after initial commit: HasCommits = false, want true")
+	}
+}
+
+func TestCreateWorktree_UnbornHead(t *testing.T) {
+	dir := t.TempDir()
+	initEmptyRepo(t, dir)
+	// A staged file must not be swept into the auto-created commit.
+	if err := os.WriteFile(filepath.Join(dir, "staged.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "add", "staged.txt")
+
+	wt := filepath.Join(t.TempDir(), "wt")
+	if err := CreateWorktree(dir, wt, "feature"); err != nil {
+		t.Fatalf("CreateWorktree on an empty repo: %v", err)
+	}
+	if !HasCommits(dir) {
+		t.Fatal("expected an initial commit after CreateWorktree")
+	}
+	if files := runGit(t, dir, "ls-tree", "--name-only", "HEAD"); files != "" {
+		t.Errorf("initial commit tree = %q, want empty", files)
+	}
+	if status := runGit(t, dir, "status", "--porcelain"); !strings.Contains(status, "A  staged.txt") {
+		t.Errorf("status = %q, want staged.txt still staged", status)
+	}
+}
+
 func TestCreateWorktree(t *testing.T) {
 	t.Run("creates worktree with existing branch", func(t *testing.T) {
 		dir := t.TempDir()
@@ -840,6 +944,41 @@ func TestGenerateWorktreePath_EdgeCases(t *testing.T) {
 			t.Errorf("path should not contain slashes or spaces in branch part: %s", path)
 		}
 	})
+
+	t.Run("valid refs with slashes derive a flat directory", func(t *testing.T) {
+		for branch, want := range map[string]string{
+			"feature/foo":         "feature-foo",
+			"user/jane/fix-login": "user-jane-fix-login",
+			"release/v1.2/hotfix": "release-v1.2-hotfix",
+			"deps/@types/node":    "deps-types-node",
+			"bug/#42":             "bug-42",
+		} {
+			if err := ValidateBranchName(branch); err != nil {
+				t.Errorf("ValidateBranchName(%q) = %v, want slashes accepted", branch, err)
+			}
+			for _, location := range []string{"sibling", "subdirectory", "~/worktrees"} {
+				path := GenerateWorktreePath("/repo", branch, location)
+				if filepath.Base(path) != want && filepath.Base(path) != "repo-"+want {
+					t.Errorf("GenerateWorktreePath(%q, %s) = %s, want a flat %q leaf", branch, location, path, want)
+				}
+			}
+		}
+	})
+
+	t.Run("worktree keeps the real branch name", func(t *testing.T) {
+		repo := t.TempDir()
+		createTestRepo(t, repo)
+		path := GenerateWorktreePath(repo, "feature/foo", "subdirectory")
+		if err := CreateWorktree(repo, path, "feature/foo"); err != nil {
+			t.Fatalf("CreateWorktree: %v", err)
+		}
+		if filepath.Base(path) != "feature-foo" {
+			t.Errorf("worktree dir = %s, want a flat feature-foo", path)
+		}
+		if branch, err := GetCurrentBranch(path); err != nil || branch != "feature/foo" {
+			t.Errorf("branch = %q, %v; want feature/foo", branch, err)
+		}
+	})
 }
 
 func TestHasUncommittedChanges(t *testing.T) {
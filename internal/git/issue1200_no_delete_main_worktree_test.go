@@ -131,3 +131,35 @@ func TestIsLinkedWorktree_Issue1200(t *testing.T) {
 		t.Errorf("non-repo directory must NOT be reported as a linked worktree")
 	}
 }
+
+func TestWorktreePathState(t *testing.T) {
+	repo := t.TempDir()
+	createTestRepo(t, repo)
+	wt := filepath.Join(t.TempDir(), "wt")
+	if err := CreateWorktree(repo, wt, "feat"); err != nil {
+		t.Fatalf("create worktree: %v", err)
+	}
+
+	used := t.TempDir()
+	if err := os.WriteFile(filepath.Join(used, "notes.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]WorktreePathStatus{
+		filepath.Join(t.TempDir(), "missing"): WorktreePathEmpty,
+		t.TempDir():                           WorktreePathEmpty,
+		wt:                                    WorktreePathWorktree,
+		used:                                  WorktreePathForeign,
+		file:                                  WorktreePathForeign,
+		repo:                                  WorktreePathForeign,
+	}
+	for path, want := range cases {
+		if got := WorktreePathState(path); got != want {
+			t.Errorf("WorktreePathState(%s) = %d, want %d", path, got, want)
+		}
+	}
+}
@@ -128,9 +128,13 @@ type WorktreeStateOptions struct {
 // script so both observe the realized state, per @smorin's spec.
 func CreateWorktreeWithStateAndSetup(repoDir, worktreePath, branchName string, state WorktreeStateOptions, stdout, stderr io.Writer, setupTimeout time.Duration) (setupErr error, err error) {
 	createdBranch := !BranchExists(repoDir, branchName)
+	unborn := IsGitRepoOrBareProjectRoot(repoDir) && !HasCommits(repoDir)
 	if err = CreateWorktree(repoDir, worktreePath, branchName); err != nil {
 		return nil, err
 	}
+	if unborn {
+		fmt.Fprintf(stderr, "worktree: %s had no commits; created an empty initial commit to branch from\n", repoDir)
+	}
 
 	if state.WithState {
 		if matErr := MaterializeWipFromParent(repoDir, worktreePath, state.WithIgnored); matErr != nil {
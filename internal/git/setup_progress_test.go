@@ -76,3 +76,27 @@ func TestCreateWorktreeWithSetup_ProgressMessages_Failure(t *testing.T) {
 		t.Errorf("expected explicit failure line on stderr, got: %q", stderrStr)
 	}
 }
+
+// An empty repository gets an initial commit so the worktree has a base, and
+// stderr says so rather than leaving a commit the user did not make.
+func TestCreateWorktreeWithSetup_ReportsInitialCommit(t *testing.T) {
+	dir := t.TempDir()
+	initEmptyRepo(t, dir)
+
+	worktreePath := filepath.Join(dir, ".worktrees", "first")
+	var stdout, stderr bytes.Buffer
+	if _, err := CreateWorktreeWithSetup(dir, worktreePath, "first", &stdout, &stderr, 0); err != nil {
+		t.Fatalf("worktree creation failed: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "created an empty initial commit") {
+		t.Errorf("expected initial-commit notice on stderr, got: %q", stderr.String())
+	}
+
+	stderr.Reset()
+	if _, err := CreateWorktreeWithSetup(dir, filepath.Join(dir, ".worktrees", "second"), "second", &stdout, &stderr, 0); err != nil {
+		t.Fatalf("second worktree creation failed: %v", err)
+	}
+	if strings.Contains(stderr.String(), "initial commit") {
+		t.Errorf("no notice expected once the repo has commits, got: %q", stderr.String())
+	}
+}
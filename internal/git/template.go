@@ -47,17 +47,19 @@ type WorktreePathOptions struct {
 	Template  string
 }
 
-// sanitizeBranchForPath converts a branch name to a safe path component.
-// This sanitizes characters that are problematic in filesystem paths,
-// collapses consecutive dashes, and trims leading/trailing dashes.
-func sanitizeBranchForPath(branch string) string {
+// SanitizeBranchForPath converts a branch name to a flat, safe path
+// component for a worktree directory: slashes and other characters that are
+// problematic in filesystem paths become dashes, consecutive dashes collapse
+// and leading/trailing dashes are trimmed ("feature/foo" -> "feature-foo").
+// The branch itself keeps its real name; only the directory is derived.
+func SanitizeBranchForPath(branch string) string {
 	result := branchSanitizer.Replace(branch)
 	result = consecutiveDashes.ReplaceAllString(result, "-")
 	return strings.Trim(result, "-")
 }
 
 // escapeBranchForPath returns a reversible path-safe branch representation.
-// Unlike sanitizeBranchForPath, this avoids collisions:
+// Unlike SanitizeBranchForPath, this avoids collisions:
 // - feature/foo    -> feature%2Ffoo
 // - feature-foo    -> feature-foo
 func escapeBranchForPath(branch string) string {
@@ -73,10 +75,11 @@ func escapeBranchForPath(branch string) string {
 // self-inflicted. The filepath.Clean call normalizes the path but does not
 // restrict it to any particular directory.
 func resolveTemplate(template string, vars templateVars) string {
-	sanitizedBranch := sanitizeBranchForPath(vars.branch)
+	sanitizedBranch := SanitizeBranchForPath(vars.branch)
 
 	replacer := strings.NewReplacer(
 		"{repo-name}", vars.repoName,
+		"{repo}", vars.repoName,
 		"{repo-root}", vars.repoRoot,
 		"{branch}", sanitizedBranch,
 		"{branch-escaped}", vars.branchEscaped,
@@ -105,7 +108,7 @@ func resolveTemplate(template string, vars templateVars) string {
 
 // WorktreePath generates a worktree path. If opts.Template is set, it expands
 // the template with variables:
-//   - {repo-name}, {repo-root}, {session-id}
+//   - {repo-name} (alias {repo}), {repo-root}, {session-id}
 //   - {branch}: sanitized (human-friendly, may collide)
 //   - {branch-escaped}: URL-escaped (collision-resistant, reversible)
 //
@@ -151,7 +151,7 @@ func TestSanitizeBranchForPath(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			result := sanitizeBranchForPath(tc.input)
+			result := SanitizeBranchForPath(tc.input)
 			require.Equal(t, tc.expected, result)
 		})
 	}
@@ -239,6 +239,17 @@ func TestResolveTemplate(t *testing.T) {
 			},
 			expected: "/Users/me/worktrees/my-project/feature-branch",
 		},
+		{
+			name:     "repo alias with slashed branch",
+			template: "/Users/me/worktrees/{repo}/{branch}",
+			vars: templateVars{
+				branch:    "feature/login",
+				repoName:  "my-project",
+				repoRoot:  "/Users/me/src/my-project",
+				sessionID: "a1b2c3d4",
+			},
+			expected: "/Users/me/worktrees/my-project/feature-login",
+		},
 		{
 			name:     "pattern with session ID",
 			template: "/tmp/wt/{repo-name}/{branch}-{session-id}",
@@ -0,0 +1,55 @@
+package session
+
+import "time"
+
+// AgentSessionAnalytics holds the metrics parsed from the session storage of
+// tools other than Claude and Gemini (OpenCode, Codex). The fields mean the
+// same for every tool, so the UI can show them without knowing which one
+// wrote the data.
+type AgentSessionAnalytics struct {
+	// Token usage summed over the session. InputTokens includes the cached
+	// portion, CachedTokens is that portion. ReasoningTokens are part of
+	// OutputTokens for tools that report them separately.
+	InputTokens     int `json:"input_tokens"`
+	OutputTokens    int `json:"output_tokens"`
+	CachedTokens    int `json:"cached_tokens"`
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
+
+	// Current context size (last turn's input tokens)
+	CurrentContextTokens int `json:"current_context_tokens"`
+
+	// Session metrics. TotalTurns counts model responses.
+	TotalTurns int           `json:"total_turns"`
+	Duration   time.Duration `json:"duration"`
+	StartTime  time.Time     `json:"start_time"`
+	LastActive time.Time     `json:"last_active"`
+
+	// Model of the most recent turn that named one
+	Model string `json:"model,omitempty"`
+
+	// EstimatedCost is the cost the tool itself recorded, when it does
+	// (OpenCode); zero otherwise.
+	EstimatedCost float64 `json:"estimated_cost,omitempty"`
+
+	// SessionID is the session the numbers were parsed from. A different ID
+	// on the next update resets the analytics instead of mixing sessions.
+	SessionID string `json:"session_id,omitempty"`
+
+	// In-memory cache: newest modification time of the parsed files (skip
+	// re-parse if unchanged)
+	LastFileModTime time.Time `json:"-"`
+}
+
+// TotalTokens returns the sum of input and output tokens
+func (a *AgentSessionAnalytics) TotalTokens() int {
+	return a.InputTokens + a.OutputTokens
+}
+
+// setSpan records the session's first and last activity.
+func (a *AgentSessionAnalytics) setSpan(start, last time.Time) {
+	a.StartTime = start
+	a.LastActive = last
+	if !start.IsZero() && !last.IsZero() {
+		a.Duration = last.Sub(start)
+	}
+}
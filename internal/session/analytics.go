@@ -304,7 +304,7 @@ func CalculateBillingBlocks(timestamps []time.Time, windowSize time.Duration) []
 	}
 
 	// Mark current block as active if within window from now
-	now := time.Now()
+	now := nowFn()
 	if len(blocks) > 0 {
 		lastBlock := &blocks[len(blocks)-1]
 		if now.Sub(lastBlock.StartTime) < windowSize {
@@ -0,0 +1,181 @@
+package session
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// Analytics is the tool-independent view of a session's parsed metrics.
+// Implemented by GeminiSessionAnalytics and AgentSessionAnalytics.
+type Analytics interface {
+	TokenCounter
+	// ActiveSpan returns the session's first and last recorded activity;
+	// either may be zero when the session file has no timestamps.
+	ActiveSpan() (start, last time.Time)
+}
+
+// ToolAnalyticsProvider finds and parses one tool's session storage. Callers
+// pick the provider by the session's tool (Instance.AnalyticsProvider, or
+// Instance.RefreshToolAnalytics) instead of calling the per-tool functions
+// directly.
+type ToolAnalyticsProvider interface {
+	// SessionsDir returns the directory the tool keeps projectPath's sessions
+	// in. Tools that do not split storage by project return the shared root.
+	SessionsDir(projectPath string) string
+
+	// Locate returns the path holding sessionID's data, searching every
+	// project.
+	Locate(sessionID string) (string, error)
+
+	// Parse computes fresh analytics from a path returned by Locate.
+	Parse(file string) (Analytics, error)
+
+	// Refresh updates the instance's cached analytics for this tool from
+	// disk, reusing what the tool allows from the previous parse (Gemini
+	// keeps an mtime cache and rate samples). Callers must not hold i.mu.
+	Refresh(i *Instance)
+}
+
+// AnalyticsProvider returns the analytics provider for the session's tool, or
+// nil when agent-deck cannot parse that tool's storage.
+func (i *Instance) AnalyticsProvider() ToolAnalyticsProvider {
+	tool := i.GetToolThreadSafe()
+	switch {
+	case tool == "gemini":
+		return geminiAnalyticsProvider{}
+	case tool == "opencode":
+		return openCodeAnalyticsProvider{}
+	case IsCodexCompatible(tool):
+		return codexAnalyticsProvider{home: i.getCodexHomeDir()}
+	}
+	return nil
+}
+
+// RefreshToolAnalytics re-reads the session's analytics through its tool's
+// provider and returns them; nil for tools without a provider or before the
+// first successful parse.
+func (i *Instance) RefreshToolAnalytics() Analytics {
+	p := i.AnalyticsProvider()
+	if p == nil {
+		return nil
+	}
+	p.Refresh(i)
+	return i.ToolAnalytics()
+}
+
+// ToolAnalytics returns the analytics last parsed for the session's tool, or
+// nil when there are none yet. Analytics left over from another tool (after
+// the session's tool was changed) are not returned.
+func (i *Instance) ToolAnalytics() Analytics {
+	tool := i.GetToolThreadSafe()
+	switch {
+	case tool == "gemini":
+		if i.GeminiAnalytics != nil {
+			return i.GeminiAnalytics
+		}
+	case tool == "opencode":
+		if i.OpenCodeAnalytics != nil {
+			return i.OpenCodeAnalytics
+		}
+	case IsCodexCompatible(tool):
+		if i.CodexAnalytics != nil {
+			return i.CodexAnalytics
+		}
+	}
+	return nil
+}
+
+// ActiveSpan implements Analytics.
+func (a *GeminiSessionAnalytics) ActiveSpan() (start, last time.Time) {
+	return a.StartTime, a.LastActive
+}
+
+// ActiveSpan implements Analytics.
+func (a *AgentSessionAnalytics) ActiveSpan() (start, last time.Time) {
+	return a.StartTime, a.LastActive
+}
+
+// geminiAnalyticsProvider reads ~/.gemini/tmp/<project_hash>/chats.
+type geminiAnalyticsProvider struct{}
+
+func (geminiAnalyticsProvider) SessionsDir(projectPath string) string {
+	return GetGeminiSessionsDir(projectPath)
+}
+
+func (geminiAnalyticsProvider) Locate(sessionID string) (string, error) {
+	if path := findGeminiSessionInAllProjects(sessionID); path != "" {
+		return path, nil
+	}
+	return "", fmt.Errorf("session file not found")
+}
+
+func (geminiAnalyticsProvider) Parse(file string) (Analytics, error) {
+	a, err := ParseGeminiAnalyticsFile(file)
+	if err != nil {
+		return nil, err // not a typed nil inside the interface
+	}
+	return a, nil
+}
+
+func (geminiAnalyticsProvider) Refresh(i *Instance) {
+	i.RefreshGeminiAnalytics()
+}
+
+// openCodeAnalyticsProvider reads OpenCode's storage; a session's data is a
+// directory of message files rather than a single file.
+type openCodeAnalyticsProvider struct{}
+
+func (openCodeAnalyticsProvider) SessionsDir(string) string {
+	return filepath.Join(GetOpenCodeDataDir(), "storage", "session")
+}
+
+func (openCodeAnalyticsProvider) Locate(sessionID string) (string, error) {
+	if sessionID == "" {
+		return "", fmt.Errorf("invalid session ID")
+	}
+	dir := openCodeMessagesDir(sessionID)
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(files) == 0 {
+		return "", fmt.Errorf("session messages not found")
+	}
+	return dir, nil
+}
+
+func (openCodeAnalyticsProvider) Parse(dir string) (Analytics, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	parsed := parseOpenCodeMessages(files)
+	parsed.SessionID = filepath.Base(dir)
+	return parsed, nil
+}
+
+func (openCodeAnalyticsProvider) Refresh(i *Instance) {
+	i.updateOpenCodeAnalytics()
+}
+
+// codexAnalyticsProvider reads the rollout files under a Codex home.
+type codexAnalyticsProvider struct {
+	home string
+}
+
+func (p codexAnalyticsProvider) SessionsDir(string) string {
+	return filepath.Join(p.home, "sessions")
+}
+
+func (p codexAnalyticsProvider) Locate(sessionID string) (string, error) {
+	return locateCodexRollout(p.home, sessionID)
+}
+
+func (p codexAnalyticsProvider) Parse(file string) (Analytics, error) {
+	a, err := parseCodexRollout(file)
+	if err != nil {
+		return nil, err // not a typed nil inside the interface
+	}
+	return a, nil
+}
+
+func (codexAnalyticsProvider) Refresh(i *Instance) {
+	i.updateCodexAnalytics()
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInstanceAnalyticsProvider_ByTool(t *testing.T) {
+	cases := map[string]ToolAnalyticsProvider{
+		"gemini":   geminiAnalyticsProvider{},
+		"opencode": openCodeAnalyticsProvider{},
+		"shell":    nil,
+	}
+	for tool, want := range cases {
+		got := (&Instance{Tool: tool}).AnalyticsProvider()
+		if got != want {
+			t.Errorf("%s: provider = %#v, want %#v", tool, got, want)
+		}
+	}
+	if _, ok := (&Instance{Tool: "codex"}).AnalyticsProvider().(codexAnalyticsProvider); !ok {
+		t.Error("codex: want a codexAnalyticsProvider")
+	}
+}
+
+func TestGeminiAnalyticsProvider_LocateAndParse(t *testing.T) {
+	geminiConfigDirOverride = t.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+
+	sessionsDir := GetGeminiSessionsDir("/Users/ashesh/provider-project")
+	_ = os.MkdirAll(sessionsDir, 0755)
+	sessionData := `{
+  "sessionId": "abc12345-7777-7777-7777-777777777777",
+  "startTime": "2025-12-23T00:24:00.000Z",
+  "lastUpdated": "2025-12-23T00:30:00.000Z",
+  "messages": [{"type": "gemini", "content": "hi", "model": "gemini-2.5-pro", "tokens": {"input": 100, "output": 20}}]
+}`
+	_ = os.WriteFile(filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json"), []byte(sessionData), 0644)
+
+	p := geminiAnalyticsProvider{}
+	file, err := p.Locate("abc12345-7777-7777-7777-777777777777")
+	if err != nil {
+		t.Fatalf("Locate: %v", err)
+	}
+	a, err := p.Parse(file)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if a.TotalTokens() != 120 {
+		t.Errorf("TotalTokens = %d, want 120", a.TotalTokens())
+	}
+	start, last := a.ActiveSpan()
+	if last.Sub(start) != 6*time.Minute {
+		t.Errorf("span = %v, want 6m", last.Sub(start))
+	}
+}
+
+func TestInstanceToolAnalytics_ByTool(t *testing.T) {
+	inst := &Instance{Tool: "gemini"}
+	if a := inst.ToolAnalytics(); a != nil {
+		t.Errorf("ToolAnalytics = %#v, want nil", a)
+	}
+	inst.CodexAnalytics = &AgentSessionAnalytics{InputTokens: 3}
+	if a := inst.ToolAnalytics(); a != nil {
+		t.Errorf("ToolAnalytics = %#v, want nil: a Gemini session ignores leftover Codex analytics", a)
+	}
+	inst.Tool = "codex"
+	if a := inst.ToolAnalytics(); a == nil || a.TotalTokens() != 3 {
+		t.Errorf("ToolAnalytics = %#v, want the Codex analytics", a)
+	}
+}
+
+func TestInstanceRefreshToolAnalytics_Gemini(t *testing.T) {
+	geminiConfigDirOverride = t.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/refresh-project"
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	_ = os.MkdirAll(sessionsDir, 0755)
+	sessionData := `{
+  "sessionId": "abc12345-9999-9999-9999-999999999999",
+  "startTime": "2025-12-23T00:24:00.000Z",
+  "lastUpdated": "2025-12-23T00:30:00.000Z",
+  "messages": [{"type": "gemini", "content": "hi", "model": "gemini-2.5-pro", "tokens": {"input": 40, "output": 2}}]
+}`
+	_ = os.WriteFile(filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json"), []byte(sessionData), 0644)
+
+	inst := &Instance{Tool: "gemini", ProjectPath: projectPath, GeminiSessionID: "abc12345-9999-9999-9999-999999999999"}
+	a := inst.RefreshToolAnalytics()
+	if a == nil || a.TotalTokens() != 42 {
+		t.Fatalf("RefreshToolAnalytics = %#v, want 42 tokens", a)
+	}
+	if (&Instance{Tool: "shell"}).RefreshToolAnalytics() != nil {
+		t.Error("a tool without a provider has no analytics")
+	}
+}
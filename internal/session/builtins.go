@@ -34,6 +34,11 @@ type builtinTool struct {
 	// "epic"/"tapioca". Mirrors detectTool()'s hasCommandToken() arm.
 	detectTokens []string
 
+	// flags is a static table of the tool's common command-line flags, offered
+	// as completions when the tool is typed as a custom shell command. It is a
+	// convenience list, not the tool's full CLI surface.
+	flags []string
+
 	// Installed reports whether this tool's command resolved on the host PATH at
 	// registry-init time. It is ONLY populated when the show_only_installed_tools
 	// filter is on (issue #1259); with the filter off the probe is skipped
@@ -55,16 +60,38 @@ type builtinTool struct {
 //   - "shell" is the catch-all fallback, never matched by a pattern.
 func builtinTools() []builtinTool {
 	return []builtinTool{
-		{Name: "claude", Icon: "🤖", detectSubstrings: []string{"claude"}},
-		{Name: "opencode", Icon: "🌐", detectSubstrings: []string{"opencode", "open-code"}},
-		{Name: "gemini", Icon: "✨", detectSubstrings: []string{"gemini"}},
-		{Name: "codex", Icon: "💻", detectSubstrings: []string{"codex"}},
+		{Name: "claude", Icon: "🤖", detectSubstrings: []string{"claude"}, flags: []string{
+			"--add-dir", "--allowedTools", "--append-system-prompt", "--continue",
+			"--dangerously-skip-permissions", "--debug", "--disallowedTools", "--fork-session",
+			"--mcp-config", "--model", "--permission-mode", "--print", "--resume",
+			"--session-id", "--settings", "--verbose",
+		}},
+		{Name: "opencode", Icon: "🌐", detectSubstrings: []string{"opencode", "open-code"}, flags: []string{
+			"--agent", "--continue", "--model", "--port", "--prompt", "--session",
+		}},
+		{Name: "gemini", Icon: "✨", detectSubstrings: []string{"gemini"}, flags: []string{
+			"--approval-mode", "--checkpointing", "--debug", "--include-directories", "--model",
+			"--prompt", "--prompt-interactive", "--resume", "--sandbox", "--yolo",
+		}},
+		{Name: "codex", Icon: "💻", detectSubstrings: []string{"codex"}, flags: []string{
+			"--ask-for-approval", "--cd", "--config", "--dangerously-bypass-approvals-and-sandbox",
+			"--full-auto", "--image", "--model", "--profile", "--sandbox", "--search",
+		}},
 		{Name: "pi", Icon: "π", detectTokens: []string{"pi"}},
-		{Name: "copilot", Icon: "🐙", detectSubstrings: []string{"copilot"}},
-		{Name: "crush", Icon: "💘", detectSubstrings: []string{"crush"}},
-		{Name: "cursor", Icon: "📝", detectSubstrings: []string{"cursor"}},
+		{Name: "copilot", Icon: "🐙", detectSubstrings: []string{"copilot"}, flags: []string{
+			"--add-dir", "--allow-all-tools", "--allow-tool", "--continue", "--deny-tool",
+			"--model", "--resume",
+		}},
+		{Name: "crush", Icon: "💘", detectSubstrings: []string{"crush"}, flags: []string{
+			"--cwd", "--debug", "--yolo",
+		}},
+		{Name: "cursor", Icon: "📝", detectSubstrings: []string{"cursor"}, flags: []string{
+			"--force", "--model", "--resume",
+		}},
 		{Name: "hermes", Icon: "☤", detectSubstrings: []string{"hermes"}},
-		{Name: "aider", Icon: "🐚"},
+		{Name: "aider", Icon: "🐚", flags: []string{
+			"--architect", "--model", "--no-auto-commits", "--read", "--yes-always",
+		}},
 		{Name: "shell", Icon: "🐚"},
 	}
 }
@@ -0,0 +1,228 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/agentpaths"
+)
+
+// claudeModelCache holds the cached model list from the Anthropic API,
+// mirroring the Gemini one.
+var (
+	claudeModelCacheMu   sync.Mutex
+	claudeModelCacheList []string
+	claudeModelCacheTime time.Time
+	claudeModelCacheTTL  = 1 * time.Hour
+)
+
+// claudeModelCacheFileName is the on-disk copy of the model cache.
+const claudeModelCacheFileName = "claude-models.json"
+
+// claudeModelCachePath locates the on-disk cache; a variable for tests.
+var claudeModelCachePath = func() (string, error) {
+	return agentpaths.CachePath(claudeModelCacheFileName)
+}
+
+// claudeModelCacheLoaded records that the on-disk cache has been read into
+// claudeModelCacheList (once per process).
+var claudeModelCacheLoaded bool
+
+// loadClaudeModelCacheLocked seeds the in-memory cache from disk. Caller holds
+// claudeModelCacheMu.
+func loadClaudeModelCacheLocked() {
+	claudeModelCacheLoaded = true
+	path, err := claudeModelCachePath()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var file modelCacheFile
+	if err := json.Unmarshal(data, &file); err != nil || len(file.Models) == 0 {
+		sessionLog.Debug("claude_model_cache_ignored", slog.String("path", path))
+		return
+	}
+	if len(claudeModelCacheList) == 0 {
+		claudeModelCacheList = file.Models
+		claudeModelCacheTime = file.FetchedAt
+	}
+}
+
+// saveClaudeModelCacheLocked writes the in-memory cache to disk; failures
+// only cost a refetch after the next restart. Caller holds claudeModelCacheMu.
+func saveClaudeModelCacheLocked() {
+	path, err := claudeModelCachePath()
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(modelCacheFile{Models: claudeModelCacheList, FetchedAt: claudeModelCacheTime})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err == nil {
+		err = atomicWriteFile(path, data, 0o600)
+	}
+	if err != nil {
+		sessionLog.Debug("claude_model_cache_save_failed", slog.String("error", err.Error()))
+	}
+}
+
+// ClaudeModelFallback is the built-in model list used when the Anthropic API
+// is unavailable; the New Session dialog suggests it until the live list
+// arrives.
+var ClaudeModelFallback = []string{
+	"claude-sonnet-4-6",
+	"claude-opus-4-8",
+	"claude-opus-4-7",
+	"claude-haiku-4-5",
+	"claude-haiku-4-5-20251001",
+}
+
+// claudeModelAliases are the model names Claude Code resolves itself, so they
+// are valid regardless of the model list.
+var claudeModelAliases = []string{"default", "opus", "sonnet", "haiku", "opusplan"}
+
+// claudeDefaultBaseURL is the public Anthropic API host, used unless
+// ANTHROPIC_BASE_URL (the variable Claude Code itself honors) is set.
+const claudeDefaultBaseURL = "https://api.anthropic.com"
+
+// claudeModelsURL returns the model list endpoint.
+func claudeModelsURL() string {
+	base := strings.TrimSpace(os.Getenv("ANTHROPIC_BASE_URL"))
+	if base == "" {
+		base = claudeDefaultBaseURL
+	}
+	return strings.TrimRight(base, "/") + "/v1/models?limit=1000"
+}
+
+// ErrNoAnthropicAPIKey is returned by GetAvailableClaudeModels alongside the
+// fallback list when ANTHROPIC_API_KEY is not set; no request was made.
+var ErrNoAnthropicAPIKey = errors.New("ANTHROPIC_API_KEY not set")
+
+// GetAvailableClaudeModels returns a sorted list of Claude model IDs.
+// Priority: 1) CLAUDE_MODELS_OVERRIDE env var, 2) cached API result, 3) live
+// API call, 4) fallback list. When the live call fails the last cached list
+// (even if expired) or ClaudeModelFallback is returned together with the
+// error (ErrNoAnthropicAPIKey, or one wrapping ErrModelFetchFailed,
+// ErrAPIKeyRejected, ErrModelRateLimited or ErrModelResponseInvalid). The
+// list is still usable.
+func GetAvailableClaudeModels() ([]string, error) {
+	// Priority 1: env var override (for testing)
+	if override := os.Getenv("CLAUDE_MODELS_OVERRIDE"); override != "" {
+		var result []string
+		for _, m := range strings.Split(override, ",") {
+			if m = strings.TrimSpace(m); m != "" {
+				result = append(result, m)
+			}
+		}
+		sort.Strings(result)
+		return result, nil
+	}
+
+	// Priority 2: cache hit (in memory, or persisted by an earlier run)
+	claudeModelCacheMu.Lock()
+	defer claudeModelCacheMu.Unlock()
+
+	if !claudeModelCacheLoaded {
+		loadClaudeModelCacheLocked()
+	}
+	if len(claudeModelCacheList) > 0 && time.Since(claudeModelCacheTime) < claudeModelCacheTTL {
+		return slices.Clone(claudeModelCacheList), nil
+	}
+
+	// Priority 3: API call (requires ANTHROPIC_API_KEY)
+	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	if apiKey == "" {
+		return slices.Clone(ClaudeModelFallback), ErrNoAnthropicAPIKey
+	}
+
+	models, err := fetchClaudeModels(apiKey)
+	if err != nil {
+		// Priority 4: a stale cached list beats the hardcoded one.
+		if len(claudeModelCacheList) > 0 {
+			return slices.Clone(claudeModelCacheList), err
+		}
+		return slices.Clone(ClaudeModelFallback), err
+	}
+
+	claudeModelCacheList = models
+	claudeModelCacheTime = time.Now()
+	saveClaudeModelCacheLocked()
+
+	return slices.Clone(models), nil
+}
+
+// fetchClaudeModels makes a single model list request. The key travels in a
+// header, so unlike Gemini no URL needs redacting.
+func fetchClaudeModels(apiKey string) ([]string, error) {
+	req, err := http.NewRequest(http.MethodGet, claudeModelsURL(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrModelFetchFailed, err)
+	}
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	// The default transport honors HTTPS_PROXY / NO_PROXY.
+	client := &http.Client{Timeout: 5 * time.Second}
+	// #nosec G704 -- URL is the Anthropic API endpoint or the user's own
+	// ANTHROPIC_BASE_URL.
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrModelFetchFailed, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, 529: // 529: Anthropic "overloaded"
+		return nil, fmt.Errorf("%w: API returned status %d", ErrModelRateLimited, resp.StatusCode)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("%w: API returned status %d", ErrAPIKeyRejected, resp.StatusCode)
+	default:
+		return nil, fmt.Errorf("%w: API returned status %d", ErrModelResponseInvalid, resp.StatusCode)
+	}
+
+	var apiResp struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode API response: %w", ErrModelResponseInvalid, err)
+	}
+	var models []string
+	for _, m := range apiResp.Data {
+		if m.ID != "" {
+			models = append(models, m.ID)
+		}
+	}
+	if len(models) == 0 {
+		return nil, fmt.Errorf("%w: empty model list", ErrModelResponseInvalid)
+	}
+	sort.Strings(models)
+	return models, nil
+}
+
+// IsValidClaudeModel reports whether model can be passed to claude --model:
+// one of models, or an alias Claude Code resolves itself ("opus",
+// "sonnet[1m]", ...).
+func IsValidClaudeModel(model string, models []string) bool {
+	model = strings.TrimSpace(model)
+	if slices.Contains(models, model) {
+		return true
+	}
+	return slices.Contains(claudeModelAliases, strings.TrimSuffix(model, "[1m]"))
+}
@@ -0,0 +1,131 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func isolateClaudeModelCache(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), claudeModelCacheFileName)
+	reset := func() {
+		claudeModelCacheMu.Lock()
+		claudeModelCacheList = nil
+		claudeModelCacheTime = time.Time{}
+		claudeModelCacheLoaded = false
+		claudeModelCacheMu.Unlock()
+	}
+	origPath := claudeModelCachePath
+	claudeModelCachePath = func() (string, error) { return path, nil }
+	reset()
+	t.Cleanup(func() {
+		claudeModelCachePath = origPath
+		reset()
+	})
+	return path
+}
+
+func TestGetAvailableClaudeModels_Override(t *testing.T) {
+	t.Setenv("CLAUDE_MODELS_OVERRIDE", "claude-b, claude-a,")
+	models, err := GetAvailableClaudeModels()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(models, []string{"claude-a", "claude-b"}) {
+		t.Errorf("models = %v, want sorted override", models)
+	}
+}
+
+func TestGetAvailableClaudeModels_NoAPIKey(t *testing.T) {
+	t.Setenv("CLAUDE_MODELS_OVERRIDE", "")
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	isolateClaudeModelCache(t)
+
+	models, err := GetAvailableClaudeModels()
+	if !errors.Is(err, ErrNoAnthropicAPIKey) {
+		t.Fatalf("err = %v, want ErrNoAnthropicAPIKey", err)
+	}
+	if !slices.Equal(models, ClaudeModelFallback) {
+		t.Errorf("models = %v, want the fallback list", models)
+	}
+}
+
+func TestGetAvailableClaudeModels_FetchAndCache(t *testing.T) {
+	t.Setenv("CLAUDE_MODELS_OVERRIDE", "")
+	t.Setenv("ANTHROPIC_API_KEY", "sk-test")
+	isolateClaudeModelCache(t)
+
+	var calls int
+	var gotPath, gotKey, gotVersion string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		gotPath, gotKey, gotVersion = r.URL.Path, r.Header.Get("x-api-key"), r.Header.Get("anthropic-version")
+		fmt.Fprint(w, `{"data":[{"id":"claude-zeta"},{"id":"claude-alpha"}],"has_more":false}`)
+	}))
+	defer srv.Close()
+	t.Setenv("ANTHROPIC_BASE_URL", srv.URL+"/")
+
+	for range 2 {
+		models, err := GetAvailableClaudeModels()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(models, []string{"claude-alpha", "claude-zeta"}) {
+			t.Fatalf("models = %v", models)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("API called %d times, want 1 (second call served from cache)", calls)
+	}
+	if gotPath != "/v1/models" || gotKey != "sk-test" || gotVersion == "" {
+		t.Errorf("request path=%q key=%q version=%q", gotPath, gotKey, gotVersion)
+	}
+
+	// A fresh process reads the list back from disk without calling the API.
+	claudeModelCacheMu.Lock()
+	claudeModelCacheList, claudeModelCacheLoaded = nil, false
+	claudeModelCacheMu.Unlock()
+	if models, err := GetAvailableClaudeModels(); err != nil || len(models) != 2 || calls != 1 {
+		t.Errorf("disk cache: models=%v err=%v calls=%d", models, err, calls)
+	}
+}
+
+func TestGetAvailableClaudeModels_KeyRejected(t *testing.T) {
+	t.Setenv("CLAUDE_MODELS_OVERRIDE", "")
+	t.Setenv("ANTHROPIC_API_KEY", "sk-bad")
+	isolateClaudeModelCache(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	t.Setenv("ANTHROPIC_BASE_URL", srv.URL)
+
+	models, err := GetAvailableClaudeModels()
+	if !errors.Is(err, ErrAPIKeyRejected) {
+		t.Fatalf("err = %v, want ErrAPIKeyRejected", err)
+	}
+	if !slices.Equal(models, ClaudeModelFallback) {
+		t.Errorf("models = %v, want the fallback list", models)
+	}
+}
+
+func TestIsValidClaudeModel(t *testing.T) {
+	models := []string{"claude-sonnet-4-6"}
+	for _, ok := range []string{"claude-sonnet-4-6", "opus", "sonnet[1m]", "opusplan"} {
+		if !IsValidClaudeModel(ok, models) {
+			t.Errorf("IsValidClaudeModel(%q) = false, want true", ok)
+		}
+	}
+	for _, bad := range []string{"claude-sonet-4-6", "gpt-5", ""} {
+		if IsValidClaudeModel(bad, models) {
+			t.Errorf("IsValidClaudeModel(%q) = true, want false", bad)
+		}
+	}
+}
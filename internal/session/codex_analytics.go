@@ -0,0 +1,244 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Codex rollout JSONL lines used for analytics:
+//
+//	{"timestamp":"...","type":"session_meta","payload":{"id":"<uuid>","cwd":"..."}}
+//	{"timestamp":"...","type":"turn_context","payload":{"model":"gpt-5-codex",...}}
+//	{"timestamp":"...","type":"event_msg","payload":{"type":"token_count","info":{
+//	    "total_token_usage":{...},"last_token_usage":{...}}}}
+//
+// VERIFIED: total_token_usage is cumulative over the session, so only the last
+// token_count matters; output_tokens already includes reasoning_output_tokens.
+type codexRolloutLine struct {
+	Timestamp string          `json:"timestamp"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type codexPayload struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Model string `json:"model"`
+	Info  *struct {
+		Total codexTokenUsage `json:"total_token_usage"`
+		Last  codexTokenUsage `json:"last_token_usage"`
+	} `json:"info"`
+}
+
+type codexTokenUsage struct {
+	InputTokens           int `json:"input_tokens"`
+	CachedInputTokens     int `json:"cached_input_tokens"`
+	OutputTokens          int `json:"output_tokens"`
+	ReasoningOutputTokens int `json:"reasoning_output_tokens"`
+}
+
+// CodexSessionInfo holds parsed session metadata
+type CodexSessionInfo struct {
+	SessionID   string // UUID
+	Cwd         string
+	Filename    string // Full path to the rollout file
+	StartTime   time.Time
+	LastUpdated time.Time
+}
+
+// codexRolloutGlob matches every rollout file, or only sessionID's when given.
+// Codex layout: codexHome/sessions/YYYY/MM/DD/rollout-<ts>-<uuid>.jsonl
+func codexRolloutGlob(codexHome, sessionID string) string {
+	if sessionID == "" {
+		sessionID = "*"
+	}
+	return filepath.Join(codexHome, "sessions", "*", "*", "*", "rollout-*-"+sessionID+".jsonl")
+}
+
+// ListCodexSessions returns the Codex sessions recorded for projectPath,
+// sorted by LastUpdated (most recent first).
+func ListCodexSessions(projectPath string) ([]CodexSessionInfo, error) {
+	return listCodexSessionsInHome(getCodexHomeDir(), projectPath)
+}
+
+func listCodexSessionsInHome(codexHome, projectPath string) ([]CodexSessionInfo, error) {
+	want := normalizePath(projectPath)
+
+	var sessions []CodexSessionInfo
+	var globErr error
+	// Same stuck-FS backstop as queryCodexSession.
+	if !runWithTimeout(codexWalkDirTimeout, func() {
+		files, err := filepath.Glob(codexRolloutGlob(codexHome, ""))
+		if err != nil {
+			globErr = err
+			return
+		}
+		for _, file := range files {
+			info, err := os.Stat(file)
+			if err != nil {
+				continue
+			}
+			s, ok := readCodexSessionMeta(file)
+			if !ok || normalizePath(s.Cwd) != want {
+				continue
+			}
+			s.Filename = file
+			s.LastUpdated = info.ModTime()
+			sessions = append(sessions, s)
+		}
+	}) {
+		return nil, fmt.Errorf("listing codex sessions timed out after %s", codexWalkDirTimeout)
+	}
+	if globErr != nil {
+		return nil, globErr
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUpdated.After(sessions[j].LastUpdated)
+	})
+	return sessions, nil
+}
+
+// readCodexSessionMeta reads the session_meta header of a rollout file. Like
+// codexSessionMatchesProject it gives up after a bounded number of lines.
+func readCodexSessionMeta(path string) (CodexSessionInfo, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return CodexSessionInfo{}, false
+	}
+	defer file.Close()
+
+	const maxLines = 256
+
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 1024*1024)
+
+	for n := 0; n < maxLines && scanner.Scan(); n++ {
+		var line codexRolloutLine
+		if json.Unmarshal(scanner.Bytes(), &line) != nil || line.Type != "session_meta" {
+			continue
+		}
+		var payload codexPayload
+		if json.Unmarshal(line.Payload, &payload) != nil || payload.ID == "" {
+			return CodexSessionInfo{}, false
+		}
+		start, _ := time.Parse(time.RFC3339Nano, line.Timestamp)
+		return CodexSessionInfo{
+			SessionID: payload.ID,
+			Cwd:       extractCodexCWDFromJSONLine(scanner.Bytes()),
+			StartTime: start,
+		}, true
+	}
+	return CodexSessionInfo{}, false
+}
+
+// UpdateCodexAnalyticsFromDisk updates the analytics struct from the session's
+// rollout file, skipping the parse when the file is unchanged.
+func UpdateCodexAnalyticsFromDisk(sessionID string, analytics *AgentSessionAnalytics) error {
+	return updateCodexAnalyticsInHome(getCodexHomeDir(), sessionID, analytics)
+}
+
+func updateCodexAnalyticsInHome(codexHome, sessionID string, analytics *AgentSessionAnalytics) error {
+	sessionID = strings.TrimSpace(sessionID)
+	if sessionID == "" {
+		return fmt.Errorf("invalid session ID")
+	}
+	sessionFile, err := locateCodexRollout(codexHome, sessionID)
+	if err != nil {
+		return err
+	}
+
+	fileInfo, err := os.Stat(sessionFile)
+	if err != nil {
+		return err
+	}
+	if analytics.SessionID == sessionID && !analytics.LastFileModTime.IsZero() && fileInfo.ModTime().Equal(analytics.LastFileModTime) {
+		return nil
+	}
+
+	parsed, err := parseCodexRollout(sessionFile)
+	if err != nil {
+		return err
+	}
+	parsed.SessionID = sessionID
+	parsed.LastFileModTime = fileInfo.ModTime()
+	*analytics = *parsed
+	return nil
+}
+
+// locateCodexRollout returns the rollout file of sessionID under codexHome.
+func locateCodexRollout(codexHome, sessionID string) (string, error) {
+	matches, err := filepath.Glob(codexRolloutGlob(codexHome, sessionID))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("session file not found")
+	}
+	return matches[len(matches)-1], nil
+}
+
+// parseCodexRollout computes fresh analytics from a rollout file. Rollouts
+// grow with every tool call, so it streams them line by line rather than
+// loading the whole file.
+func parseCodexRollout(sessionFile string) (*AgentSessionAnalytics, error) {
+	file, err := os.Open(sessionFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 10*1024*1024)
+
+	parsed := &AgentSessionAnalytics{}
+	var start, last time.Time
+	var total codexTokenUsage
+	for scanner.Scan() {
+		var line codexRolloutLine
+		if json.Unmarshal(scanner.Bytes(), &line) != nil {
+			continue // Partial last line while Codex is writing
+		}
+		if ts, err := time.Parse(time.RFC3339Nano, line.Timestamp); err == nil {
+			if start.IsZero() {
+				start = ts
+			}
+			last = ts
+		}
+		if line.Type != "turn_context" && line.Type != "event_msg" && line.Type != "session_meta" {
+			continue
+		}
+		var payload codexPayload
+		if json.Unmarshal(line.Payload, &payload) != nil {
+			continue
+		}
+		switch {
+		case line.Type == "session_meta":
+			parsed.SessionID = payload.ID
+		case line.Type == "turn_context" && payload.Model != "":
+			parsed.Model = payload.Model
+		case payload.Type == "token_count" && payload.Info != nil:
+			total = payload.Info.Total
+			parsed.CurrentContextTokens = payload.Info.Last.InputTokens
+			parsed.TotalTurns++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	parsed.InputTokens = total.InputTokens
+	parsed.CachedTokens = total.CachedInputTokens
+	parsed.OutputTokens = total.OutputTokens
+	parsed.ReasoningTokens = total.ReasoningOutputTokens
+	parsed.setSpan(start, last)
+	return parsed, nil
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const codexAnalyticsTestID = "0199a213-81c0-7800-8aa1-bbab2a035a53"
+
+func writeCodexRollout(t *testing.T, codexHome, sessionID, cwd string) string {
+	t.Helper()
+	dir := filepath.Join(codexHome, "sessions", "2025", "10", "03")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	lines := `{"timestamp":"2025-10-03T10:00:00.000Z","type":"session_meta","payload":{"id":"` + sessionID + `","cwd":"` + cwd + `"}}
+{"timestamp":"2025-10-03T10:00:01.000Z","type":"turn_context","payload":{"cwd":"` + cwd + `","model":"gpt-5-codex"}}
+{"timestamp":"2025-10-03T10:00:05.000Z","type":"event_msg","payload":{"type":"token_count","info":null}}
+{"timestamp":"2025-10-03T10:00:10.000Z","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":1000,"cached_input_tokens":200,"output_tokens":50,"reasoning_output_tokens":10},"last_token_usage":{"input_tokens":1000,"cached_input_tokens":200,"output_tokens":50,"reasoning_output_tokens":10}}}}
+{"timestamp":"2025-10-03T10:01:00.000Z","type":"turn_context","payload":{"cwd":"` + cwd + `","model":"gpt-5"}}
+{"timestamp":"2025-10-03T10:02:00.000Z","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":2500,"cached_input_tokens":900,"output_tokens":120,"reasoning_output_tokens":30},"last_token_usage":{"input_tokens":1500,"cached_input_tokens":700,"output_tokens":70,"reasoning_output_tokens":20}}}}
+{"timestamp":"2025-10-03T10:02:0`
+	path := filepath.Join(dir, "rollout-2025-10-03T10-00-00-"+sessionID+".jsonl")
+	if err := os.WriteFile(path, []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestListCodexSessions_FiltersByCwd(t *testing.T) {
+	codexHome := t.TempDir()
+	projectPath := t.TempDir()
+	writeCodexRollout(t, codexHome, codexAnalyticsTestID, projectPath)
+	writeCodexRollout(t, codexHome, "0199a213-81c0-7800-8aa1-000000000000", "/somewhere/else")
+
+	sessions, err := listCodexSessionsInHome(codexHome, projectPath)
+	if err != nil {
+		t.Fatalf("listCodexSessionsInHome: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1: %+v", len(sessions), sessions)
+	}
+	if sessions[0].SessionID != codexAnalyticsTestID {
+		t.Errorf("SessionID = %q, want %q", sessions[0].SessionID, codexAnalyticsTestID)
+	}
+	if sessions[0].StartTime.IsZero() || sessions[0].LastUpdated.IsZero() {
+		t.Errorf("times not set: %+v", sessions[0])
+	}
+}
+
+func TestUpdateCodexAnalyticsFromDisk(t *testing.T) {
+	codexHome := t.TempDir()
+	writeCodexRollout(t, codexHome, codexAnalyticsTestID, "/tmp/project")
+
+	analytics := &AgentSessionAnalytics{}
+	if err := updateCodexAnalyticsInHome(codexHome, codexAnalyticsTestID, analytics); err != nil {
+		t.Fatalf("updateCodexAnalyticsInHome: %v", err)
+	}
+	if analytics.InputTokens != 2500 || analytics.CachedTokens != 900 {
+		t.Errorf("Input/Cached = %d/%d, want 2500/900", analytics.InputTokens, analytics.CachedTokens)
+	}
+	if analytics.OutputTokens != 120 || analytics.ReasoningTokens != 30 {
+		t.Errorf("Output/Reasoning = %d/%d, want 120/30", analytics.OutputTokens, analytics.ReasoningTokens)
+	}
+	if analytics.CurrentContextTokens != 1500 {
+		t.Errorf("CurrentContextTokens = %d, want 1500", analytics.CurrentContextTokens)
+	}
+	if analytics.TotalTurns != 2 {
+		t.Errorf("TotalTurns = %d, want 2 (token_count without info is not a turn)", analytics.TotalTurns)
+	}
+	if analytics.Model != "gpt-5" {
+		t.Errorf("Model = %q, want gpt-5", analytics.Model)
+	}
+	if analytics.Duration.Minutes() != 2 {
+		t.Errorf("Duration = %v, want 2m0s", analytics.Duration)
+	}
+
+	// Unchanged file: cache hit leaves the struct alone
+	analytics.InputTokens = 999
+	if err := updateCodexAnalyticsInHome(codexHome, codexAnalyticsTestID, analytics); err != nil {
+		t.Fatal(err)
+	}
+	if analytics.InputTokens != 999 {
+		t.Errorf("InputTokens = %d, want 999 (mtime cache should skip the parse)", analytics.InputTokens)
+	}
+
+	// A different session resets instead of reusing the cache
+	other := "0199a213-81c0-7800-8aa1-111111111111"
+	writeCodexRollout(t, codexHome, other, "/tmp/project")
+	if err := updateCodexAnalyticsInHome(codexHome, other, analytics); err != nil {
+		t.Fatal(err)
+	}
+	if analytics.SessionID != other || analytics.InputTokens != 2500 {
+		t.Errorf("SessionID/InputTokens = %s/%d, want %s/2500", analytics.SessionID, analytics.InputTokens, other)
+	}
+}
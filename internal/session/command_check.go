@@ -0,0 +1,55 @@
+package session
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Values of [shell].command_check, the strictness of the custom command
+// check in the new session dialog.
+const (
+	// CommandCheckWarn shows the findings once; submitting the same command
+	// again runs it. The default.
+	CommandCheckWarn = "warn"
+	// CommandCheckBlock refuses commands with findings.
+	CommandCheckBlock = "block"
+	// CommandCheckOff disables the check.
+	CommandCheckOff = "off"
+)
+
+// CommandCheckModes lists the accepted [shell].command_check values.
+var CommandCheckModes = []string{CommandCheckWarn, CommandCheckBlock, CommandCheckOff}
+
+// shellCommandRisks are the patterns CheckShellCommand flags. They target
+// paste accidents, not legitimate scripting: ordinary pipes, redirects and
+// $VAR expansions are never reported.
+var shellCommandRisks = []struct {
+	re     *regexp.Regexp
+	reason string
+}{
+	{
+		regexp.MustCompile(`\brm\s+(?:-\S*\s+)*(?:/|/\*|~/?\*?|\$\{?HOME\}?/?\*?)(?:[\s;&|)]|$)`),
+		"deletes the root or home directory (rm -rf /)",
+	},
+	{regexp.MustCompile(`\bmkfs(?:\.\w+)?\b`), "formats a filesystem (mkfs)"},
+	{regexp.MustCompile(`\bdd\b[^\n]*\bof=/dev/`), "writes to a raw device (dd of=/dev/…)"},
+	{regexp.MustCompile(`>\s*/dev/(?:sd|nvme|disk|hd)`), "overwrites a disk device"},
+	{regexp.MustCompile(`:\(\)\s*\{`), "defines a fork bomb"},
+	{regexp.MustCompile(`\b(?:curl|wget)\b[^\n|]*\|\s*(?:sudo\s+)?(?:ba|z|da)?sh\b`), "pipes a download into a shell (curl | sh)"},
+	{regexp.MustCompile("`"), "contains backticks (command substitution)"},
+	{regexp.MustCompile(`[\x00-\x08\x0b-\x1f\x7f]`), "contains control characters (hidden pasted text?)"},
+}
+
+// CheckShellCommand reports risky patterns in a custom shell command, one
+// reason per kind found, in a fixed order. An empty result means nothing
+// was flagged.
+func CheckShellCommand(command string) []string {
+	command = strings.ReplaceAll(command, "\r\n", "\n")
+	var reasons []string
+	for _, r := range shellCommandRisks {
+		if r.re.MatchString(command) {
+			reasons = append(reasons, r.reason)
+		}
+	}
+	return reasons
+}
@@ -266,7 +266,7 @@ func (i *Instance) detectCopilotSessionAsync() {
 
 			// Propagate to tmux env for restart
 			if i.tmuxSession != nil {
-				if err := i.tmuxSession.SetEnvironment("COPILOT_SESSION_ID", sessionID); err != nil {
+				if err := i.SetEnv("COPILOT_SESSION_ID", sessionID); err != nil {
 					sessionLog.Warn("copilot_set_env_failed", slog.String("error", err.Error()))
 				}
 			}
@@ -0,0 +1,217 @@
+package session
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/vcs"
+	"github.com/asheshgoplani/agent-deck/internal/vcsbackend"
+)
+
+// CreateTxn tracks the resources a session create has made so far (a
+// worktree, a tmux session) so that a failure at a later step can tear them
+// down again, newest first. The zero value is ready to use.
+type CreateTxn struct {
+	undo []createUndo
+}
+
+type createUndo struct {
+	name string
+	fn   func() error
+}
+
+// Track records how to undo a resource that was just created. name is only
+// used for logging.
+func (t *CreateTxn) Track(name string, undo func() error) {
+	t.undo = append(t.undo, createUndo{name: name, fn: undo})
+}
+
+// Rollback undoes every tracked resource in reverse order of creation. Every
+// undo runs even if an earlier one fails; the failures are joined. Rollback
+// empties the transaction, so calling it twice is harmless.
+func (t *CreateTxn) Rollback() error {
+	var errs []error
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		u := t.undo[i]
+		if err := u.fn(); err != nil {
+			sessionLog.Warn("create_rollback_failed", slog.String("resource", u.name), slog.String("error", err.Error()))
+			errs = append(errs, fmt.Errorf("undo %s: %w", u.name, err))
+			continue
+		}
+		sessionLog.Info("create_rollback", slog.String("resource", u.name))
+	}
+	t.undo = nil
+	return errors.Join(errs...)
+}
+
+// TrackWorktree records a worktree made by backend at path, plus its branch
+// when the create made that too.
+func (t *CreateTxn) TrackWorktree(backend vcs.Backend, path, branch string, createdBranch bool) {
+	if createdBranch {
+		t.Track("branch "+branch, func() error { return backend.DeleteBranch(branch, true) })
+	}
+	t.Track("worktree "+path, func() error { return backend.RemoveWorktree(path, true) })
+}
+
+// TrackMultiRepo records a multi-repo session's parent directory and the
+// per-repo worktrees made inside it. Worktrees are undone before the
+// directory that holds them.
+func (t *CreateTxn) TrackMultiRepo(parentDir string, worktrees []MultiRepoWorktree) {
+	t.Track("multi-repo dir "+parentDir, func() error { return os.RemoveAll(parentDir) })
+	for _, wt := range worktrees {
+		t.Track("worktree "+wt.WorktreePath, func() error {
+			if err := git.RemoveWorktree(wt.RepoRoot, wt.WorktreePath, true); err != nil {
+				return err
+			}
+			return git.PruneWorktrees(wt.RepoRoot)
+		})
+	}
+}
+
+// Test seams for SessionSpec.Create: starting the tmux session, killing it
+// on rollback, and checking that the tool survived its launch.
+var (
+	createStartFn       = (*Instance).Start
+	createKillFn        = (*Instance).Kill
+	createCheckLaunchFn = checkLaunch
+)
+
+// launchGrace is how long Create waits before checking that the tool is
+// still running; a tool that fails on startup exits well within it.
+var launchGrace = 300 * time.Millisecond
+
+// CreateSession creates and starts the session described by spec,
+// all-or-nothing: when any step fails, the worktree and tmux session made so
+// far are removed again and no Instance is returned.
+func CreateSession(spec SessionSpec) (*Instance, error) {
+	var txn CreateTxn
+	inst, err := spec.Create(&txn)
+	if err != nil {
+		if rbErr := txn.Rollback(); rbErr != nil {
+			return nil, fmt.Errorf("%w (cleanup: %v)", err, rbErr)
+		}
+		return nil, err
+	}
+	return inst, nil
+}
+
+// Create makes the spec's worktree (when Worktree is set), builds the
+// instance and starts it, tracking each resource in txn. On error the caller
+// rolls txn back; callers with steps of their own after Create (such as
+// saving) roll back on those failures too. See CreateSession.
+func (s SessionSpec) Create(txn *CreateTxn) (*Instance, error) {
+	if s.MultiRepo {
+		return nil, fmt.Errorf("multi-repo sessions cannot be created from a spec yet")
+	}
+	if !s.Worktree {
+		inst, err := s.NewInstance()
+		if err != nil {
+			return nil, err
+		}
+		if err := startCreated(inst, txn); err != nil {
+			return nil, err
+		}
+		return inst, nil
+	}
+
+	if errs := ValidateSpec(s); len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	repoPath := ResolveProjectPath(strings.TrimSpace(s.Path), "")
+	branch := strings.TrimSpace(s.Branch)
+	backend, err := vcsbackend.Detect(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect VCS: %w", err)
+	}
+	wtPath, err := createSpecWorktree(backend, branch, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := s
+	plain.Worktree = false
+	plain.Path = wtPath
+	inst, err := plain.NewInstance()
+	if err != nil {
+		return nil, err
+	}
+	inst.WorktreePath = wtPath
+	inst.WorktreeRepoRoot = backend.RepoDir()
+	inst.WorktreeBranch = branch
+	inst.WorktreeType = string(backend.Type())
+	if err := startCreated(inst, txn); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+// createSpecWorktree returns the worktree for branch, reusing an existing
+// one (which is not ours to roll back) or creating it at the configured
+// location.
+func createSpecWorktree(backend vcs.Backend, branch string, txn *CreateTxn) (string, error) {
+	if existing, err := backend.GetWorktreeForBranch(branch); err == nil && existing != "" {
+		return existing, nil
+	}
+	settings := GetWorktreeSettings()
+	wtPath := backend.WorktreePath(vcs.WorktreePathOptions{
+		Branch:    branch,
+		Location:  settings.DefaultLocation,
+		SessionID: git.GeneratePathID(),
+		Template:  settings.Template(),
+	})
+	if err := os.MkdirAll(filepath.Dir(wtPath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create parent directory: %w", err)
+	}
+	createdBranch := !backend.BranchExists(branch)
+	var buf bytes.Buffer
+	setupErr, err := vcsbackend.CreateWorktreeWithSetup(backend, wtPath, branch, &buf, &buf, settings.SetupTimeout())
+	if err != nil {
+		return "", fmt.Errorf("failed to create worktree: %w", err)
+	}
+	txn.TrackWorktree(backend, wtPath, branch, createdBranch)
+	if setupErr != nil {
+		sessionLog.Warn("worktree_setup_script_failed", slog.String("error", setupErr.Error()), slog.String("output", buf.String()))
+	}
+	return wtPath, nil
+}
+
+// startCreated starts inst, tracks its tmux session in txn and checks that
+// the tool did not exit straight away.
+func startCreated(inst *Instance, txn *CreateTxn) error {
+	if err := createStartFn(inst); err != nil {
+		return fmt.Errorf("start session: %w", err)
+	}
+	txn.Track("tmux session "+inst.ID, func() error { return createKillFn(inst) })
+	if !launchCheckApplies(inst) {
+		return nil
+	}
+	return createCheckLaunchFn(inst)
+}
+
+// launchCheckApplies reports whether inst runs an agent that should still be
+// up after launchGrace. Shells and custom commands (a script, `make test`)
+// may legitimately finish at once.
+func launchCheckApplies(inst *Instance) bool {
+	if inst.Tool == "shell" {
+		return false
+	}
+	return inst.Command == "" || inst.Command == inst.Tool
+}
+
+// checkLaunch reports an error when the tool has already exited shortly
+// after Start, which would leave an empty or dead tmux session behind.
+func checkLaunch(inst *Instance) error {
+	time.Sleep(launchGrace)
+	ts := inst.GetTmuxSession()
+	if ts == nil || !ts.Exists() || ts.IsPaneDead() {
+		return fmt.Errorf("%s exited during launch", inst.Tool)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTxn_RollbackReverseOrder(t *testing.T) {
+	var txn CreateTxn
+	var order []string
+	txn.Track("first", func() error { order = append(order, "first"); return nil })
+	txn.Track("second", func() error { order = append(order, "second"); return errors.New("busy") })
+	txn.Track("third", func() error { order = append(order, "third"); return nil })
+
+	err := txn.Rollback()
+	assert.Equal(t, []string{"third", "second", "first"}, order, "every undo runs, newest first")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "undo second")
+
+	order = nil
+	require.NoError(t, txn.Rollback())
+	assert.Empty(t, order, "a second rollback has nothing left to undo")
+}
+
+// fakeCreateTmux swaps the tmux seams of SessionSpec.Create for an in-memory
+// set of live sessions, and makes the launch check fail when launchErr is set.
+func fakeCreateTmux(t *testing.T, launchErr error) map[string]bool {
+	t.Helper()
+	live := make(map[string]bool)
+	origStart, origKill, origCheck := createStartFn, createKillFn, createCheckLaunchFn
+	t.Cleanup(func() { createStartFn, createKillFn, createCheckLaunchFn = origStart, origKill, origCheck })
+	createStartFn = func(i *Instance) error { live[i.ID] = true; return nil }
+	createKillFn = func(i *Instance) error { delete(live, i.ID); return nil }
+	createCheckLaunchFn = func(*Instance) error { return launchErr }
+	return live
+}
+
+func gitWorktreeCount(t *testing.T, repo string) int {
+	t.Helper()
+	out, err := exec.Command("git", "-C", repo, "worktree", "list", "--porcelain").Output()
+	require.NoError(t, err)
+	return strings.Count(string(out), "worktree ")
+}
+
+func TestCreateSession_FailingLaunchLeavesNothingBehind(t *testing.T) {
+	repo := initTestGitRepo(t)
+	live := fakeCreateTmux(t, errors.New("claude exited during launch"))
+
+	inst, err := CreateSession(SessionSpec{Title: "wt", Path: repo, Tool: "claude", Worktree: true, Branch: "feature/launch-fails"})
+	require.Error(t, err)
+	assert.Nil(t, inst)
+	assert.Contains(t, err.Error(), "exited during launch")
+
+	assert.Empty(t, live, "no orphan tmux session may remain")
+	assert.Equal(t, 1, gitWorktreeCount(t, repo), "only the main worktree may remain")
+	out, _ := exec.Command("git", "-C", repo, "branch", "--list", "feature/launch-fails").Output()
+	assert.Empty(t, strings.TrimSpace(string(out)), "the branch made for the session is deleted")
+}
+
+func TestCreateSession_WorktreeSuccess(t *testing.T) {
+	repo := initTestGitRepo(t)
+	live := fakeCreateTmux(t, nil)
+
+	inst, err := CreateSession(SessionSpec{Title: "wt", Path: repo, Tool: "shell", Worktree: true, Branch: "feature/ok"})
+	require.NoError(t, err)
+	assert.True(t, live[inst.ID])
+	assert.Equal(t, "feature/ok", inst.WorktreeBranch)
+	assert.Equal(t, inst.WorktreePath, inst.ProjectPath)
+	assert.Equal(t, 2, gitWorktreeCount(t, repo))
+	_, statErr := os.Stat(inst.WorktreePath)
+	assert.NoError(t, statErr)
+}
+
+func TestCreateSession_ShellSkipsLaunchCheck(t *testing.T) {
+	live := fakeCreateTmux(t, errors.New("exited during launch"))
+
+	inst, err := CreateSession(SessionSpec{Title: "sh", Path: t.TempDir(), Tool: "shell"})
+	require.NoError(t, err, "a shell has no agent whose launch could fail")
+	assert.True(t, live[inst.ID])
+}
+
+func TestCreateTxn_TrackMultiRepoRollback(t *testing.T) {
+	repoA, repoB := initTestGitRepo(t), initTestGitRepo(t)
+	parentDir := filepath.Join(t.TempDir(), "multi")
+	require.NoError(t, os.MkdirAll(parentDir, 0o755))
+
+	result := CreateMultiRepoWorktrees([]string{repoA, repoB}, parentDir, "feature/multi", 0)
+	require.Len(t, result.Worktrees, 2)
+	assert.Equal(t, 2, gitWorktreeCount(t, repoA))
+
+	var txn CreateTxn
+	txn.TrackMultiRepo(parentDir, result.Worktrees)
+	require.NoError(t, txn.Rollback())
+
+	assert.Equal(t, 1, gitWorktreeCount(t, repoA), "repo A keeps only its main worktree")
+	assert.Equal(t, 1, gitWorktreeCount(t, repoB), "repo B keeps only its main worktree")
+	_, err := os.Stat(parentDir)
+	assert.True(t, os.IsNotExist(err), "the multi-repo dir is removed")
+}
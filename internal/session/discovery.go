@@ -1,6 +1,7 @@
 package session
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -15,6 +16,70 @@ func DiscoverExistingTmuxSessions(existingInstances []*Instance) ([]*Instance, e
 		return nil, err
 	}
 
+	var discovered []*Instance
+	for _, sess := range unmanagedTmuxSessions(tmuxSessions, existingInstances) {
+		title, isOrphaned := adoptedTitle(sess)
+		groupPath := ""
+		if isOrphaned {
+			// Put orphaned sessions in a "Recovered" group so user knows they were recovered
+			groupPath = "recovered"
+		}
+
+		// Determine tool type - for orphaned agent-deck sessions, assume claude (most common)
+		tool := detectToolFromName(title)
+		if isOrphaned && tool == "shell" {
+			tool = "claude" // Most agent-deck sessions are Claude sessions
+		}
+
+		discovered = append(discovered, newAdoptedInstance(sess, title, groupPath, tool))
+	}
+
+	return discovered, nil
+}
+
+// ListUnmanagedTmuxSessions returns the names of tmux sessions that none of
+// the managed instances is bound to, i.e. the candidates for AdoptSession.
+func ListUnmanagedTmuxSessions(managed []*Instance) ([]string, error) {
+	tmuxSessions, err := tmux.DiscoverAllTmuxSessions()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, sess := range unmanagedTmuxSessions(tmuxSessions, managed) {
+		names = append(names, sess.Name)
+	}
+	return names, nil
+}
+
+// AdoptSession wraps the running tmux session tmuxName in a new Instance in
+// group. An empty tool is guessed from the pane's foreground command, then
+// from its content; any other tool must be a built-in or a configured custom
+// tool. The instance is not persisted; the caller saves it, and is
+// responsible for not adopting a session that is already managed.
+func AdoptSession(tmuxName, group, tool string) (*Instance, error) {
+	if tool != "" && tool != "shell" && !isBuiltinToolName(tool) && GetToolDef(tool) == nil {
+		return nil, fmt.Errorf("unknown tool %q", tool)
+	}
+	tmuxSessions, err := tmux.DiscoverAllTmuxSessions()
+	if err != nil {
+		return nil, err
+	}
+	for _, sess := range tmuxSessions {
+		if sess.Name != tmuxName {
+			continue
+		}
+		title, _ := adoptedTitle(sess)
+		if tool == "" {
+			tool = guessAdoptedTool(sess)
+		}
+		return newAdoptedInstance(sess, title, group, tool), nil
+	}
+	return nil, fmt.Errorf("tmux session %q not found", tmuxName)
+}
+
+// unmanagedTmuxSessions filters out tmux sessions already tracked by an
+// instance, matched by tmux name or by title.
+func unmanagedTmuxSessions(tmuxSessions []*tmux.Session, existingInstances []*Instance) []*tmux.Session {
 	// Build a map of existing sessions by tmux name
 	existingMap := make(map[string]bool)
 	for _, inst := range existingInstances {
@@ -25,62 +90,78 @@ func DiscoverExistingTmuxSessions(existingInstances []*Instance) ([]*Instance, e
 		existingMap[inst.Title] = true
 	}
 
-	var discovered []*Instance
+	var unmanaged []*tmux.Session
 	for _, sess := range tmuxSessions {
 		// Skip if already tracked
 		if existingMap[sess.Name] || existingMap[sess.DisplayName] {
 			continue
 		}
+		unmanaged = append(unmanaged, sess)
+	}
+	return unmanaged
+}
 
-		// For orphaned agent-deck sessions, extract the original title from the tmux name
-		// Format: agentdeck_<title>_<hash> -> extract <title>
-		title := sess.DisplayName
-		groupPath := ""
-		isOrphaned := false
-		if strings.HasPrefix(sess.Name, tmux.SessionPrefix) {
-			isOrphaned = true
-			// Extract title from session name: agentdeck_<title>_<8-char-hash>
-			namePart := strings.TrimPrefix(sess.Name, tmux.SessionPrefix)
-			if lastUnderscore := strings.LastIndex(namePart, "_"); lastUnderscore > 0 {
-				title = namePart[:lastUnderscore]
-			} else {
-				title = namePart
-			}
-			// Put orphaned sessions in a "Recovered" group so user knows they were recovered
-			groupPath = "recovered"
-		}
+// adoptedTitle returns the title for an adopted tmux session and whether it
+// is an orphaned agent-deck session. For orphans the original title is
+// extracted from the tmux name: agentdeck_<title>_<hash> -> <title>.
+func adoptedTitle(sess *tmux.Session) (string, bool) {
+	if !strings.HasPrefix(sess.Name, tmux.SessionPrefix) {
+		return sess.DisplayName, false
+	}
+	// Extract title from session name: agentdeck_<title>_<8-char-hash>
+	namePart := strings.TrimPrefix(sess.Name, tmux.SessionPrefix)
+	if lastUnderscore := strings.LastIndex(namePart, "_"); lastUnderscore > 0 {
+		return namePart[:lastUnderscore], true
+	}
+	return namePart, true
+}
 
-		// Create instance for discovered session
-		projectPath := sess.WorkDir
-		if projectPath == "" {
-			projectPath = "~"
+// guessAdoptedTool detects the tool running in sess: the foreground command
+// is the most reliable signal, pane content the fallback.
+func guessAdoptedTool(sess *tmux.Session) string {
+	if command, err := sess.CurrentCommand(); err == nil {
+		if tool := detectToolFromName(command); tool != "shell" {
+			return tool
 		}
+	}
+	return sess.DetectTool()
+}
 
-		// Enable mouse mode for proper scrolling in imported sessions
-		// Ignore errors - non-fatal, older tmux versions may not support all options
-		_ = sess.EnableMouseMode()
-
-		// Determine tool type - for orphaned agent-deck sessions, assume claude (most common)
-		tool := detectToolFromName(title)
-		if isOrphaned && tool == "shell" {
-			tool = "claude" // Most agent-deck sessions are Claude sessions
+// ConfigureAdoptedSessions enables mouse mode (unless [tmux].mouse is off) in
+// the tmux sessions behind freshly adopted instances, for proper scrolling. Discovery itself leaves
+// tmux untouched so that listing candidates (import --dry-run) has no side
+// effects; call this once the import is committed.
+func ConfigureAdoptedSessions(instances []*Instance) {
+	for _, inst := range instances {
+		if sess := inst.GetTmuxSession(); sess != nil {
+			sess.SetMouse(GetTmuxSettings().GetMouse())
+			// Ignore errors - non-fatal, older tmux versions may not support all options
+			_ = sess.EnableMouseMode()
 		}
+	}
+}
 
-		inst := &Instance{
-			ID:             GenerateID(),
-			Title:          title,
-			ProjectPath:    projectPath,
-			GroupPath:      groupPath,
-			Status:         StatusIdle,
-			Tool:           tool,
-			TmuxSocketName: sess.SocketName, // Inherit from the tmux session we discovered (#687)
-			tmuxSession:    sess,
-		}
-		_ = inst.UpdateStatus()
-		discovered = append(discovered, inst)
+// newAdoptedInstance builds an instance bound to the already-running sess.
+// It does not reconfigure tmux; see ConfigureAdoptedSessions.
+func newAdoptedInstance(sess *tmux.Session, title, groupPath, tool string) *Instance {
+	// Create instance for discovered session
+	projectPath := sess.WorkDir
+	if projectPath == "" {
+		projectPath = "~"
 	}
 
-	return discovered, nil
+	inst := &Instance{
+		ID:             GenerateID(),
+		Title:          title,
+		ProjectPath:    projectPath,
+		GroupPath:      groupPath,
+		Status:         StatusIdle,
+		Tool:           tool,
+		TmuxSocketName: sess.SocketName, // Inherit from the tmux session we discovered (#687)
+		tmuxSession:    sess,
+	}
+	_ = inst.UpdateStatus()
+	return inst
 }
 
 // GroupByProject groups sessions by their parent project directory
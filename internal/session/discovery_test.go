@@ -3,6 +3,8 @@ package session
 import (
 	"os/exec"
 	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
 )
 
 func TestDiscoverExistingTmuxSessions(t *testing.T) {
@@ -45,6 +47,43 @@ func TestDiscoverSkipsAgentDeckSessions(t *testing.T) {
 	}
 }
 
+func TestUnmanagedTmuxSessionsAndAdoptedTitle(t *testing.T) {
+	tracked := &Instance{Title: "tracked"}
+	tmuxSessions := []*tmux.Session{
+		{Name: "tracked", DisplayName: "tracked"},
+		{Name: "scratch", DisplayName: "scratch"},
+		{Name: tmux.SessionPrefix + "api_1a2b3c4d", DisplayName: "api_1a2b3c4d"},
+	}
+
+	unmanaged := unmanagedTmuxSessions(tmuxSessions, []*Instance{tracked})
+	if len(unmanaged) != 2 || unmanaged[0].Name != "scratch" {
+		t.Fatalf("unmanaged = %v", unmanaged)
+	}
+
+	if title, orphaned := adoptedTitle(unmanaged[0]); title != "scratch" || orphaned {
+		t.Errorf("adoptedTitle(scratch) = %q, %v", title, orphaned)
+	}
+	if title, orphaned := adoptedTitle(unmanaged[1]); title != "api" || !orphaned {
+		t.Errorf("adoptedTitle(orphan) = %q, %v; want api, true", title, orphaned)
+	}
+}
+
+func TestAdoptSessionNotFound(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not available")
+	}
+	if _, err := AdoptSession("agent-deck-test-no-such-session", "", "shell"); err == nil {
+		t.Error("AdoptSession of a missing tmux session should fail")
+	}
+}
+
+func TestAdoptSessionRejectsUnknownTool(t *testing.T) {
+	_, err := AdoptSession("scratch", "", "not-a-tool")
+	if err == nil || err.Error() != `unknown tool "not-a-tool"` {
+		t.Errorf("AdoptSession with an unknown tool: err = %v", err)
+	}
+}
+
 func TestGroupByProjectDeep(t *testing.T) {
 	instances := []*Instance{
 		{Title: "s1", ProjectPath: "/home/user/projects/devops"},
@@ -127,6 +127,11 @@ func (i *Instance) buildEnvSourceCommand() string {
 		sources = append(sources, inlineEnv)
 	}
 
+	// 6b. Per-project env from .agentdeck.json (project > user config)
+	if projectEnv := i.getProjectEnv(); projectEnv != "" {
+		sources = append(sources, projectEnv)
+	}
+
 	// 7. Conductor-specific env (highest priority, overrides tool env)
 	if conductorEnv := i.getConductorEnv(ignoreMissing); conductorEnv != "" {
 		sources = append(sources, conductorEnv)
@@ -433,6 +438,42 @@ func ExpandPath(path string) string {
 	return path
 }
 
+// CanonicalPath returns the absolute, symlink-resolved form of p — the name
+// a tool that keys its state by project path sees for the directory. Gemini
+// CLI hashes its resolved cwd, so on macOS a session in /tmp/app keeps its
+// chats under the hash of /private/tmp/app (/tmp is a symlink to
+// /private/tmp). A path that cannot be resolved (e.g. does not exist yet)
+// falls back to its cleaned absolute form. HashProjectPath and the Gemini
+// launch directory both go through here so they always agree.
+func CanonicalPath(p string) string {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return p
+	}
+	if real, err := filepath.EvalSymlinks(abs); err == nil {
+		return real
+	}
+	return abs
+}
+
+// ResolveProjectPath expands a session working directory like ExpandPath and
+// makes a relative result absolute against base (itself expanded; an empty or
+// relative base resolves against the process working directory). Empty and
+// already-absolute paths are returned as expanded.
+func ResolveProjectPath(path, base string) string {
+	path = ExpandPath(path)
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	if base = ExpandPath(base); base != "" {
+		path = filepath.Join(base, path)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
 // isFilePath checks if a string looks like a file path (vs inline command).
 func isFilePath(s string) bool {
 	return strings.HasPrefix(s, "/") ||
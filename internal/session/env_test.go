@@ -78,6 +78,41 @@ func TestResolvePath(t *testing.T) {
 	}
 }
 
+func TestResolveProjectPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("Cannot get home directory")
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AGENTDECK_TEST_DIR", "/tmp/testdir")
+
+	tests := []struct {
+		name     string
+		path     string
+		base     string
+		expected string
+	}{
+		{"empty", "", "/base", ""},
+		{"absolute kept as typed", "/srv/app/", "/base", "/srv/app/"},
+		{"sibling of base", "../sibling", "/code/app", "/code/sibling"},
+		{"relative to home base", "app", "~/code", filepath.Join(home, "code", "app")},
+		{"empty base uses cwd", "sub", "", filepath.Join(cwd, "sub")},
+		{"tilde before base", "~/app", "/base", filepath.Join(home, "app")},
+		{"env var before base", "$AGENTDECK_TEST_DIR/app", "/base", "/tmp/testdir/app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveProjectPath(tt.path, tt.base); got != tt.expected {
+				t.Errorf("ResolveProjectPath(%q, %q) = %q, want %q", tt.path, tt.base, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestIsFilePath(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -0,0 +1,218 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// SessionExport is the machine-readable view of a session. It is the element
+// type of `agent-deck list --json` and of the local control API
+// (internal/api), so scripts see the same shape from both.
+type SessionExport struct {
+	ID            string    `json:"id"`
+	Title         string    `json:"title"`
+	Path          string    `json:"path"`
+	Group         string    `json:"group"`
+	Tool          string    `json:"tool"`
+	Command       string    `json:"command,omitempty"`
+	ModelID       string    `json:"model_id,omitempty"`
+	Model         string    `json:"model,omitempty"`
+	ModelVersion  string    `json:"model_version,omitempty"`
+	Status        string    `json:"status"`
+	Substate      string    `json:"substate,omitempty"` // Honest Status v2: additive refinement
+	TmuxSession   string    `json:"tmux_session,omitempty"`
+	Profile       string    `json:"profile"`
+	CreatedAt     time.Time `json:"created_at"`
+	SSHHost       string    `json:"ssh_host,omitempty"`
+	SSHRemotePath string    `json:"ssh_remote_path,omitempty"`
+	Channels      []string  `json:"channels,omitempty"`
+	ExtraArgs     []string  `json:"extra_args,omitempty"`
+	Color         string    `json:"color,omitempty"` // issue #391
+	Notes         string    `json:"notes,omitempty"`
+}
+
+// StatusLabel returns the lowercase status name used in CLI and API output.
+// Transitional states without a stable meaning for scripts map to "unknown".
+func StatusLabel(status Status) string {
+	switch status {
+	case StatusRunning, StatusWaiting, StatusIdle, StatusError, StatusStopped, StatusQueued:
+		return string(status)
+	default:
+		return "unknown"
+	}
+}
+
+// NewSessionExport snapshots inst as-is; callers that want live status
+// refresh it first (see ExportSessions).
+func NewSessionExport(inst *Instance, profile string) SessionExport {
+	e := SessionExport{
+		ID:            inst.ID,
+		Title:         inst.Title,
+		Path:          inst.ProjectPath,
+		Group:         inst.GroupPath,
+		Tool:          inst.Tool,
+		Command:       inst.Command,
+		Status:        StatusLabel(inst.Status),
+		Substate:      string(inst.Substate()),
+		Profile:       profile,
+		CreatedAt:     inst.CreatedAt,
+		SSHHost:       inst.SSHHost,
+		SSHRemotePath: inst.SSHRemotePath,
+		Channels:      inst.Channels,
+		ExtraArgs:     inst.ExtraArgs,
+		Color:         inst.Color,
+		Notes:         inst.Notes,
+	}
+	if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil {
+		e.TmuxSession = tmuxSess.Name
+	}
+	if modelInfo := inst.LaunchModelInfo(); modelInfo.ModelID != "" {
+		e.ModelID = modelInfo.ModelID
+		e.Model = modelInfo.Model
+		e.ModelVersion = modelInfo.Version
+	}
+	return e
+}
+
+// ExportSessions refreshes each instance's status the way the CLI does
+// (warm pane-title cache, hook statuses, UpdateStatus) and returns their
+// exports in order, so the result matches what the TUI shows (issue #610).
+func ExportSessions(instances []*Instance, profile string) []SessionExport {
+	RefreshInstancesForCLIStatus(instances)
+	out := make([]SessionExport, len(instances))
+	for i, inst := range instances {
+		_ = inst.UpdateStatus()
+		out[i] = NewSessionExport(inst, profile)
+	}
+	return out
+}
+
+// SessionSpec describes a session to create from a script or API call.
+type SessionSpec struct {
+	Title string `json:"title"`
+	Path  string `json:"path"`
+	Group string `json:"group,omitempty"`
+	// Tool is a built-in or custom tool name ("claude", "gemini", ...).
+	// Empty uses the configured default tool.
+	Tool    string `json:"tool,omitempty"`
+	ModelID string `json:"model_id,omitempty"`
+	// Command is the command line to run instead of the tool's own (a shell
+	// session's script, or a wrapper).
+	Command string `json:"command,omitempty"`
+	// Notes is a short description shown in the session list (at most
+	// MaxNotesLength characters).
+	Notes string `json:"notes,omitempty"`
+	// Options are the tool's launch options, in the JSON shape of its
+	// options type (ClaudeOptions, CodexOptions, ...); Gemini takes
+	// {"yolo_mode": true}. Unknown fields are rejected.
+	Options json.RawMessage `json:"options,omitempty"`
+
+	// Worktree asks for a git worktree of Path on Branch. MultiRepo makes
+	// Path the first of several repositories, the rest in AdditionalPaths.
+	// ValidateSpec checks these; NewInstance cannot build them, Create
+	// (CreateSession) builds worktrees.
+	Worktree        bool     `json:"worktree,omitempty"`
+	Branch          string   `json:"branch,omitempty"`
+	MultiRepo       bool     `json:"multi_repo,omitempty"`
+	AdditionalPaths []string `json:"additional_paths,omitempty"`
+}
+
+// NewInstance validates the spec (see ValidateSpec) and builds an unstarted
+// instance. The path is expanded (~, $VARS) and made absolute, like the
+// new-session dialog does, and must be an existing directory unless
+// allow_missing_paths covers it.
+func (s SessionSpec) NewInstance() (*Instance, error) {
+	if errs := ValidateSpec(s); len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if s.Worktree || s.MultiRepo {
+		return nil, fmt.Errorf("worktree and multi-repo sessions must be created with CreateSession")
+	}
+	title := strings.TrimSpace(s.Title)
+	path := ResolveProjectPath(strings.TrimSpace(s.Path), "")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		sessionLog.Warn("session_path_missing_allowed", slog.String("path", path))
+	}
+
+	tool := strings.TrimSpace(s.Tool)
+	if tool == "" {
+		tool = GetDefaultTool()
+	}
+	var inst *Instance
+	if group := strings.TrimSpace(s.Group); group != "" {
+		inst = NewInstanceWithGroupAndTool(title, path, group, tool)
+	} else {
+		inst = NewInstanceWithTool(title, path, tool)
+	}
+	// Same command convention as the web UI's create path.
+	if tool != "" && tool != "shell" {
+		inst.Command = tool
+	}
+	if command := strings.TrimSpace(s.Command); command != "" {
+		inst.Command = command
+	}
+	if len(s.Options) > 0 {
+		opts, geminiYolo, err := decodeSpecOptions(tool, s.Options)
+		if err != nil {
+			return nil, err
+		}
+		if opts != nil {
+			if inst.ToolOptionsJSON, err = MarshalToolOptions(opts); err != nil {
+				return nil, err
+			}
+		}
+		if geminiYolo != nil {
+			inst.SetGeminiYoloMode(*geminiYolo)
+		}
+	}
+	// After Options, so ModelID wins over an options "model".
+	if modelID := strings.TrimSpace(s.ModelID); modelID != "" {
+		if err := inst.ApplyLaunchModel(modelID); err != nil {
+			return nil, err
+		}
+	}
+	inst.Notes = strings.TrimSpace(s.Notes)
+	return inst, nil
+}
+
+// decodeSpecOptions decodes a spec's Options for tool. Gemini has no
+// ToolOptions type, so its yolo_mode comes back on its own.
+func decodeSpecOptions(tool string, raw json.RawMessage) (ToolOptions, *bool, error) {
+	var opts ToolOptions
+	var gemini struct {
+		YoloMode *bool `json:"yolo_mode,omitempty"`
+	}
+	target := any(&gemini)
+	switch {
+	case IsClaudeCompatible(tool):
+		opts = &ClaudeOptions{}
+	case IsCodexCompatible(tool):
+		opts = &CodexOptions{}
+	case tool == "opencode":
+		opts = &OpenCodeOptions{}
+	case tool == "copilot":
+		opts = &CopilotOptions{}
+	case tool == "crush":
+		opts = &CrushOptions{}
+	case tool == "hermes":
+		opts = &HermesOptions{}
+	case tool == "gemini":
+	default:
+		return nil, nil, fmt.Errorf("tool %q takes no options", tool)
+	}
+	if opts != nil {
+		target = opts
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(target); err != nil {
+		return nil, nil, fmt.Errorf("invalid %s options: %w", tool, err)
+	}
+	return opts, gemini.YoloMode, nil
+}
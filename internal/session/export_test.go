@@ -0,0 +1,139 @@
+package session
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStatusLabel(t *testing.T) {
+	tests := map[Status]string{
+		StatusRunning:  "running",
+		StatusWaiting:  "waiting",
+		StatusIdle:     "idle",
+		StatusError:    "error",
+		StatusStopped:  "stopped",
+		StatusQueued:   "queued",
+		StatusStarting: "unknown",
+		Status(""):     "unknown",
+	}
+	for status, want := range tests {
+		if got := StatusLabel(status); got != want {
+			t.Errorf("StatusLabel(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestNewSessionExport(t *testing.T) {
+	inst := NewInstanceWithGroupAndTool("exported", "/tmp", "work", "claude")
+	inst.Command = "claude"
+	inst.Status = StatusIdle
+
+	e := NewSessionExport(inst, "default")
+	if e.ID != inst.ID || e.Title != "exported" || e.Path != "/tmp" || e.Group != "work" {
+		t.Errorf("identity fields = %+v", e)
+	}
+	if e.Tool != "claude" || e.Status != "idle" || e.Profile != "default" {
+		t.Errorf("tool/status/profile = %q/%q/%q", e.Tool, e.Status, e.Profile)
+	}
+	if e.TmuxSession == "" {
+		t.Error("TmuxSession should be set from the instance's tmux session")
+	}
+}
+
+func TestSessionSpecNewInstance(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	inst, err := SessionSpec{Title: " api ", Path: "~", Group: "scripts", Tool: "gemini"}.NewInstance()
+	if err != nil {
+		t.Fatalf("NewInstance: %v", err)
+	}
+	if inst.Title != "api" || inst.ProjectPath != home || inst.GroupPath != "scripts" {
+		t.Errorf("instance = title %q path %q group %q", inst.Title, inst.ProjectPath, inst.GroupPath)
+	}
+	if inst.Tool != "gemini" || inst.Command != "gemini" {
+		t.Errorf("tool/command = %q/%q", inst.Tool, inst.Command)
+	}
+
+	shell, err := SessionSpec{Title: "sh", Path: home, Tool: "shell"}.NewInstance()
+	if err != nil {
+		t.Fatalf("shell NewInstance: %v", err)
+	}
+	if shell.Command != "" {
+		t.Errorf("shell Command = %q, want empty", shell.Command)
+	}
+
+	for name, spec := range map[string]SessionSpec{
+		"no title":  {Path: home},
+		"no path":   {Title: "x"},
+		"not a dir": {Title: "x", Path: filepath.Join(home, "missing")},
+	} {
+		if _, err := spec.NewInstance(); err == nil {
+			t.Errorf("%s: expected error", name)
+		} else if name == "not a dir" && !strings.Contains(err.Error(), "not a directory") {
+			t.Errorf("%s: err = %v", name, err)
+		}
+	}
+}
+
+func TestSessionSpecNewInstance_Options(t *testing.T) {
+	dir := t.TempDir()
+
+	inst, err := SessionSpec{Title: "c", Path: dir, Tool: "claude", ModelID: "opus",
+		Options: []byte(`{"skip_permissions": true, "model": "sonnet"}`)}.NewInstance()
+	if err != nil {
+		t.Fatalf("claude NewInstance: %v", err)
+	}
+	if opts := inst.GetClaudeOptions(); opts == nil || !opts.SkipPermissions || opts.Model != "opus" {
+		t.Errorf("claude options = %+v, want skip_permissions and model_id to win", opts)
+	}
+
+	inst, err = SessionSpec{Title: "g", Path: dir, Tool: "gemini", Options: []byte(`{"yolo_mode": true}`)}.NewInstance()
+	if err != nil {
+		t.Fatalf("gemini NewInstance: %v", err)
+	}
+	if inst.GeminiYoloMode == nil || !*inst.GeminiYoloMode {
+		t.Errorf("GeminiYoloMode = %v, want true", inst.GeminiYoloMode)
+	}
+
+	for name, spec := range map[string]SessionSpec{
+		"shell":         {Title: "s", Path: dir, Tool: "shell", Options: []byte(`{"yolo_mode": true}`)},
+		"unknown field": {Title: "x", Path: dir, Tool: "codex", Options: []byte(`{"yolo": true}`)},
+	} {
+		if _, err := spec.NewInstance(); err == nil {
+			t.Errorf("%s: expected an options error", name)
+		}
+	}
+}
+
+func TestSessionNotesLengthCap(t *testing.T) {
+	home := t.TempDir()
+	long := strings.Repeat("é", MaxNotesLength+1)
+
+	inst, err := SessionSpec{Title: "x", Path: home, Notes: " flaky test "}.NewInstance()
+	if err != nil {
+		t.Fatalf("NewInstance: %v", err)
+	}
+	if inst.Notes != "flaky test" {
+		t.Errorf("Notes = %q, want the trimmed spec notes", inst.Notes)
+	}
+	if e := NewSessionExport(inst, "default"); e.Notes != "flaky test" {
+		t.Errorf("export Notes = %q", e.Notes)
+	}
+
+	if _, err := (SessionSpec{Title: "x", Path: home, Notes: long}).NewInstance(); err == nil ||
+		!strings.Contains(err.Error(), "Notes too long") {
+		t.Errorf("over-long spec notes: err = %v", err)
+	}
+
+	if _, _, err := SetField(inst, FieldNotes, long, nil); err == nil {
+		t.Error("SetField must reject notes over MaxNotesLength")
+	}
+	if inst.Notes != "flaky test" {
+		t.Errorf("rejected notes must leave the old value, got %q", inst.Notes)
+	}
+	if _, _, err := SetField(inst, FieldNotes, strings.Repeat("é", MaxNotesLength), nil); err != nil {
+		t.Errorf("notes at the cap (in characters, not bytes) must be accepted: %v", err)
+	}
+}
@@ -1,30 +1,146 @@
 package session
 
 import (
+	"container/list"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/agentpaths"
+)
+
+// geminiConfigDirOverride allows tests to override config directory.
+// In-package tests assign it directly; everyone else goes through
+// SetGeminiConfigDir / ResetGeminiConfigDir.
+var (
+	geminiConfigDirOverride string
+	geminiConfigDirMu       sync.RWMutex
 )
 
-// geminiConfigDirOverride allows tests to override config directory
-var geminiConfigDirOverride string
+// SetGeminiConfigDir redirects Gemini config discovery (settings.json,
+// chat files, hooks) to dir until ResetGeminiConfigDir. It is meant for
+// tests and tools embedding this package; dir must be absolute.
+func SetGeminiConfigDir(dir string) error {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return errors.New("gemini config dir is empty")
+	}
+	if !filepath.IsAbs(dir) {
+		return fmt.Errorf("gemini config dir %q is not absolute", dir)
+	}
+	geminiConfigDirMu.Lock()
+	geminiConfigDirOverride = filepath.Clean(dir)
+	geminiConfigDirMu.Unlock()
+	return nil
+}
+
+// ResetGeminiConfigDir undoes SetGeminiConfigDir.
+func ResetGeminiConfigDir() {
+	geminiConfigDirMu.Lock()
+	geminiConfigDirOverride = ""
+	geminiConfigDirMu.Unlock()
+}
+
+// DefaultGeminiSessionFilePattern is the chat file name Gemini CLI writes,
+// session-YYYY-MM-DDTHH-MM-<uuid8>.json. "{id}" stands for the first eight
+// characters of the session ID.
+const DefaultGeminiSessionFilePattern = "session-*-{id}.json"
+
+var (
+	geminiSessionFilePattern   = DefaultGeminiSessionFilePattern
+	geminiSessionFilePatternMu sync.RWMutex
+)
 
-// GetGeminiConfigDir returns ~/.gemini
-// Unlike Claude, Gemini has no GEMINI_CONFIG_DIR env var override
+// SetGeminiSessionFilePattern changes the chat file name pattern used to
+// find Gemini sessions, for Gemini CLI versions that name their files
+// differently. pattern is a filepath.Match glob without directories and
+// must contain "{id}" exactly once.
+func SetGeminiSessionFilePattern(pattern string) error {
+	pattern = strings.TrimSpace(pattern)
+	if strings.Count(pattern, "{id}") != 1 {
+		return fmt.Errorf("gemini session file pattern %q must contain {id} exactly once", pattern)
+	}
+	if strings.ContainsAny(pattern, `/\`) {
+		return fmt.Errorf("gemini session file pattern %q must be a file name, not a path", pattern)
+	}
+	if _, err := filepath.Match(strings.Replace(pattern, "{id}", "*", 1), ""); err != nil {
+		return fmt.Errorf("gemini session file pattern %q: %w", pattern, err)
+	}
+	geminiSessionFilePatternMu.Lock()
+	geminiSessionFilePattern = pattern
+	geminiSessionFilePatternMu.Unlock()
+	return nil
+}
+
+// ResetGeminiSessionFilePattern restores DefaultGeminiSessionFilePattern.
+func ResetGeminiSessionFilePattern() {
+	geminiSessionFilePatternMu.Lock()
+	geminiSessionFilePattern = DefaultGeminiSessionFilePattern
+	geminiSessionFilePatternMu.Unlock()
+}
+
+// geminiSessionFileGlob returns the glob matching sessionID's chat files in
+// dir, or every chat file in dir when sessionID is "". Callers check that
+// sessionID has at least eight characters.
+func geminiSessionFileGlob(dir, sessionID string) string {
+	geminiSessionFilePatternMu.RLock()
+	pattern := geminiSessionFilePattern
+	geminiSessionFilePatternMu.RUnlock()
+	id := "*"
+	if sessionID != "" {
+		id = sessionID[:8]
+	}
+	return filepath.Join(dir, strings.Replace(pattern, "{id}", id, 1))
+}
+
+// GeminiConfigDirEnv names the env var that points agent-deck at a Gemini
+// config directory other than the default. Gemini CLI itself does not read
+// it yet; it is honored here so a relocated directory can still be found.
+const GeminiConfigDirEnv = "GEMINI_CONFIG_DIR"
+
+// GetGeminiConfigDir returns the Gemini config directory, in priority order:
+// $GEMINI_CONFIG_DIR, then ~/.gemini (where Gemini CLI writes), then
+// $XDG_CONFIG_HOME/gemini (~/.config/gemini when unset) if only that one
+// exists. An unrelated ~/.config/gemini never hides an existing ~/.gemini.
 func GetGeminiConfigDir() string {
-	if geminiConfigDirOverride != "" {
-		return geminiConfigDirOverride
+	geminiConfigDirMu.RLock()
+	override := geminiConfigDirOverride
+	geminiConfigDirMu.RUnlock()
+	if override != "" {
+		return override
+	}
+	if dir := strings.TrimSpace(os.Getenv(GeminiConfigDirEnv)); dir != "" {
+		return filepath.Clean(ExpandPath(dir))
 	}
 	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".gemini")
+	defaultDir := filepath.Join(home, ".gemini")
+	if info, err := os.Stat(defaultDir); err == nil && info.IsDir() {
+		return defaultDir
+	}
+	configHome := strings.TrimSpace(os.Getenv("XDG_CONFIG_HOME"))
+	if !filepath.IsAbs(configHome) {
+		configHome = filepath.Join(home, ".config")
+	}
+	if info, err := os.Stat(filepath.Join(configHome, "gemini")); err == nil && info.IsDir() {
+		return filepath.Join(configHome, "gemini")
+	}
+	return defaultDir
 }
 
 // HashProjectPath generates SHA256 hash of absolute project path
@@ -32,30 +148,77 @@ func GetGeminiConfigDir() string {
 // VERIFIED: echo -n "/Users/ashesh" | shasum -a 256
 // NOTE: Must resolve symlinks (e.g., /tmp -> /private/tmp on macOS)
 func HashProjectPath(projectPath string) string {
-	absPath, err := filepath.Abs(projectPath)
-	if err != nil {
+	// Resolve symlinks to match Gemini CLI behavior (see CanonicalPath).
+	realPath := CanonicalPath(projectPath)
+	if !filepath.IsAbs(realPath) {
 		return ""
 	}
-	// Resolve symlinks to match Gemini CLI behavior
-	// macOS: /tmp is symlink to /private/tmp
-	realPath, err := filepath.EvalSymlinks(absPath)
-	if err != nil {
-		// Fall back to absPath if symlink resolution fails
-		realPath = absPath
-	}
 	hash := sha256.Sum256([]byte(realPath))
 	return hex.EncodeToString(hash[:])
 }
 
+// GeminiProjectHashCandidates returns the hashes Gemini CLI may have used for
+// projectPath, most likely first: the symlink-resolved absolute path (what
+// HashProjectPath computes), the unresolved absolute path, and the literal
+// path string as the session's cwd was given. Some Gemini CLI versions hash
+// the cwd without resolving it, so a repo reached through a symlink, or moved
+// and symlinked back, stores its chats under one of the later hashes.
+func GeminiProjectHashCandidates(projectPath string) []string {
+	primary := HashProjectPath(projectPath)
+	if primary == "" {
+		return nil
+	}
+	candidates := []string{primary}
+	add := func(path string) {
+		sum := sha256.Sum256([]byte(path))
+		h := hex.EncodeToString(sum[:])
+		if !slices.Contains(candidates, h) {
+			candidates = append(candidates, h)
+		}
+	}
+	if absPath, err := filepath.Abs(projectPath); err == nil {
+		add(absPath)
+	}
+	if filepath.IsAbs(projectPath) {
+		add(projectPath)
+	}
+	return candidates
+}
+
+// ResolveGeminiSessionsDir returns the chats directory for a project together
+// with the project hash it lives under. Each candidate from
+// GeminiProjectHashCandidates is tried in order and the first directory that
+// holds session files wins; when none does, the primary (resolved-path)
+// directory is returned so new sessions are still looked up where current
+// Gemini CLI writes them. Session-ID lookups additionally fall back to
+// findGeminiSessionInAllProjects.
+func ResolveGeminiSessionsDir(projectPath string) (dir, projectHash string) {
+	candidates := GeminiProjectHashCandidates(projectPath)
+	if len(candidates) == 0 {
+		return "", "" // Cannot determine sessions dir without valid hash
+	}
+	tmpDir := filepath.Join(GetGeminiConfigDir(), "tmp")
+	for i, h := range candidates {
+		chats := filepath.Join(tmpDir, h, "chats")
+		if matches, _ := filepath.Glob(geminiSessionFileGlob(chats, "")); len(matches) > 0 {
+			if i > 0 {
+				sessionLog.Debug("gemini_sessions_dir_alternate_hash",
+					slog.String("project", projectPath),
+					slog.String("hash", h),
+					slog.Int("candidate", i))
+			}
+			return chats, h
+		}
+	}
+	return filepath.Join(tmpDir, candidates[0], "chats"), candidates[0]
+}
+
 // GetGeminiSessionsDir returns the chats directory for a project
 // Format: ~/.gemini/tmp/<project_hash>/chats/
+// See ResolveGeminiSessionsDir for how the project hash is chosen.
 func GetGeminiSessionsDir(projectPath string) string {
-	configDir := GetGeminiConfigDir()
-	projectHash := HashProjectPath(projectPath)
-	if projectHash == "" {
-		return "" // Cannot determine sessions dir without valid hash
-	}
-	return filepath.Join(configDir, "tmp", projectHash, "chats")
+	dir, _ := ResolveGeminiSessionsDir(projectPath)
+	return dir
 }
 
 // GeminiSessionInfo holds parsed session metadata
@@ -67,22 +230,18 @@ type GeminiSessionInfo struct {
 	MessageCount int
 }
 
-// parseGeminiSessionFile reads a session file and extracts metadata
-// VERIFIED: Field names use camelCase (sessionId, not session_id)
-func parseGeminiSessionFile(filePath string) (GeminiSessionInfo, error) {
+// ParseGeminiSessionFile reads one Gemini chat file and extracts its
+// metadata, without the directory scan or cache of ListGeminiSessions.
+// The file layout is sniffed by decodeGeminiSession; files in neither
+// known layout return ErrUnknownGeminiFormat.
+func ParseGeminiSessionFile(filePath string) (GeminiSessionInfo, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return GeminiSessionInfo{}, fmt.Errorf("failed to read session file: %w", err)
 	}
 
-	var session struct {
-		SessionID   string            `json:"sessionId"` // VERIFIED: camelCase
-		StartTime   string            `json:"startTime"`
-		LastUpdated string            `json:"lastUpdated"`
-		Messages    []json.RawMessage `json:"messages"`
-	}
-
-	if err := json.Unmarshal(data, &session); err != nil {
+	session, err := decodeGeminiSession(filePath, data)
+	if err != nil {
 		return GeminiSessionInfo{}, fmt.Errorf("failed to parse session: %w", err)
 	}
 
@@ -108,23 +267,122 @@ func parseGeminiSessionFile(filePath string) (GeminiSessionInfo, error) {
 	}, nil
 }
 
+// geminiSessionCacheCapacity bounds the metadata cache. A few projects with
+// hundreds of chats each fit comfortably; entries are a few hundred bytes.
+const geminiSessionCacheCapacity = 2048
+
+// geminiSessionCacheEntry is one cached parse, valid while the file's mtime
+// and size are unchanged.
+type geminiSessionCacheEntry struct {
+	path  string
+	mtime time.Time
+	size  int64
+	info  GeminiSessionInfo
+}
+
+// geminiSessionCache is an LRU of parsed session metadata keyed by file path.
+// The list view refreshes often, and re-reading multi-MB chat files whose
+// mtime has not moved is wasted I/O (same idea as the mtime check in
+// UpdateGeminiAnalyticsFromDisk).
+var geminiSessionCache = struct {
+	sync.Mutex
+	order   *list.List // front = most recently used
+	entries map[string]*list.Element
+}{order: list.New(), entries: make(map[string]*list.Element)}
+
+// ClearGeminiSessionCache drops all cached session metadata. Call after files
+// are pruned or rewritten out-of-band, and from tests.
+func ClearGeminiSessionCache() {
+	geminiSessionCache.Lock()
+	defer geminiSessionCache.Unlock()
+	geminiSessionCache.order.Init()
+	clear(geminiSessionCache.entries)
+}
+
+// parseGeminiSessionFileCached returns cached metadata when the file's mtime
+// and size match the cached entry, and parses (and caches) it otherwise.
+func parseGeminiSessionFileCached(filePath string) (GeminiSessionInfo, error) {
+	st, err := os.Stat(filePath)
+	if err != nil {
+		return GeminiSessionInfo{}, fmt.Errorf("failed to stat session file: %w", err)
+	}
+
+	c := &geminiSessionCache
+	c.Lock()
+	if el, ok := c.entries[filePath]; ok {
+		e := el.Value.(*geminiSessionCacheEntry)
+		if e.mtime.Equal(st.ModTime()) && e.size == st.Size() {
+			c.order.MoveToFront(el)
+			c.Unlock()
+			return e.info, nil
+		}
+	}
+	c.Unlock()
+
+	info, err := ParseGeminiSessionFile(filePath)
+	if err != nil {
+		return GeminiSessionInfo{}, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	entry := &geminiSessionCacheEntry{path: filePath, mtime: st.ModTime(), size: st.Size(), info: info}
+	if el, ok := c.entries[filePath]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return info, nil
+	}
+	c.entries[filePath] = c.order.PushFront(entry)
+	for c.order.Len() > geminiSessionCacheCapacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*geminiSessionCacheEntry).path)
+	}
+	return info, nil
+}
+
 // ListGeminiSessions returns all sessions for a project path
-// Scans ~/.gemini/tmp/<hash>/chats/ and parses session files
+// Scans ~/.gemini/tmp/<hash>/chats/ and parses session files, reusing
+// cached metadata for files unchanged since the last call
 // Sorted by LastUpdated (most recent first)
 func ListGeminiSessions(projectPath string) ([]GeminiSessionInfo, error) {
 	sessionsDir := GetGeminiSessionsDir(projectPath)
-	files, err := filepath.Glob(filepath.Join(sessionsDir, "session-*.json"))
+	files, err := filepath.Glob(geminiSessionFileGlob(sessionsDir, ""))
 	if err != nil {
 		return nil, err
 	}
 
+	// Parse in parallel: projects can hold hundreds of multi-MB chat files.
+	// Each worker writes only its own index, so no locking is needed.
+	parsed := make([]GeminiSessionInfo, len(files))
+	ok := make([]bool, len(files))
+	workers := min(runtime.GOMAXPROCS(0), len(files))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				info, err := parseGeminiSessionFileCached(files[i])
+				if err != nil {
+					continue // Skip malformed files
+				}
+				parsed[i], ok[i] = info, true
+			}
+		}()
+	}
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
 	var sessions []GeminiSessionInfo
-	for _, file := range files {
-		info, err := parseGeminiSessionFile(file)
-		if err != nil {
-			continue // Skip malformed files
+	for i, info := range parsed {
+		if ok[i] {
+			sessions = append(sessions, info)
 		}
-		sessions = append(sessions, info)
 	}
 
 	// Sort by LastUpdated (most recent first)
@@ -153,9 +411,6 @@ func findGeminiSessionInAllProjects(sessionID string) string {
 		return ""
 	}
 
-	// Search pattern: session-*-<uuid8>.json
-	targetPattern := "session-*-" + sessionID[:8] + ".json"
-
 	var bestPath string
 	var bestTime time.Time
 	for _, entry := range entries {
@@ -163,8 +418,7 @@ func findGeminiSessionInAllProjects(sessionID string) string {
 			continue
 		}
 
-		chatsDir := filepath.Join(tmpDir, entry.Name(), "chats")
-		pattern := filepath.Join(chatsDir, targetPattern)
+		pattern := geminiSessionFileGlob(filepath.Join(tmpDir, entry.Name(), "chats"), sessionID)
 		path, mtime := findNewestFile(pattern)
 		if path != "" && mtime.After(bestTime) {
 			bestPath = path
@@ -172,11 +426,44 @@ func findGeminiSessionInAllProjects(sessionID string) string {
 		}
 	}
 
+	if bestPath != "" {
+		sessionLog.Debug("gemini_session_found_by_scan",
+			slog.String("session_id", sessionID),
+			slog.String("hash", geminiProjectHashOf(bestPath)))
+	}
 	return bestPath
 }
 
+// geminiProjectHashOf returns the project hash directory a session file
+// lives in (~/.gemini/tmp/<hash>/chats/session-*.json).
+func geminiProjectHashOf(sessionFile string) string {
+	return filepath.Base(filepath.Dir(filepath.Dir(sessionFile)))
+}
+
+// GeminiSessionProjectHash reports which project hash directory holds the
+// given session's file, using the same lookup order as the analytics and
+// response readers. Returns "" when the file cannot be found. Meant for
+// debugging hash mismatches (e.g. `agent-deck session show`).
+func GeminiSessionProjectHash(projectPath, sessionID string) string {
+	if len(sessionID) < 8 {
+		return ""
+	}
+	pattern := geminiSessionFileGlob(GetGeminiSessionsDir(projectPath), sessionID)
+	filePath, _ := findNewestFile(pattern)
+	if filePath == "" {
+		filePath = findGeminiSessionInAllProjects(sessionID)
+	}
+	if filePath == "" {
+		return ""
+	}
+	return geminiProjectHashOf(filePath)
+}
+
 // UpdateGeminiAnalyticsFromDisk updates the analytics struct from the session file on disk.
 // Uses mtime caching to skip re-parsing unchanged files (important for 40MB+ session files).
+// Gemini rewrites the file in place, so a read can catch it half written:
+// a file that fails to parse leaves analytics at the last good values and
+// returns the error; that file version is skipped until the file changes.
 func UpdateGeminiAnalyticsFromDisk(projectPath, sessionID string, analytics *GeminiSessionAnalytics) error {
 	if sessionID == "" || len(sessionID) < 8 {
 		return fmt.Errorf("invalid session ID")
@@ -184,52 +471,70 @@ func UpdateGeminiAnalyticsFromDisk(projectPath, sessionID string, analytics *Gem
 
 	sessionsDir := GetGeminiSessionsDir(projectPath)
 	// Find file matching session ID prefix (first 8 chars)
-	// Filename format: session-YYYY-MM-DDTHH-MM-<uuid8>.json
-	pattern := filepath.Join(sessionsDir, "session-*-"+sessionID[:8]+".json")
+	pattern := geminiSessionFileGlob(sessionsDir, sessionID)
 	filePath, fileMtime := findNewestFile(pattern)
 
 	// Fallback: search across all projects if not found in expected location
 	if filePath == "" {
-		if fallbackPath := findGeminiSessionInAllProjects(sessionID); fallbackPath != "" {
-			filePath = fallbackPath
-			if info, err := os.Stat(fallbackPath); err == nil {
-				fileMtime = info.ModTime()
-			}
-		}
+		filePath = findGeminiSessionInAllProjects(sessionID)
 	}
 
 	if filePath == "" {
 		return fmt.Errorf("session file not found")
 	}
+	var fileSize int64
+	if info, err := os.Stat(filePath); err == nil {
+		fileMtime, fileSize = info.ModTime(), info.Size()
+	}
 
 	// mtime cache: skip re-parse if file hasn't changed since last read
 	if !analytics.LastFileModTime.IsZero() && !fileMtime.IsZero() && fileMtime.Equal(analytics.LastFileModTime) {
 		return nil
 	}
+	if !fileMtime.IsZero() && fileMtime.Equal(analytics.failedFileModTime) && fileSize == analytics.failedFileSize {
+		return errGeminiSessionUnreadable
+	}
 
-	data, err := os.ReadFile(filePath)
+	parsed, err := ParseGeminiAnalyticsFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read session file: %w", err)
+		analytics.failedFileModTime, analytics.failedFileSize = fileMtime, fileSize
+		return fmt.Errorf("%w: %w", errGeminiSessionUnreadable, err)
+	}
+	if parsed.SessionID == "" {
+		parsed.SessionID = sessionID
+	}
+	if parsed.SessionID == analytics.SessionID {
+		parsed.rateSamples = analytics.rateSamples
 	}
+	parsed.recordTokenSample(fileMtime, parsed.TotalTokens())
+	// Record mtime for cache
+	parsed.LastFileModTime = fileMtime
+	*analytics = *parsed
+
+	return nil
+}
 
-	var session struct {
-		SessionID   string `json:"sessionId"`
-		StartTime   string `json:"startTime"`
-		LastUpdated string `json:"lastUpdated"`
-		Messages    []struct {
-			Type   string `json:"type"`
-			Model  string `json:"model,omitempty"`
-			Tokens struct {
-				Input  int `json:"input"`
-				Output int `json:"output"`
-			} `json:"tokens"`
-		} `json:"messages"`
+// errGeminiSessionUnreadable is returned by UpdateGeminiAnalyticsFromDisk for
+// a session file that failed to read or parse, typically mid-write.
+var errGeminiSessionUnreadable = errors.New("gemini session file unreadable")
+
+// ParseGeminiAnalyticsFile computes fresh analytics from one Gemini chat
+// file, for callers that already know the path. SessionID is the file's own
+// sessionId, empty when the file has none. Unlike
+// UpdateGeminiAnalyticsFromDisk it keeps no mtime cache or rate samples.
+func ParseGeminiAnalyticsFile(filePath string) (*GeminiSessionAnalytics, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
 	}
 
-	if err := json.Unmarshal(data, &session); err != nil {
-		return fmt.Errorf("failed to parse session for analytics: %w", err)
+	session, err := decodeGeminiSession(filePath, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse session for analytics: %w", err)
 	}
 
+	analytics := &GeminiSessionAnalytics{SessionID: session.SessionID}
+
 	// Parse timestamps
 	startTime, _ := time.Parse(time.RFC3339, session.StartTime)
 	if startTime.IsZero() {
@@ -242,20 +547,37 @@ func UpdateGeminiAnalyticsFromDisk(projectPath, sessionID string, analytics *Gem
 
 	analytics.StartTime = startTime
 	analytics.LastActive = lastUpdated
-	if !startTime.IsZero() && !lastUpdated.IsZero() {
+	// Equal stamps (single-message sessions) or lastUpdated before startTime
+	// (clock skew) leave Duration at zero; see InProgress.
+	if !startTime.IsZero() && lastUpdated.After(startTime) {
 		analytics.Duration = lastUpdated.Sub(startTime)
 	}
 
-	// Reset and accumulate tokens
-	analytics.InputTokens = 0
-	analytics.OutputTokens = 0
-	analytics.TotalTurns = 0
-	analytics.Model = ""
 	for _, msg := range session.Messages {
+		if msg.Type == "user" {
+			// Kept apart from InputTokens, which already includes every
+			// prompt as part of the context of the following model turn.
+			analytics.PromptTokens += msg.Tokens.Input
+			continue
+		}
 		if msg.Type == "gemini" {
 			analytics.InputTokens += msg.Tokens.Input
 			analytics.OutputTokens += msg.Tokens.Output
+			analytics.CachedTokens += msg.Tokens.Cached
+			analytics.addModelTokens(msg.Model, msg.Tokens.Input, msg.Tokens.Output, msg.Tokens.Cached)
 			analytics.TotalTurns++
+			analytics.TurnTokens = append(analytics.TurnTokens, GeminiTurnTokens{
+				Input:  msg.Tokens.Input,
+				Output: msg.Tokens.Output,
+			})
+			switch classifyGeminiTurn(msg.Content, msg.ToolCalls) {
+			case geminiTurnToolCall:
+				analytics.ToolCallTurns++
+			case geminiTurnText:
+				analytics.TextTurns++
+			default:
+				analytics.OtherTurns++
+			}
 
 			// For Gemini, the input tokens of the last message represent the total context size
 			// including history and current prompt.
@@ -268,10 +590,7 @@ func UpdateGeminiAnalyticsFromDisk(projectPath, sessionID string, analytics *Gem
 		}
 	}
 
-	// Record mtime for cache
-	analytics.LastFileModTime = fileMtime
-
-	return nil
+	return analytics, nil
 }
 
 // geminiModelCache holds cached model list from the Gemini API
@@ -282,6 +601,68 @@ var (
 	geminiModelCacheTTL  = 1 * time.Hour
 )
 
+// geminiModelCacheFileName is the on-disk copy of the model cache, so a
+// restart within the TTL reuses the list instead of calling the API again.
+const geminiModelCacheFileName = "gemini-models.json"
+
+// geminiModelCachePath locates the on-disk cache; a variable for tests.
+var geminiModelCachePath = func() (string, error) {
+	return agentpaths.CachePath(geminiModelCacheFileName)
+}
+
+// geminiModelCacheLoaded records that the on-disk cache has been read into
+// geminiModelCacheList (once per process).
+var geminiModelCacheLoaded bool
+
+// modelCacheFile is the on-disk form of a model list cache.
+type modelCacheFile struct {
+	Models    []string  `json:"models"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// loadGeminiModelCacheLocked seeds the in-memory cache from disk. A missing
+// or corrupt file is ignored: the next call simply refetches.
+// Caller holds geminiModelCacheMu.
+func loadGeminiModelCacheLocked() {
+	geminiModelCacheLoaded = true
+	path, err := geminiModelCachePath()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var file modelCacheFile
+	if err := json.Unmarshal(data, &file); err != nil || len(file.Models) == 0 {
+		sessionLog.Debug("gemini_model_cache_ignored", slog.String("path", path))
+		return
+	}
+	if len(geminiModelCacheList) == 0 {
+		geminiModelCacheList = file.Models
+		geminiModelCacheTime = file.FetchedAt
+	}
+}
+
+// saveGeminiModelCacheLocked writes the in-memory cache to disk; failures
+// only cost a refetch after the next restart. Caller holds geminiModelCacheMu.
+func saveGeminiModelCacheLocked() {
+	path, err := geminiModelCachePath()
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(modelCacheFile{Models: geminiModelCacheList, FetchedAt: geminiModelCacheTime})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+		err = atomicWriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		sessionLog.Debug("gemini_model_cache_save_failed", slog.String("error", err.Error()))
+	}
+}
+
 // geminiModelFallback is the hardcoded fallback list when API is unavailable
 var geminiModelFallback = []string{
 	"gemini-2.5-flash",
@@ -294,9 +675,100 @@ var geminiModelFallback = []string{
 	"gemini-3.1-pro-preview-customtools",
 }
 
+// geminiDefaultBaseURL is the public Gemini API host, used unless
+// GOOGLE_GENAI_BASE_URL points somewhere else.
+const geminiDefaultBaseURL = "https://generativelanguage.googleapis.com"
+
+// geminiModelsURL returns the model list endpoint under GOOGLE_GENAI_BASE_URL
+// (for a gateway or mirror reachable where the public host is blocked), or
+// under the public host when it is unset.
+func geminiModelsURL() string {
+	base := strings.TrimSpace(os.Getenv("GOOGLE_GENAI_BASE_URL"))
+	if base == "" {
+		base = geminiDefaultBaseURL
+	}
+	return strings.TrimRight(base, "/") + "/v1beta/models"
+}
+
+// Errors returned by GetAvailableGeminiModels (and, except ErrNoAPIKey,
+// GetAvailableClaudeModels) alongside the fallback list, so callers can tell
+// why they got the defaults instead of the live list.
+var (
+	// ErrNoAPIKey means neither GOOGLE_API_KEY nor [gemini].api_keys is
+	// set; no request was made.
+	ErrNoAPIKey = errors.New("GOOGLE_API_KEY not set")
+	// ErrModelFetchFailed means the API could not be reached (network,
+	// DNS, timeout).
+	ErrModelFetchFailed = errors.New("model list request failed")
+	// ErrAPIKeyRejected means the API refused the API key.
+	ErrAPIKeyRejected = errors.New("API key rejected")
+	// ErrModelRateLimited means the API kept answering 429 or 503 after
+	// the retries ran out.
+	ErrModelRateLimited = errors.New("model API rate limited")
+	// ErrModelResponseInvalid means the API answered with an unexpected
+	// status or a body that could not be decoded.
+	ErrModelResponseInvalid = errors.New("unexpected model list response")
+)
+
+// apiKeyParamRe matches the key query parameter of a Gemini API URL.
+var apiKeyParamRe = regexp.MustCompile(`([?&]key=)[^&\s"']*`)
+
+// redactAPIKey replaces the value of any key= query parameter in s, and any
+// configured API key appearing elsewhere, with REDACTED, so URLs can appear
+// in errors and logs without leaking a key.
+func redactAPIKey(s string) string {
+	s = apiKeyParamRe.ReplaceAllString(s, "${1}REDACTED")
+	for _, k := range geminiAPIKeys() {
+		s = strings.ReplaceAll(s, k.value, "REDACTED")
+	}
+	return s
+}
+
+// geminiAPIKey is one candidate key for the model list request; source
+// names it in logs without revealing the value.
+type geminiAPIKey struct {
+	source string
+	value  string
+}
+
+// geminiAPIKeys returns GOOGLE_API_KEY followed by [gemini].api_keys, with
+// env vars expanded and blanks and duplicates dropped.
+func geminiAPIKeys() []geminiAPIKey {
+	var keys []geminiAPIKey
+	seen := make(map[string]bool)
+	add := func(source, value string) {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			return
+		}
+		seen[value] = true
+		keys = append(keys, geminiAPIKey{source: source, value: value})
+	}
+	add("GOOGLE_API_KEY", os.Getenv("GOOGLE_API_KEY"))
+	if cfg, _ := LoadUserConfig(); cfg != nil {
+		for i, k := range cfg.Gemini.APIKeys {
+			add(fmt.Sprintf("gemini.api_keys[%d]", i), os.ExpandEnv(k))
+		}
+	}
+	return keys
+}
+
 // GetAvailableGeminiModels returns a sorted list of Gemini models that support generateContent.
 // Priority: 1) GEMINI_MODELS_OVERRIDE env var, 2) cached API result, 3) live API call, 4) fallback list.
+// The live call uses GOOGLE_API_KEY, then each [gemini].api_keys entry in
+// turn while keys are rejected or rate limited.
+// When the live call fails the last cached list (even if expired) or the
+// fallback list is returned, and the error says why (ErrNoAPIKey,
+// ErrModelFetchFailed, ErrAPIKeyRejected, ErrModelRateLimited or
+// ErrModelResponseInvalid; match with errors.Is). The list is still usable.
 func GetAvailableGeminiModels() ([]string, error) {
+	return GetAvailableGeminiModelsContext(context.Background())
+}
+
+// GetAvailableGeminiModelsContext is GetAvailableGeminiModels with a context
+// that aborts the API call and any rate-limit backoff; a cancelled call
+// returns the fallback list with an error matching ctx.Err().
+func GetAvailableGeminiModelsContext(ctx context.Context) ([]string, error) {
 	// Priority 1: env var override (for testing)
 	if override := os.Getenv("GEMINI_MODELS_OVERRIDE"); override != "" {
 		models := strings.Split(override, ",")
@@ -311,34 +783,236 @@ func GetAvailableGeminiModels() ([]string, error) {
 		return result, nil
 	}
 
-	// Priority 2: cache hit
+	// Priority 2: cache hit (in memory, or persisted by an earlier run)
 	geminiModelCacheMu.Lock()
 	defer geminiModelCacheMu.Unlock()
 
-	if len(geminiModelCacheList) > 0 && time.Since(geminiModelCacheTime) < geminiModelCacheTTL {
+	if !geminiModelCacheLoaded {
+		loadGeminiModelCacheLocked()
+	}
+
+	if len(geminiModelCacheList) > 0 && nowFn().Sub(geminiModelCacheTime) < geminiModelCacheTTL {
 		result := make([]string, len(geminiModelCacheList))
 		copy(result, geminiModelCacheList)
 		return result, nil
 	}
 
-	// Priority 3: API call (requires GOOGLE_API_KEY)
-	apiKey := os.Getenv("GOOGLE_API_KEY")
-	if apiKey == "" {
+	// Priority 3: API call (requires GOOGLE_API_KEY or [gemini].api_keys)
+	keys := geminiAPIKeys()
+	if len(keys) == 0 {
 		// No API key, use fallback
-		return geminiModelFallback, nil
+		return geminiModelFallback, ErrNoAPIKey
+	}
+
+	models, err := fetchGeminiModelsRotating(ctx, keys)
+	if err != nil {
+		// Priority 4: a stale cached list beats the hardcoded one.
+		if len(geminiModelCacheList) > 0 {
+			result := make([]string, len(geminiModelCacheList))
+			copy(result, geminiModelCacheList)
+			return result, err
+		}
+		return geminiModelFallback, err
+	}
+
+	// Update cache
+	geminiModelCacheList = models
+	geminiModelCacheTime = nowFn()
+	saveGeminiModelCacheLocked()
+
+	return models, nil
+}
+
+// CredStatus is the state of the Gemini API credentials, as reported by
+// GeminiCredentialStatus.
+type CredStatus int
+
+const (
+	// CredMissing means neither GOOGLE_API_KEY nor [gemini].api_keys is set.
+	CredMissing CredStatus = iota
+	// CredUnverified means a key is set but could not be checked (network
+	// error, rate limit).
+	CredUnverified
+	// CredValid means the API accepted the key.
+	CredValid
+	// CredRejected means the API refused the key.
+	CredRejected
+)
+
+// String returns a short human-readable form for dialog headers.
+func (s CredStatus) String() string {
+	switch s {
+	case CredValid:
+		return "API key OK"
+	case CredRejected:
+		return "API key rejected"
+	case CredUnverified:
+		return "API key not verified"
+	default:
+		return "No API key"
+	}
+}
+
+// geminiCredCache remembers the last verified credential status for a key;
+// a key change or geminiCredCacheTTL expiry triggers a new check.
+var (
+	geminiCredCacheMu     sync.Mutex
+	geminiCredCacheKey    string
+	geminiCredCacheStatus CredStatus
+	geminiCredCacheTime   time.Time
+	geminiCredCacheTTL    = 5 * time.Minute
+)
+
+// recordGeminiCredStatus caches what a model request revealed about apiKey;
+// inconclusive outcomes are not cached.
+func recordGeminiCredStatus(apiKey string, err error) {
+	status := CredValid
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrAPIKeyRejected):
+		status = CredRejected
+	default:
+		return
+	}
+	geminiCredCacheMu.Lock()
+	geminiCredCacheKey, geminiCredCacheStatus, geminiCredCacheTime = apiKey, status, nowFn()
+	geminiCredCacheMu.Unlock()
+}
+
+// GeminiCredentialStatus reports whether a Gemini API key is set
+// (GOOGLE_API_KEY, else the first [gemini].api_keys entry) and, when one
+// is, whether the API accepts it. The check reuses the outcome of a recent
+// model list request, or makes a one-model request of its own; the result
+// is cached for a few minutes.
+func GeminiCredentialStatus() CredStatus {
+	keys := geminiAPIKeys()
+	if len(keys) == 0 {
+		return CredMissing
+	}
+	apiKey := keys[0].value
+	geminiCredCacheMu.Lock()
+	if geminiCredCacheKey == apiKey && nowFn().Sub(geminiCredCacheTime) < geminiCredCacheTTL {
+		status := geminiCredCacheStatus
+		geminiCredCacheMu.Unlock()
+		return status
+	}
+	geminiCredCacheMu.Unlock()
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	_, _, err := fetchGeminiModelsOnce(context.Background(), client, apiKey, "&pageSize=1")
+	recordGeminiCredStatus(apiKey, err)
+	switch {
+	case err == nil:
+		return CredValid
+	case errors.Is(err, ErrAPIKeyRejected):
+		return CredRejected
+	default:
+		return CredUnverified
+	}
+}
+
+// Retry policy for rate-limited (429) and unavailable (503) model list
+// responses; variables so tests can run without sleeping.
+var (
+	geminiModelFetchAttempts = 3
+	geminiModelRetryBase     = 500 * time.Millisecond
+	// geminiModelRetryMax caps both the backoff and a server's Retry-After:
+	// the model dialog is waiting on this call.
+	geminiModelRetryMax = 5 * time.Second
+	geminiModelSleep    = sleepContext
+)
+
+// sleepContext waits for d or until ctx is done, returning ctx.Err() in the
+// latter case.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
+// fetchGeminiModels requests the model list, retrying 429 and 503 responses
+// with exponential backoff (or the server's Retry-After) up to
+// geminiModelFetchAttempts times.
+func fetchGeminiModels(ctx context.Context, apiKey string) ([]string, error) {
+	// The default transport honors HTTPS_PROXY / NO_PROXY.
 	client := &http.Client{Timeout: 5 * time.Second}
-	// #nosec G704 -- URL is a hardcoded Google API endpoint; only the API key
-	// query param is interpolated, sourced from the local GOOGLE_API_KEY env.
-	resp, err := client.Get("https://generativelanguage.googleapis.com/v1beta/models?key=" + apiKey)
+	for attempt := 1; ; attempt++ {
+		models, retryAfter, err := fetchGeminiModelsOnce(ctx, client, apiKey, "")
+		if !errors.Is(err, ErrModelRateLimited) || attempt >= geminiModelFetchAttempts {
+			return models, err
+		}
+		delay := geminiModelRetryBase << (attempt - 1)
+		if retryAfter > 0 {
+			delay = retryAfter
+		}
+		if err := geminiModelSleep(ctx, min(delay, geminiModelRetryMax)); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrModelFetchFailed, err)
+		}
+	}
+}
+
+// fetchGeminiModelsRotating tries each key in order, moving on when a key is
+// rejected or rate limited (another project's quota may be free). Other
+// failures are not key-specific and end the rotation. The error is the last
+// key's.
+func fetchGeminiModelsRotating(ctx context.Context, keys []geminiAPIKey) ([]string, error) {
+	var err error
+	for i, k := range keys {
+		var models []string
+		models, err = fetchGeminiModels(ctx, k.value)
+		recordGeminiCredStatus(k.value, err)
+		if err == nil {
+			if i > 0 {
+				sessionLog.Info("gemini_api_key_rotated", slog.String("key", k.source))
+			}
+			return models, nil
+		}
+		if !errors.Is(err, ErrAPIKeyRejected) && !errors.Is(err, ErrModelRateLimited) {
+			return nil, err
+		}
+		sessionLog.Debug("gemini_api_key_failed",
+			slog.String("key", k.source), slog.String("error", err.Error()))
+	}
+	return nil, err
+}
+
+// fetchGeminiModelsOnce makes a single model list request; extraQuery is
+// appended to the query string. For a 429 or 503 it returns
+// ErrModelRateLimited and the server's Retry-After, if any.
+func fetchGeminiModelsOnce(ctx context.Context, client *http.Client, apiKey, extraQuery string) ([]string, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, geminiModelsURL()+"?key="+url.QueryEscape(apiKey)+extraQuery, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %s", ErrModelFetchFailed, redactAPIKey(err.Error()))
+	}
+	// #nosec G704 -- URL is the Google API endpoint or the user's own
+	// GOOGLE_GENAI_BASE_URL; only the API key query param is interpolated,
+	// sourced from the local GOOGLE_API_KEY env.
+	resp, err := client.Do(req)
 	if err != nil {
-		return geminiModelFallback, fmt.Errorf("API request failed: %w", err)
+		// *url.Error embeds the request URL, API key included.
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			uerr.URL = redactAPIKey(uerr.URL)
+		}
+		return nil, 0, fmt.Errorf("%w: %w", ErrModelFetchFailed, err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return geminiModelFallback, fmt.Errorf("API returned status %d", resp.StatusCode)
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return nil, parseRetryAfter(resp.Header.Get("Retry-After")),
+			fmt.Errorf("%w: API returned status %d", ErrModelRateLimited, resp.StatusCode)
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		// The API answers an invalid key with 400 INVALID_ARGUMENT.
+		return nil, 0, fmt.Errorf("%w: API returned status %d", ErrAPIKeyRejected, resp.StatusCode)
+	default:
+		return nil, 0, fmt.Errorf("%w: API returned status %d", ErrModelResponseInvalid, resp.StatusCode)
 	}
 
 	var apiResp struct {
@@ -348,7 +1022,7 @@ func GetAvailableGeminiModels() ([]string, error) {
 		} `json:"models"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return geminiModelFallback, fmt.Errorf("failed to decode API response: %w", err)
+		return nil, 0, fmt.Errorf("%w: failed to decode API response: %w", ErrModelResponseInvalid, err)
 	}
 
 	// Filter to models that support generateContent
@@ -370,10 +1044,23 @@ func GetAvailableGeminiModels() ([]string, error) {
 	}
 
 	sort.Strings(models)
+	return models, 0, nil
+}
 
-	// Update cache
-	geminiModelCacheList = models
-	geminiModelCacheTime = time.Now()
-
-	return models, nil
+// parseRetryAfter reads a Retry-After header in either delay-seconds or
+// HTTP-date form; zero when absent or unparseable.
+func parseRetryAfter(v string) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(nowFn()); d > 0 {
+			return d
+		}
+	}
+	return 0
 }
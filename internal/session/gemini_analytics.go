@@ -1,15 +1,29 @@
 package session
 
 import (
+	"encoding/json"
+	"sort"
+	"strings"
 	"time"
 )
 
 // GeminiSessionAnalytics holds metrics for a Gemini session
 type GeminiSessionAnalytics struct {
-	// Token usage
+	// Token usage. InputTokens is the prompt context the model reported on
+	// each of its turns (history plus the new prompt), summed over turns;
+	// that is what Gemini bills as input. OutputTokens is the model's output.
 	InputTokens  int `json:"input_tokens"`
 	OutputTokens int `json:"output_tokens"`
 
+	// PromptTokens sums the token counts some session formats record on user
+	// messages: the size of what the user typed, not the context sent. It is
+	// informational only and not part of TotalTokens or the cost estimate.
+	PromptTokens int `json:"prompt_tokens,omitempty"`
+
+	// Portion of InputTokens served from Gemini's context cache (billed at
+	// the lower cached rate). Zero when the session file has no cached count.
+	CachedTokens int `json:"cached_tokens"`
+
 	// Current context size (last turn's input + cache read tokens)
 	CurrentContextTokens int `json:"current_context_tokens"`
 
@@ -19,14 +33,215 @@ type GeminiSessionAnalytics struct {
 	StartTime  time.Time     `json:"start_time"`
 	LastActive time.Time     `json:"last_active"`
 
+	// TotalTurns split by what the model did: called tools, answered with
+	// text only, or something unrecognised (empty or unknown part types)
+	ToolCallTurns int `json:"tool_call_turns"`
+	TextTurns     int `json:"text_turns"`
+	OtherTurns    int `json:"other_turns"`
+
 	// Cost estimation
 	EstimatedCost float64 `json:"estimated_cost"`
 
-	// Model detected from session file messages
+	// Model detected from session file messages: the most recent one
 	Model string `json:"model,omitempty"`
 
+	// ModelBreakdown splits the gemini turns' tokens by the model that
+	// answered, for sessions that switch models. Turns without a model are
+	// keyed "". Sums to InputTokens / OutputTokens / CachedTokens.
+	ModelBreakdown map[string]GeminiTokenCount `json:"model_breakdown,omitempty"`
+
+	// Per-turn token counts in conversation order, recorded during the same
+	// parse pass. Not persisted: a reload re-parses the file anyway. Use
+	// Timeline for a bounded series.
+	TurnTokens []GeminiTurnTokens `json:"-"`
+
+	// SessionID is the sessionId of the file these numbers were parsed from.
+	// When the matched file carries a different one (resume, prefix
+	// collision) the analytics are reset instead of reused.
+	SessionID string `json:"session_id,omitempty"`
+
 	// In-memory cache: last file modification time (skip re-parse if unchanged)
 	LastFileModTime time.Time `json:"-"`
+
+	// failedFileModTime and failedFileSize identify a file version that
+	// failed to parse, usually one caught mid-write. It is not re-read; the
+	// next write changes them and is picked up on the following update. The
+	// size catches a write that lands within the filesystem's mtime
+	// granularity of the failed read.
+	failedFileModTime time.Time
+	failedFileSize    int64
+
+	// Token totals seen on successive updates, for TokenRate. Carried over
+	// re-parses of the same session, dropped when the session changes.
+	rateSamples []tokenRateSample
+}
+
+// tokenRateWindow is the span TokenRate averages over. Short enough that the
+// rate falls to zero a few minutes after the session goes idle.
+const tokenRateWindow = 5 * time.Minute
+
+// tokenRateSample is the session's token total as of one file write
+type tokenRateSample struct {
+	at     time.Time
+	tokens int
+}
+
+// recordTokenSample adds the total seen at time at. A total lower than the
+// previous one means the file was rewritten (compression, resume), so the
+// series restarts. Samples no newer than the last one replace it, so two
+// updates sharing a timestamp never yield a zero-length interval.
+func (a *GeminiSessionAnalytics) recordTokenSample(at time.Time, tokens int) {
+	if at.IsZero() {
+		return
+	}
+	if n := len(a.rateSamples); n > 0 {
+		last := a.rateSamples[n-1]
+		switch {
+		case tokens < last.tokens:
+			a.rateSamples = nil
+		case !at.After(last.at):
+			a.rateSamples[n-1].tokens = tokens
+			return
+		}
+	}
+	a.rateSamples = append(a.rateSamples, tokenRateSample{at: at, tokens: tokens})
+
+	// Keep one sample at or before the window start as the baseline.
+	windowStart := at.Add(-tokenRateWindow)
+	drop := 0
+	for drop+1 < len(a.rateSamples) && !a.rateSamples[drop+1].at.After(windowStart) {
+		drop++
+	}
+	a.rateSamples = a.rateSamples[drop:]
+}
+
+// TokenRate returns tokens per minute over the last few minutes, from the
+// totals seen on successive updates. It decays to zero once the session
+// stops producing tokens, and is zero until two updates have been seen.
+func (a *GeminiSessionAnalytics) TokenRate() float64 {
+	return a.tokenRateAt(nowFn())
+}
+
+func (a *GeminiSessionAnalytics) tokenRateAt(now time.Time) float64 {
+	if len(a.rateSamples) < 2 {
+		return 0
+	}
+	windowStart := now.Add(-tokenRateWindow)
+	base := a.rateSamples[0]
+	for _, s := range a.rateSamples[1:] {
+		if s.at.After(windowStart) {
+			break
+		}
+		base = s
+	}
+	gained := a.rateSamples[len(a.rateSamples)-1].tokens - base.tokens
+	if gained <= 0 {
+		return 0
+	}
+	// Until the series spans a full window, average over what it covers.
+	span := min(now.Sub(a.rateSamples[0].at), tokenRateWindow)
+	if span <= 0 {
+		return 0
+	}
+	return float64(gained) / span.Minutes()
+}
+
+// GeminiTokenCount is one model's share of a session's tokens
+type GeminiTokenCount struct {
+	Input  int `json:"input"`
+	Output int `json:"output"`
+	Cached int `json:"cached"`
+}
+
+// Total returns input plus output tokens
+func (c GeminiTokenCount) Total() int {
+	return c.Input + c.Output
+}
+
+// addModelTokens adds one gemini turn to ModelBreakdown
+func (a *GeminiSessionAnalytics) addModelTokens(model string, input, output, cached int) {
+	if a.ModelBreakdown == nil {
+		a.ModelBreakdown = make(map[string]GeminiTokenCount)
+	}
+	c := a.ModelBreakdown[model]
+	c.Input += input
+	c.Output += output
+	c.Cached += cached
+	a.ModelBreakdown[model] = c
+}
+
+// ModelsUsed returns the models in ModelBreakdown, most tokens first (ties
+// by name). The "" key for turns without a model is left out.
+func (a *GeminiSessionAnalytics) ModelsUsed() []string {
+	models := make([]string, 0, len(a.ModelBreakdown))
+	for m := range a.ModelBreakdown {
+		if m != "" {
+			models = append(models, m)
+		}
+	}
+	sort.Slice(models, func(i, j int) bool {
+		ti, tj := a.ModelBreakdown[models[i]].Total(), a.ModelBreakdown[models[j]].Total()
+		if ti != tj {
+			return ti > tj
+		}
+		return models[i] < models[j]
+	})
+	return models
+}
+
+// GeminiTurnTokens is one point of a session's token timeline
+type GeminiTurnTokens struct {
+	Input  int
+	Output int
+}
+
+// Total returns input plus output tokens for the point
+func (t GeminiTurnTokens) Total() int {
+	return t.Input + t.Output
+}
+
+// Timeline returns the per-turn token series with at most maxPoints entries.
+// Longer sessions are bucketed into maxPoints consecutive groups of turns, each
+// point holding the group's average so heights stay comparable to single
+// turns. The bucketing runs only when called, never during the parse.
+func (a *GeminiSessionAnalytics) Timeline(maxPoints int) []GeminiTurnTokens {
+	n := len(a.TurnTokens)
+	if maxPoints <= 0 || n == 0 {
+		return nil
+	}
+	if n <= maxPoints {
+		return append([]GeminiTurnTokens(nil), a.TurnTokens...)
+	}
+
+	points := make([]GeminiTurnTokens, maxPoints)
+	for b := range points {
+		lo, hi := b*n/maxPoints, (b+1)*n/maxPoints
+		var sum GeminiTurnTokens
+		for _, t := range a.TurnTokens[lo:hi] {
+			sum.Input += t.Input
+			sum.Output += t.Output
+		}
+		size := hi - lo
+		points[b] = GeminiTurnTokens{Input: sum.Input / size, Output: sum.Output / size}
+	}
+	return points
+}
+
+// InProgress reports whether the session file gave no usable duration: the
+// session has a start time but lastUpdated is equal to it or precedes it
+// (a single message so far, or clock skew).
+func (a *GeminiSessionAnalytics) InProgress() bool {
+	return a.Duration <= 0 && !a.StartTime.IsZero()
+}
+
+// ElapsedAt returns the duration to display as of now: Duration when the file
+// recorded one, else the live time since StartTime for sessions InProgress.
+// Never negative.
+func (a *GeminiSessionAnalytics) ElapsedAt(now time.Time) time.Duration {
+	if !a.InProgress() {
+		return max(a.Duration, 0)
+	}
+	return max(now.Sub(a.StartTime), 0)
 }
 
 // TotalTokens returns the sum of input and output tokens
@@ -38,28 +253,111 @@ func (a *GeminiSessionAnalytics) TotalTokens() int {
 type GeminiModelPricing struct {
 	Input  float64
 	Output float64
+	Cached float64 // context cache reads
 }
 
-// geminiPricing contains pricing per million tokens for each model (as of Jan 2025)
+// geminiPricing contains pricing per million tokens for each model (as of Jan 2025).
+// Cached input is billed at a quarter of the regular input rate.
 var geminiPricing = map[string]GeminiModelPricing{
-	"gemini-1.5-flash": {Input: 0.075, Output: 0.30},
-	"gemini-1.5-pro":   {Input: 3.50, Output: 10.50},
-	"gemini-2.0-flash": {Input: 0.10, Output: 0.40},
-	"gemini-2.5-flash": {Input: 0.15, Output: 0.60},
-	"gemini-2.5-pro":   {Input: 1.25, Output: 10.00},
+	"gemini-1.5-flash": {Input: 0.075, Output: 0.30, Cached: 0.01875},
+	"gemini-1.5-pro":   {Input: 3.50, Output: 10.50, Cached: 0.875},
+	"gemini-2.0-flash": {Input: 0.10, Output: 0.40, Cached: 0.025},
+	"gemini-2.5-flash": {Input: 0.15, Output: 0.60, Cached: 0.0375},
+	"gemini-2.5-pro":   {Input: 1.25, Output: 10.00, Cached: 0.3125},
 	// Fallback
-	"default": {Input: 0.15, Output: 0.60},
+	"default": {Input: 0.15, Output: 0.60, Cached: 0.0375},
+}
+
+// LookupGeminiPricing returns the known pricing for model, without the
+// default fallback CalculateCost uses.
+func LookupGeminiPricing(model string) (GeminiModelPricing, bool) {
+	if model == "" || model == "default" {
+		return GeminiModelPricing{}, false
+	}
+	pricing, ok := geminiPricing[model]
+	return pricing, ok
 }
 
 // CalculateCost estimates session cost based on token usage and model pricing
 func (a *GeminiSessionAnalytics) CalculateCost(model string) float64 {
+	return geminiTokenCost(model, GeminiTokenCount{Input: a.InputTokens, Output: a.OutputTokens, Cached: a.CachedTokens})
+}
+
+// EstimateCost prices each model's tokens in ModelBreakdown at that model's
+// rate, so sessions that switched models are not billed entirely at the
+// last one. Turns without a model, and sessions without a breakdown, are
+// priced as fallbackModel.
+func (a *GeminiSessionAnalytics) EstimateCost(fallbackModel string) float64 {
+	if len(a.ModelBreakdown) == 0 {
+		return a.CalculateCost(fallbackModel)
+	}
+	var cost float64
+	for model, count := range a.ModelBreakdown {
+		if model == "" {
+			model = fallbackModel
+		}
+		cost += geminiTokenCost(model, count)
+	}
+	return cost
+}
+
+// geminiTokenCost prices count at model's rate, or the default rate for an
+// unknown model.
+func geminiTokenCost(model string, count GeminiTokenCount) float64 {
 	pricing, ok := geminiPricing[model]
 	if !ok {
 		pricing = geminiPricing["default"]
 	}
 
-	inputM := float64(a.InputTokens) / 1_000_000
-	outputM := float64(a.OutputTokens) / 1_000_000
+	// Gemini's prompt count includes cached tokens; bill those separately.
+	cached := min(count.Cached, count.Input)
+	inputM := float64(count.Input-cached) / 1_000_000
+	cachedM := float64(cached) / 1_000_000
+	outputM := float64(count.Output) / 1_000_000
+
+	return inputM*pricing.Input + cachedM*pricing.Cached + outputM*pricing.Output
+}
+
+// geminiTurnKind classifies one gemini message for the turn breakdown.
+type geminiTurnKind int
 
-	return inputM*pricing.Input + outputM*pricing.Output
+const (
+	geminiTurnOther geminiTurnKind = iota
+	geminiTurnText
+	geminiTurnToolCall
+)
+
+// classifyGeminiTurn decides whether a gemini message called tools or only
+// produced text. It accepts both session file shapes seen in the wild: a
+// top-level "toolCalls" array next to a string "content", and a "content"
+// array of API-style parts ({"text": ...}, {"functionCall": ...}). Anything
+// else (empty content, unknown part types, malformed JSON) is "other".
+func classifyGeminiTurn(content, toolCalls json.RawMessage) geminiTurnKind {
+	var calls []json.RawMessage
+	if json.Unmarshal(toolCalls, &calls) == nil && len(calls) > 0 {
+		return geminiTurnToolCall
+	}
+
+	var text string
+	if json.Unmarshal(content, &text) == nil {
+		if strings.TrimSpace(text) != "" {
+			return geminiTurnText
+		}
+		return geminiTurnOther
+	}
+
+	var parts []map[string]json.RawMessage
+	if json.Unmarshal(content, &parts) != nil {
+		return geminiTurnOther
+	}
+	kind := geminiTurnOther
+	for _, part := range parts {
+		if _, ok := part["functionCall"]; ok {
+			return geminiTurnToolCall
+		}
+		if _, ok := part["text"]; ok {
+			kind = geminiTurnText
+		}
+	}
+	return kind
 }
@@ -71,3 +71,130 @@ func TestGeminiSessionAnalytics_CalculateCost(t *testing.T) {
 		t.Errorf("CalculateCost('gemini-1.5-pro') = %f, want %f", costPro, expectedPro)
 	}
 }
+
+func TestGeminiSessionAnalytics_CalculateCostCached(t *testing.T) {
+	analytics := &GeminiSessionAnalytics{
+		InputTokens:  2000000,
+		OutputTokens: 1000000,
+		CachedTokens: 1000000,
+	}
+
+	// 1M uncached input at $1.25 + 1M cached at $0.3125 + 1M output at $10.00
+	cost := analytics.CalculateCost("gemini-2.5-pro")
+	if expected := 11.5625; cost != expected {
+		t.Errorf("CalculateCost('gemini-2.5-pro') = %f, want %f", cost, expected)
+	}
+
+	// A cached count larger than the prompt is clamped, never negative.
+	analytics.CachedTokens = 5000000
+	cost = analytics.CalculateCost("gemini-2.5-pro")
+	if expected := 0.625 + 10.00; cost != expected {
+		t.Errorf("clamped CalculateCost = %f, want %f", cost, expected)
+	}
+}
+
+func TestGeminiSessionAnalytics_Timeline(t *testing.T) {
+	analytics := &GeminiSessionAnalytics{}
+	if got := analytics.Timeline(10); got != nil {
+		t.Errorf("empty timeline = %v, want nil", got)
+	}
+
+	for i := range 10 {
+		analytics.TurnTokens = append(analytics.TurnTokens, GeminiTurnTokens{Input: i * 10, Output: i})
+	}
+
+	// Short enough: returned as-is (a copy).
+	got := analytics.Timeline(20)
+	if len(got) != 10 || got[9] != (GeminiTurnTokens{Input: 90, Output: 9}) {
+		t.Fatalf("Timeline(20) = %v", got)
+	}
+	got[0].Input = 12345
+	if analytics.TurnTokens[0].Input != 0 {
+		t.Error("Timeline should not alias TurnTokens")
+	}
+
+	// Bucketed: 10 turns into 5 points of 2, averaged.
+	got = analytics.Timeline(5)
+	want := []GeminiTurnTokens{{5, 0}, {25, 2}, {45, 4}, {65, 6}, {85, 8}}
+	if len(got) != len(want) {
+		t.Fatalf("Timeline(5) len = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Timeline(5)[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	// Uneven buckets still cover every turn.
+	if got := analytics.Timeline(3); len(got) != 3 {
+		t.Errorf("Timeline(3) len = %d, want 3", len(got))
+	}
+}
+
+func TestGeminiSessionAnalytics_TokenRate(t *testing.T) {
+	t0 := time.Date(2025, 12, 23, 10, 0, 0, 0, time.UTC)
+	a := &GeminiSessionAnalytics{}
+
+	a.recordTokenSample(t0, 1000)
+	if got := a.tokenRateAt(t0); got != 0 {
+		t.Errorf("single sample: rate = %v, want 0", got)
+	}
+
+	// Same timestamp twice: replaces, no division by zero
+	a.recordTokenSample(t0, 1200)
+	if got := a.tokenRateAt(t0); got != 0 {
+		t.Errorf("shared timestamp: rate = %v, want 0", got)
+	}
+
+	// 600 tokens over 2 minutes
+	a.recordTokenSample(t0.Add(2*time.Minute), 1800)
+	if got := a.tokenRateAt(t0.Add(2 * time.Minute)); got != 300 {
+		t.Errorf("rate = %v, want 300", got)
+	}
+
+	// Idle: averaged over the full window, then zero once the last gain
+	// leaves it
+	if got := a.tokenRateAt(t0.Add(5 * time.Minute)); got != 120 {
+		t.Errorf("idle 3m: rate = %v, want 120", got)
+	}
+	if got := a.tokenRateAt(t0.Add(8 * time.Minute)); got != 0 {
+		t.Errorf("idle past window: rate = %v, want 0", got)
+	}
+
+	// A lower total restarts the series
+	a.recordTokenSample(t0.Add(9*time.Minute), 50)
+	if len(a.rateSamples) != 1 {
+		t.Errorf("samples after reset = %d, want 1", len(a.rateSamples))
+	}
+}
+
+func TestGeminiSessionAnalytics_TokenRateUsesClock(t *testing.T) {
+	t0 := time.Date(2025, 12, 23, 10, 0, 0, 0, time.UTC)
+	a := &GeminiSessionAnalytics{}
+	a.recordTokenSample(t0, 1000)
+	a.recordTokenSample(t0.Add(2*time.Minute), 1600)
+
+	pinNow(t, t0.Add(2*time.Minute))
+	if got := a.TokenRate(); got != 300 {
+		t.Errorf("rate = %v, want 300", got)
+	}
+	pinNow(t, t0.Add(8*time.Minute))
+	if got := a.TokenRate(); got != 0 {
+		t.Errorf("idle past window: rate = %v, want 0", got)
+	}
+}
+
+func TestGeminiSessionAnalytics_TokenRatePrunesOldSamples(t *testing.T) {
+	t0 := time.Date(2025, 12, 23, 10, 0, 0, 0, time.UTC)
+	a := &GeminiSessionAnalytics{}
+	for m := range 30 {
+		a.recordTokenSample(t0.Add(time.Duration(m)*time.Minute), m*100)
+	}
+	// Window of 5 minutes plus one baseline sample
+	if len(a.rateSamples) != 6 {
+		t.Errorf("samples = %d, want 6", len(a.rateSamples))
+	}
+	if got := a.tokenRateAt(t0.Add(29 * time.Minute)); got != 100 {
+		t.Errorf("steady rate = %v, want 100", got)
+	}
+}
@@ -0,0 +1,216 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+)
+
+// Gemini CLI session file layouts understood by decodeGeminiSession.
+const (
+	// geminiFormatV1 is the chat recording layout without a version field:
+	// camelCase metadata, messages typed "user"/"gemini" with a "tokens"
+	// object and a string (or parts array) "content".
+	geminiFormatV1 = 1
+	// geminiFormatV2 is stamped "version": 2 and uses Gemini API naming:
+	// messages carry a "role" ("user"/"model"), "parts" and "usageMetadata".
+	geminiFormatV2 = 2
+)
+
+// ErrUnknownGeminiFormat is returned for session files whose layout this
+// build cannot read, so callers report an error instead of zero counts.
+var ErrUnknownGeminiFormat = errors.New("unknown gemini session file format")
+
+// geminiSession is a session file decoded into the format-independent shape
+// used by the session list and analytics.
+type geminiSession struct {
+	Format      int
+	SessionID   string
+	StartTime   string
+	LastUpdated string
+	Messages    []geminiMessage
+}
+
+// geminiMessage is one normalized message. Type is "user" or "gemini"
+// regardless of how the file names roles.
+type geminiMessage struct {
+	Type   string
+	Model  string
+	Tokens struct {
+		Input  int
+		Output int
+		Cached int
+	}
+	// Content and ToolCalls vary between Gemini CLI versions (string vs.
+	// parts array); classifyGeminiTurn inspects them leniently.
+	Content   json.RawMessage
+	ToolCalls json.RawMessage
+}
+
+// geminiSessionV1 is the original layout.
+// VERIFIED: Field names use camelCase (sessionId, not session_id)
+type geminiSessionV1 struct {
+	SessionID   string `json:"sessionId"`
+	StartTime   string `json:"startTime"`
+	LastUpdated string `json:"lastUpdated"`
+	Messages    []struct {
+		Type   string `json:"type"`
+		Model  string `json:"model,omitempty"`
+		Tokens struct {
+			Input  int `json:"input"`
+			Output int `json:"output"`
+			Cached int `json:"cached"` // absent without context caching
+		} `json:"tokens"`
+		Content   json.RawMessage `json:"content,omitempty"`
+		ToolCalls json.RawMessage `json:"toolCalls,omitempty"`
+	} `json:"messages"`
+}
+
+// geminiSessionV2 is the versioned layout using Gemini API field names.
+type geminiSessionV2 struct {
+	SessionID   string `json:"sessionId"`
+	StartTime   string `json:"startTime"`
+	LastUpdated string `json:"lastUpdated"`
+	Messages    []struct {
+		Role          string          `json:"role"`
+		Model         string          `json:"model,omitempty"`
+		Parts         json.RawMessage `json:"parts,omitempty"`
+		UsageMetadata struct {
+			PromptTokenCount        int `json:"promptTokenCount"`
+			CandidatesTokenCount    int `json:"candidatesTokenCount"`
+			CachedContentTokenCount int `json:"cachedContentTokenCount"`
+		} `json:"usageMetadata"`
+	} `json:"messages"`
+}
+
+// sniffGeminiFormat picks the layout from the top-level "version" field, or,
+// when it is absent, from the message fields: "type" means v1, "role" v2.
+// Returns 0 for versions this build does not know.
+func sniffGeminiFormat(data []byte) (int, error) {
+	var probe struct {
+		Version  json.RawMessage `json:"version"`
+		Messages []struct {
+			Type *string `json:"type"`
+			Role *string `json:"role"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return 0, err
+	}
+
+	if len(probe.Version) > 0 {
+		// Accept both 2 and "2".
+		var raw any
+		_ = json.Unmarshal(probe.Version, &raw)
+		switch v := raw.(type) {
+		case float64:
+			return knownGeminiFormat(int(v)), nil
+		case string:
+			if n, err := strconv.Atoi(v); err == nil {
+				return knownGeminiFormat(n), nil
+			}
+		}
+		return 0, nil
+	}
+
+	for _, m := range probe.Messages {
+		if m.Type != nil {
+			return geminiFormatV1, nil
+		}
+		if m.Role != nil {
+			return geminiFormatV2, nil
+		}
+	}
+	return geminiFormatV1, nil // no messages yet: nothing to misread
+}
+
+func knownGeminiFormat(v int) int {
+	switch v {
+	case geminiFormatV1, geminiFormatV2:
+		return v
+	}
+	return 0
+}
+
+// geminiFormatWarned remembers files already reported as unreadable, so the
+// analytics refresh loop logs each one once instead of every tick.
+var geminiFormatWarned sync.Map
+
+// decodeGeminiSession sniffs the layout of a session file and decodes it with
+// the matching parser. filePath is only used for diagnostics.
+func decodeGeminiSession(filePath string, data []byte) (*geminiSession, error) {
+	format, err := sniffGeminiFormat(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case geminiFormatV1:
+		return decodeGeminiSessionV1(data)
+	case geminiFormatV2:
+		return decodeGeminiSessionV2(data)
+	}
+
+	if _, seen := geminiFormatWarned.LoadOrStore(filePath, true); !seen {
+		sessionLog.Warn("gemini_session_format_unknown",
+			slog.String("file", filePath),
+			slog.String("hint", "Gemini CLI may have changed its session format; update agent-deck"))
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownGeminiFormat, filePath)
+}
+
+func decodeGeminiSessionV1(data []byte) (*geminiSession, error) {
+	var raw geminiSessionV1
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	s := &geminiSession{
+		Format:      geminiFormatV1,
+		SessionID:   raw.SessionID,
+		StartTime:   raw.StartTime,
+		LastUpdated: raw.LastUpdated,
+		Messages:    make([]geminiMessage, len(raw.Messages)),
+	}
+	for i, m := range raw.Messages {
+		msg := &s.Messages[i]
+		msg.Type = m.Type
+		msg.Model = m.Model
+		msg.Tokens.Input = m.Tokens.Input
+		msg.Tokens.Output = m.Tokens.Output
+		msg.Tokens.Cached = m.Tokens.Cached
+		msg.Content = m.Content
+		msg.ToolCalls = m.ToolCalls
+	}
+	return s, nil
+}
+
+func decodeGeminiSessionV2(data []byte) (*geminiSession, error) {
+	var raw geminiSessionV2
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	s := &geminiSession{
+		Format:      geminiFormatV2,
+		SessionID:   raw.SessionID,
+		StartTime:   raw.StartTime,
+		LastUpdated: raw.LastUpdated,
+		Messages:    make([]geminiMessage, len(raw.Messages)),
+	}
+	for i, m := range raw.Messages {
+		msg := &s.Messages[i]
+		msg.Type = m.Role
+		if m.Role == "model" {
+			msg.Type = "gemini"
+		}
+		msg.Model = m.Model
+		msg.Tokens.Input = m.UsageMetadata.PromptTokenCount
+		msg.Tokens.Output = m.UsageMetadata.CandidatesTokenCount
+		msg.Tokens.Cached = m.UsageMetadata.CachedContentTokenCount
+		// Function calls live in parts; classifyGeminiTurn finds them there.
+		msg.Content = m.Parts
+	}
+	return s, nil
+}
@@ -0,0 +1,115 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// copyGeminiFixture installs testdata/gemini/<name> as a session file for
+// projectPath under the overridden Gemini config dir.
+func copyGeminiFixture(t *testing.T, projectPath, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "gemini", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	if err := os.MkdirAll(sessionsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(sessionsDir, "session-2025-12-23T00-24-f00dcafe.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSniffGeminiFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want int
+	}{
+		{"unversioned typed messages", `{"sessionId":"x","messages":[{"type":"user"}]}`, geminiFormatV1},
+		{"unversioned empty", `{"sessionId":"x","messages":[]}`, geminiFormatV1},
+		{"version 1", `{"version":1,"messages":[]}`, geminiFormatV1},
+		{"version 2", `{"version":2,"messages":[]}`, geminiFormatV2},
+		{"version as string", `{"version":"2"}`, geminiFormatV2},
+		{"unversioned role messages", `{"messages":[{"role":"model"}]}`, geminiFormatV2},
+		{"future version", `{"version":3}`, 0},
+		{"garbage version", `{"version":{"major":2}}`, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sniffGeminiFormat([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("sniffGeminiFormat: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("format = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// Both fixtures describe the same conversation, so every format must yield
+// the same metadata and analytics.
+func TestGeminiSessionFormats_SameResults(t *testing.T) {
+	for _, fixture := range []string{"session_v1.json", "session_v2.json"} {
+		t.Run(fixture, func(t *testing.T) {
+			geminiConfigDirOverride = t.TempDir()
+			defer func() { geminiConfigDirOverride = "" }()
+			projectPath := "/Users/ashesh/format-project"
+			path := copyGeminiFixture(t, projectPath, fixture)
+
+			info, err := ParseGeminiSessionFile(path)
+			if err != nil {
+				t.Fatalf("ParseGeminiSessionFile: %v", err)
+			}
+			if info.SessionID != "f00dcafe-1111-1111-1111-111111111111" || info.MessageCount != 3 {
+				t.Errorf("info = %+v", info)
+			}
+			if info.StartTime.IsZero() || info.LastUpdated.IsZero() {
+				t.Errorf("timestamps not parsed: %+v", info)
+			}
+
+			analytics := &GeminiSessionAnalytics{}
+			if err := UpdateGeminiAnalyticsFromDisk(projectPath, info.SessionID, analytics); err != nil {
+				t.Fatalf("UpdateGeminiAnalyticsFromDisk: %v", err)
+			}
+			if analytics.InputTokens != 2200 || analytics.OutputTokens != 80 || analytics.CachedTokens != 600 {
+				t.Errorf("tokens in/out/cached = %d/%d/%d, want 2200/80/600",
+					analytics.InputTokens, analytics.OutputTokens, analytics.CachedTokens)
+			}
+			if analytics.TotalTurns != 2 || analytics.ToolCallTurns != 1 || analytics.TextTurns != 1 {
+				t.Errorf("turns total/tool/text = %d/%d/%d, want 2/1/1",
+					analytics.TotalTurns, analytics.ToolCallTurns, analytics.TextTurns)
+			}
+			if analytics.Model != "gemini-2.5-pro" {
+				t.Errorf("Model = %q", analytics.Model)
+			}
+		})
+	}
+}
+
+func TestGeminiSessionFormats_UnknownVersionErrors(t *testing.T) {
+	geminiConfigDirOverride = t.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+	projectPath := "/Users/ashesh/format-project"
+	path := copyGeminiFixture(t, projectPath, "session_v99.json")
+
+	if _, err := ParseGeminiSessionFile(path); !errors.Is(err, ErrUnknownGeminiFormat) {
+		t.Errorf("ParseGeminiSessionFile err = %v, want ErrUnknownGeminiFormat", err)
+	}
+
+	analytics := &GeminiSessionAnalytics{}
+	err := UpdateGeminiAnalyticsFromDisk(projectPath, "f00dcafe-1111-1111-1111-111111111111", analytics)
+	if !errors.Is(err, ErrUnknownGeminiFormat) {
+		t.Errorf("UpdateGeminiAnalyticsFromDisk err = %v, want ErrUnknownGeminiFormat", err)
+	}
+	if !analytics.LastFileModTime.IsZero() {
+		t.Error("unreadable file must not be recorded as parsed")
+	}
+}
@@ -1,8 +1,17 @@
 package session
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -35,6 +44,64 @@ func TestGetGeminiConfigDir_Override(t *testing.T) {
 	}
 }
 
+func TestSetGeminiConfigDir(t *testing.T) {
+	t.Cleanup(ResetGeminiConfigDir)
+
+	for _, bad := range []string{"", "  ", "relative/gemini"} {
+		if err := SetGeminiConfigDir(bad); err == nil {
+			t.Errorf("SetGeminiConfigDir(%q) = nil, want error", bad)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := SetGeminiConfigDir(dir + "/"); err != nil {
+		t.Fatalf("SetGeminiConfigDir(%q) = %v", dir, err)
+	}
+	if got := GetGeminiConfigDir(); got != dir {
+		t.Errorf("GetGeminiConfigDir() = %q, want %q", got, dir)
+	}
+
+	ResetGeminiConfigDir()
+	home, _ := os.UserHomeDir()
+	if got := GetGeminiConfigDir(); got == dir || !strings.HasPrefix(got, home) {
+		t.Errorf("after reset GetGeminiConfigDir() = %q, want a dir under %q", got, home)
+	}
+}
+
+func TestGetGeminiConfigDir_Env(t *testing.T) {
+	geminiConfigDirOverride = ""
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(GeminiConfigDirEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+
+	// XDG dir is only used once it exists.
+	if got, want := GetGeminiConfigDir(), filepath.Join(home, ".gemini"); got != want {
+		t.Errorf("without XDG gemini dir: GetGeminiConfigDir() = %q, want %q", got, want)
+	}
+	xdgDir := filepath.Join(home, "xdg", "gemini")
+	if err := os.MkdirAll(xdgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetGeminiConfigDir(); got != xdgDir {
+		t.Errorf("with XDG gemini dir: GetGeminiConfigDir() = %q, want %q", got, xdgDir)
+	}
+
+	// An existing ~/.gemini, where Gemini CLI writes, wins over the XDG dir.
+	if err := os.MkdirAll(filepath.Join(home, ".gemini"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := GetGeminiConfigDir(), filepath.Join(home, ".gemini"); got != want {
+		t.Errorf("with both dirs: GetGeminiConfigDir() = %q, want %q", got, want)
+	}
+
+	// GEMINI_CONFIG_DIR beats both, with ~ expanded.
+	t.Setenv(GeminiConfigDirEnv, "~/gem/")
+	if got, want := GetGeminiConfigDir(), filepath.Join(home, "gem"); got != want {
+		t.Errorf("with %s: GetGeminiConfigDir() = %q, want %q", GeminiConfigDirEnv, got, want)
+	}
+}
+
 func TestHashProjectPath(t *testing.T) {
 	tests := []struct {
 		path     string
@@ -57,6 +124,54 @@ func TestHashProjectPath(t *testing.T) {
 	}
 }
 
+// TestCanonicalPath_SymlinkedProject pins the single canonicalization point:
+// a project reached through a symlink hashes, and launches Gemini, in the
+// resolved directory. On macOS this is the /tmp -> /private/tmp case.
+func TestCanonicalPath_SymlinkedProject(t *testing.T) {
+	target := t.TempDir()
+	if resolved, err := filepath.EvalSymlinks(target); err == nil {
+		target = resolved // the temp root itself may be a symlink (macOS /var)
+	}
+	link := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	if got := CanonicalPath(link); got != target {
+		t.Errorf("CanonicalPath(%q) = %q, want %q", link, got, target)
+	}
+	if HashProjectPath(link) != HashProjectPath(target) {
+		t.Error("a symlink and its target must hash to the same Gemini project")
+	}
+	missing := filepath.Join(target, "not-yet")
+	if got := CanonicalPath(missing); got != missing {
+		t.Errorf("CanonicalPath(missing) = %q, want %q", got, missing)
+	}
+
+	gemini := NewInstanceWithTool("gem", link, "gemini")
+	gemini.applyCanonicalWorkDir()
+	if gemini.tmuxSession.WorkDir != target {
+		t.Errorf("gemini launch dir = %q, want %q", gemini.tmuxSession.WorkDir, target)
+	}
+	shell := NewInstanceWithTool("sh", link, "shell")
+	shell.applyCanonicalWorkDir()
+	if shell.tmuxSession.WorkDir != link {
+		t.Errorf("shell launch dir = %q, want the path as given %q", shell.tmuxSession.WorkDir, link)
+	}
+}
+
+func TestCanonicalPath_MacOSTmp(t *testing.T) {
+	if runtime.GOOS != "darwin" {
+		t.Skip("/tmp is a symlink to /private/tmp only on macOS")
+	}
+	if got := CanonicalPath("/tmp"); got != "/private/tmp" {
+		t.Errorf("CanonicalPath(/tmp) = %q, want /private/tmp", got)
+	}
+	if HashProjectPath("/tmp") != HashProjectPath("/private/tmp") {
+		t.Error("/tmp and /private/tmp must hash to the same Gemini project")
+	}
+}
+
 func TestGetGeminiSessionsDir(t *testing.T) {
 	tmpDir := t.TempDir()
 	geminiConfigDirOverride = tmpDir
@@ -87,6 +202,95 @@ func TestGetGeminiSessionsDir_InvalidPath(t *testing.T) {
 	}
 }
 
+func TestGeminiProjectHashCandidates(t *testing.T) {
+	realDir := t.TempDir()
+	link := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(realDir, link); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	got := GeminiProjectHashCandidates(link + "/")
+	resolved, _ := filepath.EvalSymlinks(realDir)
+	want := []string{
+		HashProjectPath(resolved), // symlink resolved
+		hashString(link),          // unresolved, cleaned
+		hashString(link + "/"),    // literal cwd string
+	}
+	if len(got) != len(want) {
+		t.Fatalf("candidates = %v, want %d entries", got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("candidate[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	// A plain, already-resolved path yields a single candidate.
+	if got := GeminiProjectHashCandidates("/Users/ashesh"); len(got) != 1 {
+		t.Errorf("plain path candidates = %v, want 1", got)
+	}
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestResolveGeminiSessionsDir_AlternateHash(t *testing.T) {
+	tmpDir := t.TempDir()
+	geminiConfigDirOverride = tmpDir
+	defer func() { geminiConfigDirOverride = "" }()
+
+	realDir := t.TempDir()
+	link := filepath.Join(t.TempDir(), "repo")
+	if err := os.Symlink(realDir, link); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	// Nothing on disk yet: the primary (resolved) dir is returned.
+	dir, hash := ResolveGeminiSessionsDir(link)
+	if hash != HashProjectPath(link) || dir != filepath.Join(tmpDir, "tmp", hash, "chats") {
+		t.Errorf("empty lookup = %s (%s), want primary dir", dir, hash)
+	}
+
+	// Gemini CLI stored the chat under the unresolved path's hash.
+	altHash := hashString(link)
+	altDir := filepath.Join(tmpDir, "tmp", altHash, "chats")
+	_ = os.MkdirAll(altDir, 0755)
+	sessionData := `{"sessionId": "abcd1234-5555-5555-5555-555555555555", "startTime": "2025-12-23T00:24:00.000Z", "lastUpdated": "2025-12-23T00:30:00.000Z", "messages": []}`
+	_ = os.WriteFile(filepath.Join(altDir, "session-2025-12-23T00-24-abcd1234.json"), []byte(sessionData), 0644)
+
+	dir, hash = ResolveGeminiSessionsDir(link)
+	if hash != altHash || dir != altDir {
+		t.Errorf("lookup = %s (%s), want alternate %s", dir, hash, altHash)
+	}
+	sessions, err := ListGeminiSessions(link)
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("ListGeminiSessions = %v, %v; want the alternate-hash session", sessions, err)
+	}
+	if got := GeminiSessionProjectHash(link, sessions[0].SessionID); got != altHash {
+		t.Errorf("GeminiSessionProjectHash = %q, want %q", got, altHash)
+	}
+}
+
+func TestGeminiSessionProjectHash_ScanFallback(t *testing.T) {
+	tmpDir := t.TempDir()
+	geminiConfigDirOverride = tmpDir
+	defer func() { geminiConfigDirOverride = "" }()
+
+	// Stored under a hash no candidate produces (e.g. the repo's old location).
+	oldDir := filepath.Join(tmpDir, "tmp", "0ld0ld", "chats")
+	_ = os.MkdirAll(oldDir, 0755)
+	_ = os.WriteFile(filepath.Join(oldDir, "session-2025-12-23T00-24-beef1234.json"), []byte(`{"sessionId":"beef1234-0000"}`), 0644)
+
+	if got := GeminiSessionProjectHash("/Users/ashesh/moved", "beef1234-0000"); got != "0ld0ld" {
+		t.Errorf("GeminiSessionProjectHash = %q, want 0ld0ld", got)
+	}
+	if got := GeminiSessionProjectHash("/Users/ashesh/moved", "cafe0000-0000"); got != "" {
+		t.Errorf("missing session hash = %q, want empty", got)
+	}
+}
+
 func TestParseGeminiSessionFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	sessionFile := filepath.Join(tmpDir, "session-2025-12-26T15-09-4d8fcb4d.json")
@@ -117,9 +321,9 @@ func TestParseGeminiSessionFile(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	info, err := parseGeminiSessionFile(sessionFile)
+	info, err := ParseGeminiSessionFile(sessionFile)
 	if err != nil {
-		t.Fatalf("parseGeminiSessionFile() error = %v", err)
+		t.Fatalf("ParseGeminiSessionFile() error = %v", err)
 	}
 
 	// Verify sessionId (camelCase)
@@ -153,9 +357,9 @@ func TestParseGeminiSessionFile_InvalidJSON(t *testing.T) {
 	// Write malformed JSON
 	_ = os.WriteFile(sessionFile, []byte("not valid json{"), 0644)
 
-	_, err := parseGeminiSessionFile(sessionFile)
+	_, err := ParseGeminiSessionFile(sessionFile)
 	if err == nil {
-		t.Error("parseGeminiSessionFile should fail with invalid JSON")
+		t.Error("ParseGeminiSessionFile should fail with invalid JSON")
 	}
 	if !strings.Contains(err.Error(), "failed to parse session") {
 		t.Errorf("Error should be wrapped, got: %v", err)
@@ -210,6 +414,153 @@ func TestListGeminiSessions(t *testing.T) {
 	}
 }
 
+// writeSyntheticGeminiSessions creates n session files with distinct
+// lastUpdated times and messageCount messages each, returning the chats dir.
+func writeSyntheticGeminiSessions(tb testing.TB, projectPath string, n, messageCount int) string {
+	tb.Helper()
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	if err := os.MkdirAll(sessionsDir, 0o755); err != nil {
+		tb.Fatal(err)
+	}
+	msgs := make([]string, messageCount)
+	for i := range msgs {
+		msgs[i] = fmt.Sprintf(`{"id":"%d","type":"user","content":"%s"}`, i, strings.Repeat("x", 200))
+	}
+	base := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	for i := range n {
+		updated := base.Add(time.Duration(i) * time.Minute).Format(time.RFC3339)
+		data := fmt.Sprintf(`{"sessionId":"%08d-0000-0000-0000-000000000000","startTime":%q,"lastUpdated":%q,"messages":[%s]}`,
+			i, updated, updated, strings.Join(msgs, ","))
+		name := filepath.Join(sessionsDir, fmt.Sprintf("session-2025-12-01T00-00-%08d.json", i))
+		if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return sessionsDir
+}
+
+func TestListGeminiSessions_ManyFilesSkipsMalformed(t *testing.T) {
+	tmpDir := t.TempDir()
+	geminiConfigDirOverride = tmpDir
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/many-sessions"
+	sessionsDir := writeSyntheticGeminiSessions(t, projectPath, 200, 2)
+	for _, bad := range []string{"session-bad-1.json", "session-bad-2.json"} {
+		if err := os.WriteFile(filepath.Join(sessionsDir, bad), []byte("{not json"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sessions, err := ListGeminiSessions(projectPath)
+	if err != nil {
+		t.Fatalf("ListGeminiSessions() error = %v", err)
+	}
+	if len(sessions) != 200 {
+		t.Fatalf("got %d sessions, want 200 (malformed files skipped)", len(sessions))
+	}
+	for i := 1; i < len(sessions); i++ {
+		if sessions[i].LastUpdated.After(sessions[i-1].LastUpdated) {
+			t.Fatalf("sessions not sorted by LastUpdated desc at %d", i)
+		}
+	}
+	if sessions[0].SessionID != "00000199-0000-0000-0000-000000000000" {
+		t.Errorf("first session = %s, want newest", sessions[0].SessionID)
+	}
+}
+
+// BenchmarkListGeminiSessions compares the parallel listing against a serial
+// parse of the same files: go test -bench ListGeminiSessions ./internal/session
+func BenchmarkListGeminiSessions(b *testing.B) {
+	geminiConfigDirOverride = b.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/bench-project"
+	sessionsDir := writeSyntheticGeminiSessions(b, projectPath, 300, 200)
+	files, _ := filepath.Glob(filepath.Join(sessionsDir, "session-*.json"))
+
+	b.Run("serial", func(b *testing.B) {
+		for range b.N {
+			for _, f := range files {
+				_, _ = ParseGeminiSessionFile(f)
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for range b.N {
+			ClearGeminiSessionCache()
+			if _, err := ListGeminiSessions(projectPath); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		ClearGeminiSessionCache()
+		for range b.N {
+			if _, err := ListGeminiSessions(projectPath); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestListGeminiSessions_CachesByMtime(t *testing.T) {
+	geminiConfigDirOverride = t.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+	ClearGeminiSessionCache()
+	t.Cleanup(ClearGeminiSessionCache)
+
+	projectPath := "/Users/ashesh/cache-project"
+	sessionsDir := writeSyntheticGeminiSessions(t, projectPath, 1, 1)
+	file := filepath.Join(sessionsDir, "session-2025-12-01T00-00-00000000.json")
+	st, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ListGeminiSessions(projectPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// Same size and mtime: the cached parse must be served.
+	same := `{"sessionId":"cached!!-0000-0000-0000-000000000000"`
+	orig, _ := os.ReadFile(file)
+	rewritten := same + string(orig[len(same):])
+	if err := os.WriteFile(file, []byte(rewritten), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(file, st.ModTime(), st.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	sessions, _ := ListGeminiSessions(projectPath)
+	if len(sessions) != 1 || sessions[0].SessionID != "00000000-0000-0000-0000-000000000000" {
+		t.Fatalf("expected cached session, got %+v", sessions)
+	}
+
+	// A new mtime invalidates the entry.
+	newer := st.ModTime().Add(time.Minute)
+	if err := os.Chtimes(file, newer, newer); err != nil {
+		t.Fatal(err)
+	}
+	sessions, _ = ListGeminiSessions(projectPath)
+	if len(sessions) != 1 || sessions[0].SessionID != "cached!!-0000-0000-0000-000000000000" {
+		t.Fatalf("expected re-parse after mtime change, got %+v", sessions)
+	}
+
+	// Clearing forces a re-parse even when the file looks unchanged.
+	if err := os.WriteFile(file, orig, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(file, newer, newer); err != nil {
+		t.Fatal(err)
+	}
+	ClearGeminiSessionCache()
+	sessions, _ = ListGeminiSessions(projectPath)
+	if len(sessions) != 1 || sessions[0].SessionID != "00000000-0000-0000-0000-000000000000" {
+		t.Fatalf("expected fresh parse after clear, got %+v", sessions)
+	}
+}
+
 // TestFindGeminiSessionForInstance was removed - file scanning is no longer used.
 // Session ID detection now uses tmux environment variables exclusively.
 
@@ -299,6 +650,59 @@ func TestUpdateGeminiAnalyticsFromDisk_MtimeCache(t *testing.T) {
 	}
 }
 
+func TestUpdateGeminiAnalyticsFromDisk_ResetsOnSessionChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	geminiConfigDirOverride = tmpDir
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/test-project"
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	_ = os.MkdirAll(sessionsDir, 0755)
+
+	// Same 8-char prefix, different session: the second file has no model
+	// and no timestamps, so nothing of the first may survive.
+	first := `{
+  "sessionId": "abc12345-6666-6666-6666-666666666666",
+  "startTime": "2025-12-23T00:24:00.000Z",
+  "lastUpdated": "2025-12-23T00:30:00.000Z",
+  "messages": [{"type": "gemini", "content": "a", "model": "gemini-2.5-pro", "tokens": {"input": 500, "output": 50}}]
+}`
+	second := `{
+  "sessionId": "abc12345-7777-7777-7777-777777777777",
+  "messages": [{"type": "gemini", "content": "b", "tokens": {"input": 40, "output": 4}}]
+}`
+	sessionFile := filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json")
+	_ = os.WriteFile(sessionFile, []byte(first), 0644)
+
+	analytics := &GeminiSessionAnalytics{}
+	if err := UpdateGeminiAnalyticsFromDisk(projectPath, "abc12345-6666-6666-6666-666666666666", analytics); err != nil {
+		t.Fatalf("first parse: %v", err)
+	}
+	if analytics.SessionID != "abc12345-6666-6666-6666-666666666666" || analytics.Duration == 0 {
+		t.Fatalf("first parse: SessionID = %q, Duration = %v", analytics.SessionID, analytics.Duration)
+	}
+	analytics.EstimatedCost = 1.5
+
+	_ = os.WriteFile(sessionFile, []byte(second), 0644)
+	later := time.Now().Add(time.Minute)
+	_ = os.Chtimes(sessionFile, later, later)
+	if err := UpdateGeminiAnalyticsFromDisk(projectPath, "abc12345-7777-7777-7777-777777777777", analytics); err != nil {
+		t.Fatalf("second parse: %v", err)
+	}
+	if analytics.SessionID != "abc12345-7777-7777-7777-777777777777" {
+		t.Errorf("SessionID = %q, want the new session", analytics.SessionID)
+	}
+	if analytics.InputTokens != 40 || analytics.TotalTurns != 1 {
+		t.Errorf("InputTokens = %d, TotalTurns = %d; want 40 and 1", analytics.InputTokens, analytics.TotalTurns)
+	}
+	if analytics.Duration != 0 || !analytics.StartTime.IsZero() || analytics.EstimatedCost != 0 || analytics.Model != "" {
+		t.Errorf("stale state survived: %+v", analytics)
+	}
+	if analytics.LastFileModTime.IsZero() {
+		t.Error("LastFileModTime should be recorded for the new file")
+	}
+}
+
 func TestUpdateGeminiAnalyticsFromDisk_ExtractsModel(t *testing.T) {
 	tmpDir := t.TempDir()
 	geminiConfigDirOverride = tmpDir
@@ -344,6 +748,134 @@ func TestUpdateGeminiAnalyticsFromDisk_ExtractsModel(t *testing.T) {
 	}
 }
 
+func TestUpdateGeminiAnalyticsFromDisk_TurnBreakdown(t *testing.T) {
+	tmpDir := t.TempDir()
+	geminiConfigDirOverride = tmpDir
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/test-project"
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	_ = os.MkdirAll(sessionsDir, 0755)
+
+	// Mixes both content shapes plus empty and unknown part types.
+	sessionData := `{
+  "sessionId": "abc12345-3333-3333-3333-333333333333",
+  "startTime": "2025-12-23T00:24:00.000Z",
+  "lastUpdated": "2025-12-23T00:30:00.000Z",
+  "messages": [
+    {"type": "user", "content": "list files"},
+    {"type": "gemini", "content": "", "toolCalls": [{"id": "1", "name": "list_directory", "args": {"path": "."}}]},
+    {"type": "gemini", "content": "Here are the files."},
+    {"type": "gemini", "content": [{"text": "Reading it now."}, {"functionCall": {"name": "read_file"}}]},
+    {"type": "gemini", "content": [{"text": "Done."}]},
+    {"type": "gemini", "content": [{"inlineData": {"mimeType": "image/png"}}]},
+    {"type": "gemini", "content": "   ", "toolCalls": []},
+    {"type": "gemini", "content": {"unexpected": true}}
+  ]
+}`
+	sessionFile := filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json")
+	_ = os.WriteFile(sessionFile, []byte(sessionData), 0644)
+
+	analytics := &GeminiSessionAnalytics{ToolCallTurns: 42, TextTurns: 42, OtherTurns: 42}
+	if err := UpdateGeminiAnalyticsFromDisk(projectPath, "abc12345-3333-3333-3333-333333333333", analytics); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+
+	if analytics.TotalTurns != 7 {
+		t.Errorf("TotalTurns = %d, want 7", analytics.TotalTurns)
+	}
+	if analytics.ToolCallTurns != 2 {
+		t.Errorf("ToolCallTurns = %d, want 2", analytics.ToolCallTurns)
+	}
+	if analytics.TextTurns != 2 {
+		t.Errorf("TextTurns = %d, want 2", analytics.TextTurns)
+	}
+	if analytics.OtherTurns != 3 {
+		t.Errorf("OtherTurns = %d, want 3", analytics.OtherTurns)
+	}
+	if sum := analytics.ToolCallTurns + analytics.TextTurns + analytics.OtherTurns; sum != analytics.TotalTurns {
+		t.Errorf("breakdown sums to %d, want TotalTurns %d", sum, analytics.TotalTurns)
+	}
+}
+
+func TestUpdateGeminiAnalyticsFromDisk_CachedTokens(t *testing.T) {
+	tmpDir := t.TempDir()
+	geminiConfigDirOverride = tmpDir
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/test-project"
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	_ = os.MkdirAll(sessionsDir, 0755)
+
+	// Second turn has no "cached" field at all.
+	sessionData := `{
+  "sessionId": "abc12345-4444-4444-4444-444444444444",
+  "startTime": "2025-12-23T00:24:00.000Z",
+  "lastUpdated": "2025-12-23T00:30:00.000Z",
+  "messages": [
+    {"type": "gemini", "content": "a", "tokens": {"input": 1000, "output": 10, "cached": 800}},
+    {"type": "gemini", "content": "b", "tokens": {"input": 500, "output": 20}}
+  ]
+}`
+	sessionFile := filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json")
+	_ = os.WriteFile(sessionFile, []byte(sessionData), 0644)
+
+	analytics := &GeminiSessionAnalytics{CachedTokens: 99}
+	if err := UpdateGeminiAnalyticsFromDisk(projectPath, "abc12345-4444-4444-4444-444444444444", analytics); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	if analytics.CachedTokens != 800 {
+		t.Errorf("CachedTokens = %d, want 800", analytics.CachedTokens)
+	}
+	if analytics.InputTokens != 1500 {
+		t.Errorf("InputTokens = %d, want 1500", analytics.InputTokens)
+	}
+	want := []GeminiTurnTokens{{Input: 1000, Output: 10}, {Input: 500, Output: 20}}
+	if len(analytics.TurnTokens) != len(want) || analytics.TurnTokens[0] != want[0] || analytics.TurnTokens[1] != want[1] {
+		t.Errorf("TurnTokens = %v, want %v", analytics.TurnTokens, want)
+	}
+}
+
+func TestUpdateGeminiAnalyticsFromDisk_PromptTokens(t *testing.T) {
+	tmpDir := t.TempDir()
+	geminiConfigDirOverride = tmpDir
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/test-project"
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	_ = os.MkdirAll(sessionsDir, 0755)
+
+	// User messages carry their own token counts; the second has none.
+	sessionData := `{
+  "sessionId": "abc12345-5555-5555-5555-555555555555",
+  "startTime": "2025-12-23T00:24:00.000Z",
+  "lastUpdated": "2025-12-23T00:30:00.000Z",
+  "messages": [
+    {"type": "user", "content": "hi", "tokens": {"input": 12}},
+    {"type": "gemini", "content": "a", "tokens": {"input": 1000, "output": 10}},
+    {"type": "user", "content": "more"},
+    {"type": "gemini", "content": "b", "tokens": {"input": 1100, "output": 20}}
+  ]
+}`
+	sessionFile := filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json")
+	_ = os.WriteFile(sessionFile, []byte(sessionData), 0644)
+
+	analytics := &GeminiSessionAnalytics{PromptTokens: 99}
+	if err := UpdateGeminiAnalyticsFromDisk(projectPath, "abc12345-5555-5555-5555-555555555555", analytics); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	if analytics.PromptTokens != 12 {
+		t.Errorf("PromptTokens = %d, want 12", analytics.PromptTokens)
+	}
+	if analytics.InputTokens != 2100 || analytics.TotalTurns != 2 {
+		t.Errorf("InputTokens = %d, TotalTurns = %d; want 2100 and 2 (model turns only)",
+			analytics.InputTokens, analytics.TotalTurns)
+	}
+	if analytics.TotalTokens() != 2130 {
+		t.Errorf("TotalTokens = %d, want 2130 (prompt tokens not added)", analytics.TotalTokens())
+	}
+}
+
 func TestGetAvailableGeminiModels_Fallback(t *testing.T) {
 	// Clear cache and env vars to force fallback
 	geminiModelCacheMu.Lock()
@@ -365,8 +897,8 @@ func TestGetAvailableGeminiModels_Fallback(t *testing.T) {
 	}()
 
 	models, err := GetAvailableGeminiModels()
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	if !errors.Is(err, ErrNoAPIKey) {
+		t.Fatalf("err = %v, want ErrNoAPIKey", err)
 	}
 	if len(models) == 0 {
 		t.Fatal("expected non-empty fallback model list")
@@ -435,8 +967,8 @@ func TestGetAvailableGeminiModels_UpdatedFallback(t *testing.T) {
 	}()
 
 	models, err := GetAvailableGeminiModels()
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	if !errors.Is(err, ErrNoAPIKey) {
+		t.Fatalf("err = %v, want ErrNoAPIKey", err)
 	}
 
 	expected := []string{
@@ -460,3 +992,812 @@ func TestGetAvailableGeminiModels_UpdatedFallback(t *testing.T) {
 		}
 	}
 }
+
+func TestGetAvailableGeminiModels_ErrorKinds(t *testing.T) {
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+	isolateGeminiModelCache(t)
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    error
+	}{
+		{"rejected key", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusBadRequest) }, ErrAPIKeyRejected},
+		{"server error", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusInternalServerError) }, ErrModelResponseInvalid},
+		{"bad body", func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, "not json") }, ErrModelResponseInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+			t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL)
+
+			models, err := GetAvailableGeminiModels()
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if len(models) != len(geminiModelFallback) {
+				t.Errorf("models = %v, want the fallback list", models)
+			}
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL)
+		srv.Close()
+
+		if _, err := GetAvailableGeminiModels(); !errors.Is(err, ErrModelFetchFailed) {
+			t.Fatalf("err = %v, want ErrModelFetchFailed", err)
+		}
+	})
+}
+
+func TestGetAvailableGeminiModels_CustomBaseURL(t *testing.T) {
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+	isolateGeminiModelCache(t)
+
+	var gotPath, gotKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotKey = r.URL.Path, r.URL.Query().Get("key")
+		fmt.Fprint(w, `{"models":[{"name":"models/gemini-proxy","supportedGenerationMethods":["generateContent"]}]}`)
+	}))
+	defer srv.Close()
+	t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL+"/gateway/")
+
+	models, err := GetAvailableGeminiModels()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/gateway/v1beta/models" || gotKey != "test-key" {
+		t.Errorf("request path=%q key=%q, want /gateway/v1beta/models with the API key", gotPath, gotKey)
+	}
+	if len(models) != 1 || models[0] != "gemini-proxy" {
+		t.Errorf("models = %v, want [gemini-proxy]", models)
+	}
+}
+
+func TestRedactAPIKey(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{`Get "https://host/v1beta/models?key=secret123": dial tcp`, `Get "https://host/v1beta/models?key=REDACTED": dial tcp`},
+		{"https://host/models?alt=json&key=secret123&page=2", "https://host/models?alt=json&key=REDACTED&page=2"},
+		{"no key here", "no key here"},
+	}
+	for _, tt := range tests {
+		if got := redactAPIKey(tt.in); got != tt.want {
+			t.Errorf("redactAPIKey(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGetAvailableGeminiModels_ErrorOmitsAPIKey(t *testing.T) {
+	const key = "AIzaSecretKeyValue"
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	t.Setenv("GOOGLE_API_KEY", key)
+	isolateGeminiModelCache(t)
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL)
+	srv.Close()
+
+	_, err := GetAvailableGeminiModels()
+	if err == nil {
+		t.Fatal("expected an error from an unreachable endpoint")
+	}
+	if strings.Contains(err.Error(), key) {
+		t.Fatalf("error leaks the API key: %v", err)
+	}
+	if !strings.Contains(err.Error(), "key=REDACTED") {
+		t.Errorf("error = %v, want the redacted URL", err)
+	}
+}
+
+// setGeminiAPIKeysForTest writes [gemini].api_keys into the TestMain-isolated
+// config.toml, restoring the previous contents on cleanup.
+func setGeminiAPIKeysForTest(t *testing.T, keys ...string) {
+	t.Helper()
+	path, err := GetUserConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev, prevErr := os.ReadFile(path)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "[gemini]\napi_keys = [" + `"` + strings.Join(keys, `", "`) + `"` + "]\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ClearUserConfigCache()
+	t.Cleanup(func() {
+		if prevErr == nil {
+			_ = os.WriteFile(path, prev, 0o644)
+		} else {
+			_ = os.Remove(path)
+		}
+		ClearUserConfigCache()
+	})
+}
+
+func TestGetAvailableGeminiModels_RotatesKeys(t *testing.T) {
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	t.Setenv("GOOGLE_API_KEY", "key-rejected")
+	t.Setenv("GEMINI_KEY_B", "key-good")
+	setGeminiAPIKeysForTest(t, "key-quota", "$GEMINI_KEY_B")
+	stubGeminiModelRetry(t)
+	isolateGeminiModelCache(t)
+
+	var tried []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key")
+		tried = append(tried, key)
+		switch key {
+		case "key-rejected":
+			w.WriteHeader(http.StatusBadRequest)
+		case "key-quota":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			fmt.Fprint(w, `{"models":[{"name":"models/gemini-x","supportedGenerationMethods":["generateContent"]}]}`)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL)
+
+	models, err := GetAvailableGeminiModels()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(models) != 1 || models[0] != "gemini-x" {
+		t.Errorf("models = %v, want [gemini-x]", models)
+	}
+	if len(tried) == 0 || tried[0] != "key-rejected" || tried[len(tried)-1] != "key-good" {
+		t.Errorf("keys tried = %v, want GOOGLE_API_KEY first and the env-expanded key last", tried)
+	}
+}
+
+func TestGetAvailableGeminiModels_AllKeysFailRedacted(t *testing.T) {
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	t.Setenv("GOOGLE_API_KEY", "")
+	setGeminiAPIKeysForTest(t, "AIzaFirstSecret", "AIzaSecondSecret")
+	isolateGeminiModelCache(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+	t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL)
+
+	_, err := GetAvailableGeminiModels()
+	if !errors.Is(err, ErrAPIKeyRejected) {
+		t.Fatalf("err = %v, want ErrAPIKeyRejected", err)
+	}
+	if got := redactAPIKey("tried AIzaFirstSecret and AIzaSecondSecret"); got != "tried REDACTED and REDACTED" {
+		t.Errorf("redactAPIKey = %q, want both configured keys redacted", got)
+	}
+}
+
+// isolateGeminiModelCache points the on-disk model cache at a temp file and
+// starts from an empty, not-yet-loaded in-memory cache.
+func isolateGeminiModelCache(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), geminiModelCacheFileName)
+	reset := func() {
+		geminiModelCacheMu.Lock()
+		geminiModelCacheList = nil
+		geminiModelCacheTime = time.Time{}
+		geminiModelCacheLoaded = false
+		geminiModelCacheMu.Unlock()
+		geminiCredCacheMu.Lock()
+		geminiCredCacheKey, geminiCredCacheTime = "", time.Time{}
+		geminiCredCacheMu.Unlock()
+	}
+	origPath := geminiModelCachePath
+	geminiModelCachePath = func() (string, error) { return path, nil }
+	reset()
+	t.Cleanup(func() {
+		geminiModelCachePath = origPath
+		reset()
+	})
+	return path
+}
+
+// stubGeminiModelRetry makes retries instant and records the delays asked for.
+func stubGeminiModelRetry(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var slept []time.Duration
+	origSleep, origBase := geminiModelSleep, geminiModelRetryBase
+	geminiModelSleep = func(_ context.Context, d time.Duration) error { slept = append(slept, d); return nil }
+	geminiModelRetryBase = 10 * time.Millisecond
+	t.Cleanup(func() { geminiModelSleep, geminiModelRetryBase = origSleep, origBase })
+	return &slept
+}
+
+// pinNow fixes the package clock (nowFn) at at for the rest of the test.
+func pinNow(t *testing.T, at time.Time) {
+	t.Helper()
+	orig := nowFn
+	nowFn = func() time.Time { return at }
+	t.Cleanup(func() { nowFn = orig })
+}
+
+func TestGetAvailableGeminiModels_CacheTTLBoundary(t *testing.T) {
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	t.Setenv("GOOGLE_API_KEY", "")
+	isolateGeminiModelCache(t)
+	cachedAt := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	geminiModelCacheMu.Lock()
+	geminiModelCacheList = []string{"gemini-cached"}
+	geminiModelCacheTime = cachedAt
+	geminiModelCacheLoaded = true
+	geminiModelCacheMu.Unlock()
+
+	pinNow(t, cachedAt.Add(geminiModelCacheTTL-time.Nanosecond))
+	if models, err := GetAvailableGeminiModels(); err != nil || len(models) != 1 || models[0] != "gemini-cached" {
+		t.Errorf("just inside the TTL: models = %v, err = %v; want the cache", models, err)
+	}
+
+	pinNow(t, cachedAt.Add(geminiModelCacheTTL))
+	if _, err := GetAvailableGeminiModels(); !errors.Is(err, ErrNoAPIKey) {
+		t.Errorf("at the TTL: err = %v, want a refetch attempt (ErrNoAPIKey)", err)
+	}
+}
+
+func TestGetAvailableGeminiModels_RetriesRateLimit(t *testing.T) {
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+	slept := stubGeminiModelRetry(t)
+	isolateGeminiModelCache(t)
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			fmt.Fprint(w, `{"models":[{"name":"models/gemini-x","supportedGenerationMethods":["generateContent"]}]}`)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL)
+
+	models, err := GetAvailableGeminiModels()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(models) != 1 || models[0] != "gemini-x" {
+		t.Errorf("models = %v, want [gemini-x]", models)
+	}
+	// Retry-After wins over the backoff; the second retry backs off 2x base.
+	want := []time.Duration{2 * time.Second, 20 * time.Millisecond}
+	if len(*slept) != len(want) || (*slept)[0] != want[0] || (*slept)[1] != want[1] {
+		t.Errorf("slept %v, want %v", *slept, want)
+	}
+}
+
+func TestParseRetryAfter_HTTPDate(t *testing.T) {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	pinNow(t, now)
+
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Format(http.TimeFormat), 0},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"not a date", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.header); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestGetAvailableGeminiModels_RateLimitFallsBackToStaleCache(t *testing.T) {
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+	slept := stubGeminiModelRetry(t)
+	isolateGeminiModelCache(t)
+	geminiModelCacheMu.Lock()
+	geminiModelCacheList = []string{"gemini-cached"}
+	geminiModelCacheTime = time.Now().Add(-2 * geminiModelCacheTTL)
+	geminiModelCacheMu.Unlock()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL)
+
+	models, err := GetAvailableGeminiModels()
+	if !errors.Is(err, ErrModelRateLimited) {
+		t.Fatalf("err = %v, want ErrModelRateLimited", err)
+	}
+	if len(models) != 1 || models[0] != "gemini-cached" {
+		t.Errorf("models = %v, want the stale cache", models)
+	}
+	if len(*slept) != geminiModelFetchAttempts-1 {
+		t.Fatalf("slept %d times, want %d", len(*slept), geminiModelFetchAttempts-1)
+	}
+	for _, d := range *slept {
+		if d != geminiModelRetryMax {
+			t.Errorf("slept %v, want Retry-After capped at %v", d, geminiModelRetryMax)
+		}
+	}
+}
+
+func TestGetAvailableGeminiModels_DiskCache(t *testing.T) {
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+	path := isolateGeminiModelCache(t)
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"models":[{"name":"models/gemini-live","supportedGenerationMethods":["generateContent"]}]}`)
+	}))
+	defer srv.Close()
+	t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL)
+
+	if _, err := GetAvailableGeminiModels(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected cache file after a fetch: %v", err)
+	}
+
+	// A fresh process: empty memory, fresh file on disk -> no API call.
+	geminiModelCacheMu.Lock()
+	geminiModelCacheList, geminiModelCacheLoaded = nil, false
+	geminiModelCacheMu.Unlock()
+	models, err := GetAvailableGeminiModels()
+	if err != nil || len(models) != 1 || models[0] != "gemini-live" {
+		t.Fatalf("models = %v, err = %v; want [gemini-live] from disk", models, err)
+	}
+	if calls != 1 {
+		t.Errorf("API called %d times, want 1 (second call served from disk)", calls)
+	}
+
+	// An expired file is not fresh: refetch.
+	stale := fmt.Sprintf(`{"models":["gemini-old"],"fetched_at":%q}`, time.Now().Add(-2*geminiModelCacheTTL).Format(time.RFC3339))
+	if err := os.WriteFile(path, []byte(stale), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	geminiModelCacheMu.Lock()
+	geminiModelCacheList, geminiModelCacheLoaded = nil, false
+	geminiModelCacheMu.Unlock()
+	if models, _ := GetAvailableGeminiModels(); len(models) != 1 || models[0] != "gemini-live" || calls != 2 {
+		t.Errorf("models = %v after %d calls, want a refetch of [gemini-live]", models, calls)
+	}
+
+	// A corrupt file is ignored.
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	geminiModelCacheMu.Lock()
+	geminiModelCacheList, geminiModelCacheLoaded = nil, false
+	geminiModelCacheMu.Unlock()
+	if models, err := GetAvailableGeminiModels(); err != nil || len(models) != 1 || calls != 3 {
+		t.Errorf("models = %v, err = %v after %d calls, want a refetch", models, err, calls)
+	}
+}
+
+func TestGeminiCredentialStatus(t *testing.T) {
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	isolateGeminiModelCache(t)
+
+	t.Setenv("GOOGLE_API_KEY", "")
+	if got := GeminiCredentialStatus(); got != CredMissing {
+		t.Fatalf("no key: status = %v, want CredMissing", got)
+	}
+
+	calls := 0
+	var gotPageSize string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		gotPageSize = r.URL.Query().Get("pageSize")
+		if r.URL.Query().Get("key") == "bad-key" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"models":[{"name":"models/gemini-x","supportedGenerationMethods":["generateContent"]}]}`)
+	}))
+	defer srv.Close()
+	t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL)
+
+	t.Setenv("GOOGLE_API_KEY", "good-key")
+	for range 2 {
+		if got := GeminiCredentialStatus(); got != CredValid {
+			t.Fatalf("good key: status = %v, want CredValid", got)
+		}
+	}
+	if calls != 1 || gotPageSize != "1" {
+		t.Errorf("calls = %d, pageSize = %q; want one cheap call, then the cached result", calls, gotPageSize)
+	}
+
+	t.Setenv("GOOGLE_API_KEY", "bad-key")
+	if got := GeminiCredentialStatus(); got != CredRejected {
+		t.Errorf("bad key: status = %v, want CredRejected", got)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want a new check after the key changed", calls)
+	}
+}
+
+func TestGeminiCredentialStatus_UsesModelFetch(t *testing.T) {
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+	isolateGeminiModelCache(t)
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"models":[{"name":"models/gemini-x","supportedGenerationMethods":["generateContent"]}]}`)
+	}))
+	defer srv.Close()
+	t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL)
+
+	if _, err := GetAvailableGeminiModels(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := GeminiCredentialStatus(); got != CredValid || calls != 1 {
+		t.Errorf("status = %v after %d calls, want CredValid from the model fetch", got, calls)
+	}
+}
+
+func TestGetAvailableGeminiModelsContext_Cancel(t *testing.T) {
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "")
+	t.Setenv("GOOGLE_API_KEY", "test-key")
+	isolateGeminiModelCache(t)
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+	t.Setenv("GOOGLE_GENAI_BASE_URL", srv.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+	models, err := GetAvailableGeminiModelsContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("cancel took %v, want the request aborted promptly", time.Since(start))
+	}
+	if !slices.Equal(models, geminiModelFallback) {
+		t.Errorf("models = %v, want the fallback list", models)
+	}
+}
+
+func TestSleepContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleepContext(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestUpdateGeminiAnalyticsFromDisk_TokenRateAcrossUpdates(t *testing.T) {
+	geminiConfigDirOverride = t.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/rate-project"
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	_ = os.MkdirAll(sessionsDir, 0755)
+	sessionFile := filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json")
+	write := func(input int, mtime time.Time) {
+		data := fmt.Sprintf(`{"sessionId": "abc12345-8888-8888-8888-888888888888",
+  "messages": [{"type": "gemini", "content": "r", "tokens": {"input": %d, "output": 0}}]}`, input)
+		_ = os.WriteFile(sessionFile, []byte(data), 0644)
+		_ = os.Chtimes(sessionFile, mtime, mtime)
+	}
+
+	now := time.Now()
+	analytics := &GeminiSessionAnalytics{}
+	write(1000, now.Add(-time.Minute))
+	if err := UpdateGeminiAnalyticsFromDisk(projectPath, "abc12345-8888-8888-8888-888888888888", analytics); err != nil {
+		t.Fatal(err)
+	}
+	write(1600, now)
+	if err := UpdateGeminiAnalyticsFromDisk(projectPath, "abc12345-8888-8888-8888-888888888888", analytics); err != nil {
+		t.Fatal(err)
+	}
+	if got := analytics.tokenRateAt(now); got != 600 {
+		t.Errorf("TokenRate = %v, want 600 tokens/min", got)
+	}
+}
+
+func TestUpdateGeminiAnalyticsFromDisk_KeepsLastGoodOnPartialWrite(t *testing.T) {
+	geminiConfigDirOverride = t.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/partial-project"
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	_ = os.MkdirAll(sessionsDir, 0755)
+	sessionFile := filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json")
+	const sessionID = "abc12345-7777-7777-7777-777777777777"
+	full := func(input int) string {
+		return fmt.Sprintf(`{"sessionId": "%s",
+  "messages": [{"type": "gemini", "content": "r", "tokens": {"input": %d, "output": 0}}]}`, sessionID, input)
+	}
+	write := func(data string, mtime time.Time) {
+		_ = os.WriteFile(sessionFile, []byte(data), 0644)
+		_ = os.Chtimes(sessionFile, mtime, mtime)
+	}
+
+	now := time.Now()
+	inst := &Instance{Tool: "gemini", ProjectPath: projectPath, GeminiSessionID: sessionID}
+	write(full(500), now.Add(-2*time.Minute))
+	inst.updateGeminiAnalytics()
+	if inst.GeminiAnalytics.InputTokens != 500 {
+		t.Fatalf("InputTokens = %d, want 500", inst.GeminiAnalytics.InputTokens)
+	}
+
+	// Caught mid-write: the numbers stay and the next tick retries.
+	half := full(900)
+	write(half[:len(half)/2], now.Add(-time.Minute))
+	inst.lastGeminiAnalyticsAt = time.Time{}
+	inst.updateGeminiAnalytics()
+	if inst.GeminiAnalytics.InputTokens != 500 {
+		t.Errorf("after partial write InputTokens = %d, want the last good 500", inst.GeminiAnalytics.InputTokens)
+	}
+	if !inst.lastGeminiAnalyticsAt.IsZero() {
+		t.Error("a partial read should not wait out the refresh interval")
+	}
+	err := UpdateGeminiAnalyticsFromDisk(projectPath, sessionID, inst.GeminiAnalytics)
+	if !errors.Is(err, errGeminiSessionUnreadable) {
+		t.Errorf("same partial file again: err = %v, want errGeminiSessionUnreadable", err)
+	}
+
+	// The write completes within the same mtime tick: the size differs, so
+	// the finished file is still read.
+	write(full(900), now.Add(-time.Minute))
+	inst.updateGeminiAnalytics()
+	if inst.GeminiAnalytics.InputTokens != 900 {
+		t.Errorf("after the write completed InputTokens = %d, want 900", inst.GeminiAnalytics.InputTokens)
+	}
+}
+
+func TestUpdateGeminiAnalytics_HonorsRefreshInterval(t *testing.T) {
+	geminiConfigDirOverride = t.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/refresh-project"
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	_ = os.MkdirAll(sessionsDir, 0755)
+	sessionFile := filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json")
+	write := func(input int, mtime time.Time) {
+		data := fmt.Sprintf(`{"sessionId": "abc12345-9999-9999-9999-999999999999",
+  "messages": [{"type": "gemini", "content": "r", "tokens": {"input": %d, "output": 0}}]}`, input)
+		_ = os.WriteFile(sessionFile, []byte(data), 0644)
+		_ = os.Chtimes(sessionFile, mtime, mtime)
+	}
+
+	inst := &Instance{Tool: "gemini", ProjectPath: projectPath, GeminiSessionID: "abc12345-9999-9999-9999-999999999999"}
+	write(100, time.Now().Add(-time.Minute))
+	inst.updateGeminiAnalytics()
+	if inst.GeminiAnalytics.InputTokens != 100 {
+		t.Fatalf("InputTokens = %d, want 100", inst.GeminiAnalytics.InputTokens)
+	}
+
+	// Within the interval the file is not re-read, even though it changed
+	write(200, time.Now())
+	inst.updateGeminiAnalytics()
+	if inst.GeminiAnalytics.InputTokens != 100 {
+		t.Errorf("InputTokens = %d, want 100 (refresh interval not elapsed)", inst.GeminiAnalytics.InputTokens)
+	}
+
+	inst.lastGeminiAnalyticsAt = time.Now().Add(-time.Minute)
+	inst.updateGeminiAnalytics()
+	if inst.GeminiAnalytics.InputTokens != 200 {
+		t.Errorf("InputTokens = %d, want 200 after the interval", inst.GeminiAnalytics.InputTokens)
+	}
+}
+
+func TestUpdateGeminiAnalyticsFromDisk_ZeroAndNegativeDuration(t *testing.T) {
+	cases := []struct {
+		name        string
+		start, last string
+	}{
+		{"single message", "2025-12-23T00:24:00.000Z", "2025-12-23T00:24:00.000Z"},
+		{"clock skew", "2025-12-23T00:30:00.000Z", "2025-12-23T00:24:00.000Z"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			geminiConfigDirOverride = t.TempDir()
+			defer func() { geminiConfigDirOverride = "" }()
+
+			projectPath := "/Users/ashesh/duration-project"
+			sessionsDir := GetGeminiSessionsDir(projectPath)
+			_ = os.MkdirAll(sessionsDir, 0755)
+			data := fmt.Sprintf(`{"sessionId": "abc12345-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "startTime": %q, "lastUpdated": %q,
+  "messages": [{"type": "gemini", "content": "r", "tokens": {"input": 10, "output": 1}}]}`, tc.start, tc.last)
+			_ = os.WriteFile(filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json"), []byte(data), 0644)
+
+			analytics := &GeminiSessionAnalytics{}
+			if err := UpdateGeminiAnalyticsFromDisk(projectPath, "abc12345-aaaa-aaaa-aaaa-aaaaaaaaaaaa", analytics); err != nil {
+				t.Fatal(err)
+			}
+			if analytics.Duration != 0 {
+				t.Errorf("Duration = %v, want 0", analytics.Duration)
+			}
+			if !analytics.InProgress() {
+				t.Error("InProgress = false, want true")
+			}
+			start, _ := time.Parse(time.RFC3339, tc.start)
+			if got := analytics.ElapsedAt(start.Add(90 * time.Second)); got != 90*time.Second {
+				t.Errorf("ElapsedAt = %v, want 1m30s from startTime", got)
+			}
+			if got := analytics.ElapsedAt(start.Add(-time.Minute)); got != 0 {
+				t.Errorf("ElapsedAt before startTime = %v, want 0", got)
+			}
+		})
+	}
+}
+
+func TestGeminiSessionAnalytics_ElapsedAtRecordedDuration(t *testing.T) {
+	a := &GeminiSessionAnalytics{StartTime: time.Now().Add(-time.Hour), Duration: 6 * time.Minute}
+	if a.InProgress() {
+		t.Error("InProgress = true for a recorded duration")
+	}
+	if got := a.ElapsedAt(time.Now()); got != 6*time.Minute {
+		t.Errorf("ElapsedAt = %v, want the recorded 6m", got)
+	}
+}
+
+func TestUpdateGeminiAnalyticsFromDisk_ModelBreakdown(t *testing.T) {
+	geminiConfigDirOverride = t.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/switch-project"
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	_ = os.MkdirAll(sessionsDir, 0755)
+	const sessionID = "abc12345-8888-8888-8888-888888888888"
+	sessionData := `{
+  "sessionId": "` + sessionID + `",
+  "messages": [
+    {"type": "gemini", "content": "a", "model": "gemini-2.5-pro", "tokens": {"input": 1000000, "output": 0}},
+    {"type": "gemini", "content": "b", "tokens": {"input": 50, "output": 0}},
+    {"type": "gemini", "content": "c", "model": "gemini-2.5-flash", "tokens": {"input": 100, "output": 100}}
+  ]
+}`
+	_ = os.WriteFile(filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json"), []byte(sessionData), 0644)
+
+	analytics := &GeminiSessionAnalytics{}
+	if err := UpdateGeminiAnalyticsFromDisk(projectPath, sessionID, analytics); err != nil {
+		t.Fatalf("UpdateGeminiAnalyticsFromDisk: %v", err)
+	}
+	if analytics.Model != "gemini-2.5-flash" {
+		t.Errorf("Model = %q, want the most recent gemini-2.5-flash", analytics.Model)
+	}
+	if got := analytics.ModelBreakdown["gemini-2.5-pro"]; got.Input != 1000000 {
+		t.Errorf("pro breakdown = %+v, want 1M input", got)
+	}
+	if got := analytics.ModelBreakdown[""]; got.Input != 50 {
+		t.Errorf("unattributed breakdown = %+v, want 50 input", got)
+	}
+	used := analytics.ModelsUsed()
+	if len(used) != 2 || used[0] != "gemini-2.5-pro" || used[1] != "gemini-2.5-flash" {
+		t.Errorf("ModelsUsed = %v, want [gemini-2.5-pro gemini-2.5-flash]", used)
+	}
+
+	// The 1M pro tokens must not be billed at flash rates.
+	lastOnly := analytics.CalculateCost(analytics.Model)
+	split := analytics.EstimateCost(analytics.Model)
+	if split <= lastOnly {
+		t.Errorf("EstimateCost = %f, want more than the flash-only %f", split, lastOnly)
+	}
+	if want := 1.25; split < want {
+		t.Errorf("EstimateCost = %f, want at least the pro input cost %f", split, want)
+	}
+}
+
+func TestSetGeminiSessionFilePattern_AlternateNames(t *testing.T) {
+	geminiConfigDirOverride = t.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+	if err := SetGeminiSessionFilePattern("chat-{id}-*.json"); err != nil {
+		t.Fatalf("SetGeminiSessionFilePattern: %v", err)
+	}
+	defer ResetGeminiSessionFilePattern()
+
+	projectPath := "/Users/ashesh/renamed-files"
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	_ = os.MkdirAll(sessionsDir, 0755)
+	const sessionID = "c0ffee00-9999-9999-9999-999999999999"
+	sessionData := `{
+  "sessionId": "` + sessionID + `",
+  "startTime": "2025-12-23T00:24:00.000Z",
+  "lastUpdated": "2025-12-23T00:30:00.000Z",
+  "messages": [
+    {"type": "user", "content": "hi"},
+    {"type": "gemini", "content": "hello", "tokens": {"input": 70, "output": 7}}
+  ]
+}`
+	_ = os.WriteFile(filepath.Join(sessionsDir, "chat-c0ffee00-20251223.json"), []byte(sessionData), 0644)
+	// Old-style names are no longer picked up.
+	_ = os.WriteFile(filepath.Join(sessionsDir, "session-2025-12-23T00-24-abc12345.json"),
+		[]byte(`{"sessionId": "abc12345-1111-1111-1111-111111111111", "messages": []}`), 0644)
+
+	sessions, err := ListGeminiSessions(projectPath)
+	if err != nil {
+		t.Fatalf("ListGeminiSessions: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].SessionID != sessionID {
+		t.Fatalf("ListGeminiSessions = %+v, want only %s", sessions, sessionID)
+	}
+
+	analytics := &GeminiSessionAnalytics{}
+	if err := UpdateGeminiAnalyticsFromDisk(projectPath, sessionID, analytics); err != nil {
+		t.Fatalf("UpdateGeminiAnalyticsFromDisk: %v", err)
+	}
+	if analytics.InputTokens != 70 {
+		t.Errorf("InputTokens = %d, want 70", analytics.InputTokens)
+	}
+
+	// The cross-project scan uses the same pattern.
+	if got := GeminiSessionProjectHash("/Users/ashesh/elsewhere", sessionID); got != filepath.Base(filepath.Dir(sessionsDir)) {
+		t.Errorf("GeminiSessionProjectHash = %q, want the renamed file's hash dir", got)
+	}
+}
+
+func TestSetGeminiSessionFilePattern_Invalid(t *testing.T) {
+	defer ResetGeminiSessionFilePattern()
+	for _, pattern := range []string{"", "session-*.json", "{id}-{id}.json", "chats/{id}.json", "session-[{id}.json"} {
+		if err := SetGeminiSessionFilePattern(pattern); err == nil {
+			t.Errorf("SetGeminiSessionFilePattern(%q) = nil, want an error", pattern)
+		}
+	}
+	if got := geminiSessionFileGlob("/d", "abc12345-xyz"); got != "/d/session-*-abc12345.json" {
+		t.Errorf("invalid patterns should leave the default in place, glob = %q", got)
+	}
+}
+
+func TestParseGeminiFiles_AnyPath(t *testing.T) {
+	// No config dir override: the exported parsers read the file they are
+	// given and never look under ~/.gemini.
+	path := filepath.Join(t.TempDir(), "exported.json")
+	_ = os.WriteFile(path, []byte(`{
+  "sessionId": "0badf00d-1111-1111-1111-111111111111",
+  "startTime": "2025-12-23T00:24:00.000Z",
+  "lastUpdated": "2025-12-23T00:30:00.000Z",
+  "messages": [
+    {"type": "user", "content": "hi"},
+    {"type": "gemini", "content": "hello", "model": "gemini-2.5-pro", "tokens": {"input": 40, "output": 4}}
+  ]
+}`), 0644)
+
+	info, err := ParseGeminiSessionFile(path)
+	if err != nil {
+		t.Fatalf("ParseGeminiSessionFile: %v", err)
+	}
+	if info.SessionID != "0badf00d-1111-1111-1111-111111111111" || info.Filename != "exported.json" || info.MessageCount != 2 {
+		t.Errorf("info = %+v", info)
+	}
+
+	analytics, err := ParseGeminiAnalyticsFile(path)
+	if err != nil {
+		t.Fatalf("ParseGeminiAnalyticsFile: %v", err)
+	}
+	if analytics.SessionID != info.SessionID || analytics.InputTokens != 40 || analytics.Model != "gemini-2.5-pro" {
+		t.Errorf("analytics = %+v", analytics)
+	}
+
+	if _, err := ParseGeminiSessionFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("missing file should return an error")
+	}
+}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -83,6 +84,13 @@ type GroupTree struct {
 	// command/UI layer from [group_defaults].max_concurrent before a create; an
 	// explicit `group create --max-concurrent` flag still wins per-group.
 	DefaultMaxConcurrent *int
+
+	// SortBy and SortOrder are a display-only ordering applied by Flatten
+	// within each group (see SortSessions). They never touch group.Sessions
+	// or Order, so switching back to SortByDefault restores the stored order.
+	// Set by the TUI before each Flatten; the tree is rebuilt often.
+	SortBy    SessionSortKey
+	SortOrder SortOrder
 }
 
 // actionablePriority maps a session.Status to an "attention-needed" rank
@@ -590,6 +598,15 @@ func (t *GroupTree) Flatten() []Item {
 				}
 			}
 
+			// Apply the display sort, if any, before pins: pinned rows keep
+			// their bands and Order, only the normal band is reordered.
+			if t.SortBy != SortByDefault {
+				parentSessions = SortSessions(parentSessions, t.SortBy, t.SortOrder)
+				for parentID, subs := range subSessionsByParent {
+					subSessionsByParent[parentID] = SortSessions(subs, t.SortBy, t.SortOrder)
+				}
+			}
+
 			// Apply pin ordering live (pin-sessions): a pin edit mutates
 			// Instance.Pin but does not rebuild the tree, so the load-time
 			// SortInstancesByActionable has not re-run. Stable-partition the
@@ -972,7 +989,7 @@ func (t *GroupTree) MoveSessionToGroup(inst *Instance, newGroupPath string) {
 	}
 
 	// Add to new group
-	inst.GroupPath = newGroupPath
+	inst.SetGroup(newGroupPath)
 	newGroup, exists := t.Groups[newGroupPath]
 	if !exists {
 		newGroup = &Group{
@@ -1160,6 +1177,12 @@ func (t *GroupTree) RenameGroup(oldPath, newName string) {
 		return
 	}
 
+	// Never merge into (and clobber) another group; ValidateRenameGroup
+	// reports this case to the user.
+	if _, taken := t.Groups[newPath]; taken {
+		return
+	}
+
 	// Update all sessions in the group
 	for _, sess := range group.Sessions {
 		sess.GroupPath = newPath
@@ -1201,6 +1224,70 @@ func (t *GroupTree) RenameGroup(oldPath, newName string) {
 	t.rebuildGroupList()
 }
 
+// ErrGroupExists is returned when a create or rename would land on the path
+// of a group that already exists.
+var ErrGroupExists = errors.New("group already exists")
+
+// ValidateGroupName checks a single group name segment as typed by the user.
+// Nesting is expressed with a parent path, so separators are rejected rather
+// than silently flattened to hyphens by sanitizeGroupName.
+func ValidateGroupName(name string) error {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return fmt.Errorf("group name cannot be empty")
+	case strings.ContainsAny(name, "/\\"):
+		return fmt.Errorf("group name cannot contain path separators; create a subgroup to nest")
+	case sanitizeGroupName(name) == "unnamed" && name != "unnamed":
+		return fmt.Errorf("group name must contain a letter or digit")
+	}
+	return nil
+}
+
+// groupPathFor returns the path CreateGroup/CreateSubgroup/RenameGroup derive
+// for name under parentPath ("" for root).
+func groupPathFor(parentPath, name string) string {
+	base := strings.ReplaceAll(sanitizeGroupName(name), " ", "-")
+	if parentPath == "" {
+		return base
+	}
+	return parentPath + "/" + base
+}
+
+// ValidateNewGroup reports whether name can be created under parentPath
+// ("" for root): the name must be valid, the parent must exist, and the
+// resulting path must not already be taken.
+func (t *GroupTree) ValidateNewGroup(parentPath, name string) error {
+	if err := ValidateGroupName(name); err != nil {
+		return err
+	}
+	if parentPath != "" {
+		if _, ok := t.Groups[parentPath]; !ok {
+			return fmt.Errorf("parent group %q not found", parentPath)
+		}
+	}
+	if path := groupPathFor(parentPath, name); t.Groups[path] != nil {
+		return fmt.Errorf("%w: %s", ErrGroupExists, path)
+	}
+	return nil
+}
+
+// ValidateRenameGroup reports whether the group at oldPath can be renamed to
+// newName without colliding with a sibling. Renaming to a name that maps to
+// the same path (e.g. a case or spacing change) is allowed.
+func (t *GroupTree) ValidateRenameGroup(oldPath, newName string) error {
+	if _, ok := t.Groups[oldPath]; !ok {
+		return fmt.Errorf("group %q not found", oldPath)
+	}
+	if err := ValidateGroupName(newName); err != nil {
+		return err
+	}
+	if path := groupPathFor(getParentPath(oldPath), newName); path != oldPath && t.Groups[path] != nil {
+		return fmt.Errorf("%w: %s", ErrGroupExists, path)
+	}
+	return nil
+}
+
 // MoveGroupTo reparents a group (and its entire subtree) under destParentPath.
 // An empty destParentPath promotes the group to root level. Returns an error
 // for: unknown source, source == DefaultGroupPath, unknown destParent,
@@ -1287,7 +1374,17 @@ func (t *GroupTree) MoveGroupTo(sourcePath, destParentPath string) error {
 	return nil
 }
 
-// DeleteGroup deletes a group, all its subgroups, and moves all sessions to default
+// DeleteGroupTarget returns where DeleteGroup moves the sessions of the group
+// at path: its parent group, or the default group for a root-level group.
+func DeleteGroupTarget(path string) string {
+	if parent := getParentPath(path); parent != "" {
+		return parent
+	}
+	return DefaultGroupPath
+}
+
+// DeleteGroup deletes a group and all its subgroups, moving every session in
+// the subtree to DeleteGroupTarget(path).
 func (t *GroupTree) DeleteGroup(path string) []*Instance {
 	group, exists := t.Groups[path]
 	if !exists || path == DefaultGroupPath {
@@ -1317,25 +1414,30 @@ func (t *GroupTree) DeleteGroup(path string) []*Instance {
 	// Add sessions from the main group
 	allMovedSessions = append(allMovedSessions, group.Sessions...)
 
-	// Only touch the default group when there are sessions that need a new home.
-	// Otherwise deleting an empty non-default group would spuriously materialize
-	// a "My Sessions" group the user never asked for.
+	// Sessions move up to the parent group; a root group's sessions go to the
+	// default group. Only touch the target when there are sessions that need a
+	// new home, so deleting an empty non-default group never materializes a
+	// "My Sessions" group the user never asked for.
 	if len(allMovedSessions) > 0 {
-		for _, sess := range allMovedSessions {
-			sess.GroupPath = DefaultGroupPath
+		targetPath := DeleteGroupTarget(path)
+		target, exists := t.Groups[targetPath]
+		if !exists {
+			targetPath = DefaultGroupPath
+			target, exists = t.Groups[targetPath]
 		}
-
-		defaultGroup, exists := t.Groups[DefaultGroupPath]
 		if !exists {
-			defaultGroup = &Group{
+			target = &Group{
 				Name:     DefaultGroupName,
 				Path:     DefaultGroupPath,
 				Expanded: true,
 				Sessions: []*Instance{},
 			}
-			t.Groups[DefaultGroupPath] = defaultGroup
+			t.Groups[DefaultGroupPath] = target
+		}
+		for _, sess := range allMovedSessions {
+			sess.SetGroup(targetPath)
 		}
-		defaultGroup.Sessions = append(defaultGroup.Sessions, allMovedSessions...)
+		target.Sessions = append(target.Sessions, allMovedSessions...)
 	}
 
 	// Remove the main group
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -415,6 +416,63 @@ func TestRenameGroup(t *testing.T) {
 	}
 }
 
+func TestRenameGroupDoesNotClobberExisting(t *testing.T) {
+	instances := []*Instance{
+		{ID: "1", Title: "a", GroupPath: "alpha"},
+		{ID: "2", Title: "b", GroupPath: "beta"},
+	}
+	tree := NewGroupTree(instances)
+
+	if err := tree.ValidateRenameGroup("alpha", "beta"); !errors.Is(err, ErrGroupExists) {
+		t.Errorf("ValidateRenameGroup onto sibling = %v, want ErrGroupExists", err)
+	}
+	tree.RenameGroup("alpha", "beta")
+	if tree.Groups["alpha"] == nil || len(tree.Groups["beta"].Sessions) != 1 {
+		t.Error("RenameGroup onto an existing path must leave both groups intact")
+	}
+	if instances[0].GroupPath != "alpha" {
+		t.Errorf("session moved to %q", instances[0].GroupPath)
+	}
+
+	// Same path with different display text is fine.
+	if err := tree.ValidateRenameGroup("alpha", "alpha"); err != nil {
+		t.Errorf("rename to same path: %v", err)
+	}
+	if err := tree.ValidateRenameGroup("missing", "x"); err == nil {
+		t.Error("rename of unknown group should fail")
+	}
+}
+
+func TestValidateNewGroup(t *testing.T) {
+	tree := NewGroupTree([]*Instance{{ID: "1", Title: "a", GroupPath: "work"}})
+
+	if err := tree.ValidateNewGroup("", "Side Project"); err != nil {
+		t.Errorf("valid root group: %v", err)
+	}
+	if err := tree.ValidateNewGroup("work", "api"); err != nil {
+		t.Errorf("valid subgroup: %v", err)
+	}
+	tree.CreateSubgroup("work", "api")
+
+	tests := map[string]struct{ parent, name string }{
+		"empty":          {"", "  "},
+		"slash":          {"", "a/b"},
+		"backslash":      {"", `a\b`},
+		"no letters":     {"", "!!!"},
+		"duplicate root": {"", "work"},
+		"duplicate sub":  {"work", "api"},
+		"missing parent": {"nope", "x"},
+	}
+	for name, tt := range tests {
+		if err := tree.ValidateNewGroup(tt.parent, tt.name); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if err := tree.ValidateNewGroup("", "work"); !errors.Is(err, ErrGroupExists) {
+		t.Errorf("duplicate err = %v, want ErrGroupExists", err)
+	}
+}
+
 func TestRenameGroupWithSubgroups(t *testing.T) {
 	tree := NewGroupTree([]*Instance{})
 
@@ -548,6 +606,28 @@ func TestDeleteGroup(t *testing.T) {
 	if movedSessions[0].GroupPath != DefaultGroupPath {
 		t.Errorf("Session should be moved to %s, got '%s'", DefaultGroupPath, movedSessions[0].GroupPath)
 	}
+	if got := instances[0].Group(); got != DefaultGroupPath {
+		t.Errorf("Group() = %q after delete, want %q", got, DefaultGroupPath)
+	}
+}
+
+func TestInstanceGroupAccessors(t *testing.T) {
+	inst := &Instance{ID: "1", Title: "s", GroupPath: "work"}
+	if got := inst.Group(); got != "work" {
+		t.Fatalf("Group() = %q, want %q", got, "work")
+	}
+
+	tree := NewGroupTree([]*Instance{inst})
+	tree.CreateGroup("play")
+	tree.MoveSessionToGroup(inst, "play")
+	if got := inst.Group(); got != "play" {
+		t.Fatalf("Group() = %q after move, want %q", got, "play")
+	}
+
+	inst.SetGroup("other")
+	if inst.GroupPath != "other" {
+		t.Fatalf("SetGroup must update the persisted GroupPath, got %q", inst.GroupPath)
+	}
 }
 
 func TestDeleteGroupWithSubgroups(t *testing.T) {
@@ -587,6 +667,34 @@ func TestDeleteGroupWithSubgroups(t *testing.T) {
 	}
 }
 
+func TestDeleteSubgroupMovesSessionsToParent(t *testing.T) {
+	tree := NewGroupTree([]*Instance{})
+	tree.CreateGroup("work")
+	tree.CreateSubgroup("work", "backend")
+	tree.CreateSubgroup("work/backend", "db")
+	tree.Groups["work/backend"].Sessions = []*Instance{{ID: "1", GroupPath: "work/backend"}}
+	tree.Groups["work/backend/db"].Sessions = []*Instance{{ID: "2", GroupPath: "work/backend/db"}}
+
+	if got := DeleteGroupTarget("work/backend"); got != "work" {
+		t.Errorf("DeleteGroupTarget = %q, want work", got)
+	}
+	moved := tree.DeleteGroup("work/backend")
+	if len(moved) != 2 {
+		t.Fatalf("moved %d sessions, want 2", len(moved))
+	}
+	for _, sess := range moved {
+		if sess.GroupPath != "work" {
+			t.Errorf("session %s moved to %q, want parent work", sess.ID, sess.GroupPath)
+		}
+	}
+	if len(tree.Groups["work"].Sessions) != 2 {
+		t.Errorf("parent has %d sessions, want 2", len(tree.Groups["work"].Sessions))
+	}
+	if tree.Groups[DefaultGroupPath] != nil {
+		t.Error("deleting a subgroup must not create the default group")
+	}
+}
+
 func TestDeleteDefaultGroup(t *testing.T) {
 	// Create a session with empty GroupPath - this auto-creates the default group
 	instances := []*Instance{
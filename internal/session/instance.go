@@ -192,18 +192,24 @@ type Instance struct {
 	GeminiModel      string                  `json:"gemini_model,omitempty"`     // Active model for this session
 	GeminiAnalytics  *GeminiSessionAnalytics `json:"gemini_analytics,omitempty"` // Per-session analytics
 
+	lastGeminiAnalyticsAt time.Time // Rate-limits session file re-reads (analytics_refresh_seconds)
+
 	// OpenCode CLI integration
 	OpenCodeSessionID  string    `json:"opencode_session_id,omitempty"`
 	OpenCodeDetectedAt time.Time `json:"opencode_detected_at,omitempty"`
 	OpenCodeStartedAt  int64     `json:"-"` // Unix millis when we started OpenCode (for session matching, not persisted)
 	lastOpenCodeScanAt time.Time // Rate-limits expensive `opencode session list` scans
 
+	OpenCodeAnalytics *AgentSessionAnalytics `json:"opencode_analytics,omitempty"` // Per-session analytics
+
 	// Codex CLI integration
 	CodexSessionID   string    `json:"codex_session_id,omitempty"`
 	CodexDetectedAt  time.Time `json:"codex_detected_at,omitempty"`
 	CodexStartedAt   int64     `json:"-"` // Unix millis when we started Codex (for session matching, not persisted)
 	lastCodexScanAt  time.Time // Rate-limits expensive ~/.codex/sessions scans
 	lastCodexProbeAt time.Time // Rate-limits expensive Codex process-file probes
+
+	CodexAnalytics *AgentSessionAnalytics `json:"codex_analytics,omitempty"` // Per-session analytics
 	// pendingCodexRestartWarning is consumed by UI/CLI after Restart() succeeds.
 	// It is intentionally transient and never persisted.
 	pendingCodexRestartWarning string `json:"-"`
@@ -401,6 +407,12 @@ type Instance struct {
 	// Not serialized - resets on load, but that's fine since we'll recheck on first poll
 	lastErrorCheck time.Time
 
+	// seenAlive is set once this process has observed the tmux session
+	// running. Only a live→dead transition seen here is a crash; an instance
+	// loaded after its tmux died (a reboot, `agent-deck api` reloading the
+	// store per request) is just in error. Not serialized.
+	seenAlive bool
+
 	// Tiered polling: skip expensive checks for idle sessions with no activity
 	lastIdleCheck     time.Time // When we last did a full check for an idle session
 	lastKnownActivity int64     // Last window_activity timestamp seen
@@ -718,6 +730,18 @@ func (i *Instance) applyLaunchSettingsFromConfig() {
 	i.tmuxSession.LaunchInUserScope = settings.GetLaunchInUserScope()
 	i.tmuxSession.LaunchAs = settings.GetLaunchAs()
 	i.applyVimModeFromConfig()
+	i.applyCanonicalWorkDir()
+}
+
+// applyCanonicalWorkDir starts Gemini sessions in the symlink-resolved
+// project directory, the same path HashProjectPath hashes, so the chats the
+// agent writes land where GetGeminiSessionsDir looks for them. Other tools
+// keep the path as the user gave it.
+func (i *Instance) applyCanonicalWorkDir() {
+	if i.tmuxSession == nil || i.Tool != "gemini" || i.tmuxSession.WorkDir == "" {
+		return
+	}
+	i.tmuxSession.WorkDir = CanonicalPath(i.tmuxSession.WorkDir)
 }
 
 // applyVimModeFromConfig copies [claude].vim_mode onto the tmux session so the
@@ -1058,7 +1082,7 @@ func (i *Instance) ensureProfileEnv() {
 	if i.tmuxSession == nil {
 		return
 	}
-	if err := i.tmuxSession.SetEnvironment("AGENTDECK_PROFILE", sessionProfileEnvValue()); err != nil {
+	if err := i.SetEnv("AGENTDECK_PROFILE", sessionProfileEnvValue()); err != nil {
 		sessionLog.Warn("set_profile_failed", slog.String("error", err.Error()))
 	}
 }
@@ -1189,12 +1213,8 @@ func (i *Instance) buildClaudeExtraFlags(opts *ClaudeOptions) string {
 
 	// Options-level flags
 	if opts != nil {
-		if opts.SkipPermissions {
-			flags = append(flags, "--dangerously-skip-permissions")
-		} else if opts.AutoMode {
-			flags = append(flags, "--permission-mode auto")
-		} else if opts.AllowSkipPermissions {
-			flags = append(flags, "--allow-dangerously-skip-permissions")
+		for _, arg := range opts.permissionArgs() {
+			flags = append(flags, shellescape.Quote(arg))
 		}
 		if opts.UseChrome {
 			flags = append(flags, "--chrome")
@@ -1202,6 +1222,15 @@ func (i *Instance) buildClaudeExtraFlags(opts *ClaudeOptions) string {
 		if opts.UseTeammateMode {
 			flags = append(flags, "--teammate-mode tmux")
 		}
+		if opts.MCPConfig != "" {
+			flags = append(flags, "--mcp-config "+shellescape.Quote(ExpandPath(opts.MCPConfig)))
+		}
+		if opts.AppendSystemPrompt != "" {
+			flags = append(flags, "--append-system-prompt "+shellescape.Quote(opts.AppendSystemPrompt))
+		}
+		if len(opts.AllowedTools) > 0 {
+			flags = append(flags, "--allowedTools "+shellescape.Quote(strings.Join(opts.AllowedTools, ",")))
+		}
 	}
 
 	// Plugin channels: subscribe the claude session to inbound messages from
@@ -1774,7 +1803,7 @@ func (i *Instance) setOpenCodeSession(sessionID string) {
 	i.OpenCodeStartedAt = 0
 
 	if i.tmuxSession != nil {
-		if err := i.tmuxSession.SetEnvironment("OPENCODE_SESSION_ID", sessionID); err != nil {
+		if err := i.SetEnv("OPENCODE_SESSION_ID", sessionID); err != nil {
 			sessionLog.Warn("opencode_set_env_failed", slog.String("error", err.Error()))
 		}
 	}
@@ -1979,7 +2008,7 @@ func (i *Instance) detectCodexSessionAsync() {
 
 			// Store in tmux environment for restart
 			if i.tmuxSession != nil {
-				if err := i.tmuxSession.SetEnvironment("CODEX_SESSION_ID", sessionID); err != nil {
+				if err := i.SetEnv("CODEX_SESSION_ID", sessionID); err != nil {
 					sessionLog.Warn("codex_set_env_failed", slog.String("error", err.Error()))
 				}
 			}
@@ -2592,6 +2621,20 @@ func codexProbeMissingWarning(missingDep string) string {
 // Fallback: project-aware filesystem scan.
 func (i *Instance) UpdateCodexSession(excludeIDs map[string]bool) {
 	i.updateCodexSession(excludeIDs, false)
+	i.updateCodexAnalytics()
+}
+
+// updateCodexAnalytics refreshes token counts, turns, and model from the
+// session's rollout file.
+func (i *Instance) updateCodexAnalytics() {
+	if !IsCodexCompatible(i.Tool) || i.CodexSessionID == "" {
+		return
+	}
+	if i.CodexAnalytics == nil {
+		i.CodexAnalytics = &AgentSessionAnalytics{}
+	}
+	// Non-blocking update (ignore errors, best effort)
+	_ = updateCodexAnalyticsInHome(i.getCodexHomeDir(), i.CodexSessionID, i.CodexAnalytics)
 }
 
 // updateCodexSession refreshes Codex session ID from env/process-files/disk.
@@ -2605,7 +2648,7 @@ func (i *Instance) updateCodexSession(excludeIDs map[string]bool, forceProbe boo
 
 	// 1. Try to read from tmux environment first (authoritative if set)
 	if i.tmuxSession != nil {
-		if sessionID, err := i.tmuxSession.GetEnvironment("CODEX_SESSION_ID"); err == nil && sessionID != "" {
+		if sessionID, err := i.GetEnv("CODEX_SESSION_ID"); err == nil && sessionID != "" {
 			envSessionID = sessionID
 			if i.CodexSessionID != sessionID {
 				i.CodexSessionID = sessionID
@@ -2628,8 +2671,8 @@ func (i *Instance) updateCodexSession(excludeIDs map[string]bool, forceProbe boo
 			}
 			i.CodexSessionID = sessionID
 			i.CodexDetectedAt = time.Now()
-			if i.tmuxSession != nil && i.tmuxSession.Exists() && (changed || envSessionID == "") {
-				_ = i.tmuxSession.SetEnvironment("CODEX_SESSION_ID", i.CodexSessionID)
+			if i.Exists() && (changed || envSessionID == "") {
+				_ = i.SetEnv("CODEX_SESSION_ID", i.CodexSessionID)
 			}
 			return ""
 		} else if missingDep != "" {
@@ -2676,8 +2719,8 @@ func (i *Instance) updateCodexSession(excludeIDs map[string]bool, forceProbe boo
 
 		// Sync back to tmux environment for future restarts
 		// Skip redundant writes when env already matches: each write is a tmux subprocess.
-		if i.tmuxSession != nil && i.tmuxSession.Exists() && (changed || envSessionID == "") {
-			_ = i.tmuxSession.SetEnvironment("CODEX_SESSION_ID", i.CodexSessionID)
+		if i.Exists() && (changed || envSessionID == "") {
+			_ = i.SetEnv("CODEX_SESSION_ID", i.CodexSessionID)
 		}
 	}
 	return missingProbeDep
@@ -2716,7 +2759,7 @@ func (i *Instance) buildGenericCommand(baseCommand string) string {
 	// Get existing session ID from tmux environment (for restart/resume)
 	existingSessionID := ""
 	if i.tmuxSession != nil {
-		if sid, err := i.tmuxSession.GetEnvironment(toolDef.SessionIDEnv); err == nil && sid != "" {
+		if sid, err := i.GetEnv(toolDef.SessionIDEnv); err == nil && sid != "" {
 			existingSessionID = sid
 		}
 	}
@@ -2771,7 +2814,7 @@ func (i *Instance) GetGenericSessionID() string {
 	if i.tmuxSession == nil {
 		return ""
 	}
-	sessionID, err := i.tmuxSession.GetEnvironment(toolDef.SessionIDEnv)
+	sessionID, err := i.GetEnv(toolDef.SessionIDEnv)
 	if err != nil {
 		return ""
 	}
@@ -3200,6 +3243,7 @@ func (i *Instance) Start() error {
 	if err := i.tmuxSession.Start(command); err != nil {
 		return fmt.Errorf("failed to start tmux session: %w", err)
 	}
+	i.applyConfiguredLayout()
 
 	// CFG-07: emit a single-shot log line documenting which priority level
 	// resolved CLAUDE_CONFIG_DIR for this session. Claude-compatible tools
@@ -3210,7 +3254,7 @@ func (i *Instance) Start() error {
 
 	// Set AGENTDECK_INSTANCE_ID for Claude hooks to identify this session
 	// This enables real-time status updates via Stop/SessionStart hooks
-	if err := i.tmuxSession.SetEnvironment("AGENTDECK_INSTANCE_ID", i.ID); err != nil {
+	if err := i.SetEnv("AGENTDECK_INSTANCE_ID", i.ID); err != nil {
 		sessionLog.Warn("set_instance_id_failed", slog.String("error", err.Error()))
 	}
 
@@ -3225,26 +3269,26 @@ func (i *Instance) Start() error {
 	// "tmux set-environment" calls in the shell command string, which silently failed
 	// inside Docker sandbox containers that have no access to the host tmux socket.
 	if i.ClaudeSessionID != "" {
-		_ = i.tmuxSession.SetEnvironment("CLAUDE_SESSION_ID", i.ClaudeSessionID)
+		_ = i.SetEnv("CLAUDE_SESSION_ID", i.ClaudeSessionID)
 		// Kill any other agentdeck tmux session with the same Claude session ID
 		// to prevent duplicates running `claude --resume` with the same conversation (#596).
 		tmux.KillSessionsWithEnvValue("CLAUDE_SESSION_ID", i.ClaudeSessionID, i.tmuxSession.Name)
 	}
 	if i.GeminiSessionID != "" {
-		_ = i.tmuxSession.SetEnvironment("GEMINI_SESSION_ID", i.GeminiSessionID)
+		_ = i.SetEnv("GEMINI_SESSION_ID", i.GeminiSessionID)
 	}
 	if i.Tool == "gemini" {
 		yoloVal := "false"
 		if i.GeminiYoloMode != nil && *i.GeminiYoloMode {
 			yoloVal = "true"
 		}
-		_ = i.tmuxSession.SetEnvironment("GEMINI_YOLO_MODE", yoloVal)
+		_ = i.SetEnv("GEMINI_YOLO_MODE", yoloVal)
 	}
 	// OpenCode and Codex IDs are detected asynchronously; SyncSessionIDsToTmux() handles
 	// propagation once they are available.
 	// Copilot session ID propagation (if already known from prior session)
 	if i.CopilotSessionID != "" {
-		_ = i.tmuxSession.SetEnvironment("COPILOT_SESSION_ID", i.CopilotSessionID)
+		_ = i.SetEnv("COPILOT_SESSION_ID", i.CopilotSessionID)
 	}
 
 	// Propagate COLORFGBG into the tmux session environment so that any new
@@ -3252,7 +3296,7 @@ func (i *Instance) Start() error {
 	// light/dark hint. The command prefix already exports it for the initial
 	// process, but set-environment covers subsequent shells/windows.
 	if colorfgbg := ThemeColorFGBG(); colorfgbg != "" {
-		_ = i.tmuxSession.SetEnvironment("COLORFGBG", colorfgbg)
+		_ = i.SetEnv("COLORFGBG", colorfgbg)
 	}
 
 	// Capture MCPs that are now loaded (for sync tracking)
@@ -3286,6 +3330,7 @@ func (i *Instance) Start() error {
 		go i.detectCopilotSessionAsync()
 	}
 
+	publishSessionEvent(i.lifecycleEvent(SessionEventCreated))
 	return nil
 }
 
@@ -3441,6 +3486,7 @@ func (i *Instance) StartWithMessage(message string) error {
 	if err := i.tmuxSession.Start(command); err != nil {
 		return fmt.Errorf("failed to start tmux session: %w", err)
 	}
+	i.applyConfiguredLayout()
 
 	// CFG-07: emit a single-shot log line documenting which priority level
 	// resolved CLAUDE_CONFIG_DIR for this session. Claude-compatible tools
@@ -3451,7 +3497,7 @@ func (i *Instance) StartWithMessage(message string) error {
 
 	// Set AGENTDECK_INSTANCE_ID for Claude hooks to identify this session
 	// This enables real-time status updates via Stop/SessionStart hooks
-	if err := i.tmuxSession.SetEnvironment("AGENTDECK_INSTANCE_ID", i.ID); err != nil {
+	if err := i.SetEnv("AGENTDECK_INSTANCE_ID", i.ID); err != nil {
 		sessionLog.Warn("set_instance_id_failed", slog.String("error", err.Error()))
 	}
 
@@ -3464,24 +3510,24 @@ func (i *Instance) StartWithMessage(message string) error {
 	// Propagate tool session IDs into the tmux environment (host-side, works for both
 	// sandbox and non-sandbox sessions).
 	if i.ClaudeSessionID != "" {
-		_ = i.tmuxSession.SetEnvironment("CLAUDE_SESSION_ID", i.ClaudeSessionID)
+		_ = i.SetEnv("CLAUDE_SESSION_ID", i.ClaudeSessionID)
 	}
 	if i.GeminiSessionID != "" {
-		_ = i.tmuxSession.SetEnvironment("GEMINI_SESSION_ID", i.GeminiSessionID)
+		_ = i.SetEnv("GEMINI_SESSION_ID", i.GeminiSessionID)
 	}
 	if i.Tool == "gemini" {
 		yoloVal := "false"
 		if i.GeminiYoloMode != nil && *i.GeminiYoloMode {
 			yoloVal = "true"
 		}
-		_ = i.tmuxSession.SetEnvironment("GEMINI_YOLO_MODE", yoloVal)
+		_ = i.SetEnv("GEMINI_YOLO_MODE", yoloVal)
 	}
 
 	// Propagate COLORFGBG into the tmux session environment so that any new
 	// shell or process spawned inside the session inherits the correct
 	// light/dark hint.
 	if colorfgbg := ThemeColorFGBG(); colorfgbg != "" {
-		_ = i.tmuxSession.SetEnvironment("COLORFGBG", colorfgbg)
+		_ = i.SetEnv("COLORFGBG", colorfgbg)
 	}
 
 	// Capture MCPs that are now loaded (for sync tracking)
@@ -3502,6 +3548,8 @@ func (i *Instance) StartWithMessage(message string) error {
 		go i.detectCodexSessionAsync()
 	}
 
+	publishSessionEvent(i.lifecycleEvent(SessionEventCreated))
+
 	// Send message synchronously (CLI will wait)
 	if message != "" {
 		return i.sendMessageWhenReady(message)
@@ -3766,7 +3814,13 @@ func (i *Instance) UpdateStatus() error {
 			// absent tmux is expected — classify as idle, not error (✕ → ○).
 			i.Status = StatusIdle
 		} else if i.Status != StatusStopped {
+			// A session this process saw running that vanished without a
+			// kill is a crash; finding it already gone on load is not.
 			i.Status = StatusError
+			if i.seenAlive {
+				i.seenAlive = false
+				publishSessionEvent(i.lifecycleEvent(SessionEventCrashed))
+			}
 		}
 		i.lastErrorCheck = time.Now() // Record when we confirmed error/stopped
 		return nil
@@ -3779,6 +3833,7 @@ func (i *Instance) UpdateStatus() error {
 
 	// Session exists - clear error check timestamp
 	i.lastErrorCheck = time.Time{}
+	i.seenAlive = true
 
 	// Tiered polling: skip expensive checks for idle sessions with no new activity
 	if i.Status == StatusIdle {
@@ -4349,8 +4404,8 @@ func (i *Instance) bindCodexSessionFromHook(sessionID, hookEvent string) {
 	i.CodexDetectedAt = time.Now()
 	i.hookSessionID = sessionID
 
-	if i.tmuxSession != nil && i.tmuxSession.Exists() {
-		_ = i.tmuxSession.SetEnvironment("CODEX_SESSION_ID", sessionID)
+	if i.Exists() {
+		_ = i.SetEnv("CODEX_SESSION_ID", sessionID)
 	}
 
 	// Persist the rebind to SQLite. See bindClaudeSessionFromHook for the
@@ -4388,8 +4443,8 @@ func (i *Instance) bindGeminiSessionFromHook(sessionID, hookEvent string) {
 	i.GeminiDetectedAt = time.Now()
 	i.hookSessionID = sessionID
 
-	if i.tmuxSession != nil && i.tmuxSession.Exists() {
-		_ = i.tmuxSession.SetEnvironment("GEMINI_SESSION_ID", sessionID)
+	if i.Exists() {
+		_ = i.SetEnv("GEMINI_SESSION_ID", sessionID)
 	}
 
 	// Persist the rebind to SQLite. See bindClaudeSessionFromHook for
@@ -4447,6 +4502,23 @@ func (i *Instance) SetAutoName(autoName bool) {
 	i.mu.Unlock()
 }
 
+// Group returns the path of the group the session belongs to (GroupPath, the
+// persisted field). Thread-safe.
+func (i *Instance) Group() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.GroupPath
+}
+
+// SetGroup moves the session to the group at path by updating GroupPath, so
+// the next save persists it. It does not touch the GroupTree; use
+// GroupTree.MoveSessionToGroup to keep the tree in step. Thread-safe.
+func (i *Instance) SetGroup(path string) {
+	i.mu.Lock()
+	i.GroupPath = path
+	i.mu.Unlock()
+}
+
 // SetAutoNameDescription records the latest Claude task description for an
 // AutoName session so it can be persisted and shown on reopen. Thread-safe.
 func (i *Instance) SetAutoNameDescription(desc string) {
@@ -4515,7 +4587,7 @@ func (i *Instance) SetGeminiYoloMode(enabled bool) {
 		if enabled {
 			val = "true"
 		}
-		_ = i.tmuxSession.SetEnvironment("GEMINI_YOLO_MODE", val)
+		_ = i.SetEnv("GEMINI_YOLO_MODE", val)
 	}
 }
 
@@ -4535,6 +4607,39 @@ func (i *Instance) UpdateGeminiSession(excludeIDs map[string]bool) {
 // state without stealing a different tab's session from the same project.
 func (i *Instance) UpdateOpenCodeSession() {
 	i.updateOpenCodeSession(false)
+	i.updateOpenCodeAnalytics()
+}
+
+// updateOpenCodeAnalytics refreshes token counts, cost, and model from the
+// session's message files. Like updateOpenCodeSession it parses outside i.mu
+// and only swaps the result in under it.
+//
+// Contract: callers MUST NOT hold i.mu when invoking this function.
+func (i *Instance) updateOpenCodeAnalytics() {
+	if i.Tool != "opencode" {
+		return
+	}
+	i.mu.RLock()
+	sessionID := i.OpenCodeSessionID
+	var analytics AgentSessionAnalytics
+	if i.OpenCodeAnalytics != nil {
+		analytics = *i.OpenCodeAnalytics
+	}
+	i.mu.RUnlock()
+	if sessionID == "" {
+		return
+	}
+
+	// Best effort: keep the previous numbers when the files can't be read.
+	if err := UpdateOpenCodeAnalyticsFromDisk(sessionID, &analytics); err != nil {
+		return
+	}
+
+	i.mu.Lock()
+	if i.OpenCodeSessionID == sessionID {
+		i.OpenCodeAnalytics = &analytics
+	}
+	i.mu.Unlock()
 }
 
 // updateOpenCodeSession self-manages i.mu: state reads/writes happen under the
@@ -4602,7 +4707,7 @@ func (i *Instance) syncGeminiSessionFromTmux() {
 	if i.tmuxSession == nil {
 		return
 	}
-	if sessionID, err := i.tmuxSession.GetEnvironment("GEMINI_SESSION_ID"); err == nil && sessionID != "" {
+	if sessionID, err := i.GetEnv("GEMINI_SESSION_ID"); err == nil && sessionID != "" {
 		if i.GeminiSessionID != sessionID {
 			i.GeminiSessionID = sessionID
 		}
@@ -4610,7 +4715,7 @@ func (i *Instance) syncGeminiSessionFromTmux() {
 	}
 
 	// Detect YOLO Mode from environment (authoritative sync)
-	if yoloEnv, err := i.tmuxSession.GetEnvironment("GEMINI_YOLO_MODE"); err == nil && yoloEnv != "" {
+	if yoloEnv, err := i.GetEnv("GEMINI_YOLO_MODE"); err == nil && yoloEnv != "" {
 		enabled := yoloEnv == "true"
 		i.GeminiYoloMode = &enabled
 	}
@@ -4637,13 +4742,35 @@ func (i *Instance) syncGeminiSessionFromDisk() {
 	i.GeminiDetectedAt = time.Now()
 
 	// Sync back to tmux environment for future restarts
-	if i.tmuxSession != nil && i.tmuxSession.Exists() {
-		_ = i.tmuxSession.SetEnvironment("GEMINI_SESSION_ID", i.GeminiSessionID)
+	if i.Exists() {
+		_ = i.SetEnv("GEMINI_SESSION_ID", i.GeminiSessionID)
+	}
+}
+
+// RefreshGeminiAnalytics re-reads the Gemini session file if the configured
+// refresh interval has passed, for callers outside the status poll (the
+// analytics panel).
+func (i *Instance) RefreshGeminiAnalytics() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.Tool != "gemini" {
+		return
 	}
+	i.updateGeminiAnalytics()
+}
+
+// analyticsRefreshInterval returns the configured analytics refresh interval.
+func analyticsRefreshInterval() time.Duration {
+	if cfg, err := LoadUserConfig(); err == nil && cfg != nil {
+		return cfg.GetAnalyticsRefreshInterval()
+	}
+	return (&PreviewSettings{}).GetAnalyticsRefreshInterval()
 }
 
 // updateGeminiAnalytics refreshes token counts, cost, and model from the session file.
 // Syncs the detected model back to the instance's GeminiModel field.
+// Runs at most once per analytics refresh interval, except right after the
+// session ID changes.
 func (i *Instance) updateGeminiAnalytics() {
 	if i.GeminiSessionID == "" {
 		return
@@ -4651,8 +4778,16 @@ func (i *Instance) updateGeminiAnalytics() {
 	if i.GeminiAnalytics == nil {
 		i.GeminiAnalytics = &GeminiSessionAnalytics{}
 	}
-	// Non-blocking update (ignore errors, best effort)
-	_ = UpdateGeminiAnalyticsFromDisk(i.ProjectPath, i.GeminiSessionID, i.GeminiAnalytics)
+	if i.GeminiAnalytics.SessionID == i.GeminiSessionID && !i.lastGeminiAnalyticsAt.IsZero() &&
+		time.Since(i.lastGeminiAnalyticsAt) < analyticsRefreshInterval() {
+		return
+	}
+	i.lastGeminiAnalyticsAt = time.Now()
+	// Best effort: on error the last good values stay. A file caught mid-write
+	// is retried on the next tick rather than after a full interval.
+	if err := UpdateGeminiAnalyticsFromDisk(i.ProjectPath, i.GeminiSessionID, i.GeminiAnalytics); errors.Is(err, errGeminiSessionUnreadable) {
+		i.lastGeminiAnalyticsAt = time.Time{}
+	}
 
 	// Sync detected model from analytics to instance (if not explicitly set by user)
 	if i.GeminiModel == "" && i.GeminiAnalytics.Model != "" {
@@ -4668,7 +4803,7 @@ func (i *Instance) updateGeminiLatestPrompt() {
 	}
 
 	sessionsDir := GetGeminiSessionsDir(i.ProjectPath)
-	pattern := filepath.Join(sessionsDir, "session-*-"+i.GeminiSessionID[:8]+".json")
+	pattern := geminiSessionFileGlob(sessionsDir, i.GeminiSessionID)
 	filePath, fileMtime := findNewestFile(pattern)
 
 	// Fallback: cross-project search
@@ -4759,9 +4894,7 @@ func (i *Instance) PostStartSync(maxWait time.Duration) {
 			if sid := detectCopilotSessionFromDisk(cwd, startedAfter); sid != "" {
 				i.CopilotSessionID = sid
 				i.CopilotDetectedAt = time.Now()
-				if i.tmuxSession != nil {
-					_ = i.tmuxSession.SetEnvironment("COPILOT_SESSION_ID", sid)
-				}
+				_ = i.SetEnv("COPILOT_SESSION_ID", sid)
 			}
 		}
 	}
@@ -4851,27 +4984,27 @@ func (i *Instance) SyncSessionIDsToTmux() {
 
 	// Sync ClaudeSessionID
 	if i.ClaudeSessionID != "" {
-		_ = i.tmuxSession.SetEnvironment("CLAUDE_SESSION_ID", i.ClaudeSessionID)
+		_ = i.SetEnv("CLAUDE_SESSION_ID", i.ClaudeSessionID)
 	}
 
 	// Sync GeminiSessionID
 	if i.GeminiSessionID != "" {
-		_ = i.tmuxSession.SetEnvironment("GEMINI_SESSION_ID", i.GeminiSessionID)
+		_ = i.SetEnv("GEMINI_SESSION_ID", i.GeminiSessionID)
 	}
 
 	// Sync OpenCodeSessionID
 	if i.OpenCodeSessionID != "" {
-		_ = i.tmuxSession.SetEnvironment("OPENCODE_SESSION_ID", i.OpenCodeSessionID)
+		_ = i.SetEnv("OPENCODE_SESSION_ID", i.OpenCodeSessionID)
 	}
 
 	// Sync CodexSessionID
 	if i.CodexSessionID != "" {
-		_ = i.tmuxSession.SetEnvironment("CODEX_SESSION_ID", i.CodexSessionID)
+		_ = i.SetEnv("CODEX_SESSION_ID", i.CodexSessionID)
 	}
 
 	// Sync CopilotSessionID
 	if i.CopilotSessionID != "" {
-		_ = i.tmuxSession.SetEnvironment("COPILOT_SESSION_ID", i.CopilotSessionID)
+		_ = i.SetEnv("COPILOT_SESSION_ID", i.CopilotSessionID)
 	}
 }
 
@@ -4953,26 +5086,26 @@ func (i *Instance) SyncSessionIDsFromTmux() {
 		return
 	}
 
-	if id, err := i.tmuxSession.GetEnvironment("CLAUDE_SESSION_ID"); err == nil && id != "" {
+	if id, err := i.GetEnv("CLAUDE_SESSION_ID"); err == nil && id != "" {
 		i.ClaudeSessionID = id
 		if i.ClaudeDetectedAt.IsZero() {
 			i.ClaudeDetectedAt = time.Now()
 		}
 	}
 
-	if id, err := i.tmuxSession.GetEnvironment("GEMINI_SESSION_ID"); err == nil && id != "" {
+	if id, err := i.GetEnv("GEMINI_SESSION_ID"); err == nil && id != "" {
 		i.GeminiSessionID = id
 	}
 
-	if id, err := i.tmuxSession.GetEnvironment("OPENCODE_SESSION_ID"); err == nil && id != "" {
+	if id, err := i.GetEnv("OPENCODE_SESSION_ID"); err == nil && id != "" {
 		i.OpenCodeSessionID = id
 	}
 
-	if id, err := i.tmuxSession.GetEnvironment("CODEX_SESSION_ID"); err == nil && id != "" {
+	if id, err := i.GetEnv("CODEX_SESSION_ID"); err == nil && id != "" {
 		i.CodexSessionID = id
 	}
 
-	if id, err := i.tmuxSession.GetEnvironment("COPILOT_SESSION_ID"); err == nil && id != "" {
+	if id, err := i.GetEnv("COPILOT_SESSION_ID"); err == nil && id != "" {
 		i.CopilotSessionID = id
 		if i.CopilotDetectedAt.IsZero() {
 			i.CopilotDetectedAt = time.Now()
@@ -5063,8 +5196,8 @@ func (i *Instance) GetLastResponseBestEffort() (*ResponseOutput, error) {
 			i.ClaudeSessionID = id
 			i.ClaudeDetectedAt = time.Now()
 			// Sync back to tmux so subsequent reads (and restarts) stay current.
-			if i.tmuxSession != nil && i.tmuxSession.Exists() {
-				_ = i.tmuxSession.SetEnvironment("CLAUDE_SESSION_ID", id)
+			if i.Exists() {
+				_ = i.SetEnv("CLAUDE_SESSION_ID", id)
 			}
 			return recovered, nil
 		}
@@ -5587,8 +5720,7 @@ func (i *Instance) getGeminiLastResponse() (*ResponseOutput, error) {
 	sessionsDir := GetGeminiSessionsDir(i.ProjectPath)
 
 	// Find file by session ID (first 8 chars in filename)
-	// Filename format is session-YYYY-MM-DDTHH-MM-<uuid8>.json
-	pattern := filepath.Join(sessionsDir, "session-*-"+i.GeminiSessionID[:8]+".json")
+	pattern := geminiSessionFileGlob(sessionsDir, i.GeminiSessionID)
 	files, _ := filepath.Glob(pattern)
 
 	// Fallback: cross-project search if not found in expected location
@@ -5859,7 +5991,9 @@ func (i *Instance) killInternal(sync bool) error {
 		}
 	}
 	i.Status = StatusStopped
+	ev := i.lifecycleEvent(SessionEventKilled)
 	i.mu.Unlock()
+	publishSessionEvent(ev)
 
 	// Clean up sandbox container (only if name matches our prefix convention).
 	// Runs regardless of tmux kill result to avoid orphaned containers.
@@ -6066,7 +6200,7 @@ func (i *Instance) Restart() error {
 		// Try to get session ID from tmux environment if not already set
 		// (async detection stores it there but Instance might not have been saved)
 		if i.OpenCodeSessionID == "" {
-			if envID, err := i.tmuxSession.GetEnvironment("OPENCODE_SESSION_ID"); err == nil && envID != "" {
+			if envID, err := i.GetEnv("OPENCODE_SESSION_ID"); err == nil && envID != "" {
 				i.OpenCodeSessionID = envID
 				i.OpenCodeDetectedAt = time.Now()
 				sessionLog.Info("restart_opencode_recovered_id", slog.String("session_id", envID))
@@ -6140,7 +6274,7 @@ func (i *Instance) Restart() error {
 	if IsCodexCompatible(i.Tool) && i.tmuxSession != nil && i.tmuxSession.Exists() {
 		// Try to get session ID from tmux environment if not already set
 		if i.CodexSessionID == "" {
-			if envID, err := i.tmuxSession.GetEnvironment("CODEX_SESSION_ID"); err == nil && envID != "" {
+			if envID, err := i.GetEnv("CODEX_SESSION_ID"); err == nil && envID != "" {
 				i.CodexSessionID = envID
 				i.CodexDetectedAt = time.Now()
 				sessionLog.Info("restart_codex_recovered_id", slog.String("session_id", envID))
@@ -6341,6 +6475,7 @@ func (i *Instance) Restart() error {
 	}
 
 	mcpLog.Debug("restart_start_succeeded")
+	i.applyConfiguredLayout()
 
 	// CFG-07: emit the config-resolution log on restart too — triage must not
 	// go dark on the exact scenario most likely to need debugging.
@@ -6350,7 +6485,7 @@ func (i *Instance) Restart() error {
 
 	// Set AGENTDECK_INSTANCE_ID for Claude hooks to identify this session
 	// This enables real-time status updates via Stop/SessionStart hooks
-	if err := i.tmuxSession.SetEnvironment("AGENTDECK_INSTANCE_ID", i.ID); err != nil {
+	if err := i.SetEnv("AGENTDECK_INSTANCE_ID", i.ID); err != nil {
 		sessionLog.Warn("set_instance_id_failed", slog.String("error", err.Error()))
 	}
 
@@ -6419,6 +6554,85 @@ func (i *Instance) RestartFresh() error {
 	return nil
 }
 
+// RestartToolOption adjusts RestartWithTool.
+type RestartToolOption func(*restartToolConfig)
+
+type restartToolConfig struct {
+	command    string
+	commandSet bool
+}
+
+// WithRestartCommand launches command instead of the tool's default one,
+// e.g. a custom command for a shell session.
+func WithRestartCommand(command string) RestartToolOption {
+	return func(c *restartToolConfig) {
+		c.command = command
+		c.commandSet = true
+	}
+}
+
+// RestartWithTool kills the session and relaunches it with another tool in
+// the same path, keeping its title and group. The old tool's session binding
+// is dropped and, when the tool changes, its per-tool options too, so the new
+// tool starts fresh.
+func (i *Instance) RestartWithTool(tool string, opts ...RestartToolOption) error {
+	if err := i.switchTool(tool, opts...); err != nil {
+		return err
+	}
+
+	if i.tmuxSession != nil && i.tmuxSession.Exists() {
+		if killErr := i.tmuxSession.Kill(); killErr != nil {
+			mcpLog.Warn("restart_with_tool_kill_old_session_failed", slog.String("error", killErr.Error()))
+		}
+	}
+
+	i.prepareRestartMCPConfig()
+	i.recreateTmuxSession()
+
+	if err := i.Start(); err != nil {
+		i.Status = StatusError
+		return fmt.Errorf("failed to restart session with %s: %w", i.Tool, err)
+	}
+	return nil
+}
+
+// switchTool is the metadata half of RestartWithTool: it clears the current
+// tool's session binding, then sets Tool and Command. Without
+// WithRestartCommand the command is the tool's own: "" for shell, the
+// configured command for a custom tool, else the tool name.
+func (i *Instance) switchTool(tool string, opts ...RestartToolOption) error {
+	tool = strings.TrimSpace(tool)
+	if tool == "" {
+		return errors.New("restart with tool: tool is required")
+	}
+	cfg := restartToolConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if !cfg.commandSet {
+		switch {
+		case tool == "shell":
+			cfg.command = ""
+		case GetToolDef(tool) != nil:
+			cfg.command = GetToolDef(tool).Command
+		default:
+			cfg.command = tool
+		}
+	}
+
+	i.clearSessionBindingForFreshStart()
+	if tool != i.Tool {
+		i.ToolOptionsJSON = nil
+	}
+	sessionLog.Info("restart_with_tool",
+		slog.String("instance_id", i.ID),
+		slog.String("from", i.Tool),
+		slog.String("to", tool))
+	i.Tool = tool
+	i.Command = cfg.command
+	return nil
+}
+
 // buildClaudeResumeCommand builds the claude resume command with proper config options
 // Respects: CLAUDE_CONFIG_DIR, dangerous_mode, and [shell].env_files + init_script
 // CLAUDE_SESSION_ID is set via host-side SetEnvironment (called by SyncSessionIDsToTmux after restart)
@@ -7274,6 +7488,52 @@ func (i *Instance) GetTmuxSession() *tmux.Session {
 	return i.tmuxSession
 }
 
+// ErrNoTmuxSession is returned by GetEnv and SetEnv for an instance that has
+// no tmux session object yet.
+var ErrNoTmuxSession = errors.New("tmux session not initialized")
+
+// GetEnv returns key from the session's tmux environment, where tool session
+// IDs and per-session modes (GEMINI_YOLO_MODE, ...) survive restarts. Reads
+// are served from a short-lived cache.
+func (i *Instance) GetEnv(key string) (string, error) {
+	if i.tmuxSession == nil {
+		return "", ErrNoTmuxSession
+	}
+	return i.tmuxSession.GetEnvironment(key)
+}
+
+// SetEnv sets key in the session's tmux environment. It fails when the tmux
+// session is not running; callers that only sync opportunistically check
+// Exists first.
+func (i *Instance) SetEnv(key, value string) error {
+	if i.tmuxSession == nil {
+		return ErrNoTmuxSession
+	}
+	return i.tmuxSession.SetEnvironment(key, value)
+}
+
+// AttachCommand returns the tmux command that connects the current terminal
+// to this session: `switch-client` when already inside a client of the
+// session's tmux server, `attach-session` otherwise. See
+// tmux.Session.AttachCommand.
+func (i *Instance) AttachCommand() (*exec.Cmd, error) {
+	if !i.Exists() {
+		return nil, fmt.Errorf("session %q is not running", i.Title)
+	}
+	return i.tmuxSession.AttachCommand(), nil
+}
+
+// Attach runs AttachCommand wired to the process's stdio and blocks until the
+// user detaches (or, for switch-client, until the client has switched).
+func (i *Instance) Attach() error {
+	cmd, err := i.AttachCommand()
+	if err != nil {
+		return err
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
 // Substate returns the additive Honest-Status-v2 refinement for this session
 // (see Substate). It reads the live tmux pane and classifies it; SubstateNone
 // when there is no tmux session, the pane is dead, or the tool has no substate
@@ -7435,7 +7695,7 @@ func (i *Instance) GetSessionIDFromTmux() string {
 	if i.tmuxSession == nil {
 		return ""
 	}
-	sessionID, err := i.tmuxSession.GetEnvironment("CLAUDE_SESSION_ID")
+	sessionID, err := i.GetEnv("CLAUDE_SESSION_ID")
 	if err != nil {
 		return ""
 	}
@@ -7596,7 +7856,7 @@ func geminiSessionHasConversationData(sessionID, projectPath string) bool {
 	}
 
 	sessionsDir := GetGeminiSessionsDir(projectPath)
-	pattern := filepath.Join(sessionsDir, "session-*-"+sessionID[:8]+".json")
+	pattern := geminiSessionFileGlob(sessionsDir, sessionID)
 	filePath, _ := findNewestFile(pattern)
 	if filePath == "" {
 		filePath = findGeminiSessionInAllProjects(sessionID)
@@ -7737,8 +7997,8 @@ func (i *Instance) bindClaudeSessionFromHook(sessionID, hookSource, hookEvent, a
 	i.ClaudeDetectedAt = time.Now()
 	i.hookSessionID = sessionID
 
-	if i.tmuxSession != nil && i.tmuxSession.Exists() {
-		_ = i.tmuxSession.SetEnvironment("CLAUDE_SESSION_ID", sessionID)
+	if i.Exists() {
+		_ = i.SetEnv("CLAUDE_SESSION_ID", sessionID)
 	}
 
 	// Persist the rebind to SQLite. The PERSIST-12 contract above assumed
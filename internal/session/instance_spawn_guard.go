@@ -103,7 +103,9 @@ func (a SpawnAttempt) Run() error {
 	return nil
 }
 
-// nowFn is a test seam so tests can pin time without sleeping.
+// nowFn is the package clock: a test seam so tests can pin time without
+// sleeping. The spawn guard, the Gemini model and credential caches, and the
+// analytics "now" all read it; production keeps time.Now.
 var nowFn = time.Now
 
 // spawnedSince reports whether the per-instance spawn stamp's mtime is
@@ -2,6 +2,7 @@ package session
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -693,6 +694,43 @@ func TestInstance_GetSessionIDFromTmux(t *testing.T) {
 	}
 }
 
+func TestInstance_EnvWithoutTmuxSession(t *testing.T) {
+	inst := &Instance{Title: "no-tmux"}
+	if _, err := inst.GetEnv("GEMINI_YOLO_MODE"); !errors.Is(err, ErrNoTmuxSession) {
+		t.Errorf("GetEnv err = %v, want ErrNoTmuxSession", err)
+	}
+	if err := inst.SetEnv("GEMINI_YOLO_MODE", "true"); !errors.Is(err, ErrNoTmuxSession) {
+		t.Errorf("SetEnv err = %v, want ErrNoTmuxSession", err)
+	}
+}
+
+func TestInstance_SetEnvGetEnv(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	inst := NewInstanceWithTool("tmux-env-api-test", "/tmp", "shell")
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Failed to start instance: %v", err)
+	}
+	defer func() { _ = inst.Kill() }()
+
+	if err := inst.SetEnv("AGENTDECK_TEST_VAR", "one"); err != nil {
+		t.Fatalf("SetEnv: %v", err)
+	}
+	if v, err := inst.GetEnv("AGENTDECK_TEST_VAR"); err != nil || v != "one" {
+		t.Fatalf("GetEnv = %q, %v; want one", v, err)
+	}
+	// A write invalidates the cached read.
+	if err := inst.SetEnv("AGENTDECK_TEST_VAR", "two"); err != nil {
+		t.Fatalf("SetEnv: %v", err)
+	}
+	if v, _ := inst.GetEnv("AGENTDECK_TEST_VAR"); v != "two" {
+		t.Errorf("GetEnv after overwrite = %q, want two", v)
+	}
+	if _, err := inst.GetEnv("AGENTDECK_TEST_UNSET"); err == nil {
+		t.Error("GetEnv of an unset variable must fail")
+	}
+}
+
 func TestInstance_UpdateClaudeSession_TmuxFirst(t *testing.T) {
 	skipIfNoTmuxServer(t)
 	skipIfNoClaudeBinary(t)
@@ -2379,6 +2417,42 @@ func TestInstance_CanRestartFresh(t *testing.T) {
 	}
 }
 
+func TestInstance_SwitchTool(t *testing.T) {
+	inst := &Instance{
+		Title:           "api",
+		GroupPath:       "work",
+		ProjectPath:     "/tmp/api",
+		Tool:            "claude",
+		Command:         "claude",
+		ClaudeSessionID: "claude-session-1",
+		ToolOptionsJSON: []byte(`{"tool":"claude"}`),
+	}
+
+	if err := inst.switchTool("  "); err == nil {
+		t.Fatal("switchTool with a blank tool should fail")
+	}
+
+	if err := inst.switchTool("gemini"); err != nil {
+		t.Fatalf("switchTool(gemini) = %v", err)
+	}
+	if inst.Tool != "gemini" || inst.Command != "gemini" {
+		t.Errorf("tool/command = %q/%q, want gemini/gemini", inst.Tool, inst.Command)
+	}
+	if inst.ClaudeSessionID != "" || inst.ToolOptionsJSON != nil {
+		t.Errorf("old claude binding/options kept: id=%q opts=%s", inst.ClaudeSessionID, inst.ToolOptionsJSON)
+	}
+	if inst.Title != "api" || inst.GroupPath != "work" || inst.ProjectPath != "/tmp/api" {
+		t.Errorf("title/group/path changed: %q %q %q", inst.Title, inst.GroupPath, inst.ProjectPath)
+	}
+
+	if err := inst.switchTool("shell"); err != nil || inst.Command != "" {
+		t.Errorf("switchTool(shell) = %v, command %q; want a bare shell", err, inst.Command)
+	}
+	if err := inst.switchTool("shell", WithRestartCommand("htop")); err != nil || inst.Command != "htop" {
+		t.Errorf("switchTool(shell, htop) = %v, command %q", err, inst.Command)
+	}
+}
+
 func TestInstance_ClearSessionBindingForFreshStart(t *testing.T) {
 	inst := &Instance{
 		Tool:               "opencode",
@@ -4589,3 +4663,13 @@ func TestInstance_RefreshLiveSessionIDs_NoOpForNonAgenticTool(t *testing.T) {
 		t.Errorf("GeminiSessionID mutated for non-agentic tool: got %q", inst.GeminiSessionID)
 	}
 }
+
+func TestInstanceAttachCommand_NotRunning(t *testing.T) {
+	inst := &Instance{Title: "idle"}
+	if _, err := inst.AttachCommand(); err == nil {
+		t.Fatal("AttachCommand should fail without a live tmux session")
+	}
+	if err := inst.Attach(); err == nil {
+		t.Fatal("Attach should fail without a live tmux session")
+	}
+}
@@ -0,0 +1,64 @@
+package session
+
+import "strings"
+
+// Placeholders for values that are only minted at creation time, so a
+// preview stays stable while the user edits the form.
+const (
+	LaunchPreviewInstanceID = "<instance-id>"
+	LaunchPreviewSessionID  = "<session-id>"
+)
+
+// NewLaunchPreviewInstance returns a throwaway instance for
+// LaunchCommandPreview. Unlike NewInstanceWithTool it has no tmux session,
+// logs nothing, and uses LaunchPreviewInstanceID as its ID.
+func NewLaunchPreviewInstance(title, projectPath, tool, command string) *Instance {
+	return &Instance{
+		ID:          LaunchPreviewInstanceID,
+		Title:       title,
+		ProjectPath: projectPath,
+		Tool:        tool,
+		Command:     command,
+		Status:      StatusIdle,
+	}
+}
+
+// LaunchCommandPreview returns the command line Start would send to tmux for
+// a fresh session with the instance's current settings, without starting
+// anything. It is meant for not-yet-started instances (the new session
+// dialog); resume of a bound conversation, fork start commands and sandbox
+// wrapping are not reflected. Keep the dispatch in sync with Start.
+func (i *Instance) LaunchCommandPreview() string {
+	boundSessionID := i.ClaudeSessionID
+	defer func() { i.ClaudeSessionID = boundSessionID }()
+
+	var command string
+	switch {
+	case IsClaudeCompatible(i.Tool):
+		command = i.buildClaudeCommand(i.Command)
+		if i.ClaudeSessionID != "" && i.ClaudeSessionID != boundSessionID {
+			command = strings.ReplaceAll(command, i.ClaudeSessionID, LaunchPreviewSessionID)
+		}
+	case i.Tool == "gemini":
+		command = i.buildGeminiCommand(i.Command)
+	case i.Tool == "copilot":
+		command = buildCopilotCommand(i)
+	case i.Tool == "opencode":
+		command = i.buildOpenCodeCommand(i.Command)
+	case IsCodexCompatible(i.Tool):
+		command = i.buildCodexCommand(i.Command)
+	case i.Tool == "pi":
+		command = i.buildPiCommand(i.Command)
+	case i.Tool == "cursor":
+		command = i.buildCursorCommand(i.Command, false)
+	case i.Tool == "hermes":
+		command = i.buildHermesCommand(i.Command)
+	default:
+		if toolDef := GetToolDef(i.Tool); toolDef != nil {
+			command = i.buildGenericCommand(i.Command)
+		} else {
+			command = i.Command
+		}
+	}
+	return command
+}
@@ -0,0 +1,176 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// Layout split directions, named after the tmux split-window flags.
+const (
+	// LayoutSplitVertical stacks the new pane below the agent (tmux -v).
+	LayoutSplitVertical = "vertical"
+	// LayoutSplitHorizontal puts the new pane right of the agent (tmux -h).
+	LayoutSplitHorizontal = "horizontal"
+)
+
+// tmuxLayoutNames are the preset layouts accepted by tmux select-layout.
+var tmuxLayoutNames = []string{"even-horizontal", "even-vertical", "main-horizontal", "main-vertical", "tiled"}
+
+// LayoutSpec describes an extra pane created next to the agent right after a
+// session starts. The zero value means the default single-pane session.
+//
+// config.toml (custom tools):
+//
+//	[tools.mytool.layout]
+//	split = "vertical"
+//	command = "npm run dev"
+//	size = 30
+//
+// .agentdeck.json:
+//
+//	{"layout": {"split": "vertical", "command": "npm run dev", "size": 30}}
+type LayoutSpec struct {
+	// Split is "vertical" (new pane below) or "horizontal" (new pane right).
+	Split string `toml:"split,omitempty" json:"split,omitempty"`
+
+	// Command runs in the new pane. Empty starts the default shell.
+	Command string `toml:"command,omitempty" json:"command,omitempty"`
+
+	// Size is the new pane's share of the window in percent (1-90).
+	// 0 lets tmux split evenly.
+	Size int `toml:"size,omitempty" json:"size,omitempty"`
+
+	// Layout optionally applies a tmux preset (select-layout) afterwards,
+	// e.g. "main-horizontal".
+	Layout string `toml:"layout,omitempty" json:"layout,omitempty"`
+}
+
+// IsZero reports whether the spec requests no layout.
+func (s LayoutSpec) IsZero() bool {
+	return s == LayoutSpec{}
+}
+
+// Validate checks the spec before any tmux call so a bad config can never
+// leave a half-built session behind.
+func (s LayoutSpec) Validate() error {
+	switch s.Split {
+	case LayoutSplitVertical, LayoutSplitHorizontal:
+	case "":
+		return fmt.Errorf("layout: split is required (%q or %q)", LayoutSplitVertical, LayoutSplitHorizontal)
+	default:
+		return fmt.Errorf("layout: unknown split %q (want %q or %q)", s.Split, LayoutSplitVertical, LayoutSplitHorizontal)
+	}
+	if s.Size < 0 || s.Size > 90 {
+		return fmt.Errorf("layout: size %d out of range (1-90 percent, 0 for even)", s.Size)
+	}
+	if s.Layout != "" && !slices.Contains(tmuxLayoutNames, s.Layout) {
+		return fmt.Errorf("layout: unknown tmux layout %q (want one of %s)", s.Layout, strings.Join(tmuxLayoutNames, ", "))
+	}
+	return nil
+}
+
+// splitArgs builds the split-window argv. -d keeps the agent pane active so
+// status detection and attach keep targeting it; -P prints the new pane ID so
+// a failed follow-up step can remove it again.
+func (s LayoutSpec) splitArgs(target, workDir string) []string {
+	args := []string{"split-window", "-d", "-P", "-F", "#{pane_id}", "-t", target}
+	if s.Split == LayoutSplitHorizontal {
+		args = append(args, "-h")
+	} else {
+		args = append(args, "-v")
+	}
+	if s.Size > 0 {
+		args = append(args, "-l", fmt.Sprintf("%d%%", s.Size))
+	}
+	if workDir != "" {
+		args = append(args, "-c", workDir)
+	}
+	if s.Command != "" {
+		args = append(args, s.Command)
+	}
+	return args
+}
+
+// runTmuxLayoutCmd runs one tmux command against the session's server.
+// Overridable in tests.
+var runTmuxLayoutCmd = func(socketName string, args ...string) ([]byte, error) {
+	out, err := tmux.Exec(socketName, args...).CombinedOutput()
+	if err != nil {
+		return out, fmt.Errorf("tmux %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return out, nil
+}
+
+// ApplyLayout splits the running session's window according to spec. The
+// spec is validated first; if a later step fails, the new pane is killed so
+// the session is back to its single-pane state.
+func (i *Instance) ApplyLayout(spec LayoutSpec) error {
+	if spec.IsZero() {
+		return nil
+	}
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	if i.tmuxSession == nil {
+		return fmt.Errorf("tmux session not initialized")
+	}
+	socket, target := i.tmuxSession.SocketName, i.tmuxSession.Name
+
+	out, err := runTmuxLayoutCmd(socket, spec.splitArgs(target, i.ProjectPath)...)
+	if err != nil {
+		return err
+	}
+	paneID := strings.TrimSpace(string(out))
+
+	if spec.Layout != "" {
+		if _, err := runTmuxLayoutCmd(socket, "select-layout", "-t", target, spec.Layout); err != nil {
+			if paneID != "" {
+				_, _ = runTmuxLayoutCmd(socket, "kill-pane", "-t", paneID)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// configuredLayout returns the layout for this session: the project's
+// .agentdeck.json wins over the custom tool definition. An untrusted
+// project file still splits the window but does not get to run its command.
+func (i *Instance) configuredLayout() LayoutSpec {
+	if cfg, err := LoadProjectConfig(i.ProjectPath); err == nil && cfg != nil && cfg.Layout != nil {
+		spec := *cfg.Layout
+		if !cfg.Trusted && spec.Command != "" {
+			sessionLog.Warn("project_layout_command_untrusted",
+				slog.String("session", i.Title),
+				slog.String("project", i.ProjectPath))
+			spec.Command = ""
+		}
+		return spec
+	}
+	if def := GetToolDef(i.Tool); def != nil && def.Layout != nil {
+		return *def.Layout
+	}
+	return LayoutSpec{}
+}
+
+// applyConfiguredLayout runs after a successful tmux start. Layout problems
+// are logged, never fatal: the agent pane is already up. Sandboxed sessions
+// are skipped because the extra pane would run on the host.
+func (i *Instance) applyConfiguredLayout() {
+	if i.IsSandboxed() {
+		return
+	}
+	spec := i.configuredLayout()
+	if spec.IsZero() {
+		return
+	}
+	if err := i.ApplyLayout(spec); err != nil {
+		sessionLog.Warn("layout_apply_failed",
+			slog.String("session", i.Title),
+			slog.String("error", err.Error()))
+	}
+}
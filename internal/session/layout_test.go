@@ -0,0 +1,143 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// stubTmuxLayoutCmd records layout tmux calls; failOn makes the command with
+// that subcommand fail.
+func stubTmuxLayoutCmd(t *testing.T, failOn string) *[][]string {
+	t.Helper()
+	var calls [][]string
+	orig := runTmuxLayoutCmd
+	runTmuxLayoutCmd = func(socketName string, args ...string) ([]byte, error) {
+		calls = append(calls, append([]string{socketName}, args...))
+		if args[0] == failOn {
+			return nil, errors.New("boom")
+		}
+		if args[0] == "split-window" {
+			return []byte("%7\n"), nil
+		}
+		return nil, nil
+	}
+	t.Cleanup(func() { runTmuxLayoutCmd = orig })
+	return &calls
+}
+
+func TestLayoutSpec_Validate(t *testing.T) {
+	cases := []struct {
+		spec    LayoutSpec
+		wantErr string
+	}{
+		{LayoutSpec{Split: "vertical"}, ""},
+		{LayoutSpec{Split: "horizontal", Size: 40, Layout: "main-vertical"}, ""},
+		{LayoutSpec{Command: "htop"}, "split is required"},
+		{LayoutSpec{Split: "diagonal"}, "unknown split"},
+		{LayoutSpec{Split: "vertical", Size: 95}, "out of range"},
+		{LayoutSpec{Split: "vertical", Size: -1}, "out of range"},
+		{LayoutSpec{Split: "vertical", Layout: "spiral"}, "unknown tmux layout"},
+	}
+	for _, tc := range cases {
+		err := tc.spec.Validate()
+		if tc.wantErr == "" {
+			if err != nil {
+				t.Errorf("%+v: unexpected error %v", tc.spec, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("%+v: err = %v, want containing %q", tc.spec, err, tc.wantErr)
+		}
+	}
+}
+
+func TestInstanceApplyLayout_SplitAndSelect(t *testing.T) {
+	calls := stubTmuxLayoutCmd(t, "")
+	inst := &Instance{ProjectPath: "/work/app", tmuxSession: &tmux.Session{Name: "agentdeck_app_1", SocketName: "agent-deck"}}
+
+	err := inst.ApplyLayout(LayoutSpec{Split: "vertical", Command: "npm run dev", Size: 30, Layout: "main-horizontal"})
+	if err != nil {
+		t.Fatalf("ApplyLayout: %v", err)
+	}
+	want := [][]string{
+		{"agent-deck", "split-window", "-d", "-P", "-F", "#{pane_id}", "-t", "agentdeck_app_1", "-v", "-l", "30%", "-c", "/work/app", "npm run dev"},
+		{"agent-deck", "select-layout", "-t", "agentdeck_app_1", "main-horizontal"},
+	}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Fatalf("tmux calls =\n%v\nwant\n%v", *calls, want)
+	}
+}
+
+func TestInstanceApplyLayout_InvalidSpecRunsNothing(t *testing.T) {
+	calls := stubTmuxLayoutCmd(t, "")
+	inst := &Instance{tmuxSession: &tmux.Session{Name: "s"}}
+
+	if err := inst.ApplyLayout(LayoutSpec{Split: "sideways"}); err == nil {
+		t.Fatal("expected validation error")
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("invalid spec must not touch tmux, got %v", *calls)
+	}
+	if err := inst.ApplyLayout(LayoutSpec{}); err != nil || len(*calls) != 0 {
+		t.Fatalf("zero spec should be a no-op: err=%v calls=%v", err, *calls)
+	}
+}
+
+func TestInstanceApplyLayout_RollsBackPaneOnFailure(t *testing.T) {
+	calls := stubTmuxLayoutCmd(t, "select-layout")
+	inst := &Instance{tmuxSession: &tmux.Session{Name: "s"}}
+
+	if err := inst.ApplyLayout(LayoutSpec{Split: "horizontal", Layout: "tiled"}); err == nil {
+		t.Fatal("expected select-layout failure")
+	}
+	last := (*calls)[len(*calls)-1]
+	if want := []string{"", "kill-pane", "-t", "%7"}; !reflect.DeepEqual(last, want) {
+		t.Fatalf("last call = %v, want %v", last, want)
+	}
+}
+
+func TestLoadProjectConfig_RejectsInvalidLayout(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ProjectConfigFileName), []byte(`{"layout":{"split":"up"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadProjectConfig(dir); err == nil {
+		t.Fatal("expected invalid layout to be rejected")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ProjectConfigFileName), []byte(`{"layout":{"split":"vertical","command":"make watch"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadProjectConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig: %v", err)
+	}
+	if cfg.Layout == nil || cfg.Layout.Command != "make watch" {
+		t.Fatalf("Layout = %+v", cfg.Layout)
+	}
+}
+
+func TestConfiguredLayout_UntrustedProjectRunsNoCommand(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ProjectConfigFileName), []byte(`{"layout":{"split":"vertical","command":"make watch"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	inst := &Instance{Title: "l", ProjectPath: dir}
+
+	if got := inst.configuredLayout(); got != (LayoutSpec{Split: "vertical"}) {
+		t.Fatalf("untrusted layout = %+v, want the split without its command", got)
+	}
+	if err := TrustProjectConfig(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := inst.configuredLayout(); got.Command != "make watch" {
+		t.Fatalf("trusted layout = %+v, want its command", got)
+	}
+}
@@ -0,0 +1,162 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SessionEventType names a session lifecycle transition.
+type SessionEventType string
+
+const (
+	SessionEventCreated SessionEventType = "created"
+	SessionEventKilled  SessionEventType = "killed"
+	SessionEventCrashed SessionEventType = "crashed"
+)
+
+// SessionEventTypes lists every event type, in lifecycle order.
+var SessionEventTypes = []SessionEventType{SessionEventCreated, SessionEventKilled, SessionEventCrashed}
+
+// SessionEvent is published on the in-process event bus whenever an
+// instance is started, killed, or found dead without being stopped.
+type SessionEvent struct {
+	Type       SessionEventType `json:"type"`
+	InstanceID string           `json:"instance_id"`
+	Title      string           `json:"title"`
+	Tool       string           `json:"tool"`
+	Group      string           `json:"group,omitempty"`
+	Status     string           `json:"status"`
+	Time       time.Time        `json:"time"`
+}
+
+// sessionEventBuffer is each subscriber's channel capacity. A subscriber
+// that falls further behind than this loses events rather than blocking the
+// publisher (the TUI status loop or a CLI command).
+const sessionEventBuffer = 64
+
+var sessionEventBus = struct {
+	mu   sync.Mutex
+	subs map[chan SessionEvent]struct{}
+}{subs: make(map[chan SessionEvent]struct{})}
+
+// Subscribe returns a channel that receives every subsequent SessionEvent.
+// Call Unsubscribe when done so the channel is released.
+func Subscribe() <-chan SessionEvent {
+	ch := make(chan SessionEvent, sessionEventBuffer)
+	sessionEventBus.mu.Lock()
+	sessionEventBus.subs[ch] = struct{}{}
+	sessionEventBus.mu.Unlock()
+	return ch
+}
+
+// Unsubscribe removes a subscription and closes its channel.
+func Unsubscribe(sub <-chan SessionEvent) {
+	sessionEventBus.mu.Lock()
+	defer sessionEventBus.mu.Unlock()
+	for ch := range sessionEventBus.subs {
+		if ch == sub {
+			delete(sessionEventBus.subs, ch)
+			close(ch)
+			return
+		}
+	}
+}
+
+// publishSessionEvent fans ev out to all subscribers without blocking: a
+// subscriber whose buffer is full misses the event.
+func publishSessionEvent(ev SessionEvent) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	sessionEventBus.mu.Lock()
+	defer sessionEventBus.mu.Unlock()
+	for ch := range sessionEventBus.subs {
+		select {
+		case ch <- ev:
+		default:
+			sessionLog.Debug("session_event_dropped",
+				slog.String("type", string(ev.Type)),
+				slog.String("instance_id", ev.InstanceID))
+		}
+	}
+}
+
+// lifecycleEvent builds an event from the instance's current fields. It does
+// not take i.mu, so it is safe to call from paths that already hold it.
+func (i *Instance) lifecycleEvent(t SessionEventType) SessionEvent {
+	return SessionEvent{
+		Type:       t,
+		InstanceID: i.ID,
+		Title:      i.Title,
+		Tool:       i.Tool,
+		Group:      i.GroupPath,
+		Status:     string(i.Status),
+	}
+}
+
+// webhookTimeout bounds each webhook POST.
+const webhookTimeout = 5 * time.Second
+
+// StartSessionEventWebhook posts matching session events as JSON to the
+// configured webhook URL until ctx is cancelled. It is a no-op when no URL
+// is set. Delivery is best effort: failures are logged and not retried, and
+// a slow endpoint only costs this goroutine its buffered backlog.
+func StartSessionEventWebhook(ctx context.Context, settings EventsSettings) {
+	if settings.WebhookURL == "" {
+		return
+	}
+	sub := Subscribe()
+	client := &http.Client{Timeout: webhookTimeout}
+	go func() {
+		defer Unsubscribe(sub)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-sub:
+				if !settings.WantsEvent(ev.Type) {
+					continue
+				}
+				if err := postSessionEvent(ctx, client, settings.WebhookURL, ev); err != nil {
+					sessionLog.Warn("session_event_webhook_failed",
+						slog.String("type", string(ev.Type)),
+						slog.String("instance_id", ev.InstanceID),
+						slog.String("error", err.Error()))
+				}
+			}
+		}
+	}()
+}
+
+func postSessionEvent(ctx context.Context, client *http.Client, url string, ev SessionEvent) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// isSessionEventType reports whether name is a known event type.
+func isSessionEventType(name string) bool {
+	return slices.Contains(SessionEventTypes, SessionEventType(name))
+}
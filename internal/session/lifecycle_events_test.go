@@ -0,0 +1,147 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSessionEventBus_FanOutAndNonBlocking(t *testing.T) {
+	a := Subscribe()
+	defer Unsubscribe(a)
+	b := Subscribe()
+	defer Unsubscribe(b)
+
+	inst := NewInstanceWithGroupAndTool("bus", "/tmp", "work", "claude")
+	inst.Status = StatusRunning
+	publishSessionEvent(inst.lifecycleEvent(SessionEventCreated))
+
+	for name, ch := range map[string]<-chan SessionEvent{"a": a, "b": b} {
+		select {
+		case ev := <-ch:
+			if ev.Type != SessionEventCreated || ev.Title != "bus" || ev.Tool != "claude" ||
+				ev.Group != "work" || ev.Status != "running" || ev.Time.IsZero() {
+				t.Errorf("%s: event = %+v", name, ev)
+			}
+		default:
+			t.Errorf("%s: no event delivered", name)
+		}
+	}
+
+	// A subscriber that never reads must not block the publisher.
+	done := make(chan struct{})
+	go func() {
+		for range sessionEventBuffer + 10 {
+			publishSessionEvent(SessionEvent{Type: SessionEventKilled})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishSessionEvent blocked on a full subscriber")
+	}
+	if len(a) != sessionEventBuffer {
+		t.Errorf("buffered = %d, want %d", len(a), sessionEventBuffer)
+	}
+}
+
+func TestUnsubscribe_ClosesChannel(t *testing.T) {
+	ch := Subscribe()
+	Unsubscribe(ch)
+	if _, ok := <-ch; ok {
+		t.Error("channel should be closed after Unsubscribe")
+	}
+	Unsubscribe(ch) // second call is a no-op
+}
+
+func TestStartSessionEventWebhook_FiltersAndPosts(t *testing.T) {
+	got := make(chan SessionEvent, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		var ev SessionEvent
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		got <- ev
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartSessionEventWebhook(ctx, EventsSettings{WebhookURL: srv.URL, WebhookEvents: []string{"crashed"}})
+
+	publishSessionEvent(SessionEvent{Type: SessionEventCreated, Title: "skipped"})
+	publishSessionEvent(SessionEvent{Type: SessionEventCrashed, Title: "posted", Tool: "gemini"})
+
+	select {
+	case ev := <-got:
+		if ev.Type != SessionEventCrashed || ev.Title != "posted" || ev.Tool != "gemini" {
+			t.Errorf("posted event = %+v", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not called")
+	}
+	select {
+	case ev := <-got:
+		t.Errorf("unexpected second post: %+v", ev)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEventsSettings_WantsEvent(t *testing.T) {
+	if !(EventsSettings{}).WantsEvent(SessionEventKilled) {
+		t.Error("empty filter should accept every event")
+	}
+	s := EventsSettings{WebhookEvents: []string{"created"}}
+	if !s.WantsEvent(SessionEventCreated) || s.WantsEvent(SessionEventKilled) {
+		t.Error("filter should only accept listed events")
+	}
+}
+
+func TestUpdateStatus_CrashOnlyAfterSeenAlive(t *testing.T) {
+	ch := Subscribe()
+	defer Unsubscribe(ch)
+
+	inst := NewInstanceWithTool("ghost", "/tmp", "shell")
+	inst.addedThisProcess = false // as if loaded from the store
+	inst.CreatedAt = time.Now().Add(-time.Hour)
+	inst.Status = StatusRunning
+	crashes := func() int {
+		n := 0
+		for {
+			select {
+			case ev := <-ch:
+				if ev.Type == SessionEventCrashed && ev.Title == "ghost" {
+					n++
+				}
+			default:
+				return n
+			}
+		}
+	}
+
+	// Its tmux session was already gone when loaded: error, not a crash.
+	_ = inst.UpdateStatus()
+	if inst.Status != StatusError {
+		t.Fatalf("Status = %q, want error", inst.Status)
+	}
+	if n := crashes(); n != 0 {
+		t.Fatalf("got %d crash events for a session never seen alive", n)
+	}
+
+	// Seen running by this process, then gone: one crash event.
+	inst.Status = StatusRunning
+	inst.seenAlive = true
+	_ = inst.UpdateStatus()
+	inst.lastErrorCheck = time.Time{}
+	_ = inst.UpdateStatus()
+	if n := crashes(); n != 1 {
+		t.Fatalf("got %d crash events after a live session died, want 1", n)
+	}
+}
@@ -37,6 +37,9 @@ func RunMaintenance(ctx context.Context) MaintenanceResult {
 	geminiDir := GetGeminiConfigDir()
 
 	prunedLogs := pruneGeminiLogs(geminiDir)
+	if prunedLogs > 0 {
+		ClearGeminiSessionCache()
+	}
 	prunedBackups := cleanupDeckBackups(filepath.Join(profileRoot, "profiles"))
 	archivedSessions := archiveBloatedSessions(profileRoot)
 	orphanContainers := cleanupOrphanContainers(ctx)
@@ -82,15 +85,35 @@ func StartMaintenanceWorker(ctx context.Context, onComplete func(MaintenanceResu
 	}()
 }
 
-// pruneGeminiLogs deletes .txt files found directly inside ~/.gemini/tmp/*/
-// directories, but NOT inside chats/ subdirectories.
-func pruneGeminiLogs(baseDir string) int {
-	pruned := 0
+// GeminiPrunePlan lists the Gemini log files a prune would remove and the
+// space that would free.
+type GeminiPrunePlan struct {
+	Paths []string
+	Bytes int64
+}
+
+// Summary describes the plan, e.g. "would remove 42 files, 310.0 MB".
+func (p GeminiPrunePlan) Summary() string {
+	noun := "files"
+	if len(p.Paths) == 1 {
+		noun = "file"
+	}
+	return fmt.Sprintf("would remove %d %s, %s", len(p.Paths), noun, FormatByteSize(p.Bytes))
+}
+
+// PlanGeminiLogPrune is the dry run of the maintenance log prune: it reports
+// the files pruneGeminiLogs would delete under baseDir and their total size,
+// without removing anything. An empty baseDir means GetGeminiConfigDir().
+func PlanGeminiLogPrune(baseDir string) GeminiPrunePlan {
+	if baseDir == "" {
+		baseDir = GetGeminiConfigDir()
+	}
+	var plan GeminiPrunePlan
 
 	dirs, err := filepath.Glob(filepath.Join(baseDir, "tmp", "*"))
 	if err != nil {
 		maintLog.Warn("prune_gemini_logs_glob_error", slog.String("error", err.Error()))
-		return 0
+		return plan
 	}
 
 	for _, dir := range dirs {
@@ -112,21 +135,55 @@ func pruneGeminiLogs(baseDir string) int {
 				continue
 			}
 			fullPath := filepath.Join(dir, entry.Name())
-			if err := os.Remove(fullPath); err != nil {
-				maintLog.Warn(
-					"maintenance_file_remove_failed",
-					slog.String("path", fullPath),
-					slog.String("error", err.Error()),
-				)
-			} else {
-				pruned++
+			fi, err := os.Stat(fullPath)
+			if err != nil {
+				continue // removed since ReadDir
 			}
+			plan.Paths = append(plan.Paths, fullPath)
+			plan.Bytes += fi.Size()
 		}
 	}
 
+	return plan
+}
+
+// pruneGeminiLogs deletes .txt files found directly inside ~/.gemini/tmp/*/
+// directories, but NOT inside chats/ subdirectories.
+func pruneGeminiLogs(baseDir string) int {
+	return PlanGeminiLogPrune(baseDir).Remove()
+}
+
+// Remove deletes the planned files and returns how many were removed.
+func (p GeminiPrunePlan) Remove() int {
+	pruned := 0
+	for _, path := range p.Paths {
+		if err := os.Remove(path); err != nil {
+			maintLog.Warn(
+				"maintenance_file_remove_failed",
+				slog.String("path", path),
+				slog.String("error", err.Error()),
+			)
+		} else {
+			pruned++
+		}
+	}
 	return pruned
 }
 
+// FormatByteSize renders a byte count with a binary unit, e.g. "310.0 MB".
+func FormatByteSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
 // cleanupDeckBackups keeps only the 3 most recent .bak.* files per profile
 // directory, deleting the rest.
 func cleanupDeckBackups(profilesDir string) int {
@@ -55,6 +55,50 @@ func TestPruneGeminiLogs(t *testing.T) {
 	}
 }
 
+func TestPlanGeminiLogPrune_DryRun(t *testing.T) {
+	base := t.TempDir()
+	hashDir := filepath.Join(base, "tmp", "abc123hash")
+	chatsDir := filepath.Join(hashDir, "chats")
+	if err := os.MkdirAll(chatsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	logs := map[string]int{"output.txt": 1000, "debug.txt": 2 << 20}
+	for name, size := range logs {
+		if err := os.WriteFile(filepath.Join(hashDir, name), make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(chatsDir, "chat1.json"), make([]byte, 4096), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := PlanGeminiLogPrune(base)
+	if len(plan.Paths) != 2 {
+		t.Fatalf("plan paths = %v, want the 2 .txt logs", plan.Paths)
+	}
+	if want := int64(1000 + 2<<20); plan.Bytes != want {
+		t.Errorf("plan bytes = %d, want %d", plan.Bytes, want)
+	}
+	if got, want := plan.Summary(), "would remove 2 files, 2.0 MB"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+	for name := range logs {
+		if _, err := os.Stat(filepath.Join(hashDir, name)); err != nil {
+			t.Errorf("dry run removed %s: %v", name, err)
+		}
+	}
+
+	if got := pruneGeminiLogs(base); got != len(plan.Paths) {
+		t.Errorf("pruneGeminiLogs removed %d, plan said %d", got, len(plan.Paths))
+	}
+}
+
+func TestGeminiPrunePlan_SummaryEmpty(t *testing.T) {
+	if got, want := (GeminiPrunePlan{}).Summary(), "would remove 0 files, 0 B"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
+
 func TestCleanupDeckBackups(t *testing.T) {
 	// Create temp dir with backup files having staggered mtimes
 	base := t.TempDir()
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/asheshgoplani/agent-deck/internal/tmux"
 )
@@ -175,6 +176,12 @@ func SetField(inst *Instance, field, value string, extraArgsTokens []string) (ol
 
 	case FieldNotes:
 		oldValue = inst.Notes
+		if n := utf8.RuneCountInString(value); n > MaxNotesLength {
+			return oldValue, nil, &MutationError{
+				Field: field,
+				Msg:   fmt.Sprintf("notes too long (%d characters, max %d)", n, MaxNotesLength),
+			}
+		}
 		inst.Notes = value
 
 	case FieldColor:
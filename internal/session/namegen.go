@@ -4,7 +4,9 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
+	"regexp"
 	"time"
+	"unicode/utf8"
 )
 
 // adjectives for auto-generated session names (nature/weather themed)
@@ -87,6 +89,40 @@ func GenerateUniqueSessionName(instances []*Instance, groupPath string) string {
 	return fmt.Sprintf("%s-%d", name, time.Now().Unix())
 }
 
+// GenerateCopyTitle returns the title for a duplicate of a session titled
+// title in groupPath: "<title>-copy", then "<title>-copy-2", "-copy-3", ...
+// until it doesn't collide with a title in the group. Copying a copy extends
+// the existing suffix rather than stacking a second one. The title is cut
+// short before the suffix when needed so the result fits MaxTitleLength.
+func GenerateCopyTitle(instances []*Instance, groupPath, title string) string {
+	existing := make(map[string]bool)
+	for _, inst := range instances {
+		if inst.GroupPath == groupPath {
+			existing[inst.Title] = true
+		}
+	}
+
+	base := copySuffixRe.ReplaceAllString(title, "")
+	name := withCopySuffix(base, "-copy")
+	for n := 2; existing[name]; n++ {
+		name = withCopySuffix(base, fmt.Sprintf("-copy-%d", n))
+	}
+	return name
+}
+
+// withCopySuffix appends suffix to base, dropping trailing runes of base so
+// the result is at most MaxTitleLength bytes.
+func withCopySuffix(base, suffix string) string {
+	for base != "" && len(base)+len(suffix) > MaxTitleLength {
+		_, size := utf8.DecodeLastRuneInString(base)
+		base = base[:len(base)-size]
+	}
+	return base + suffix
+}
+
+// copySuffixRe matches a "-copy" or "-copy-N" suffix from GenerateCopyTitle.
+var copySuffixRe = regexp.MustCompile(`-copy(-\d+)?$`)
+
 // cryptoRandInt returns a cryptographically random int in [0, max).
 func cryptoRandInt(max int) int {
 	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
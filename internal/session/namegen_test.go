@@ -3,6 +3,7 @@ package session
 import (
 	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestGenerateSessionName(t *testing.T) {
@@ -81,6 +82,48 @@ func TestGenerateUniqueSessionName_EmptyInstances(t *testing.T) {
 	}
 }
 
+func TestGenerateCopyTitle(t *testing.T) {
+	instances := []*Instance{
+		{Title: "api", GroupPath: "work"},
+		{Title: "api-copy", GroupPath: "work"},
+		{Title: "api-copy-2", GroupPath: "work"},
+		{Title: "web-copy", GroupPath: "personal"},
+	}
+	tests := []struct {
+		group, title, want string
+	}{
+		{"work", "api", "api-copy-3"},
+		{"work", "api-copy", "api-copy-3"},
+		{"work", "web", "web-copy"},
+		{"personal", "api", "api-copy"},
+		{"personal", "web", "web-copy-2"},
+	}
+	for _, tt := range tests {
+		if got := GenerateCopyTitle(instances, tt.group, tt.title); got != tt.want {
+			t.Errorf("GenerateCopyTitle(%q, %q) = %q, want %q", tt.group, tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateCopyTitle_FitsMaxTitleLength(t *testing.T) {
+	long := strings.Repeat("x", MaxTitleLength)
+	got := GenerateCopyTitle(nil, "", long)
+	if want := strings.Repeat("x", MaxTitleLength-len("-copy")) + "-copy"; got != want {
+		t.Errorf("GenerateCopyTitle(long) = %q, want %q", got, want)
+	}
+
+	instances := []*Instance{{Title: got}}
+	if got := GenerateCopyTitle(instances, "", long); len(got) > MaxTitleLength || !strings.HasSuffix(got, "-copy-2") {
+		t.Errorf("GenerateCopyTitle(long, taken) = %q (%d bytes)", got, len(got))
+	}
+
+	// Multi-byte runes are dropped whole, never split.
+	wide := strings.Repeat("é", MaxTitleLength)
+	if got := GenerateCopyTitle(nil, "", wide); len(got) > MaxTitleLength || !utf8.ValidString(got) {
+		t.Errorf("GenerateCopyTitle(wide) = %q (%d bytes)", got, len(got))
+	}
+}
+
 func TestCryptoRandInt(t *testing.T) {
 	// Should return values in [0, max)
 	for range 100 {
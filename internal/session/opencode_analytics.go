@@ -0,0 +1,200 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// opencodeDataDirOverride allows tests to override the data directory
+var opencodeDataDirOverride string
+
+// GetOpenCodeDataDir returns OpenCode's data directory:
+// $XDG_DATA_HOME/opencode, else ~/.local/share/opencode
+func GetOpenCodeDataDir() string {
+	if opencodeDataDirOverride != "" {
+		return opencodeDataDirOverride
+	}
+	if dataHome := strings.TrimSpace(os.Getenv("XDG_DATA_HOME")); filepath.IsAbs(dataHome) {
+		return filepath.Join(dataHome, "opencode")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "share", "opencode")
+}
+
+// OpenCode storage layout (one JSON file per record):
+//
+//	storage/session/<projectID>/<sessionID>.json
+//	storage/message/<sessionID>/<messageID>.json
+//
+// VERIFIED: times are Unix milliseconds; token counts live on assistant
+// messages only.
+type opencodeSessionFile struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Directory string `json:"directory"`
+	Time      struct {
+		Created int64 `json:"created"`
+		Updated int64 `json:"updated"`
+	} `json:"time"`
+}
+
+type opencodeMessageFile struct {
+	Role    string  `json:"role"`
+	ModelID string  `json:"modelID"`
+	Cost    float64 `json:"cost"`
+	Time    struct {
+		Created   int64 `json:"created"`
+		Completed int64 `json:"completed"`
+	} `json:"time"`
+	Tokens struct {
+		Input     int `json:"input"`
+		Output    int `json:"output"`
+		Reasoning int `json:"reasoning"`
+		Cache     struct {
+			Read  int `json:"read"`
+			Write int `json:"write"`
+		} `json:"cache"`
+	} `json:"tokens"`
+}
+
+// OpenCodeSessionInfo holds parsed session metadata
+type OpenCodeSessionInfo struct {
+	SessionID   string // ses_XXXXX
+	Title       string
+	Directory   string
+	StartTime   time.Time
+	LastUpdated time.Time
+}
+
+// unixMilli converts an OpenCode timestamp, keeping zero as the zero time.
+func unixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// ListOpenCodeSessions returns the OpenCode sessions whose directory is
+// projectPath, sorted by LastUpdated (most recent first).
+func ListOpenCodeSessions(projectPath string) ([]OpenCodeSessionInfo, error) {
+	files, err := filepath.Glob(filepath.Join(GetOpenCodeDataDir(), "storage", "session", "*", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	want := normalizePath(projectPath)
+
+	var sessions []OpenCodeSessionInfo
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var s opencodeSessionFile
+		if json.Unmarshal(data, &s) != nil || s.ID == "" {
+			continue // Skip malformed files
+		}
+		if normalizePath(s.Directory) != want {
+			continue
+		}
+		sessions = append(sessions, OpenCodeSessionInfo{
+			SessionID:   s.ID,
+			Title:       s.Title,
+			Directory:   s.Directory,
+			StartTime:   unixMilli(s.Time.Created),
+			LastUpdated: unixMilli(s.Time.Updated),
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUpdated.After(sessions[j].LastUpdated)
+	})
+	return sessions, nil
+}
+
+// openCodeMessagesDir returns the directory holding a session's message files.
+func openCodeMessagesDir(sessionID string) string {
+	return filepath.Join(GetOpenCodeDataDir(), "storage", "message", sessionID)
+}
+
+// UpdateOpenCodeAnalyticsFromDisk updates the analytics struct from the
+// session's message files. OpenCode rewrites a message file while the reply
+// streams, so the mtime cache compares the newest mtime over all of them; a
+// stat per message is far cheaper than re-parsing them.
+func UpdateOpenCodeAnalyticsFromDisk(sessionID string, analytics *AgentSessionAnalytics) error {
+	if sessionID == "" {
+		return fmt.Errorf("invalid session ID")
+	}
+	files, err := filepath.Glob(filepath.Join(openCodeMessagesDir(sessionID), "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("session messages not found")
+	}
+
+	var newest time.Time
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil && info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	if analytics.SessionID == sessionID && !analytics.LastFileModTime.IsZero() && newest.Equal(analytics.LastFileModTime) {
+		return nil
+	}
+
+	parsed := parseOpenCodeMessages(files)
+	parsed.SessionID = sessionID
+	parsed.LastFileModTime = newest
+	*analytics = *parsed
+	return nil
+}
+
+// parseOpenCodeMessages computes fresh analytics from a session's message
+// files, which must be given in name order.
+func parseOpenCodeMessages(files []string) *AgentSessionAnalytics {
+	parsed := &AgentSessionAnalytics{}
+	var start, last time.Time
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue // Deleted since the glob
+		}
+		var msg opencodeMessageFile
+		if json.Unmarshal(data, &msg) != nil {
+			continue // Half-written; picked up on the next update
+		}
+		created, done := unixMilli(msg.Time.Created), unixMilli(msg.Time.Completed)
+		if !created.IsZero() && (start.IsZero() || created.Before(start)) {
+			start = created
+		}
+		for _, t := range []time.Time{created, done} {
+			if t.After(last) {
+				last = t
+			}
+		}
+		if msg.Role != "assistant" {
+			continue
+		}
+		// OpenCode reports cache reads apart from input; fold them in so
+		// InputTokens means the full prompt, as for the other tools.
+		input := msg.Tokens.Input + msg.Tokens.Cache.Read
+		parsed.InputTokens += input
+		parsed.CachedTokens += msg.Tokens.Cache.Read
+		parsed.OutputTokens += msg.Tokens.Output + msg.Tokens.Reasoning
+		parsed.ReasoningTokens += msg.Tokens.Reasoning
+		parsed.EstimatedCost += msg.Cost
+		parsed.TotalTurns++
+		// Message IDs sort in creation order, and so does the glob.
+		parsed.CurrentContextTokens = input
+		if msg.ModelID != "" {
+			parsed.Model = msg.ModelID
+		}
+	}
+	parsed.setSpan(start, last)
+	return parsed
+}
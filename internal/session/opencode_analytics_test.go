@@ -0,0 +1,102 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeOpenCodeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListOpenCodeSessions_FiltersByDirectory(t *testing.T) {
+	dataDir := t.TempDir()
+	opencodeDataDirOverride = dataDir
+	defer func() { opencodeDataDirOverride = "" }()
+
+	projectPath := t.TempDir()
+	sessionDir := filepath.Join(dataDir, "storage", "session", "proj1")
+	writeOpenCodeFile(t, filepath.Join(sessionDir, "ses_old.json"),
+		`{"id":"ses_old","title":"old","directory":"`+projectPath+`","time":{"created":1700000000000,"updated":1700000100000}}`)
+	writeOpenCodeFile(t, filepath.Join(sessionDir, "ses_new.json"),
+		`{"id":"ses_new","title":"new","directory":"`+projectPath+`","time":{"created":1700000200000,"updated":1700000300000}}`)
+	writeOpenCodeFile(t, filepath.Join(sessionDir, "ses_other.json"),
+		`{"id":"ses_other","directory":"/somewhere/else","time":{"created":1700000400000,"updated":1700000500000}}`)
+	writeOpenCodeFile(t, filepath.Join(sessionDir, "broken.json"), `{not json`)
+
+	sessions, err := ListOpenCodeSessions(projectPath)
+	if err != nil {
+		t.Fatalf("ListOpenCodeSessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("got %d sessions, want 2: %+v", len(sessions), sessions)
+	}
+	if sessions[0].SessionID != "ses_new" || sessions[1].SessionID != "ses_old" {
+		t.Errorf("order = %s, %s; want ses_new, ses_old", sessions[0].SessionID, sessions[1].SessionID)
+	}
+	if sessions[0].Title != "new" {
+		t.Errorf("Title = %q, want new", sessions[0].Title)
+	}
+}
+
+func TestUpdateOpenCodeAnalyticsFromDisk(t *testing.T) {
+	dataDir := t.TempDir()
+	opencodeDataDirOverride = dataDir
+	defer func() { opencodeDataDirOverride = "" }()
+
+	msgDir := filepath.Join(dataDir, "storage", "message", "ses_abc")
+	writeOpenCodeFile(t, filepath.Join(msgDir, "msg_001.json"),
+		`{"role":"user","time":{"created":1700000000000}}`)
+	writeOpenCodeFile(t, filepath.Join(msgDir, "msg_002.json"),
+		`{"role":"assistant","modelID":"claude-sonnet-4","cost":0.01,"time":{"created":1700000001000,"completed":1700000005000},
+		  "tokens":{"input":100,"output":20,"reasoning":5,"cache":{"read":400,"write":0}}}`)
+	writeOpenCodeFile(t, filepath.Join(msgDir, "msg_003.json"),
+		`{"role":"assistant","modelID":"gpt-5","cost":0.02,"time":{"created":1700000010000,"completed":1700000060000},
+		  "tokens":{"input":50,"output":10,"reasoning":0,"cache":{"read":600,"write":0}}}`)
+
+	analytics := &AgentSessionAnalytics{}
+	if err := UpdateOpenCodeAnalyticsFromDisk("ses_abc", analytics); err != nil {
+		t.Fatalf("UpdateOpenCodeAnalyticsFromDisk: %v", err)
+	}
+	if analytics.InputTokens != 1150 || analytics.CachedTokens != 1000 {
+		t.Errorf("Input/Cached = %d/%d, want 1150/1000", analytics.InputTokens, analytics.CachedTokens)
+	}
+	if analytics.OutputTokens != 35 || analytics.ReasoningTokens != 5 {
+		t.Errorf("Output/Reasoning = %d/%d, want 35/5", analytics.OutputTokens, analytics.ReasoningTokens)
+	}
+	if analytics.TotalTurns != 2 {
+		t.Errorf("TotalTurns = %d, want 2", analytics.TotalTurns)
+	}
+	if analytics.CurrentContextTokens != 650 {
+		t.Errorf("CurrentContextTokens = %d, want 650", analytics.CurrentContextTokens)
+	}
+	if analytics.Model != "gpt-5" {
+		t.Errorf("Model = %q, want gpt-5", analytics.Model)
+	}
+	if analytics.EstimatedCost < 0.0299 || analytics.EstimatedCost > 0.0301 {
+		t.Errorf("EstimatedCost = %f, want 0.03", analytics.EstimatedCost)
+	}
+	if analytics.Duration.Seconds() != 60 {
+		t.Errorf("Duration = %v, want 1m0s", analytics.Duration)
+	}
+
+	// Unchanged files: cache hit leaves the struct alone
+	analytics.InputTokens = 999
+	if err := UpdateOpenCodeAnalyticsFromDisk("ses_abc", analytics); err != nil {
+		t.Fatal(err)
+	}
+	if analytics.InputTokens != 999 {
+		t.Errorf("InputTokens = %d, want 999 (mtime cache should skip the parse)", analytics.InputTokens)
+	}
+
+	if err := UpdateOpenCodeAnalyticsFromDisk("ses_missing", analytics); err == nil {
+		t.Error("expected an error for a session without messages")
+	}
+}
@@ -0,0 +1,160 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// projectTrustFileName stores which .agentdeck.json files the user trusted.
+// A repository's file can run commands (layout.command, shell_init) and set
+// env vars (PATH, LD_PRELOAD, ...) in every session, so those parts are only
+// honored for a file whose exact content was trusted with `agent-deck trust`.
+// Any edit to the file, such as one pulled from upstream, revokes the trust.
+const projectTrustFileName = "trusted-projects.json"
+
+var projectTrustMu sync.Mutex
+
+// projectTrustPath returns the trust store, next to the other agent-deck data.
+func projectTrustPath() (string, error) {
+	return dataPath(projectTrustFileName)
+}
+
+// loadProjectTrust returns the store: project directory -> SHA-256 of the
+// trusted .agentdeck.json. A missing store is empty.
+func loadProjectTrust() (map[string]string, error) {
+	path, err := projectTrustPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", projectTrustFileName, err)
+	}
+	trusted := map[string]string{}
+	if err := json.Unmarshal(data, &trusted); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", projectTrustFileName, err)
+	}
+	return trusted, nil
+}
+
+func saveProjectTrust(trusted map[string]string) error {
+	path, err := projectTrustPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(trusted, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// projectTrustKey is the absolute, cleaned project directory.
+func projectTrustKey(projectPath string) (string, error) {
+	abs, err := filepath.Abs(ExpandPath(projectPath))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(abs), nil
+}
+
+func projectConfigDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// TrustProjectConfig trusts the current content of projectPath's
+// .agentdeck.json, so its env, shell_init and layout are applied.
+func TrustProjectConfig(projectPath string) error {
+	key, err := projectTrustKey(projectPath)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Join(key, ProjectConfigFileName))
+	if err != nil {
+		return fmt.Errorf("read %s: %w", ProjectConfigFileName, err)
+	}
+	if _, err := LoadProjectConfig(key); err != nil {
+		return err
+	}
+
+	projectTrustMu.Lock()
+	defer projectTrustMu.Unlock()
+	trusted, err := loadProjectTrust()
+	if err != nil {
+		return err
+	}
+	trusted[key] = projectConfigDigest(data)
+	return saveProjectTrust(trusted)
+}
+
+// UntrustProjectConfig forgets projectPath. Forgetting an untrusted project
+// is not an error.
+func UntrustProjectConfig(projectPath string) error {
+	key, err := projectTrustKey(projectPath)
+	if err != nil {
+		return err
+	}
+	projectTrustMu.Lock()
+	defer projectTrustMu.Unlock()
+	trusted, err := loadProjectTrust()
+	if err != nil {
+		return err
+	}
+	if _, ok := trusted[key]; !ok {
+		return nil
+	}
+	delete(trusted, key)
+	return saveProjectTrust(trusted)
+}
+
+// TrustedProjects lists the trusted project directories, sorted.
+func TrustedProjects() ([]string, error) {
+	projectTrustMu.Lock()
+	defer projectTrustMu.Unlock()
+	trusted, err := loadProjectTrust()
+	if err != nil {
+		return nil, err
+	}
+	dirs := make([]string, 0, len(trusted))
+	for dir := range trusted {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// projectConfigTrusted reports whether data is the trusted content of
+// projectPath's .agentdeck.json. An unreadable store trusts nothing.
+func projectConfigTrusted(projectPath string, data []byte) bool {
+	key, err := projectTrustKey(projectPath)
+	if err != nil {
+		return false
+	}
+	projectTrustMu.Lock()
+	defer projectTrustMu.Unlock()
+	trusted, err := loadProjectTrust()
+	if err != nil {
+		return false
+	}
+	digest, ok := trusted[key]
+	return ok && digest == projectConfigDigest(data)
+}
@@ -0,0 +1,191 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+// ProjectConfigFileName is the per-project defaults file read from the
+// project root.
+const ProjectConfigFileName = ".agentdeck.json"
+
+// ProjectConfig holds per-project New Session defaults read from
+// .agentdeck.json. Unset fields fall back to the user config, so the
+// effective precedence is project > user > built-in defaults.
+//
+// Claude, Env, ShellInit and Layout can run code or lift permission checks
+// in every session, so they only apply once the user has trusted the file
+// (`agent-deck trust`, see TrustProjectConfig); Trusted reports that.
+//
+// Example:
+//
+//	{
+//	  "tool": "claude",
+//	  "worktree": true,
+//	  "claude": {"permission_mode": "plan", "allowed_tools": ["Read", "Grep"]},
+//	  "env": {"NODE_ENV": "development"}
+//	}
+type ProjectConfig struct {
+	// Tool preselects the tool in the New Session dialog (e.g. "claude").
+	Tool string `json:"tool,omitempty"`
+
+	// Worktree overrides [worktree].default_enabled for this project.
+	Worktree *bool `json:"worktree,omitempty"`
+
+	// Claude overlays ClaudeOptions fields (same JSON keys as the persisted
+	// tool options). Only keys present in the file override the user config.
+	// Use ApplyClaude, which ignores it until trusted: it can skip
+	// permissions or add MCP servers.
+	Claude json.RawMessage `json:"claude,omitempty"`
+
+	// Layout splits new sessions' windows (see LayoutSpec). Overrides the
+	// custom tool's layout.
+	Layout *LayoutSpec `json:"layout,omitempty"`
+
+	// ShellInit overrides [shell].shell_init for plain shell sessions in
+	// this project. Use TrustedShellInit, which ignores it until trusted.
+	ShellInit string `json:"shell_init,omitempty"`
+
+	// Env is exported into every session started in this project. Applied
+	// after the user config's env files and inline env, so project keys win.
+	Env map[string]string `json:"env,omitempty"`
+
+	// Trusted is set by LoadProjectConfig when this exact file content was
+	// trusted. Without it Claude, Env, ShellInit and Layout must be ignored.
+	Trusted bool `json:"-"`
+}
+
+// LoadProjectConfig reads .agentdeck.json from projectPath. It returns
+// (nil, nil) when the file does not exist, and an error when the file is
+// unreadable or malformed so callers can warn without failing.
+func LoadProjectConfig(projectPath string) (*ProjectConfig, error) {
+	if projectPath == "" {
+		return nil, nil
+	}
+	file := filepath.Join(ExpandPath(projectPath), ProjectConfigFileName)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ENOTDIR) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", ProjectConfigFileName, err)
+	}
+
+	var cfg ProjectConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", ProjectConfigFileName, err)
+	}
+	if len(cfg.Claude) > 0 {
+		var probe ClaudeOptions
+		if err := json.Unmarshal(cfg.Claude, &probe); err != nil {
+			return nil, fmt.Errorf("parse %s claude: %w", ProjectConfigFileName, err)
+		}
+	}
+	if cfg.Layout != nil {
+		if err := cfg.Layout.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", ProjectConfigFileName, err)
+		}
+	}
+	for k := range cfg.Env {
+		if !isValidEnvKey(k) {
+			return nil, fmt.Errorf("%s: invalid env var name %q", ProjectConfigFileName, k)
+		}
+	}
+	cfg.Trusted = projectConfigTrusted(projectPath, data)
+	return &cfg, nil
+}
+
+// ApplyClaude overlays the project's claude section onto opts when the file
+// is trusted. Keys absent from the file leave the existing (user config)
+// values untouched.
+func (c *ProjectConfig) ApplyClaude(opts *ClaudeOptions) error {
+	if c == nil || !c.Trusted || len(c.Claude) == 0 || opts == nil {
+		return nil
+	}
+	return json.Unmarshal(c.Claude, opts)
+}
+
+// TrustedShellInit returns ShellInit when the file is trusted, else "".
+func (c *ProjectConfig) TrustedShellInit() string {
+	if c == nil || !c.Trusted {
+		return ""
+	}
+	return c.ShellInit
+}
+
+// UntrustedParts names the settings that are present but ignored because the
+// file is not trusted; nil for a trusted file.
+func (c *ProjectConfig) UntrustedParts() []string {
+	if c == nil || c.Trusted {
+		return nil
+	}
+	var parts []string
+	if len(c.Claude) > 0 {
+		parts = append(parts, "claude")
+	}
+	if len(c.Env) > 0 {
+		parts = append(parts, "env")
+	}
+	if c.ShellInit != "" {
+		parts = append(parts, "shell_init")
+	}
+	if c.Layout != nil && c.Layout.Command != "" {
+		parts = append(parts, "layout command")
+	}
+	return parts
+}
+
+// ShellInitCommand returns the command for a plain shell session that runs
+// init first and then replaces itself with the user's interactive $SHELL, so
+// whatever init set up (an activated venv, exported variables) is still in
+// effect at the prompt. An empty init yields "" (a bare shell).
+func ShellInitCommand(init string) string {
+	init = strings.TrimSpace(init)
+	if init == "" {
+		return ""
+	}
+	return "sh -c " + shellQuote(init+`; exec "${SHELL:-sh}"`)
+}
+
+// getProjectEnv returns shell export statements for the project's
+// .agentdeck.json env map. Malformed and untrusted project configs are
+// logged and skipped so a bad file never blocks a spawn.
+func (i *Instance) getProjectEnv() string {
+	cfg, err := LoadProjectConfig(i.ProjectPath)
+	if err != nil {
+		sessionLog.Warn("project config ignored at spawn",
+			slog.String("session", i.Title),
+			slog.String("error", err.Error()))
+		return ""
+	}
+	if cfg == nil || len(cfg.Env) == 0 {
+		return ""
+	}
+	if !cfg.Trusted {
+		sessionLog.Warn("project_env_untrusted",
+			slog.String("session", i.Title),
+			slog.String("project", i.ProjectPath))
+		return ""
+	}
+
+	keys := make([]string, 0, len(cfg.Env))
+	for k := range cfg.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	exports := make([]string, 0, len(keys))
+	for _, k := range keys {
+		escaped := strings.ReplaceAll(cfg.Env[k], "'", "'\\''")
+		exports = append(exports, fmt.Sprintf("export %s='%s'", k, escaped))
+	}
+	return strings.Join(exports, " && ")
+}
@@ -0,0 +1,187 @@
+package session
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeProjectConfig(t *testing.T, dir, body string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, ProjectConfigFileName), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadProjectConfig_Missing(t *testing.T) {
+	cfg, err := LoadProjectConfig(t.TempDir())
+	if err != nil || cfg != nil {
+		t.Fatalf("missing file: got (%v, %v), want (nil, nil)", cfg, err)
+	}
+	cfg, err = LoadProjectConfig("")
+	if err != nil || cfg != nil {
+		t.Fatalf("empty path: got (%v, %v), want (nil, nil)", cfg, err)
+	}
+}
+
+func TestLoadProjectConfig_Parses(t *testing.T) {
+	dir := t.TempDir()
+	writeProjectConfig(t, dir, `{
+		"tool": "claude",
+		"worktree": true,
+		"claude": {"permission_mode": "plan"},
+		"env": {"NODE_ENV": "development"}
+	}`)
+
+	cfg, err := LoadProjectConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig: %v", err)
+	}
+	if cfg.Tool != "claude" {
+		t.Errorf("Tool = %q, want claude", cfg.Tool)
+	}
+	if cfg.Worktree == nil || !*cfg.Worktree {
+		t.Errorf("Worktree = %v, want true", cfg.Worktree)
+	}
+	if cfg.Env["NODE_ENV"] != "development" {
+		t.Errorf("Env = %v", cfg.Env)
+	}
+
+	// Keys absent from the project file keep the user config value.
+	opts := &ClaudeOptions{UseChrome: true, PermissionMode: "acceptEdits"}
+	cfg.Trusted = true
+	if err := cfg.ApplyClaude(opts); err != nil {
+		t.Fatalf("ApplyClaude: %v", err)
+	}
+	if opts.PermissionMode != "plan" || !opts.UseChrome {
+		t.Errorf("ApplyClaude overlay = %+v, want plan + chrome kept", opts)
+	}
+}
+
+func TestApplyClaude_IgnoredUntilTrusted(t *testing.T) {
+	dir := t.TempDir()
+	writeProjectConfig(t, dir, `{"claude": {"skip_permissions": true, "mcp_config": "/tmp/evil-mcp.json"}}`)
+
+	cfg, err := LoadProjectConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig: %v", err)
+	}
+	opts := &ClaudeOptions{MCPConfig: "user.json"}
+	if err := cfg.ApplyClaude(opts); err != nil {
+		t.Fatalf("ApplyClaude: %v", err)
+	}
+	if opts.SkipPermissions || opts.MCPConfig != "user.json" {
+		t.Errorf("untrusted claude section applied: %+v", opts)
+	}
+	if parts := cfg.UntrustedParts(); !slices.Equal(parts, []string{"claude"}) {
+		t.Errorf("UntrustedParts = %v, want [claude]", parts)
+	}
+
+	if err := TrustProjectConfig(dir); err != nil {
+		t.Fatalf("TrustProjectConfig: %v", err)
+	}
+	cfg, _ = LoadProjectConfig(dir)
+	if err := cfg.ApplyClaude(opts); err != nil {
+		t.Fatalf("ApplyClaude: %v", err)
+	}
+	if !opts.SkipPermissions || opts.MCPConfig != "/tmp/evil-mcp.json" {
+		t.Errorf("trusted claude section not applied: %+v", opts)
+	}
+}
+
+func TestLoadProjectConfig_Malformed(t *testing.T) {
+	dir := t.TempDir()
+	writeProjectConfig(t, dir, `{"tool": `)
+	if _, err := LoadProjectConfig(dir); err == nil {
+		t.Fatal("expected parse error for malformed JSON")
+	}
+
+	writeProjectConfig(t, dir, `{"env": {"BAD-KEY": "x"}}`)
+	if _, err := LoadProjectConfig(dir); err == nil {
+		t.Fatal("expected error for invalid env var name")
+	}
+}
+
+func TestGetProjectEnv(t *testing.T) {
+	dir := t.TempDir()
+	writeProjectConfig(t, dir, `{"env": {"B": "it's", "A": "1"}}`)
+
+	inst := &Instance{Title: "p", ProjectPath: dir}
+	if got := inst.getProjectEnv(); got != "" {
+		t.Fatalf("untrusted config must not export env, got %q", got)
+	}
+
+	if err := TrustProjectConfig(dir); err != nil {
+		t.Fatalf("TrustProjectConfig: %v", err)
+	}
+	got := inst.getProjectEnv()
+	want := `export A='1' && export B='it'\''s'`
+	if got != want {
+		t.Fatalf("getProjectEnv() = %q, want %q", got, want)
+	}
+
+	// Any edit revokes the trust.
+	writeProjectConfig(t, dir, `{"env": {"LD_PRELOAD": "/tmp/evil.so"}}`)
+	if got := inst.getProjectEnv(); got != "" {
+		t.Fatalf("edited config must need trusting again, got %q", got)
+	}
+
+	writeProjectConfig(t, dir, `not json`)
+	if got := inst.getProjectEnv(); got != "" {
+		t.Fatalf("malformed config should be skipped, got %q", got)
+	}
+}
+
+func TestShellInitCommand(t *testing.T) {
+	if got := ShellInitCommand("  "); got != "" {
+		t.Fatalf("ShellInitCommand(blank) = %q, want empty", got)
+	}
+
+	// $SHELL stands in for the interactive shell: env shows that the init's
+	// exports survive into it.
+	cmd := exec.Command("sh", "-c", ShellInitCommand("export INIT_MARK='it works'"))
+	cmd.Env = append(os.Environ(), "SHELL=env")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !strings.Contains(string(out), "INIT_MARK=it works") {
+		t.Fatalf("init export missing from the shell's environment:\n%s", out)
+	}
+}
+
+func TestProjectTrust_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	if err := TrustProjectConfig(dir); err == nil {
+		t.Fatal("trusting a project without a config file should fail")
+	}
+	writeProjectConfig(t, dir, `{"env": {"A": "1"}}`)
+	if err := TrustProjectConfig(dir); err != nil {
+		t.Fatalf("TrustProjectConfig: %v", err)
+	}
+	cfg, err := LoadProjectConfig(dir)
+	if err != nil || cfg == nil || !cfg.Trusted {
+		t.Fatalf("cfg = %+v, err = %v; want trusted", cfg, err)
+	}
+	if parts := cfg.UntrustedParts(); parts != nil {
+		t.Errorf("UntrustedParts = %v for a trusted file", parts)
+	}
+	dirs, err := TrustedProjects()
+	if err != nil || !slices.Contains(dirs, filepath.Clean(dir)) {
+		t.Errorf("TrustedProjects = %v, %v; want %s listed", dirs, err, dir)
+	}
+
+	if err := UntrustProjectConfig(dir); err != nil {
+		t.Fatalf("UntrustProjectConfig: %v", err)
+	}
+	cfg, _ = LoadProjectConfig(dir)
+	if cfg.Trusted {
+		t.Error("config still trusted after UntrustProjectConfig")
+	}
+	if parts := cfg.UntrustedParts(); !slices.Equal(parts, []string{"env"}) {
+		t.Errorf("UntrustedParts = %v, want [env]", parts)
+	}
+}
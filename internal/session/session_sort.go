@@ -0,0 +1,145 @@
+package session
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// SessionSortKey selects the field SortSessions orders by.
+type SessionSortKey string
+
+const (
+	// SortByDefault keeps the stored order (creation, K/J manual order, or
+	// actionable per group_sort).
+	SortByDefault    SessionSortKey = ""
+	SortByLastActive SessionSortKey = "last_active"
+	SortByName       SessionSortKey = "name"
+	SortByTool       SessionSortKey = "tool"
+)
+
+// SessionSortKeys lists the sort keys in the order the TUI cycles through them.
+var SessionSortKeys = []SessionSortKey{SortByDefault, SortByLastActive, SortByName, SortByTool}
+
+// Label returns a short human-readable name for the key (for status hints).
+func (k SessionSortKey) Label() string {
+	switch k {
+	case SortByLastActive:
+		return "Last active"
+	case SortByName:
+		return "Name"
+	case SortByTool:
+		return "Tool"
+	default:
+		return "Default"
+	}
+}
+
+// DefaultOrder is the natural direction for the key: most recent first for
+// last-active, alphabetical otherwise.
+func (k SessionSortKey) DefaultOrder() SortOrder {
+	if k == SortByLastActive {
+		return SortDescending
+	}
+	return SortAscending
+}
+
+// SortOrder is the direction of a SortSessions call.
+type SortOrder int
+
+const (
+	SortAscending SortOrder = iota
+	SortDescending
+)
+
+// SortSessions returns a new slice with sessions ordered by the given key and
+// direction; the input slice is not modified. The sort is stable, and ties
+// (including everything under SortByDefault) fall back to Order and then ID,
+// so equal keys never shuffle between renders.
+func SortSessions(sessions []*Instance, by SessionSortKey, order SortOrder) []*Instance {
+	sorted := make([]*Instance, len(sessions))
+	copy(sorted, sessions)
+	if by == SortByDefault {
+		return sorted
+	}
+
+	// Resolve activity once per session: it reads tmux state under a lock.
+	var activity map[*Instance]time.Time
+	if by == SortByLastActive {
+		activity = make(map[*Instance]time.Time, len(sorted))
+		for _, inst := range sorted {
+			activity[inst] = inst.LastActivityTime()
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		var cmp int
+		switch by {
+		case SortByLastActive:
+			cmp = activity[a].Compare(activity[b])
+		case SortByName:
+			cmp = strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		case SortByTool:
+			cmp = strings.Compare(a.Tool, b.Tool)
+		}
+		if cmp != 0 {
+			if order == SortDescending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return a.ID < b.ID
+	})
+	return sorted
+}
+
+// FilterSessions returns a new slice holding the sessions for which keep
+// returns true, in their original order.
+func FilterSessions(sessions []*Instance, keep func(*Instance) bool) []*Instance {
+	filtered := make([]*Instance, 0, len(sessions))
+	for _, inst := range sessions {
+		if keep(inst) {
+			filtered = append(filtered, inst)
+		}
+	}
+	return filtered
+}
+
+// ByTool is a FilterSessions predicate matching sessions of the given tool.
+func ByTool(tool string) func(*Instance) bool {
+	return func(inst *Instance) bool { return inst.Tool == tool }
+}
+
+// InGroup is a FilterSessions predicate matching sessions in the group at
+// path or any of its subgroups.
+func InGroup(path string) func(*Instance) bool {
+	return func(inst *Instance) bool {
+		return inst.GroupPath == path || strings.HasPrefix(inst.GroupPath, path+"/")
+	}
+}
+
+// LastActivityTime returns the most recent sign of life for the session: tmux
+// pane activity, the tool's own analytics (Gemini, OpenCode and Codex record
+// LastActive), the last attach, and finally creation time for sessions never
+// touched since.
+func (i *Instance) LastActivityTime() time.Time {
+	latest := i.CreatedAt
+	candidates := []time.Time{i.LastAccessedAt}
+	if ts := i.GetTmuxSession(); ts != nil {
+		candidates = append(candidates, ts.GetLastActivityTime())
+	}
+	if a := i.ToolAnalytics(); a != nil {
+		_, last := a.ActiveSpan()
+		candidates = append(candidates, last)
+	}
+	for _, t := range candidates {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
@@ -0,0 +1,88 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func sortedIDs(insts []*Instance) []string {
+	ids := make([]string, len(insts))
+	for i, inst := range insts {
+		ids[i] = inst.ID
+	}
+	return ids
+}
+
+func TestSortSessions(t *testing.T) {
+	now := time.Now()
+	sessions := []*Instance{
+		{ID: "a", Title: "beta", Tool: "gemini", Order: 0, CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: "b", Title: "Alpha", Tool: "claude", Order: 1, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "c", Title: "gamma", Tool: "claude", Order: 2, CreatedAt: now.Add(-4 * time.Hour), LastAccessedAt: now.Add(-time.Minute)},
+		{ID: "d", Title: "alpha", Tool: "shell", Order: 3, CreatedAt: now.Add(-3 * time.Hour)},
+	}
+
+	tests := []struct {
+		by    SessionSortKey
+		order SortOrder
+		want  []string
+	}{
+		{SortByDefault, SortAscending, []string{"a", "b", "c", "d"}},
+		{SortByName, SortAscending, []string{"b", "d", "a", "c"}}, // case-insensitive, ties by Order
+		{SortByName, SortDescending, []string{"c", "a", "b", "d"}},
+		{SortByTool, SortAscending, []string{"b", "c", "a", "d"}},
+		{SortByLastActive, SortDescending, []string{"c", "b", "a", "d"}}, // a/d tie on CreatedAt
+		{SortByLastActive, SortAscending, []string{"a", "d", "b", "c"}},
+	}
+	for _, tt := range tests {
+		got := sortedIDs(SortSessions(sessions, tt.by, tt.order))
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s/%d: got %v", tt.by, tt.order, got)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s/%d: got %v, want %v", tt.by, tt.order, got, tt.want)
+				break
+			}
+		}
+	}
+
+	if ids := sortedIDs(sessions); ids[0] != "a" || ids[3] != "d" {
+		t.Errorf("input slice was modified: %v", ids)
+	}
+}
+
+func TestFilterSessions(t *testing.T) {
+	sessions := []*Instance{
+		{ID: "a", Tool: "claude", GroupPath: "work"},
+		{ID: "b", Tool: "gemini", GroupPath: "work/api"},
+		{ID: "c", Tool: "claude", GroupPath: "workshop"},
+	}
+	if got := sortedIDs(FilterSessions(sessions, ByTool("claude"))); len(got) != 2 || got[0] != "a" || got[1] != "c" {
+		t.Errorf("ByTool(claude) = %v", got)
+	}
+	if got := sortedIDs(FilterSessions(sessions, InGroup("work"))); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("InGroup(work) = %v", got)
+	}
+}
+
+func TestFlattenAppliesSessionSort(t *testing.T) {
+	a := &Instance{ID: "a", Title: "zeta", GroupPath: "g", Order: 0}
+	b := &Instance{ID: "b", Title: "eta", GroupPath: "g", Order: 1}
+	pinned := &Instance{ID: "p", Title: "alpha", GroupPath: "g", Order: 2, Pin: PinTop}
+	tree := NewGroupTree([]*Instance{a, b, pinned})
+	tree.SortBy = SortByName
+
+	var got []string
+	for _, item := range tree.Flatten() {
+		if item.Type == ItemTypeSession {
+			got = append(got, item.Session.ID)
+		}
+	}
+	if len(got) != 3 || got[0] != "p" || got[1] != "b" || got[2] != "a" {
+		t.Errorf("flattened order = %v, want [p b a]", got)
+	}
+	if stored := sortedIDs(tree.Groups["g"].Sessions); stored[1] != "a" || stored[2] != "b" {
+		t.Errorf("Flatten reordered group.Sessions: %v", stored)
+	}
+}
@@ -0,0 +1,131 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sessionSpecFile is the layout of a spec file: a default group and the
+// sessions to create. A file may also be a bare list of sessions.
+type sessionSpecFile struct {
+	// Group is used for sessions that do not set their own.
+	Group    string        `json:"group,omitempty"`
+	Sessions []SessionSpec `json:"sessions"`
+}
+
+// LoadSessionSpecs reads the sessions declared in a spec file, for bringing
+// up a set of sessions in one go (see CreateSessions). Files ending in .json
+// are JSON, anything else YAML; both use SessionSpec's JSON field names:
+//
+//	group: dev
+//	sessions:
+//	  - title: api
+//	    path: ~/src/api
+//	    tool: claude
+//	    options: {skip_permissions: true}
+//
+// Unknown fields are errors, so a typo does not silently drop a setting.
+// Relative paths are relative to the spec file, not the working directory,
+// so a file checked into a repository works from anywhere. The specs are
+// only parsed here; ValidateSpec runs when each is created.
+func LoadSessionSpecs(path string) ([]SessionSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read spec file: %w", err)
+	}
+	if !strings.EqualFold(filepath.Ext(path), ".json") {
+		// Go through JSON so both formats share SessionSpec's field names
+		// and tool options stay raw JSON.
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parse spec file %s: %w", path, err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("parse spec file %s: %w", path, err)
+		}
+	}
+
+	var file sessionSpecFile
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		err = decodeSpecJSON(trimmed, &file.Sessions)
+	} else {
+		err = decodeSpecJSON(trimmed, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse spec file %s: %w", path, err)
+	}
+	if len(file.Sessions) == 0 {
+		return nil, fmt.Errorf("spec file %s declares no sessions", path)
+	}
+	group := strings.TrimSpace(file.Group)
+	base := filepath.Dir(path)
+	for i := range file.Sessions {
+		spec := &file.Sessions[i]
+		if group != "" && strings.TrimSpace(spec.Group) == "" {
+			spec.Group = group
+		}
+		if p := strings.TrimSpace(spec.Path); p != "" {
+			spec.Path = ResolveProjectPath(p, base)
+		}
+		for j, p := range spec.AdditionalPaths {
+			if p = strings.TrimSpace(p); p != "" {
+				spec.AdditionalPaths[j] = ResolveProjectPath(p, base)
+			}
+		}
+	}
+	return file.Sessions, nil
+}
+
+func decodeSpecJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// SpecResult is the outcome of creating one spec in CreateSessions.
+type SpecResult struct {
+	Spec SessionSpec
+	// Instance is the started session; nil when Err is set.
+	Instance *Instance
+	Err      error
+}
+
+// CreateSessions creates each spec, in order, and hands the started instance
+// to save. A spec that fails to create or save is rolled back, leaving no
+// tmux session or worktree behind, and does not stop the rest; the results
+// line up with specs.
+func CreateSessions(specs []SessionSpec, save func(*Instance) error) []SpecResult {
+	results := make([]SpecResult, len(specs))
+	for i, spec := range specs {
+		inst, err := createAndSave(spec, save)
+		if err != nil {
+			err = fmt.Errorf("session %d (%s): %w", i+1, strings.TrimSpace(spec.Title), err)
+		}
+		results[i] = SpecResult{Spec: spec, Instance: inst, Err: err}
+	}
+	return results
+}
+
+func createAndSave(spec SessionSpec, save func(*Instance) error) (*Instance, error) {
+	var txn CreateTxn
+	inst, err := spec.Create(&txn)
+	if err == nil {
+		if err = save(inst); err != nil {
+			err = fmt.Errorf("save session: %w", err)
+		}
+	}
+	if err != nil {
+		if rbErr := txn.Rollback(); rbErr != nil {
+			return nil, fmt.Errorf("%w (cleanup: %v)", err, rbErr)
+		}
+		return nil, err
+	}
+	return inst, nil
+}
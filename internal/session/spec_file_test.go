@@ -0,0 +1,135 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSpecFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadSessionSpecs_YAML(t *testing.T) {
+	path := writeSpecFile(t, "dev.yaml", `
+group: dev
+sessions:
+  - title: api
+    path: ~/src/api
+    tool: claude
+    options:
+      skip_permissions: true
+      allowed_tools: [Read, Edit]
+  - title: docs
+    path: ~/src/docs
+    group: writing
+    tool: gemini
+    options: {yolo_mode: true}
+`)
+	specs, err := LoadSessionSpecs(path)
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+
+	assert.Equal(t, "api", specs[0].Title)
+	assert.Equal(t, "dev", specs[0].Group, "file group fills in sessions without one")
+	assert.JSONEq(t, `{"skip_permissions": true, "allowed_tools": ["Read", "Edit"]}`, string(specs[0].Options))
+	assert.Equal(t, "writing", specs[1].Group, "a session's own group wins")
+	assert.JSONEq(t, `{"yolo_mode": true}`, string(specs[1].Options))
+}
+
+func TestLoadSessionSpecs_JSONList(t *testing.T) {
+	path := writeSpecFile(t, "dev.json", `[
+  {"title": "sh", "path": "/tmp", "tool": "shell", "command": "make watch"},
+  {"title": "wt", "path": "/tmp", "worktree": true, "branch": "feature/x"}
+]`)
+	specs, err := LoadSessionSpecs(path)
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+	assert.Equal(t, "make watch", specs[0].Command)
+	assert.True(t, specs[1].Worktree)
+	assert.Equal(t, "feature/x", specs[1].Branch)
+}
+
+func TestLoadSessionSpecs_Errors(t *testing.T) {
+	for name, tc := range map[string]struct{ file, content, want string }{
+		"unknown field":  {"a.yaml", "sessions:\n  - title: x\n    pth: /tmp\n", `unknown field "pth"`},
+		"bad yaml":       {"b.yml", "sessions: [\n", "parse spec file"},
+		"bad json":       {"c.json", `{"sessions": [}`, "parse spec file"},
+		"no sessions":    {"d.yaml", "group: dev\n", "declares no sessions"},
+		"empty document": {"e.yaml", "", "declares no sessions"},
+	} {
+		_, err := LoadSessionSpecs(writeSpecFile(t, tc.file, tc.content))
+		if assert.Error(t, err, name) {
+			assert.Contains(t, err.Error(), tc.want, name)
+		}
+	}
+
+	_, err := LoadSessionSpecs(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "read spec file")
+}
+
+func TestLoadSessionSpecs_RelativePathsFromSpecFile(t *testing.T) {
+	path := writeSpecFile(t, "dev.yaml", `
+sessions:
+  - title: api
+    path: ./api
+    multi_repo: true
+    additional_paths: [../shared, /abs/lib]
+  - title: home
+    path: ~/src
+`)
+	specs, err := LoadSessionSpecs(path)
+	require.NoError(t, err)
+	dir := filepath.Dir(path)
+	assert.Equal(t, filepath.Join(dir, "api"), specs[0].Path, "resolved against the spec file, not the cwd")
+	assert.Equal(t, []string{filepath.Join(filepath.Dir(dir), "shared"), "/abs/lib"}, specs[0].AdditionalPaths)
+	assert.Equal(t, ExpandPath("~/src"), specs[1].Path)
+}
+
+func TestCreateSessions_ReportsEachResult(t *testing.T) {
+	dir := t.TempDir()
+	live := fakeCreateTmux(t, nil)
+
+	var saved []string
+	results := CreateSessions([]SessionSpec{
+		{Title: "first", Path: dir, Tool: "shell", Group: "dev"},
+		{Title: "", Path: dir, Tool: "shell"},
+		{Title: "third", Path: dir, Tool: "claude", Options: []byte(`{"skip_permissions": true}`)},
+	}, func(inst *Instance) error { saved = append(saved, inst.Title); return nil })
+	require.Len(t, results, 3)
+	assert.Equal(t, []string{"first", "third"}, saved, "each created session is saved")
+
+	require.NoError(t, results[0].Err)
+	assert.True(t, live[results[0].Instance.ID])
+	assert.Equal(t, "dev", results[0].Instance.GroupPath)
+
+	require.Error(t, results[1].Err, "an invalid spec fails on its own")
+	assert.Nil(t, results[1].Instance)
+	assert.True(t, strings.HasPrefix(results[1].Err.Error(), "session 2 ()"), results[1].Err.Error())
+
+	require.NoError(t, results[2].Err, "later specs still run")
+	opts := results[2].Instance.GetClaudeOptions()
+	require.NotNil(t, opts)
+	assert.True(t, opts.SkipPermissions)
+	assert.Len(t, live, 2)
+}
+
+func TestCreateSessions_SaveFailureRollsBack(t *testing.T) {
+	live := fakeCreateTmux(t, nil)
+
+	results := CreateSessions([]SessionSpec{
+		{Title: "lost", Path: t.TempDir(), Tool: "shell"},
+	}, func(*Instance) error { return errors.New("database is locked") })
+	require.Len(t, results, 1)
+	assert.Nil(t, results[0].Instance)
+	assert.ErrorContains(t, results[0].Err, "save session: database is locked")
+	assert.Empty(t, live, "an unsaved session must not keep running")
+}
@@ -0,0 +1,121 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// MaxTitleLength is the longest session title the new-session dialog and
+// ValidateSpec accept.
+const MaxTitleLength = 50
+
+// MaxNotesLength caps a session's notes, in characters. Notes are a short
+// description shown in the session list, not a scratch file.
+const MaxNotesLength = 500
+
+// SpecError is one problem ValidateSpec found in a SessionSpec.
+type SpecError struct {
+	// Field is the spec field at fault: "title", "path", "additional_paths",
+	// "tool", "options", "branch", "worktree" or "notes".
+	Field   string
+	Message string
+	// Feasibility marks checks against the machine rather than the values
+	// (the path exists, is a git repository). The new-session dialog skips
+	// these: it offers to create a missing directory and decides the worktree
+	// fallback at submit time.
+	Feasibility bool
+}
+
+func (e *SpecError) Error() string { return e.Message }
+
+// ValidateSpec checks a SessionSpec without creating anything: the checks the
+// new-session dialog runs on its values, plus whether the path and worktree
+// can actually be used on this machine. It returns every problem found, value
+// checks first; nil means the spec is valid. Errors are *SpecError.
+func ValidateSpec(spec SessionSpec) []error {
+	var errs []error
+	add := func(field string, feasibility bool, format string, args ...any) {
+		errs = append(errs, &SpecError{Field: field, Message: fmt.Sprintf(format, args...), Feasibility: feasibility})
+	}
+
+	title := strings.TrimSpace(spec.Title)
+	switch {
+	case title == "":
+		add("title", false, "Session name cannot be empty")
+	case len(title) > MaxTitleLength:
+		add("title", false, "Session name too long (max %d characters)", MaxTitleLength)
+	}
+
+	path := strings.TrimSpace(spec.Path)
+	if path == "" && !spec.MultiRepo {
+		add("path", false, "Project path cannot be empty")
+	}
+
+	if spec.MultiRepo {
+		seen := make(map[string]bool)
+		count := 0
+		for _, p := range append([]string{spec.Path}, spec.AdditionalPaths...) {
+			if strings.TrimSpace(p) == "" {
+				continue
+			}
+			resolved := filepath.Clean(ResolveProjectPath(strings.TrimSpace(p), ""))
+			if seen[resolved] {
+				add("additional_paths", false, "Duplicate paths in multi-repo mode")
+				break
+			}
+			seen[resolved] = true
+			count++
+		}
+		if count < 2 {
+			add("additional_paths", false, "Multi-repo mode requires at least 2 paths")
+		}
+	}
+
+	if tool := strings.TrimSpace(spec.Tool); tool != "" && tool != "shell" &&
+		!currentRegistry().IsBuiltin(tool) && GetToolDef(tool) == nil {
+		add("tool", false, "Unknown tool %q", tool)
+	}
+
+	if len(spec.Options) > 0 {
+		tool := strings.TrimSpace(spec.Tool)
+		if tool == "" {
+			tool = GetDefaultTool()
+		}
+		if _, _, err := decodeSpecOptions(tool, spec.Options); err != nil {
+			add("options", false, "%s", err.Error())
+		}
+	}
+
+	if n := utf8.RuneCountInString(spec.Notes); n > MaxNotesLength {
+		add("notes", false, "Notes too long (%d characters, max %d)", n, MaxNotesLength)
+	}
+
+	branch := strings.TrimSpace(spec.Branch)
+	if spec.Worktree {
+		if branch == "" {
+			add("branch", false, "Branch name required for worktree")
+		} else if err := git.ValidateBranchName(branch); err != nil {
+			add("branch", false, "%s", err.Error())
+		}
+	}
+
+	// Feasibility: only meaningful once the values themselves are sane.
+	if len(errs) > 0 || path == "" {
+		return errs
+	}
+	resolved := ResolveProjectPath(path, "")
+	info, err := os.Stat(resolved)
+	switch {
+	case os.IsNotExist(err) && MissingPathAllowed(resolved):
+	case err != nil || !info.IsDir():
+		add("path", true, "Path is not a directory: %s", resolved)
+	case spec.Worktree && !git.IsGitRepoOrBareProjectRoot(resolved):
+		add("worktree", true, "Path is not a git repository: %s", resolved)
+	}
+	return errs
+}
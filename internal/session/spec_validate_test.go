@@ -0,0 +1,95 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func specErrorFields(errs []error) map[string]*SpecError {
+	fields := make(map[string]*SpecError)
+	for _, err := range errs {
+		var specErr *SpecError
+		if errors.As(err, &specErr) {
+			fields[specErr.Field] = specErr
+		}
+	}
+	return fields
+}
+
+func TestValidateSpec_ValueChecks(t *testing.T) {
+	dir := t.TempDir()
+
+	if errs := ValidateSpec(SessionSpec{Title: "api", Path: dir, Tool: "claude"}); errs != nil {
+		t.Fatalf("valid spec: %v", errs)
+	}
+
+	errs := ValidateSpec(SessionSpec{
+		Title:    strings.Repeat("x", MaxTitleLength+1),
+		Tool:     "no-such-tool",
+		Worktree: true,
+		Branch:   "bad..branch",
+	})
+	fields := specErrorFields(errs)
+	for _, field := range []string{"title", "path", "tool", "branch"} {
+		if fields[field] == nil {
+			t.Errorf("missing %s error in %v", field, errs)
+		} else if fields[field].Feasibility {
+			t.Errorf("%s error should be a value check", field)
+		}
+	}
+
+	errs = ValidateSpec(SessionSpec{Title: "multi", MultiRepo: true, Path: dir, AdditionalPaths: []string{dir + "/"}})
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "Duplicate paths") {
+		t.Errorf("duplicate multi-repo paths: %v", errs)
+	}
+	errs = ValidateSpec(SessionSpec{Title: "multi", MultiRepo: true, Path: dir})
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "at least 2 paths") {
+		t.Errorf("single multi-repo path: %v", errs)
+	}
+	errs = ValidateSpec(SessionSpec{Title: "sh", Path: dir, Tool: "shell", Options: []byte(`{"model": "x"}`)})
+	if f := specErrorFields(errs)["options"]; f == nil || !strings.Contains(f.Message, "takes no options") {
+		t.Errorf("options for a tool without any: %v", errs)
+	}
+	errs = ValidateSpec(SessionSpec{Title: "wt", Path: dir, Worktree: true})
+	if len(errs) != 1 || errs[0].Error() != "Branch name required for worktree" {
+		t.Errorf("worktree without branch: %v", errs)
+	}
+}
+
+func TestValidateSpec_Feasibility(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, spec := range map[string]SessionSpec{
+		"missing path":      {Title: "x", Path: filepath.Join(dir, "missing")},
+		"file path":         {Title: "x", Path: file},
+		"worktree, no repo": {Title: "x", Path: dir, Worktree: true, Branch: "feature/x"},
+	} {
+		errs := ValidateSpec(spec)
+		if len(errs) != 1 {
+			t.Errorf("%s: errs = %v, want one", name, errs)
+			continue
+		}
+		var specErr *SpecError
+		if !errors.As(errs[0], &specErr) || !specErr.Feasibility {
+			t.Errorf("%s: %v should be a feasibility error", name, errs[0])
+		}
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+	if errs := ValidateSpec(SessionSpec{Title: "x", Path: dir, Worktree: true, Branch: "feature/x"}); errs != nil {
+		t.Errorf("worktree in a repo: %v", errs)
+	}
+}
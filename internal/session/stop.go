@@ -0,0 +1,77 @@
+package session
+
+import (
+	"log/slog"
+	"time"
+)
+
+// DefaultStopTimeout is how long Stop waits for an agent to exit on its own
+// before force-killing the tmux session.
+const DefaultStopTimeout = 5 * time.Second
+
+// stopPollInterval is how often Stop checks whether the agent has exited.
+const stopPollInterval = 100 * time.Millisecond
+
+// stopKeyGap spaces out the interrupt keystrokes. Claude and Gemini treat two
+// Ctrl+C presses in quick succession as "exit", but only after the first one
+// has been processed.
+var stopKeyGap = 300 * time.Millisecond
+
+// sendGracefulExit asks the agent in the pane to exit. Claude, Gemini, Codex
+// and most REPLs quit on a repeated Ctrl+C (the first one interrupts a running
+// turn); a plain shell needs an explicit `exit`. Overridable in tests.
+var sendGracefulExit = func(i *Instance) error {
+	for range 2 {
+		if err := i.tmuxSession.SendCtrlC(); err != nil {
+			return err
+		}
+		time.Sleep(stopKeyGap)
+	}
+	if i.Tool == "shell" || i.Tool == "" {
+		return i.tmuxSession.SendKeysAndEnter("exit")
+	}
+	return nil
+}
+
+// agentExited reports whether the pane's process is gone. Overridable in
+// tests.
+var agentExited = func(i *Instance) bool {
+	return !i.tmuxSession.Exists() || i.tmuxSession.IsPaneDead()
+}
+
+// Stop shuts the session down gracefully: it asks the agent to exit so it can
+// flush its session files, waits up to timeout for the process to end, then
+// runs the normal Kill teardown (which force-kills whatever is left and cleans
+// up MCP children, sandbox containers and status). A timeout <= 0 skips
+// straight to Kill.
+//
+// Sessions configured with exit_to_shell keep a shell after the agent exits,
+// so they always run into the timeout; the agent has still been told to quit.
+func (i *Instance) Stop(timeout time.Duration) error {
+	if i.tmuxSession == nil || timeout <= 0 || agentExited(i) {
+		return i.Kill()
+	}
+
+	// Discover MCP children while the pane tree still exists; once the agent
+	// exits they are reparented and Kill could no longer find them.
+	i.discoverMCPChildrenFromPaneTree()
+
+	if err := sendGracefulExit(i); err != nil {
+		sessionLog.Warn("graceful_stop_send_failed",
+			slog.String("session", i.Title),
+			slog.String("error", err.Error()))
+		return i.Kill()
+	}
+
+	deadline := time.Now().Add(timeout)
+	for !agentExited(i) {
+		if time.Now().After(deadline) {
+			sessionLog.Info("graceful_stop_timeout",
+				slog.String("session", i.Title),
+				slog.Duration("timeout", timeout))
+			break
+		}
+		time.Sleep(stopPollInterval)
+	}
+	return i.Kill()
+}
@@ -0,0 +1,95 @@
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// stubGracefulStop replaces the keystroke sender and the exit probe. exitAfter
+// is how many probes report "still running" after the exit request.
+func stubGracefulStop(t *testing.T, sendErr error, exitAfter int) (sends *int) {
+	t.Helper()
+	origSend, origExited := sendGracefulExit, agentExited
+	sent := 0
+	probes := 0
+	sendGracefulExit = func(*Instance) error {
+		sent++
+		return sendErr
+	}
+	agentExited = func(*Instance) bool {
+		if sent == 0 {
+			return false
+		}
+		probes++
+		return probes > exitAfter
+	}
+	t.Cleanup(func() { sendGracefulExit, agentExited = origSend, origExited })
+	return &sent
+}
+
+func newStopTestInstance() *Instance {
+	return &Instance{Title: "stop-test", Tool: "claude", Status: StatusRunning,
+		tmuxSession: &tmux.Session{Name: "agentdeck_stop-test_missing"}}
+}
+
+func TestInstanceStop_GracefulExitBeforeTimeout(t *testing.T) {
+	sends := stubGracefulStop(t, nil, 2)
+	inst := newStopTestInstance()
+
+	start := time.Now()
+	if err := inst.Stop(5 * time.Second); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if *sends != 1 {
+		t.Errorf("graceful exit sent %d times, want 1", *sends)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("Stop waited %v although the agent exited", time.Since(start))
+	}
+	if inst.Status != StatusStopped {
+		t.Errorf("Status = %v, want stopped", inst.Status)
+	}
+}
+
+func TestInstanceStop_ForceKillsOnTimeout(t *testing.T) {
+	stubGracefulStop(t, nil, 1<<30)
+	inst := newStopTestInstance()
+
+	start := time.Now()
+	if err := inst.Stop(300 * time.Millisecond); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Errorf("Stop returned after %v, before the timeout", elapsed)
+	}
+	if inst.Status != StatusStopped {
+		t.Errorf("Status = %v, want stopped after force kill", inst.Status)
+	}
+}
+
+func TestInstanceStop_SendFailureFallsBackToKill(t *testing.T) {
+	sends := stubGracefulStop(t, errors.New("no pane"), 0)
+	inst := newStopTestInstance()
+
+	if err := inst.Stop(5 * time.Second); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if *sends != 1 || inst.Status != StatusStopped {
+		t.Errorf("sends=%d status=%v, want 1 and stopped", *sends, inst.Status)
+	}
+}
+
+func TestInstanceStop_ZeroTimeoutSkipsGracefulExit(t *testing.T) {
+	sends := stubGracefulStop(t, nil, 0)
+	inst := newStopTestInstance()
+
+	if err := inst.Stop(0); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if *sends != 0 {
+		t.Errorf("zero timeout should not send exit keys, sent %d", *sends)
+	}
+}
@@ -1028,8 +1028,10 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 	return s.convertToInstances(data)
 }
 
-// SaveRecentSession captures a deleted session's config for quick re-creation.
-func (s *Storage) SaveRecentSession(inst *Instance) error {
+// SaveRecentSession records a session's config in the recent sessions
+// history for quick re-creation; deleted tells whether it is recorded on
+// delete or on create.
+func (s *Storage) SaveRecentSession(inst *Instance, deleted bool) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -1047,12 +1049,14 @@ func (s *Storage) SaveRecentSession(inst *Instance) error {
 		ToolOptions:    inst.ToolOptionsJSON,
 		SandboxEnabled: inst.Sandbox != nil,
 		GeminiYoloMode: inst.GeminiYoloMode,
+		Deleted:        deleted,
 	}
 
 	return s.db.SaveRecentSession(row)
 }
 
-// LoadRecentSessions returns recently deleted session configs for the picker.
+// LoadRecentSessions returns the recent sessions history for the picker,
+// most recently used first.
 func (s *Storage) LoadRecentSessions() ([]*statedb.RecentSessionRow, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -1064,6 +1068,33 @@ func (s *Storage) LoadRecentSessions() ([]*statedb.RecentSessionRow, error) {
 	return s.db.LoadRecentSessions()
 }
 
+// RecentSpecs returns the recent sessions history as specs, most recently
+// used first. Entries differing only in what a spec does not carry (tool
+// options, wrapper, sandbox) or in being recorded on create or delete
+// collapse into one.
+func (s *Storage) RecentSpecs() ([]SessionSpec, error) {
+	rows, err := s.LoadRecentSessions()
+	if err != nil {
+		return nil, err
+	}
+	type specKey struct{ title, path, group, tool, command string }
+	specs := make([]SessionSpec, 0, len(rows))
+	seen := make(map[specKey]bool, len(rows))
+	for _, r := range rows {
+		spec := SessionSpec{Title: r.Title, Path: r.ProjectPath, Group: r.GroupPath, Tool: r.Tool}
+		if r.Command != r.Tool {
+			spec.Command = r.Command
+		}
+		key := specKey{spec.Title, spec.Path, spec.Group, spec.Tool, spec.Command}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
 // GetDBPathForProfile returns the path to the state.db file for a specific profile.
 func GetDBPathForProfile(profile string) (string, error) {
 	if profile == "" {
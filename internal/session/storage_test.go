@@ -551,3 +551,62 @@ func TestSaveSessionData_PreservesGroupSortOrder(t *testing.T) {
 		t.Error("NewGroupTree unexpectedly preserved custom order; test premise is wrong")
 	}
 }
+
+func TestStorage_RecentSpecs(t *testing.T) {
+	s := newTestStorage(t)
+
+	saves := []*Instance{
+		{Title: "api", ProjectPath: "/src/api", GroupPath: "work", Tool: "claude", Command: "claude"},
+		// Same spec, different tool options: one entry.
+		{Title: "api", ProjectPath: "/src/api", GroupPath: "work", Tool: "claude", Command: "claude",
+			ToolOptionsJSON: []byte(`{"tool":"claude","options":{"skip_permissions":true}}`)},
+		{Title: "logs", ProjectPath: "/var/log", Tool: "shell", Command: "tail -f syslog"},
+	}
+	for i, inst := range saves {
+		// Created and deleted records of one spec also collapse.
+		if err := s.SaveRecentSession(inst, i == 1); err != nil {
+			t.Fatalf("SaveRecentSession: %v", err)
+		}
+	}
+
+	specs, err := s.RecentSpecs()
+	if err != nil {
+		t.Fatalf("RecentSpecs: %v", err)
+	}
+	if len(specs) != 2 {
+		t.Fatalf("RecentSpecs = %+v, want 2 de-duplicated specs", specs)
+	}
+	byTitle := map[string]SessionSpec{}
+	for _, spec := range specs {
+		byTitle[spec.Title] = spec
+	}
+	if got := byTitle["api"]; got.Path != "/src/api" || got.Group != "work" || got.Tool != "claude" || got.Command != "" {
+		t.Errorf("api spec = %+v, want the tool's own command left implicit", got)
+	}
+	if got := byTitle["logs"]; got.Tool != "shell" || got.Command != "tail -f syslog" {
+		t.Errorf("logs spec = %+v, want the shell command kept", got)
+	}
+}
+
+func TestStorage_SaveRecentSessionRecordsKind(t *testing.T) {
+	s := newTestStorage(t)
+
+	if err := s.SaveRecentSession(&Instance{Title: "new", ProjectPath: "/src/api", Tool: "claude"}, false); err != nil {
+		t.Fatalf("SaveRecentSession(created): %v", err)
+	}
+	if err := s.SaveRecentSession(&Instance{Title: "gone", ProjectPath: "/src/api", Tool: "claude"}, true); err != nil {
+		t.Fatalf("SaveRecentSession(deleted): %v", err)
+	}
+
+	rows, err := s.LoadRecentSessions()
+	if err != nil {
+		t.Fatalf("LoadRecentSessions: %v", err)
+	}
+	deleted := map[string]bool{}
+	for _, r := range rows {
+		deleted[r.Title] = r.Deleted
+	}
+	if len(deleted) != 2 || deleted["new"] || !deleted["gone"] {
+		t.Errorf("recorded kinds = %v, want new created and gone deleted", deleted)
+	}
+}
@@ -0,0 +1,73 @@
+package session
+
+import (
+	"fmt"
+	"time"
+)
+
+// TokenCounter is implemented by the per-tool analytics types
+// (SessionAnalytics, GeminiSessionAnalytics, AgentSessionAnalytics).
+type TokenCounter interface {
+	TotalTokens() int
+}
+
+// Summary returns a one-line description of the session for logs and the API:
+//
+//	<title> [<tool>] <path> — <status>, <tokens> tok, active <rel>
+//
+// Tokens come from the Gemini, OpenCode, or Codex analytics when the session
+// has them, else 0. The format is stable; scripts may parse it.
+func (i *Instance) Summary() string {
+	return i.SummaryWithAnalytics(i.ToolAnalytics())
+}
+
+// SummaryWithAnalytics is Summary with the token count taken from a, for
+// callers that hold analytics the instance does not (Claude's are parsed on
+// demand). a may be nil.
+func (i *Instance) SummaryWithAnalytics(a TokenCounter) string {
+	return i.summaryAt(a, time.Now())
+}
+
+func (i *Instance) summaryAt(a TokenCounter, now time.Time) string {
+	tool := i.GetToolThreadSafe()
+	if tool == "" {
+		tool = "shell"
+	}
+	tokens := 0
+	if a != nil {
+		tokens = a.TotalTokens()
+	}
+	active := "never"
+	if t := i.LastActivityTime(); !t.IsZero() {
+		active = RelativeTime(t, now)
+	}
+	return fmt.Sprintf("%s [%s] %s — %s, %d tok, active %s",
+		i.Title, tool, i.ProjectPath, i.GetStatusThreadSafe(), tokens, active)
+}
+
+// RelativeTime renders t relative to now: "just now" under a minute (or
+// when t is in the future), else HumanizeDuration plus " ago", e.g. "5m ago",
+// "3d ago". A zero t is the caller's to handle.
+func RelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	if d < time.Minute {
+		return "just now"
+	}
+	return HumanizeDuration(d) + " ago"
+}
+
+// HumanizeDuration formats an elapsed time in its largest whole unit ("45s",
+// "12m", "2h", "3d"), the compact form behind relative times like "2h ago".
+// Negative durations (clock skew) count as zero.
+func HumanizeDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(max(d, 0).Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,75 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInstanceSummary_Format(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	inst := &Instance{
+		Title:       "api",
+		Tool:        "gemini",
+		ProjectPath: "/src/api",
+		Status:      StatusWaiting,
+		CreatedAt:   now.Add(-3 * time.Hour),
+		GeminiAnalytics: &GeminiSessionAnalytics{
+			InputTokens:  1200,
+			OutputTokens: 34,
+			LastActive:   now.Add(-5 * time.Minute),
+		},
+	}
+
+	want := "api [gemini] /src/api — waiting, 1234 tok, active 5m ago"
+	if got := inst.summaryAt(inst.GeminiAnalytics, now); got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+
+	// No tool is a plain shell; no analytics is zero tokens.
+	shell := &Instance{Title: "sh", ProjectPath: "/tmp", Status: StatusIdle, CreatedAt: now.Add(-50 * time.Hour)}
+	want = "sh [shell] /tmp — idle, 0 tok, active 2d ago"
+	if got := shell.summaryAt(nil, now); got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+	shell.CreatedAt = time.Now().Add(-50 * time.Hour)
+	if got := shell.SummaryWithAnalytics(&SessionAnalytics{InputTokens: 7}); got != "sh [shell] /tmp — idle, 7 tok, active 2d ago" {
+		t.Errorf("SummaryWithAnalytics = %q", got)
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(time.Minute), "just now"}, // clock skew
+		{now.Add(-59 * time.Minute), "59m ago"},
+		{now.Add(-2 * time.Hour), "2h ago"},
+		{now.Add(-72 * time.Hour), "3d ago"},
+	}
+	for _, tt := range tests {
+		if got := RelativeTime(tt.at, now); got != tt.want {
+			t.Errorf("RelativeTime(%v) = %q, want %q", now.Sub(tt.at), got, tt.want)
+		}
+	}
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := []struct {
+		input    time.Duration
+		expected string
+	}{
+		{-5 * time.Second, "0s"},
+		{45 * time.Second, "45s"},
+		{12*time.Minute + 59*time.Second, "12m"},
+		{2*time.Hour + 40*time.Minute, "2h"},
+		{75 * time.Hour, "3d"},
+	}
+	for _, tt := range tests {
+		if got := HumanizeDuration(tt.input); got != tt.expected {
+			t.Errorf("HumanizeDuration(%v) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// ErrNotInsideTmux is returned by CurrentTmuxSession when $TMUX is unset.
+var ErrNotInsideTmux = errors.New("not running inside tmux")
+
+// tmuxDisplaySessionName asks the enclosing tmux client for its session name.
+// No -L flag: with $TMUX set, tmux talks to the server that owns our pane.
+// Overridable in tests.
+var tmuxDisplaySessionName = func() ([]byte, error) {
+	return tmux.Exec("", "display-message", "-p", "#S").Output()
+}
+
+// InsideTmux reports whether agent-deck runs inside a tmux pane. Attaching
+// from there nests tmux in tmux unless the client is switched instead.
+func InsideTmux() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+// CurrentTmuxSession returns the name of the tmux session agent-deck runs in,
+// or ErrNotInsideTmux outside tmux.
+func CurrentTmuxSession() (string, error) {
+	if !InsideTmux() {
+		return "", ErrNotInsideTmux
+	}
+	out, err := tmuxDisplaySessionName()
+	if err != nil {
+		return "", fmt.Errorf("query current tmux session: %w", err)
+	}
+	name := strings.TrimSpace(string(out))
+	if name == "" {
+		return "", fmt.Errorf("query current tmux session: empty name")
+	}
+	return name, nil
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"errors"
+	"testing"
+)
+
+func stubTmuxDisplaySessionName(t *testing.T, out string, err error) *int {
+	t.Helper()
+	calls := 0
+	orig := tmuxDisplaySessionName
+	tmuxDisplaySessionName = func() ([]byte, error) {
+		calls++
+		return []byte(out), err
+	}
+	t.Cleanup(func() { tmuxDisplaySessionName = orig })
+	return &calls
+}
+
+func TestInsideTmux(t *testing.T) {
+	t.Setenv("TMUX", "")
+	if InsideTmux() {
+		t.Error("InsideTmux() = true with empty $TMUX")
+	}
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,4242,0")
+	if !InsideTmux() {
+		t.Error("InsideTmux() = false with $TMUX set")
+	}
+}
+
+func TestCurrentTmuxSession_OutsideTmux(t *testing.T) {
+	t.Setenv("TMUX", "")
+	calls := stubTmuxDisplaySessionName(t, "ignored", nil)
+
+	if _, err := CurrentTmuxSession(); !errors.Is(err, ErrNotInsideTmux) {
+		t.Fatalf("err = %v, want ErrNotInsideTmux", err)
+	}
+	if *calls != 0 {
+		t.Errorf("tmux queried %d times outside tmux, want 0", *calls)
+	}
+}
+
+func TestCurrentTmuxSession_InsideTmux(t *testing.T) {
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,4242,0")
+	stubTmuxDisplaySessionName(t, "agentdeck_api_1a2b3c4d\n", nil)
+
+	name, err := CurrentTmuxSession()
+	if err != nil {
+		t.Fatalf("CurrentTmuxSession: %v", err)
+	}
+	if name != "agentdeck_api_1a2b3c4d" {
+		t.Errorf("name = %q, want agentdeck_api_1a2b3c4d", name)
+	}
+}
+
+func TestCurrentTmuxSession_QueryFails(t *testing.T) {
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,4242,0")
+	stubTmuxDisplaySessionName(t, "", errors.New("no server running"))
+
+	if _, err := CurrentTmuxSession(); err == nil {
+		t.Fatal("expected error when tmux query fails")
+	}
+
+	stubTmuxDisplaySessionName(t, "  \n", nil)
+	if _, err := CurrentTmuxSession(); err == nil {
+		t.Fatal("expected error for empty session name")
+	}
+}
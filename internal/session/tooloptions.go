@@ -2,6 +2,9 @@ package session
 
 import (
 	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
 )
 
 // ToolOptions is the interface for tool-specific launch options
@@ -33,10 +36,20 @@ type ClaudeOptions struct {
 	// Uses a classifier model to auto-approve safe operations while blocking risky ones.
 	// Only used when SkipPermissions is false (SkipPermissions takes precedence).
 	AutoMode bool `json:"auto_mode,omitempty"`
+	// PermissionMode adds --permission-mode <mode> (one of ClaudePermissionModes).
+	// Empty or "default" emits no flag. Ignored when SkipPermissions or AutoMode is set.
+	PermissionMode string `json:"permission_mode,omitempty"`
 	// UseChrome adds --chrome flag
 	UseChrome bool `json:"use_chrome,omitempty"`
 	// UseTeammateMode adds --teammate-mode tmux flag
 	UseTeammateMode bool `json:"use_teammate_mode,omitempty"`
+	// MCPConfig is a path to an MCP server config file, passed via --mcp-config
+	MCPConfig string `json:"mcp_config,omitempty"`
+	// AppendSystemPrompt is appended to Claude's system prompt via --append-system-prompt
+	AppendSystemPrompt string `json:"append_system_prompt,omitempty"`
+	// AllowedTools restricts the tools Claude may use (--allowedTools).
+	// Empty means no flag, so Claude uses its defaults.
+	AllowedTools []string `json:"allowed_tools,omitempty"`
 
 	// Transient fields for worktree fork (not persisted)
 	WorkDir          string `json:"-"`
@@ -70,23 +83,50 @@ func (o *ClaudeOptions) ToArgs() []string {
 	}
 
 	// Permission flags (mutually exclusive, SkipPermissions takes precedence)
-	if o.SkipPermissions {
-		args = append(args, "--dangerously-skip-permissions")
-	} else if o.AutoMode {
-		args = append(args, "--permission-mode", "auto")
-	} else if o.AllowSkipPermissions {
-		args = append(args, "--allow-dangerously-skip-permissions")
-	}
+	args = append(args, o.permissionArgs()...)
 	if o.UseChrome {
 		args = append(args, "--chrome")
 	}
 	if o.UseTeammateMode {
 		args = append(args, "--teammate-mode", "tmux")
 	}
+	if o.MCPConfig != "" {
+		args = append(args, "--mcp-config", ExpandPath(o.MCPConfig))
+	}
+	if o.AppendSystemPrompt != "" {
+		args = append(args, "--append-system-prompt", o.AppendSystemPrompt)
+	}
+	if len(o.AllowedTools) > 0 {
+		args = append(args, "--allowedTools", strings.Join(o.AllowedTools, ","))
+	}
 
 	return args
 }
 
+// ClaudePermissionModes lists the values accepted by claude --permission-mode,
+// in the order the options panel cycles through them. "auto" is handled by
+// AutoMode and is intentionally absent.
+var ClaudePermissionModes = []string{"default", "acceptEdits", "plan", "bypassPermissions"}
+
+// permissionArgs returns the permission flags. SkipPermissions wins over
+// AutoMode, which wins over an explicit PermissionMode.
+func (o *ClaudeOptions) permissionArgs() []string {
+	if o.SkipPermissions {
+		return []string{"--dangerously-skip-permissions"}
+	}
+	if o.AutoMode {
+		return []string{"--permission-mode", "auto"}
+	}
+	var args []string
+	if o.PermissionMode != "" && o.PermissionMode != "default" {
+		args = append(args, "--permission-mode", o.PermissionMode)
+	}
+	if o.AllowSkipPermissions {
+		args = append(args, "--allow-dangerously-skip-permissions")
+	}
+	return args
+}
+
 // ToArgsForFork returns arguments suitable for fork resume command
 // Fork always uses --resume internally, so session mode flags are not included
 func (o *ClaudeOptions) ToArgsForFork() []string {
@@ -95,19 +135,22 @@ func (o *ClaudeOptions) ToArgsForFork() []string {
 	if o.Model != "" {
 		args = append(args, "--model", o.Model)
 	}
-	if o.SkipPermissions {
-		args = append(args, "--dangerously-skip-permissions")
-	} else if o.AutoMode {
-		args = append(args, "--permission-mode", "auto")
-	} else if o.AllowSkipPermissions {
-		args = append(args, "--allow-dangerously-skip-permissions")
-	}
+	args = append(args, o.permissionArgs()...)
 	if o.UseChrome {
 		args = append(args, "--chrome")
 	}
 	if o.UseTeammateMode {
 		args = append(args, "--teammate-mode", "tmux")
 	}
+	if o.MCPConfig != "" {
+		args = append(args, "--mcp-config", ExpandPath(o.MCPConfig))
+	}
+	if o.AppendSystemPrompt != "" {
+		args = append(args, "--append-system-prompt", o.AppendSystemPrompt)
+	}
+	if len(o.AllowedTools) > 0 {
+		args = append(args, "--allowedTools", strings.Join(o.AllowedTools, ","))
+	}
 
 	return args
 }
@@ -121,8 +164,11 @@ func NewClaudeOptions(config *UserConfig) *ClaudeOptions {
 		opts.SkipPermissions = config.Claude.GetDangerousMode()
 		opts.AutoMode = config.Claude.AutoMode
 		opts.AllowSkipPermissions = config.Claude.AllowDangerousMode
+		opts.PermissionMode = config.Claude.PermissionMode
 		opts.UseChrome = config.Claude.UseChrome
 		opts.UseTeammateMode = config.Claude.UseTeammateMode
+		opts.MCPConfig = config.Claude.MCPConfig
+		opts.AppendSystemPrompt = config.Claude.AppendSystemPrompt
 		// Apply [claude].default_model so sessions spawned without per-session
 		// options (CLI / programmatic / resume) honor the configured model. This
 		// matches OpenCode/Copilot, which already wire their default_model here;
@@ -133,6 +179,25 @@ func NewClaudeOptions(config *UserConfig) *ClaudeOptions {
 	return opts
 }
 
+// ValidateMCPConfigFile checks that path points to a readable file containing
+// valid JSON, as required by claude --mcp-config. An empty path is valid.
+func ValidateMCPConfigFile(path string) error {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(ExpandPath(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("MCP config not found: %s", path)
+		}
+		return fmt.Errorf("MCP config unreadable: %w", err)
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("MCP config is not valid JSON: %s", path)
+	}
+	return nil
+}
+
 // CodexOptions holds launch options for Codex CLI sessions
 type CodexOptions struct {
 	// Model overrides the Codex model for this session (for example, "gpt-5").
@@ -2,6 +2,8 @@ package session
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -130,6 +132,49 @@ func TestClaudeOptions_ToArgs(t *testing.T) {
 			},
 			expected: []string{"--permission-mode", "auto"},
 		},
+		{
+			name: "plan permission mode",
+			opts: ClaudeOptions{
+				PermissionMode: "plan",
+			},
+			expected: []string{"--permission-mode", "plan"},
+		},
+		{
+			name: "default permission mode emits no flag",
+			opts: ClaudeOptions{
+				PermissionMode: "default",
+			},
+			expected: nil,
+		},
+		{
+			name: "skip permissions overrides permission mode",
+			opts: ClaudeOptions{
+				SkipPermissions: true,
+				PermissionMode:  "acceptEdits",
+			},
+			expected: []string{"--dangerously-skip-permissions"},
+		},
+		{
+			name: "append system prompt",
+			opts: ClaudeOptions{
+				AppendSystemPrompt: "be terse\nuse tabs",
+			},
+			expected: []string{"--append-system-prompt", "be terse\nuse tabs"},
+		},
+		{
+			name: "allowed tools",
+			opts: ClaudeOptions{
+				AllowedTools: []string{"Bash", "Read"},
+			},
+			expected: []string{"--allowedTools", "Bash,Read"},
+		},
+		{
+			name: "mcp config",
+			opts: ClaudeOptions{
+				MCPConfig: "/tmp/mcp.json",
+			},
+			expected: []string{"--mcp-config", "/tmp/mcp.json"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -624,6 +669,31 @@ func TestClaudeOptions_RoundTrip_AllowSkipPermissions(t *testing.T) {
 	}
 }
 
+func TestValidateMCPConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "mcp.json")
+	if err := os.WriteFile(valid, []byte(`{"mcpServers":{}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(invalid, []byte(`{"mcpServers":`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ValidateMCPConfigFile(""); err != nil {
+		t.Errorf("empty path should be valid, got %v", err)
+	}
+	if err := ValidateMCPConfigFile(valid); err != nil {
+		t.Errorf("valid file rejected: %v", err)
+	}
+	if err := ValidateMCPConfigFile(invalid); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	if err := ValidateMCPConfigFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
 // === OpenCode Options Tests ===
 
 func TestOpenCodeOptions_ToolName(t *testing.T) {
@@ -239,6 +239,29 @@ func (r *Registry) Match(cmd string) string {
 	return "shell"
 }
 
+// Flags returns the known command-line flags for the tool a shell command
+// line starts with, for completion in the custom command field. The first
+// word after any leading VAR=value assignments must name a built-in (a path
+// like /usr/local/bin/claude counts) or a custom tool compatible_with one.
+// Anything else returns nil.
+func (r *Registry) Flags(cmdline string) []string {
+	fields := strings.Fields(cmdline)
+	for len(fields) > 0 && strings.Contains(fields[0], "=") {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	name := filepath.Base(fields[0])
+	if def, ok := r.custom[name]; ok {
+		name = strings.ToLower(strings.TrimSpace(def.CompatibleWith))
+	}
+	if bt, ok := r.builtins[strings.ToLower(name)]; ok {
+		return bt.flags
+	}
+	return nil
+}
+
 // CustomNames returns the sorted names of user-defined tools (built-in shadows
 // already excluded at Init). Returns nil when there are no custom tools, exactly
 // like the legacy GetCustomToolNames(). Replaces GetCustomToolNames()'s body.
@@ -388,6 +411,12 @@ func MatchTool(cmd string) string {
 	return currentRegistry().Match(cmd)
 }
 
+// ToolFlags returns the known flags for the tool a command line starts with,
+// using the process registry. See Registry.Flags.
+func ToolFlags(cmdline string) []string {
+	return currentRegistry().Flags(cmdline)
+}
+
 // --- process-wide show_only_installed_tools accessors (issue #1259) ----------
 //
 // These back the new-session dialog call sites. They read the cached registry,
@@ -2,6 +2,7 @@ package session
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -116,6 +117,27 @@ func TestRegistry_CustomTool(t *testing.T) {
 	}
 }
 
+func TestRegistry_Flags(t *testing.T) {
+	r := Init(map[string]ToolDef{
+		"my-claude": {Command: "my-wrapper", CompatibleWith: "claude"},
+		"my-tool":   {Command: "my-tool-bin"},
+	})
+	claude := r.Flags("claude")
+	if !slices.Contains(claude, "--model") {
+		t.Fatalf("Flags(claude) = %v, want --model among them", claude)
+	}
+	for _, cmd := range []string{"claude --mo", "/usr/local/bin/claude -", "FOO=1 claude --", "my-claude --"} {
+		if got := r.Flags(cmd); !reflect.DeepEqual(got, claude) {
+			t.Errorf("Flags(%q) = %v, want claude's flags", cmd, got)
+		}
+	}
+	for _, cmd := range []string{"", "FOO=1", "vim --", "my-tool --", "echo claude --"} {
+		if got := r.Flags(cmd); got != nil {
+			t.Errorf("Flags(%q) = %v, want nil", cmd, got)
+		}
+	}
+}
+
 // TestRegistry_PrecedenceRejectsShadow pins down precedence rule (a) from
 // issue #1258: a [tools.<builtin>] entry is rejected and the built-in wins.
 func TestRegistry_PrecedenceRejectsShadow(t *testing.T) {
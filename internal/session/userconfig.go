@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -50,6 +51,10 @@ var ErrRefusingConfigSectionDrop = fmt.Errorf("session: refusing to save config.
 // this, SaveUserConfig bloats the file with sections the user never configured.
 // TestSaveUserConfig_ZeroValueConfigProducesNoSections enforces this invariant.
 type UserConfig struct {
+	// Version is the schema version the file was written with (config_version).
+	// Older files are upgraded by MigrateUserConfig on load; absent means 0.
+	Version int `toml:"config_version,omitzero"`
+
 	// DefaultTool is the pre-selected AI tool when creating new sessions
 	// Valid values: "claude", "gemini", "opencode", "codex", "pi", or any custom tool name
 	// If empty or invalid, defaults to "shell" (no pre-selection)
@@ -59,6 +64,22 @@ type UserConfig struct {
 	// when no explicit path or group default_path is provided.
 	DefaultPath string `toml:"default_path,omitempty"`
 
+	// AllowMissingPaths lists path prefixes (~ and $VARS expanded) under which
+	// a session's working directory may not exist yet — a lazily mounted
+	// network share, or a directory the launch command creates. New sessions
+	// there get a warning instead of an error or a "create directory?"
+	// prompt. "*" allows any path.
+	AllowMissingPaths []string `toml:"allow_missing_paths,omitempty"`
+
+	// RelativePathBase is the directory a relative path typed in the
+	// new-session dialog (e.g. "../sibling") resolves against. Empty uses the
+	// directory agent-deck was started from.
+	RelativePathBase string `toml:"relative_path_base,omitempty"`
+
+	// PathBookmarks are directories listed first in the new-session dialog's
+	// path dropdown, above the recently used paths (~ and $VARS expanded).
+	PathBookmarks []string `toml:"path_bookmarks,omitempty"`
+
 	// Hotkeys overrides default keyboard shortcuts in the TUI.
 	// Keys are action names, values are key bindings (e.g., "delete" = "backspace").
 	// Set an action to "" to explicitly unbind it.
@@ -230,6 +251,12 @@ type UserConfig struct {
 	// Web defines `agent-deck web` HTTP server settings.
 	Web WebSettings `toml:"web,omitempty"`
 
+	// API defines the local control API served by `agent-deck api`.
+	API APISettings `toml:"api,omitempty"`
+
+	// Events defines the session lifecycle webhook.
+	Events EventsSettings `toml:"events,omitempty"`
+
 	// UI defines TUI layout settings (split ratios, etc).
 	UI UISettings `toml:"ui,omitempty"`
 
@@ -568,6 +595,50 @@ type WebSettings struct {
 	MutationsEnabled *bool `toml:"mutations_enabled,omitempty"`
 }
 
+// APISettings configures the local control API (internal/api), a JSON HTTP
+// API on a unix socket for scripting agent-deck without the TUI. Access
+// control is the socket's file mode (0600, owner only).
+type APISettings struct {
+	// Enabled must be true for `agent-deck api` to serve. Default: false.
+	Enabled bool `toml:"enabled,omitempty"`
+
+	// SocketPath overrides the unix socket location. Supports ~ and env vars.
+	// Default: <agent-deck data dir>/api-<profile>.sock
+	SocketPath string `toml:"socket_path,omitempty"`
+}
+
+// GetSocketPath returns the socket path for profile, expanding SocketPath or
+// falling back to the per-profile default in the agent-deck data directory.
+func (a APISettings) GetSocketPath(profile string) (string, error) {
+	if p := strings.TrimSpace(a.SocketPath); p != "" {
+		return ExpandPath(p), nil
+	}
+	dir, err := GetAgentDeckDir()
+	if err != nil {
+		return "", err
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	return filepath.Join(dir, "api-"+profile+".sock"), nil
+}
+
+// EventsSettings configures the session lifecycle webhook. When WebhookURL
+// is set, the TUI and `agent-deck api` POST each SessionEvent to it as JSON.
+type EventsSettings struct {
+	// WebhookURL receives events. Empty disables the webhook.
+	WebhookURL string `toml:"webhook_url,omitempty"`
+
+	// WebhookEvents limits which event types are sent ("created", "killed",
+	// "crashed"). Empty sends all of them.
+	WebhookEvents []string `toml:"webhook_events,omitempty"`
+}
+
+// WantsEvent reports whether events of type t should be sent to the webhook.
+func (e EventsSettings) WantsEvent(t SessionEventType) bool {
+	return len(e.WebhookEvents) == 0 || slices.Contains(e.WebhookEvents, string(t))
+}
+
 // FeedbackSettings controls the in-product feedback prompts.
 // When Disabled is true, neither the auto-prompt (TUI) nor the post-launch
 // auto-trigger (CLI, if any) will fire. Explicit `agent-deck feedback`
@@ -956,6 +1027,15 @@ type PreviewSettings struct {
 	// in the preview pane when output is visible.
 	// Range: 0.1 - 0.9 (fraction reserved for notes). Default: 0.33
 	NotesOutputSplit float64 `toml:"notes_output_split,omitzero"`
+
+	// AnalyticsRefreshSeconds controls how often Gemini analytics are re-read
+	// from the session file, both by the status poll and by the analytics
+	// panel. Range: 1 - 30. Default: 5
+	// Polling an unchanged file costs one stat (the mtime check skips the
+	// parse), so short intervals are cheap while a session is idle. When the
+	// file does change, the full re-parse dominates: raise this for sessions
+	// whose files grow to tens of MB.
+	AnalyticsRefreshSeconds int `toml:"analytics_refresh_seconds,omitzero"`
 }
 
 // AnalyticsDisplaySettings configures which analytics sections to display
@@ -1072,6 +1152,12 @@ type ShellSettings struct {
 	// (e.g., 'eval "$(direnv hook bash)"')
 	InitScript string `toml:"init_script,omitempty"`
 
+	// ShellInit runs before the interactive shell of a plain shell session
+	// (shell selected, no command), e.g. "source .venv/bin/activate". Unlike a
+	// custom command the shell stays open afterwards. Overridden by the
+	// project's .agentdeck.json shell_init.
+	ShellInit string `toml:"shell_init,omitempty"`
+
 	// IgnoreMissingEnvFiles silently ignores missing .env files (default: true)
 	// When false, sessions will error if an env_file doesn't exist
 	IgnoreMissingEnvFiles *bool `toml:"ignore_missing_env_files,omitempty"`
@@ -1093,6 +1179,12 @@ type ShellSettings struct {
 	// environment.
 	// Default: false (opt-in). Issue #1218.
 	LaunchShell *bool `toml:"launch_shell,omitempty"`
+
+	// CommandCheck sets how the new session dialog treats a custom command
+	// that CheckShellCommand flags (rm -rf /, curl | sh, backticks, ...):
+	// "warn" (default) asks for a second submit, "block" refuses it, "off"
+	// skips the check.
+	CommandCheck string `toml:"command_check,omitempty"`
 }
 
 // GetIgnoreMissingEnvFiles returns whether to ignore missing env files, defaulting to true
@@ -1122,6 +1214,17 @@ func (s *ShellSettings) GetLaunchShell() bool {
 	return *s.LaunchShell
 }
 
+// GetCommandCheck returns the custom command check strictness, defaulting
+// to CommandCheckWarn for an empty or unknown value.
+func (s *ShellSettings) GetCommandCheck() string {
+	switch mode := strings.ToLower(strings.TrimSpace(s.CommandCheck)); mode {
+	case CommandCheckBlock, CommandCheckOff:
+		return mode
+	default:
+		return CommandCheckWarn
+	}
+}
+
 // GetShowAnalytics returns whether to show analytics, defaulting to false
 func (p *PreviewSettings) GetShowAnalytics() bool {
 	if p.ShowAnalytics == nil {
@@ -1165,6 +1268,18 @@ func (p *PreviewSettings) GetNotesOutputSplit() float64 {
 	return p.NotesOutputSplit
 }
 
+// GetAnalyticsRefreshInterval returns the analytics refresh interval, clamped
+// to 1s - 30s.
+func (p *PreviewSettings) GetAnalyticsRefreshInterval() time.Duration {
+	switch {
+	case p.AnalyticsRefreshSeconds <= 0:
+		return 5 * time.Second
+	case p.AnalyticsRefreshSeconds > 30:
+		return 30 * time.Second
+	}
+	return time.Duration(p.AnalyticsRefreshSeconds) * time.Second
+}
+
 // GetShowContextBar returns whether to show context bar, defaulting to true
 func (a *AnalyticsDisplaySettings) GetShowContextBar() bool {
 	if a.ShowContextBar == nil {
@@ -1215,6 +1330,11 @@ func (c *UserConfig) GetShowAnalytics() bool {
 	return c.Preview.GetShowAnalytics()
 }
 
+// GetAnalyticsRefreshInterval returns how often analytics are re-read from disk
+func (c *UserConfig) GetAnalyticsRefreshInterval() time.Duration {
+	return c.Preview.GetAnalyticsRefreshInterval()
+}
+
 // GetShowNotes returns whether to show notes section, defaulting to false
 func (c *UserConfig) GetShowNotes() bool {
 	return c.Preview.GetShowNotes()
@@ -1268,6 +1388,12 @@ type ClaudeSettings struct {
 	// Default: false
 	AutoMode bool `toml:"auto_mode,omitempty"`
 
+	// PermissionMode is the default --permission-mode for Claude sessions:
+	// "default", "acceptEdits", "plan", or "bypassPermissions".
+	// Ignored when dangerous_mode or auto_mode is true.
+	// Default: "" (no flag)
+	PermissionMode string `toml:"permission_mode,omitempty"`
+
 	// ExtraArgs are user-supplied Claude CLI flags used as the New Session
 	// dialog default. They are persisted as discrete TOML array entries and
 	// copied to Instance.ExtraArgs when a Claude session is created.
@@ -1285,6 +1411,14 @@ type ClaudeSettings struct {
 	// UseTeammateMode enables --teammate-mode tmux by default for Claude sessions.
 	UseTeammateMode bool `toml:"use_teammate_mode,omitempty"`
 
+	// MCPConfig is the default MCP server config file passed via --mcp-config.
+	// Path can be absolute, ~ for home, or $HOME/${VAR} for env vars.
+	MCPConfig string `toml:"mcp_config,omitempty"`
+
+	// AppendSystemPrompt pre-fills the New Session dialog's system prompt
+	// field; passed to Claude via --append-system-prompt. May span lines.
+	AppendSystemPrompt string `toml:"append_system_prompt,omitempty"`
+
 	// EnvFile is a .env file specific to Claude sessions
 	// Sourced AFTER global [shell].env_files
 	// Path can be absolute, ~ for home, $HOME/${VAR} for env vars, or relative to session working directory
@@ -1311,6 +1445,56 @@ type ClaudeSettings struct {
 	// (issue #1264). Off by default — only enable for sessions running Claude
 	// Code with vim editor mode. Other tools and non-vim Claude are unaffected.
 	VimMode bool `toml:"vim_mode,omitempty"`
+
+	// Presets adds or overrides the named option presets the New Session
+	// dialog cycles through with "p" ([claude.presets.<name>]). The built-in
+	// "safe", "fast" and "yolo" presets are always available unless redefined.
+	Presets map[string]ClaudePreset `toml:"presets,omitempty"`
+}
+
+// ClaudePreset is a coherent combination of the Claude permission options,
+// applied to the New Session dialog in one step.
+type ClaudePreset struct {
+	PermissionMode  string `toml:"permission_mode,omitempty"`
+	SkipPermissions bool   `toml:"skip_permissions,omitempty"`
+	AutoMode        bool   `toml:"auto_mode,omitempty"`
+	// AllowedTools selects the --allowedTools list; empty means Claude's
+	// defaults.
+	AllowedTools []string `toml:"allowed_tools,omitempty"`
+}
+
+// defaultClaudePresets are the built-in presets, in cycle order.
+var defaultClaudePresets = []struct {
+	name   string
+	preset ClaudePreset
+}{
+	{"safe", ClaudePreset{PermissionMode: "default"}},
+	{"fast", ClaudePreset{PermissionMode: "acceptEdits"}},
+	{"yolo", ClaudePreset{SkipPermissions: true}},
+}
+
+// GetPresets returns the built-in presets overlaid with the configured ones,
+// plus their names in cycle order: built-ins first, then the user's own
+// presets alphabetically.
+func (c *ClaudeSettings) GetPresets() (map[string]ClaudePreset, []string) {
+	presets := make(map[string]ClaudePreset, len(defaultClaudePresets))
+	names := make([]string, 0, len(defaultClaudePresets))
+	for _, p := range defaultClaudePresets {
+		presets[p.name] = p.preset
+		names = append(names, p.name)
+	}
+	if c == nil {
+		return presets, names
+	}
+	var custom []string
+	for name, preset := range c.Presets {
+		if _, builtin := presets[name]; !builtin {
+			custom = append(custom, name)
+		}
+		presets[name] = preset
+	}
+	sort.Strings(custom)
+	return presets, append(names, custom...)
 }
 
 // GetVimMode reports whether vim-mode insert-guard sends are enabled. Off by
@@ -1652,6 +1836,15 @@ type GeminiSettings struct {
 	// Command overrides the default binary/invocation for Gemini sessions.
 	// Supports flags (e.g., "gemini --custom-flag"). Default: "gemini"
 	Command string `toml:"command,omitempty"`
+
+	// Favorites lists models pinned to the top of the model picker.
+	// Toggled with "f" in the picker; unknown models are ignored.
+	Favorites []string `toml:"favorites,omitempty"`
+
+	// APIKeys are Google API keys tried in order after GOOGLE_API_KEY when
+	// the model list request is rejected or hits its quota. Entries may
+	// reference env vars ("$GEMINI_KEY_PROJECT_B") to keep keys out of the file.
+	APIKeys []string `toml:"api_keys,omitempty"`
 }
 
 // OpenCodeSettings defines OpenCode CLI configuration
@@ -1788,9 +1981,10 @@ type WorktreeSettings struct {
 	// or a custom path (e.g., "~/worktrees") creating <path>/<repo_name>/<branch>
 	DefaultLocation string `toml:"default_location,omitempty"`
 
-	// PathTemplate: custom path template for worktree location.
+	// PathTemplate: custom path template for worktree location, e.g.
+	// "~/worktrees/{repo}/{branch}".
 	// Variables:
-	//   {repo-name}, {repo-root}, {session-id}
+	//   {repo-name} (alias {repo}), {repo-root}, {session-id}
 	//   {branch}         -> sanitized (human-friendly, may collide)
 	//   {branch-escaped} -> URL-escaped (collision-resistant, reversible)
 	// Unknown variables like {foo} are left as-is in the path.
@@ -1974,6 +2168,12 @@ type ToolDef struct {
 
 	// SpinnerCharsExtra appends additional spinner characters to the built-in defaults
 	SpinnerCharsExtra []string `toml:"spinner_chars_extra,omitempty"`
+
+	// Layout splits the window right after the session starts, e.g. the
+	// agent on top and a dev server below. Unset keeps a single pane.
+	// Custom tools only ([tools.<builtin>] entries are dropped as shadows).
+	// A project's .agentdeck.json layout takes precedence.
+	Layout *LayoutSpec `toml:"layout,omitempty"`
 }
 
 // HTTPServerConfig defines how to auto-start an HTTP MCP server
@@ -2220,6 +2420,12 @@ type TmuxSettings struct {
 	// Precedence at Instance creation: CLI flag `--tmux-socket <name>`
 	// wins, else this config value, else empty.
 	SocketName string `toml:"socket_name,omitempty"`
+
+	// SocketPath selects the tmux server by socket path (`tmux -S <path>`)
+	// instead of by name, for servers whose socket lives outside
+	// $TMUX_TMPDIR. Takes precedence over SocketName; ~ and $VARS are
+	// expanded. Captured per session exactly like SocketName.
+	SocketPath string `toml:"socket_path,omitempty"`
 }
 
 // GetInjectStatusLine returns whether to inject status line, defaulting to true.
@@ -2230,11 +2436,16 @@ func (t TmuxSettings) GetInjectStatusLine() bool {
 	return *t.InjectStatusLine
 }
 
-// GetSocketName returns the trimmed `[tmux].socket_name` value, or "" when
-// unset, whitespace-only, or absent. Centralising the trim here means
+// GetSocketName returns the tmux server selector: the expanded
+// `[tmux].socket_path` when set (passed to tmux as -S), else the trimmed
+// `[tmux].socket_name` value (-L), or "" when both are unset, whitespace-only,
+// or absent. Centralising the trim here means
 // every caller — tmux.SetDefaultSocketName at startup, CLI flag merging,
 // Instance creation — sees the same sanitised value.
 func (t TmuxSettings) GetSocketName() string {
+	if p := strings.TrimSpace(t.SocketPath); p != "" {
+		return ExpandPath(p)
+	}
 	return strings.TrimSpace(t.SocketName)
 }
 
@@ -2761,8 +2972,13 @@ func LoadUserConfig() (*UserConfig, error) {
 		return userConfigCache, nil
 	}
 
-	var config UserConfig
-	if _, err := toml.DecodeFile(configPath, &config); err != nil {
+	raw, err := os.ReadFile(configPath)
+	var migrated *UserConfig
+	var changed bool
+	if err == nil {
+		migrated, changed, err = migrateUserConfig(raw)
+	}
+	if err != nil {
 		// Cache default to prevent hot-looping on a broken file, and cache
 		// the error too so every call (not just the first after the mtime
 		// change) can surface that the on-disk config is being ignored.
@@ -2774,6 +2990,19 @@ func LoadUserConfig() (*UserConfig, error) {
 		return userConfigCache, userConfigCacheErr
 	}
 
+	config := *migrated
+
+	// Persist upgraded layouts so the legacy keys are gone for good. The
+	// pre-migration file survives as config.toml.bak. A failed rewrite is not
+	// fatal: the migrated values are still used for this process.
+	if changed {
+		if err := writeUserConfig(configPath, &config, false); err != nil {
+			registryLog.Warn("failed to rewrite migrated config.toml", "path", configPath, "error", err)
+		} else if st, err := os.Stat(configPath); err == nil {
+			currentMtime = st.ModTime()
+		}
+	}
+
 	if config.Tools == nil {
 		config.Tools = make(map[string]ToolDef)
 	}
@@ -2831,6 +3060,27 @@ func SaveUserConfigWithIntent(config *UserConfig, allowSectionDrop bool) error {
 	if err != nil {
 		return fmt.Errorf("failed to get config path: %w", err)
 	}
+	if err := writeUserConfig(configPath, config, allowSectionDrop); err != nil {
+		return err
+	}
+
+	// Clear cache so next load picks up changes
+	ClearUserConfigCache()
+
+	return nil
+}
+
+// writeUserConfig is the guarded, atomic write behind SaveUserConfigWithIntent.
+// It does not touch the config cache, so LoadUserConfig can call it while
+// holding userConfigCacheMu.
+func writeUserConfig(configPath string, config *UserConfig, allowSectionDrop bool) error {
+	// Stamp the schema version (on a copy, the caller's struct is left alone)
+	// so future loads skip migrations that have already been applied.
+	if config.Version < CurrentUserConfigVersion {
+		stamped := *config
+		stamped.Version = CurrentUserConfigVersion
+		config = &stamped
+	}
 
 	// Ensure directory exists
 	dir := filepath.Dir(configPath)
@@ -2899,9 +3149,6 @@ func SaveUserConfigWithIntent(config *UserConfig, allowSectionDrop bool) error {
 		return fmt.Errorf("failed to finalize config save: %w", err)
 	}
 
-	// Clear cache so next load picks up changes
-	ClearUserConfigCache()
-
 	return nil
 }
 
@@ -3311,6 +3558,52 @@ func MergeToolPatterns(toolName string) *tmux.RawPatterns {
 	return tmux.MergeRawPatterns(defaults, overrides, extras)
 }
 
+// AllowsMissingPath reports whether path may be used as a session working
+// directory before it exists, per allow_missing_paths.
+func (c *UserConfig) AllowsMissingPath(path string) bool {
+	path = filepath.Clean(ExpandPath(path))
+	for _, entry := range c.AllowMissingPaths {
+		if entry == "*" {
+			return true
+		}
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		prefix := filepath.Clean(ExpandPath(entry))
+		if path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPathBookmarks returns path_bookmarks expanded and cleaned, without
+// blanks, relative entries, or duplicates, in configured order.
+func (c *UserConfig) GetPathBookmarks() []string {
+	var bookmarks []string
+	for _, entry := range c.PathBookmarks {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		path := filepath.Clean(ExpandPath(entry))
+		if !filepath.IsAbs(path) || slices.Contains(bookmarks, path) {
+			continue
+		}
+		bookmarks = append(bookmarks, path)
+	}
+	return bookmarks
+}
+
+// MissingPathAllowed is AllowsMissingPath on the loaded user config; false
+// when the config cannot be loaded.
+func MissingPathAllowed(path string) bool {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return false
+	}
+	return config.AllowsMissingPath(path)
+}
+
 // GetDefaultTool returns the user's preferred default tool for new sessions
 // Returns empty string if not configured (defaults to shell)
 func GetDefaultTool() string {
@@ -3778,6 +4071,18 @@ func CreateExampleConfig() error {
 # Leave commented out or empty to default to shell (no pre-selection)
 # default_tool = "claude"
 
+# Paths that may not exist when a session is created (e.g. lazily mounted
+# network shares). Under these prefixes a missing directory is a warning
+# instead of an error. "*" allows any path.
+# allow_missing_paths = ["/mnt/nfs", "~/remote"]
+
+# Directory that relative paths typed in the new-session dialog resolve
+# against. Defaults to the directory agent-deck was started from.
+# relative_path_base = "~/code"
+
+# Directories always offered first in the new-session dialog's path dropdown.
+# path_bookmarks = ["~/code", "~/work"]
+
 # Hotkey overrides (optional)
 # Action names are defined by agent-deck. Value is the key string.
 # Set value to "" to unbind an action.
@@ -3813,6 +4118,8 @@ func CreateExampleConfig() error {
 # [preview]
 # show_notes = false
 # notes_output_split = 0.33
+# analytics_refresh_seconds = 5   # 1-30. Unchanged files cost one stat per
+#                                 # refresh; raise it for huge Gemini sessions
 
 # Claude Code integration
 # [claude]
@@ -3824,6 +4131,8 @@ func CreateExampleConfig() error {
 # config_dir = "~/.claude-work"
 # Enable --dangerously-skip-permissions by default (default: false)
 # dangerous_mode = true
+# Default --permission-mode: default, acceptEdits, plan, bypassPermissions
+# permission_mode = "plan"
 # Extra Claude CLI flags remembered from the New Session dialog
 # extra_args = ["--agent", "reviewer"]
 # Default model preselected for new sessions (must be a known catalog model)
@@ -3831,11 +4140,17 @@ func CreateExampleConfig() error {
 # Enable Chrome / teammate mode by default
 # use_chrome = false
 # use_teammate_mode = false
+# Default MCP server config file (passed via --mcp-config)
+# mcp_config = "~/.config/mcp/servers.json"
+# Default text appended to Claude's system prompt (--append-system-prompt)
+# append_system_prompt = "Prefer small, reviewable commits."
 
 # Gemini CLI integration
 # [gemini]
 # Enable --yolo (auto-approve all actions) by default (default: false)
 # yolo_mode = true
+# Models pinned to the top of the model picker (toggle with "f")
+# favorites = ["gemini-2.5-pro", "gemini-2.5-flash"]
 
 # OpenCode CLI integration
 # [opencode]
@@ -3957,6 +4272,26 @@ auto_cleanup = true
 # options = { "allow-passthrough" = "all", "history-limit" = "50000" }
 # Example: keep agent-deck notifications but use a 2-line status bar
 # options = { "status" = "2" }
+# socket_name runs agent-deck sessions on a dedicated tmux server (tmux -L).
+# socket_name = "agent-deck"
+# socket_path selects the server by socket path instead (tmux -S); wins over
+# socket_name when both are set.
+# socket_path = "~/.local/state/agent-deck/tmux.sock"
+
+# Local control API for scripts (agent-deck api): list, create and delete
+# sessions over a unix socket readable only by you.
+# [api]
+# enabled = true
+# Default: <data dir>/api-<profile>.sock
+# socket_path = "~/.local/state/agent-deck/api.sock"
+
+# Session lifecycle webhook: POST a JSON event (type, instance_id, title,
+# tool, group, status, time) whenever a session is created, killed, or
+# crashes. Delivery is best effort and never blocks the UI.
+# [events]
+# webhook_url = "http://localhost:9000/agent-deck"
+# Default: all event types
+# webhook_events = ["created", "crashed"]
 
 # Outer-terminal chrome (sequences agent-deck writes to the host terminal,
 # bypassing tmux). Currently controls the iTerm2 badge; future window-title
@@ -4094,6 +4429,17 @@ auto_cleanup = true
 # compatible_with = "codex"
 # icon = "C"
 
+# Example: Split the window when a session starts (agent on top, dev server
+# below). split = "vertical" stacks panes, "horizontal" puts them side by side.
+# size is the new pane's share in percent; layout is an optional tmux preset.
+# Custom tools only: for a built-in, define a wrapper with
+# compatible_with = "claude" and give it the layout. A trusted project's
+# .agentdeck.json "layout" wins.
+# [tools.my-ai.layout]
+# split = "vertical"
+# command = "npm run dev"
+# size = 30
+
 # ============================================================================
 # Status Detection Pattern Overrides (Advanced)
 # ============================================================================
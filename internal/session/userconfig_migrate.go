@@ -0,0 +1,88 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// CurrentUserConfigVersion is the config_version written by this build.
+// Bump it together with a new entry in userConfigMigrations.
+const CurrentUserConfigVersion = 1
+
+// userConfigMigration upgrades a decoded config.toml from version to-1 to
+// version to. apply edits the raw key/value tree in place and reports whether
+// it changed anything, so configs that merely lack a version stamp are not
+// rewritten (and do not lose their comments) for nothing.
+type userConfigMigration struct {
+	to    int
+	name  string
+	apply func(raw map[string]any) bool
+}
+
+// userConfigMigrations is applied in order; each entry runs only when the
+// file's config_version is below its target. A migration that changes the
+// file rewrites it without its comments (the original is kept as
+// config.toml.bak), so add one only for a key a released version actually
+// wrote. No key has been renamed or reshaped since versioning began, so the
+// list is empty.
+var userConfigMigrations []userConfigMigration
+
+// MigrateUserConfig decodes raw config.toml content, upgrading layouts written
+// by older versions to the current schema. Files stamped with a newer
+// config_version than this build knows are decoded as-is: unknown keys are
+// ignored rather than treated as an error, so a downgrade keeps working.
+func MigrateUserConfig(raw []byte) (*UserConfig, error) {
+	config, _, err := migrateUserConfig(raw)
+	return config, err
+}
+
+// migrateUserConfig is MigrateUserConfig that also reports whether any
+// migration changed the content, i.e. whether the file should be rewritten.
+func migrateUserConfig(raw []byte) (*UserConfig, bool, error) {
+	var tree map[string]any
+	if _, err := toml.Decode(string(raw), &tree); err != nil {
+		return nil, false, err
+	}
+
+	version := 0
+	if v, ok := tree["config_version"].(int64); ok {
+		version = int(v)
+	}
+
+	if version > CurrentUserConfigVersion {
+		registryLog.Warn("config.toml is from a newer agent-deck; unknown settings are ignored",
+			"config_version", version,
+			"supported", CurrentUserConfigVersion)
+		var config UserConfig
+		if _, err := toml.Decode(string(raw), &config); err != nil {
+			return nil, false, err
+		}
+		return &config, false, nil
+	}
+
+	changed := false
+	for _, m := range userConfigMigrations {
+		if version >= m.to {
+			continue
+		}
+		if m.apply(tree) {
+			registryLog.Info("migrated config.toml", "to_version", m.to, "migration", m.name)
+			changed = true
+		}
+	}
+	tree["config_version"] = int64(CurrentUserConfigVersion)
+
+	// Round-trip the migrated tree through the encoder so the typed decode
+	// sees exactly what a rewritten file would contain.
+	var buf strings.Builder
+	if err := toml.NewEncoder(&buf).Encode(tree); err != nil {
+		return nil, false, fmt.Errorf("encode migrated config: %w", err)
+	}
+	var config UserConfig
+	if _, err := toml.Decode(buf.String(), &config); err != nil {
+		return nil, false, err
+	}
+	return &config, changed, nil
+}
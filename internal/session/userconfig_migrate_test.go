@@ -0,0 +1,190 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Fixtures written by hypothetical earlier releases.
+const (
+	// Current layout with nothing to migrate.
+	userConfigFixtureCurrent = `
+config_version = 1
+theme = "light"
+
+[gemini]
+default_model = "gemini-2.5-flash"
+`
+	// Written by a newer agent-deck with settings this build does not know.
+	userConfigFixtureFuture = `
+config_version = 99
+theme = "dark"
+hologram_mode = true
+
+[claude]
+command = "claude"
+`
+	// Unversioned, with a key the test migration renames.
+	userConfigFixtureLegacy = `
+# my theme
+theme_name = "light"
+
+[groups.work]
+default_path = "/tmp/work"
+`
+)
+
+// withThemeNameMigration installs a migration renaming a hypothetical
+// top-level theme_name to theme, so the runner can be tested while no real
+// migration exists.
+func withThemeNameMigration(t *testing.T) {
+	t.Helper()
+	orig := userConfigMigrations
+	t.Cleanup(func() { userConfigMigrations = orig })
+	userConfigMigrations = []userConfigMigration{{
+		to:   1,
+		name: "theme_name -> theme",
+		apply: func(raw map[string]any) bool {
+			v, ok := raw["theme_name"]
+			if !ok {
+				return false
+			}
+			delete(raw, "theme_name")
+			raw["theme"] = v
+			return true
+		},
+	}}
+}
+
+func TestMigrateUserConfig_AppliesPendingMigrations(t *testing.T) {
+	withThemeNameMigration(t)
+
+	cfg, changed, err := migrateUserConfig([]byte(userConfigFixtureLegacy))
+	if err != nil {
+		t.Fatalf("migrateUserConfig: %v", err)
+	}
+	if !changed {
+		t.Fatal("legacy fixture has theme_name, changed should be true")
+	}
+	if cfg.Version != CurrentUserConfigVersion || cfg.Theme != "light" {
+		t.Errorf("Version = %d, Theme = %q; want %d, light", cfg.Version, cfg.Theme, CurrentUserConfigVersion)
+	}
+	if cfg.Groups["work"].DefaultPath != "/tmp/work" {
+		t.Errorf("unrelated settings lost: groups=%v", cfg.Groups)
+	}
+
+	// A file already at the migration's version is left alone.
+	stamped := "config_version = 1\n" + userConfigFixtureLegacy
+	if cfg, changed, err := migrateUserConfig([]byte(stamped)); err != nil || changed || cfg.Theme != "" {
+		t.Errorf("finished migration ran again: changed=%v theme=%q err=%v", changed, cfg.Theme, err)
+	}
+}
+
+func TestMigrateUserConfig_CurrentIsUnchanged(t *testing.T) {
+	cfg, changed, err := migrateUserConfig([]byte(userConfigFixtureCurrent))
+	if err != nil {
+		t.Fatalf("migrateUserConfig: %v", err)
+	}
+	if changed {
+		t.Error("current fixture should not need a rewrite")
+	}
+	if cfg.Theme != "light" || cfg.Gemini.DefaultModel != "gemini-2.5-flash" {
+		t.Errorf("decoded config = %+v", cfg)
+	}
+
+	// Unversioned files without legacy keys are not rewritten either.
+	if _, changed, err := migrateUserConfig([]byte(`theme = "dark"`)); err != nil || changed {
+		t.Errorf("unversioned clean config: changed=%v err=%v, want false, nil", changed, err)
+	}
+}
+
+func TestMigrateUserConfig_FutureVersion(t *testing.T) {
+	cfg, err := MigrateUserConfig([]byte(userConfigFixtureFuture))
+	if err != nil {
+		t.Fatalf("future config should load, got %v", err)
+	}
+	if cfg.Version != 99 {
+		t.Errorf("Version = %d, want 99 preserved", cfg.Version)
+	}
+	if cfg.Theme != "dark" || cfg.Claude.Command != "claude" {
+		t.Errorf("known settings lost: %+v", cfg)
+	}
+}
+
+func TestMigrateUserConfig_InvalidTOML(t *testing.T) {
+	if _, err := MigrateUserConfig([]byte("theme = ")); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
+
+func writeTestUserConfig(t *testing.T, content string) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	isolateConfigHomeXDG(t)
+
+	configPath, err := GetUserConfigPath()
+	if err != nil {
+		t.Fatalf("GetUserConfigPath: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return configPath
+}
+
+func TestLoadUserConfig_RewritesMigratedFile(t *testing.T) {
+	withThemeNameMigration(t)
+	configPath := writeTestUserConfig(t, userConfigFixtureLegacy)
+
+	cfg, err := LoadUserConfig()
+	if err != nil {
+		t.Fatalf("LoadUserConfig: %v", err)
+	}
+	if cfg.Theme != "light" {
+		t.Errorf("Theme = %q, want migrated value", cfg.Theme)
+	}
+
+	rewritten, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(rewritten)
+	if !strings.Contains(content, "config_version = 1") || strings.Contains(content, "theme_name") {
+		t.Errorf("rewritten file not migrated:\n%s", content)
+	}
+	if _, err := os.Stat(configPath + ".bak"); err != nil {
+		t.Errorf("pre-migration backup missing: %v", err)
+	}
+
+	// Round-trip: the rewritten file loads to the same values with no
+	// further migration.
+	again, changed, err := migrateUserConfig(rewritten)
+	if err != nil || changed {
+		t.Fatalf("reload: changed=%v err=%v", changed, err)
+	}
+	if again.Theme != "light" || again.Groups["work"].DefaultPath != "/tmp/work" {
+		t.Errorf("round-trip mismatch: %+v", again)
+	}
+}
+
+func TestLoadUserConfig_KeepsUnmigratedFileAndComments(t *testing.T) {
+	const content = "# keep me\ntheme = \"dark\"\n"
+	configPath := writeTestUserConfig(t, content)
+
+	if _, err := LoadUserConfig(); err != nil {
+		t.Fatalf("LoadUserConfig: %v", err)
+	}
+	got, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != content {
+		t.Errorf("config without legacy keys was rewritten:\n%s", got)
+	}
+}
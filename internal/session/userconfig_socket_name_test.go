@@ -126,3 +126,22 @@ socket_name = "   "
 		t.Fatalf("whitespace-only socket_name must resolve to empty; got %q", got)
 	}
 }
+
+// TestTmuxSettings_SocketPath_WinsOverName: socket_path (tmux -S) takes
+// precedence over socket_name and is ~-expanded.
+func TestTmuxSettings_SocketPath_WinsOverName(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home dir")
+	}
+	settings := TmuxSettings{SocketName: "agent-deck", SocketPath: " ~/tmux/agent.sock "}
+	want := filepath.Join(home, "tmux", "agent.sock")
+	if got := settings.GetSocketName(); got != want {
+		t.Fatalf("GetSocketName() = %q, want %q", got, want)
+	}
+
+	settings.SocketPath = ""
+	if got := settings.GetSocketName(); got != "agent-deck" {
+		t.Fatalf("without socket_path GetSocketName() = %q, want agent-deck", got)
+	}
+}
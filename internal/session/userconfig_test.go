@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -1149,6 +1150,23 @@ func TestPreviewSettingsNotesOutputSplitDefaultsAndClamp(t *testing.T) {
 	}
 }
 
+func TestPreviewSettingsAnalyticsRefreshIntervalDefaultsAndClamp(t *testing.T) {
+	settings := PreviewSettings{}
+	if got := settings.GetAnalyticsRefreshInterval(); got != 5*time.Second {
+		t.Fatalf("GetAnalyticsRefreshInterval default = %v, want 5s", got)
+	}
+
+	settings.AnalyticsRefreshSeconds = 120
+	if got := settings.GetAnalyticsRefreshInterval(); got != 30*time.Second {
+		t.Fatalf("GetAnalyticsRefreshInterval high clamp = %v, want 30s", got)
+	}
+
+	settings.AnalyticsRefreshSeconds = 1
+	if got := settings.GetAnalyticsRefreshInterval(); got != time.Second {
+		t.Fatalf("GetAnalyticsRefreshInterval configured = %v, want 1s", got)
+	}
+}
+
 // TestInstanceSettingsAllowMultipleDefault is the #1246 regression guard.
 // allow_multiple previously defaulted to TRUE, so two agent-deck instances
 // could run against one profile and their reviver/restart loops tore down
@@ -1344,6 +1362,31 @@ allow_dangerous_mode = true
 	}
 }
 
+func TestClaudeSettings_Presets(t *testing.T) {
+	var config UserConfig
+	if _, err := toml.Decode(`
+[claude.presets.review]
+permission_mode = "plan"
+allowed_tools = ["Read"]
+
+[claude.presets.yolo]
+auto_mode = true
+`, &config); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	presets, names := config.Claude.GetPresets()
+	if got := strings.Join(names, ","); got != "safe,fast,yolo,review" {
+		t.Fatalf("names = %q, want built-ins first then custom", got)
+	}
+	if p := presets["yolo"]; !p.AutoMode || p.SkipPermissions {
+		t.Errorf("configured yolo should replace the built-in, got %+v", p)
+	}
+	if p := presets["review"]; p.PermissionMode != "plan" || len(p.AllowedTools) != 1 {
+		t.Errorf("review = %+v", p)
+	}
+}
+
 func TestClaudeSettings_AllowDangerousMode_Default(t *testing.T) {
 	var config UserConfig
 	if config.Claude.AllowDangerousMode {
@@ -2669,3 +2712,15 @@ func TestSaveUserConfig_OmitsUnsetGroupSort(t *testing.T) {
 		t.Errorf("config.toml must contain a set group_sort; got:\n%s", raw)
 	}
 }
+
+func TestGetPathBookmarks(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	cfg := &UserConfig{PathBookmarks: []string{"~/code", " ", "relative/dir", "/srv/work/", "~/code"}}
+	want := []string{filepath.Join(home, "code"), "/srv/work"}
+	if got := cfg.GetPathBookmarks(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetPathBookmarks() = %v, want %v", got, want)
+	}
+}
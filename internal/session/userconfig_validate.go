@@ -0,0 +1,181 @@
+package session
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ConfigIssueSeverity classifies a ConfigIssue.
+type ConfigIssueSeverity string
+
+const (
+	// ConfigIssueError marks a setting that will make a feature fail.
+	ConfigIssueError ConfigIssueSeverity = "error"
+	// ConfigIssueWarning marks a setting that is suspicious but may work.
+	ConfigIssueWarning ConfigIssueSeverity = "warning"
+)
+
+// ConfigIssue is one problem found by UserConfig.Validate.
+type ConfigIssue struct {
+	Severity ConfigIssueSeverity `json:"severity"`
+	// Field is the TOML key path, e.g. "claude.command" or "tools.foo.command".
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// String renders the issue as "severity: field: message".
+func (i ConfigIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Severity, i.Field, i.Message)
+}
+
+// geminiModelPattern matches plausible Gemini model IDs such as
+// "gemini-2.5-flash" or "gemini-2.0-flash-exp".
+var geminiModelPattern = regexp.MustCompile(`^gemini-[a-z0-9][a-z0-9.\-]*$`)
+
+// Validate checks the config for settings that would silently misbehave:
+// missing binaries, relative or missing paths, unknown tools, implausible
+// model names and unknown enum values. It never mutates the config and returns
+// nil when no issues are found. Issues are ordered errors first, then by field.
+func (c *UserConfig) Validate() []ConfigIssue {
+	if c == nil {
+		return nil
+	}
+	var issues []ConfigIssue
+	add := func(sev ConfigIssueSeverity, field, format string, args ...any) {
+		issues = append(issues, ConfigIssue{Severity: sev, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// default_tool must name a built-in or a configured custom tool.
+	if t := c.DefaultTool; t != "" && t != "shell" && !isBuiltinToolName(t) {
+		if _, ok := c.Tools[t]; !ok {
+			add(ConfigIssueError, "default_tool", "unknown tool %q", t)
+		}
+	}
+
+	// Explicit command overrides must resolve on PATH.
+	commands := []struct{ field, command string }{
+		{"claude.command", c.Claude.Command},
+		{"gemini.command", c.Gemini.Command},
+		{"opencode.command", c.OpenCode.Command},
+		{"codex.command", c.Codex.Command},
+		{"copilot.command", c.Copilot.Command},
+		{"hermes.command", c.Hermes.Command},
+	}
+	for name, def := range c.Tools {
+		commands = append(commands, struct{ field, command string }{"tools." + name + ".command", def.Command})
+	}
+	for _, cmd := range commands {
+		if cmd.command != "" && !probeInstalled(cmd.command) {
+			add(ConfigIssueError, cmd.field, "command %q not found on PATH", cmd.command)
+		}
+	}
+
+	for name, def := range c.Tools {
+		if def.Layout != nil {
+			if err := def.Layout.Validate(); err != nil {
+				add(ConfigIssueError, "tools."+name+".layout", "%s", err.Error())
+			}
+		}
+	}
+
+	// Directory paths must be absolute (after ~ / $VAR expansion) and exist.
+	dirs := []struct{ field, path string }{
+		{"default_path", c.DefaultPath},
+		{"relative_path_base", c.RelativePathBase},
+		{"claude.config_dir", c.Claude.ConfigDir},
+	}
+	for name, g := range c.Groups {
+		dirs = append(dirs, struct{ field, path string }{"groups." + name + ".default_path", g.DefaultPath})
+	}
+	for idx, b := range c.PathBookmarks {
+		dirs = append(dirs, struct{ field, path string }{fmt.Sprintf("path_bookmarks[%d]", idx), b})
+	}
+	for _, d := range dirs {
+		if d.path == "" {
+			continue
+		}
+		expanded := ExpandPath(d.path)
+		if !filepath.IsAbs(expanded) {
+			add(ConfigIssueWarning, d.field, "path %q is not absolute", d.path)
+			continue
+		}
+		info, err := os.Stat(expanded)
+		switch {
+		case err != nil:
+			add(ConfigIssueWarning, d.field, "path %q does not exist", d.path)
+		case !info.IsDir():
+			add(ConfigIssueWarning, d.field, "path %q is not a directory", d.path)
+		}
+	}
+
+	// Missing-path allowlist entries are prefixes, so they must be absolute
+	// (they need not exist — that is the point).
+	for idx, entry := range c.AllowMissingPaths {
+		if entry != "*" && !filepath.IsAbs(ExpandPath(entry)) {
+			add(ConfigIssueWarning, fmt.Sprintf("allow_missing_paths[%d]", idx), "path %q is not absolute and never matches", entry)
+		}
+	}
+
+	// Absolute env files should exist; relative ones resolve per session.
+	for idx, f := range c.Shell.EnvFiles {
+		expanded := ExpandPath(f)
+		if filepath.IsAbs(expanded) {
+			if _, err := os.Stat(expanded); err != nil {
+				add(ConfigIssueWarning, fmt.Sprintf("shell.env_files[%d]", idx), "env file %q does not exist", f)
+			}
+		}
+	}
+
+	if p := strings.TrimSpace(c.Tmux.SocketPath); p != "" && !filepath.IsAbs(ExpandPath(p)) {
+		add(ConfigIssueError, "tmux.socket_path", "socket path %q is not absolute", p)
+	}
+
+	if err := ValidateMCPConfigFile(c.Claude.MCPConfig); err != nil {
+		add(ConfigIssueError, "claude.mcp_config", "%s", err.Error())
+	}
+
+	if m := c.Claude.PermissionMode; m != "" && !slices.Contains(ClaudePermissionModes, m) {
+		add(ConfigIssueError, "claude.permission_mode", "unknown permission mode %q (want one of %s)",
+			m, strings.Join(ClaudePermissionModes, ", "))
+	}
+
+	if m := strings.ToLower(strings.TrimSpace(c.Shell.CommandCheck)); m != "" && !slices.Contains(CommandCheckModes, m) {
+		add(ConfigIssueWarning, "shell.command_check", "unknown value %q (want one of %s); using %q",
+			c.Shell.CommandCheck, strings.Join(CommandCheckModes, ", "), CommandCheckWarn)
+	}
+
+	if m := c.Gemini.DefaultModel; m != "" && !geminiModelPattern.MatchString(m) {
+		add(ConfigIssueWarning, "gemini.default_model", "model %q does not look like a Gemini model ID (e.g. gemini-2.5-flash)", m)
+	}
+
+	if u := strings.TrimSpace(c.Events.WebhookURL); u != "" {
+		if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			add(ConfigIssueError, "events.webhook_url", "webhook URL %q must be an absolute http(s) URL", u)
+		}
+	}
+	for _, name := range c.Events.WebhookEvents {
+		if !isSessionEventType(name) {
+			add(ConfigIssueWarning, "events.webhook_events", "unknown event type %q (want created, killed or crashed)", name)
+		}
+	}
+
+	switch c.Theme {
+	case "", "dark", "light", "system":
+	default:
+		add(ConfigIssueWarning, "theme", "unknown theme %q (want dark, light or system)", c.Theme)
+	}
+
+	sort.SliceStable(issues, func(a, b int) bool {
+		if issues[a].Severity != issues[b].Severity {
+			return issues[a].Severity == ConfigIssueError
+		}
+		return issues[a].Field < issues[b].Field
+	})
+	return issues
+}
@@ -0,0 +1,154 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func findIssue(issues []ConfigIssue, field string) *ConfigIssue {
+	for i := range issues {
+		if issues[i].Field == field {
+			return &issues[i]
+		}
+	}
+	return nil
+}
+
+func TestUserConfigValidate_Clean(t *testing.T) {
+	withStubbedProbe(t, []string{"claude"}, func() {
+		dir := t.TempDir()
+		cfg := &UserConfig{
+			DefaultTool: "claude",
+			DefaultPath: dir,
+			Theme:       "light",
+			Claude:      ClaudeSettings{Command: "claude", PermissionMode: "plan"},
+			Gemini:      GeminiSettings{DefaultModel: "gemini-2.5-flash"},
+		}
+		if issues := cfg.Validate(); len(issues) != 0 {
+			t.Fatalf("Validate() = %v, want no issues", issues)
+		}
+	})
+	var nilCfg *UserConfig
+	if issues := nilCfg.Validate(); issues != nil {
+		t.Fatalf("nil config Validate() = %v, want nil", issues)
+	}
+}
+
+func TestUserConfigValidate_ReportsProblems(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	withStubbedProbe(t, nil, func() {
+		cfg := &UserConfig{
+			DefaultTool: "nonexistent-tool",
+			DefaultPath: "relative/dir",
+			Theme:       "neon",
+			Claude: ClaudeSettings{
+				Command:        "claude-missing",
+				ConfigDir:      file,
+				PermissionMode: "yolo",
+				MCPConfig:      filepath.Join(dir, "missing.json"),
+			},
+			Gemini: GeminiSettings{DefaultModel: "gpt-4"},
+			Shell:  ShellSettings{EnvFiles: []string{filepath.Join(dir, "nope.env"), ".env"}},
+			Tools:  map[string]ToolDef{"mytool": {Command: "mytool-bin"}},
+			Groups: map[string]GroupSettings{"work": {DefaultPath: filepath.Join(dir, "gone")}},
+			Events: EventsSettings{WebhookURL: "localhost:9000", WebhookEvents: []string{"paused"}},
+
+			AllowMissingPaths: []string{"*", "~/remote", "mnt/nfs"},
+		}
+		issues := cfg.Validate()
+
+		want := map[string]ConfigIssueSeverity{
+			"default_tool":             ConfigIssueError,
+			"claude.command":           ConfigIssueError,
+			"tools.mytool.command":     ConfigIssueError,
+			"claude.mcp_config":        ConfigIssueError,
+			"claude.permission_mode":   ConfigIssueError,
+			"events.webhook_url":       ConfigIssueError,
+			"default_path":             ConfigIssueWarning,
+			"claude.config_dir":        ConfigIssueWarning,
+			"groups.work.default_path": ConfigIssueWarning,
+			"shell.env_files[0]":       ConfigIssueWarning,
+			"gemini.default_model":     ConfigIssueWarning,
+			"theme":                    ConfigIssueWarning,
+			"events.webhook_events":    ConfigIssueWarning,
+			"allow_missing_paths[2]":   ConfigIssueWarning,
+		}
+		for field, sev := range want {
+			issue := findIssue(issues, field)
+			if issue == nil {
+				t.Errorf("missing issue for %s in %v", field, issues)
+				continue
+			}
+			if issue.Severity != sev {
+				t.Errorf("%s severity = %s, want %s", field, issue.Severity, sev)
+			}
+		}
+		if findIssue(issues, "shell.env_files[1]") != nil {
+			t.Error("relative env files resolve per session and must not be flagged")
+		}
+		if len(issues) != len(want) {
+			t.Errorf("got %d issues, want %d: %v", len(issues), len(want), issues)
+		}
+
+		// Errors sort before warnings.
+		seenWarning := false
+		for _, issue := range issues {
+			if issue.Severity == ConfigIssueWarning {
+				seenWarning = true
+			} else if seenWarning {
+				t.Fatalf("error after warning in %v", issues)
+			}
+		}
+	})
+}
+
+func TestUserConfigValidate_CustomDefaultTool(t *testing.T) {
+	withStubbedProbe(t, []string{"mytool-bin"}, func() {
+		cfg := &UserConfig{
+			DefaultTool: "mytool",
+			Tools:       map[string]ToolDef{"mytool": {Command: "mytool-bin"}},
+		}
+		if issues := cfg.Validate(); len(issues) != 0 {
+			t.Fatalf("Validate() = %v, want no issues", issues)
+		}
+	})
+}
+
+func TestUserConfigAllowsMissingPath(t *testing.T) {
+	t.Setenv("HOME", "/home/test")
+	cfg := &UserConfig{AllowMissingPaths: []string{"/mnt/nfs/", "~/remote", " "}}
+	cases := map[string]bool{
+		"/mnt/nfs":             true,
+		"/mnt/nfs/project":     true,
+		"/mnt/nfsx/project":    false,
+		"~/remote/repo":        true,
+		"/home/test/remote":    true,
+		"/home/test/remotes":   false,
+		"/home/test/code/repo": false,
+	}
+	for path, want := range cases {
+		if got := cfg.AllowsMissingPath(path); got != want {
+			t.Errorf("AllowsMissingPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+
+	if (&UserConfig{}).AllowsMissingPath("/mnt/nfs") {
+		t.Error("empty allowlist must not allow missing paths")
+	}
+	if !(&UserConfig{AllowMissingPaths: []string{"*"}}).AllowsMissingPath("/anywhere") {
+		t.Error(`"*" must allow any path`)
+	}
+}
+
+func TestConfigIssueString(t *testing.T) {
+	issue := ConfigIssue{Severity: ConfigIssueError, Field: "theme", Message: "bad"}
+	if got := issue.String(); got != "error: theme: bad" {
+		t.Fatalf("String() = %q", got)
+	}
+}
@@ -96,7 +96,7 @@ func withBusyRetry(op func() error) error {
 
 // SchemaVersion tracks the current database schema version.
 // Bump this when adding migrations.
-const SchemaVersion = 13
+const SchemaVersion = 14
 
 // StateDB wraps a SQLite database for session/group persistence.
 // Thread-safe for concurrent use from multiple goroutines within one process.
@@ -257,7 +257,8 @@ type StatusRow struct {
 	Acknowledged bool
 }
 
-// RecentSessionRow captures the config of a deleted session for quick re-creation.
+// RecentSessionRow captures the config of a created or deleted session for
+// quick re-creation.
 type RecentSessionRow struct {
 	ID             string // SHA-256 dedup key (title+path+tool+group)
 	Title          string
@@ -269,7 +270,8 @@ type RecentSessionRow struct {
 	ToolOptions    json.RawMessage // serialized ToolOptionsWrapper
 	SandboxEnabled bool
 	GeminiYoloMode *bool
-	DeletedAt      time.Time
+	DeletedAt      time.Time // last recorded (create or delete); the column predates create tracking
+	Deleted        bool      // last recorded on delete; created and deleted entries are capped separately
 }
 
 // global singleton for cross-package access (status writes from background worker)
@@ -438,7 +440,8 @@ func (s *StateDB) Migrate() error {
 			tool_options    TEXT NOT NULL DEFAULT '{}',
 			sandbox_enabled INTEGER NOT NULL DEFAULT 0,
 			gemini_yolo     INTEGER,
-			deleted_at      INTEGER NOT NULL
+			deleted_at      INTEGER NOT NULL,
+			deleted         INTEGER NOT NULL DEFAULT 1
 		)
 	`); err != nil {
 		return fmt.Errorf("statedb: create recent_sessions: %w", err)
@@ -552,6 +555,10 @@ func (s *StateDB) Migrate() error {
 		// deliberate-idle (never a self-heal candidate). Additive + targeted-write
 		// only (WriteLastSentAt); never part of a whole-row REPLACE/SaveInstances.
 		"ALTER TABLE instances ADD COLUMN last_sent_at INTEGER NOT NULL DEFAULT 0",
+		// v14: recent_sessions records creates as well as deletes, each kind
+		// capped on its own. Default 1: rows from before create tracking were
+		// all recorded on delete.
+		"ALTER TABLE recent_sessions ADD COLUMN deleted INTEGER NOT NULL DEFAULT 1",
 	}
 	for _, stmt := range alterMigrations {
 		if _, err := tx.Exec(stmt); err != nil {
@@ -648,6 +655,13 @@ func (s *StateDB) Migrate() error {
 				}
 			}
 		}
+		if oldVer < 14 {
+			if _, err := tx.Exec(`ALTER TABLE recent_sessions ADD COLUMN deleted INTEGER NOT NULL DEFAULT 1`); err != nil {
+				if !strings.Contains(err.Error(), "duplicate column") {
+					return fmt.Errorf("statedb: migrate v14 recent_sessions deleted: %w", err)
+				}
+			}
+		}
 		if _, err := tx.Exec(`
 			UPDATE metadata SET value = ? WHERE key = 'schema_version'
 		`, schemaVersion); err != nil {
@@ -1560,7 +1574,13 @@ func recentSessionDedupID(row *RecentSessionRow) string {
 	return hex.EncodeToString(h[:16]) // 32-char hex
 }
 
-// SaveRecentSession inserts or replaces a recent session entry, then prunes to 20.
+// recentSessionsPerKind caps the recent sessions history, separately for
+// entries recorded on create and on delete, so a burst of new sessions
+// cannot push out every deleted config.
+const recentSessionsPerKind = 20
+
+// SaveRecentSession inserts or replaces a recent session entry, then prunes
+// entries of the same kind (created or deleted) to recentSessionsPerKind.
 //
 // The INSERT and the prune are bundled in a single transaction so a crash
 // between them cannot leave the table over-budget (the prune always sees the
@@ -1579,6 +1599,10 @@ func (s *StateDB) SaveRecentSession(row *RecentSessionRow) error {
 	if row.SandboxEnabled {
 		sandbox = 1
 	}
+	deleted := 0
+	if row.Deleted {
+		deleted = 1
+	}
 
 	var geminiYolo *int
 	if row.GeminiYoloMode != nil {
@@ -1600,21 +1624,22 @@ func (s *StateDB) SaveRecentSession(row *RecentSessionRow) error {
 			INSERT OR REPLACE INTO recent_sessions (
 				id, title, project_path, group_path,
 				command, wrapper, tool, tool_options,
-				sandbox_enabled, gemini_yolo, deleted_at
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				sandbox_enabled, gemini_yolo, deleted_at, deleted
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`,
 			id, row.Title, row.ProjectPath, row.GroupPath,
 			row.Command, row.Wrapper, row.Tool, string(toolOpts),
-			sandbox, geminiYolo, time.Now().Unix(),
+			sandbox, geminiYolo, time.Now().Unix(), deleted,
 		); err != nil {
 			return err
 		}
 
 		if _, err := tx.Exec(`
-			DELETE FROM recent_sessions WHERE id NOT IN (
-				SELECT id FROM recent_sessions ORDER BY deleted_at DESC LIMIT ?
+			DELETE FROM recent_sessions WHERE deleted = ? AND id NOT IN (
+				SELECT id FROM recent_sessions WHERE deleted = ?
+				ORDER BY deleted_at DESC LIMIT ?
 			)
-		`, 20); err != nil {
+		`, deleted, deleted, recentSessionsPerKind); err != nil {
 			return err
 		}
 
@@ -1622,12 +1647,12 @@ func (s *StateDB) SaveRecentSession(row *RecentSessionRow) error {
 	})
 }
 
-// LoadRecentSessions returns all recent sessions ordered by most recently deleted.
+// LoadRecentSessions returns all recent sessions, most recently recorded first.
 func (s *StateDB) LoadRecentSessions() ([]*RecentSessionRow, error) {
 	rows, err := s.db.Query(`
 		SELECT id, title, project_path, group_path,
 			command, wrapper, tool, tool_options,
-			sandbox_enabled, gemini_yolo, deleted_at
+			sandbox_enabled, gemini_yolo, deleted_at, deleted
 		FROM recent_sessions ORDER BY deleted_at DESC
 	`)
 	if err != nil {
@@ -1642,10 +1667,11 @@ func (s *StateDB) LoadRecentSessions() ([]*RecentSessionRow, error) {
 		var sandbox int
 		var geminiYolo *int
 		var deletedUnix int64
+		var deleted int
 		if err := rows.Scan(
 			&r.ID, &r.Title, &r.ProjectPath, &r.GroupPath,
 			&r.Command, &r.Wrapper, &r.Tool, &toolOptsStr,
-			&sandbox, &geminiYolo, &deletedUnix,
+			&sandbox, &geminiYolo, &deletedUnix, &deleted,
 		); err != nil {
 			return nil, err
 		}
@@ -1656,6 +1682,7 @@ func (s *StateDB) LoadRecentSessions() ([]*RecentSessionRow, error) {
 			r.GeminiYoloMode = &v
 		}
 		r.DeletedAt = time.Unix(deletedUnix, 0)
+		r.Deleted = deleted != 0
 		result = append(result, r)
 	}
 	return result, rows.Err()
@@ -827,6 +827,37 @@ func TestRecentSessions_DedupUsesFullConfig(t *testing.T) {
 	}
 }
 
+func TestRecentSessions_CapsCreatedAndDeletedSeparately(t *testing.T) {
+	db := newTestDB(t)
+
+	save := func(title string, deleted bool) {
+		t.Helper()
+		row := &RecentSessionRow{Title: title, ProjectPath: "/tmp/project", Tool: "shell", Deleted: deleted}
+		if err := db.SaveRecentSession(row); err != nil {
+			t.Fatalf("SaveRecentSession(%s): %v", title, err)
+		}
+	}
+	for i := 0; i < recentSessionsPerKind+5; i++ {
+		save(fmt.Sprintf("deleted-%d", i), true)
+	}
+	for i := 0; i < recentSessionsPerKind+5; i++ {
+		save(fmt.Sprintf("created-%d", i), false)
+	}
+
+	rows, err := db.LoadRecentSessions()
+	if err != nil {
+		t.Fatalf("LoadRecentSessions: %v", err)
+	}
+	counts := map[bool]int{}
+	for _, r := range rows {
+		counts[r.Deleted]++
+	}
+	if counts[true] != recentSessionsPerKind || counts[false] != recentSessionsPerKind {
+		t.Fatalf("kept %d deleted and %d created rows, want %d of each",
+			counts[true], counts[false], recentSessionsPerKind)
+	}
+}
+
 func TestRecentSessions_DedupIdenticalConfig(t *testing.T) {
 	db := newTestDB(t)
 
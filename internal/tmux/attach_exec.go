@@ -0,0 +1,51 @@
+package tmux
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// InsideServer reports whether the current process runs inside a tmux client
+// of the server addressed by socketName ("" = the default server), i.e. $TMUX
+// names that server's socket. Only then can `switch-client` move the current
+// client to another session on it.
+func InsideServer(socketName string) bool {
+	envPath := tmuxSocketPathFromEnv()
+	if envPath == "" {
+		return false
+	}
+	name := strings.TrimSpace(socketName)
+	if IsSocketPath(name) {
+		return filepath.Clean(envPath) == filepath.Clean(name)
+	}
+	if name == "" {
+		name = "default"
+	}
+	return filepath.Base(envPath) == name
+}
+
+// AttachCommand returns a plain tmux command that connects the current
+// terminal to this session, for callers that hand the terminal to a child
+// process (tea.ExecProcess, CLI) instead of using the PTY proxy in Attach.
+//
+// Inside a client of the same tmux server it returns `switch-client`, which
+// moves the existing client instead of nesting tmux in tmux. Otherwise it
+// returns `attach-session` with $TMUX removed from the environment, since
+// tmux refuses to attach from inside another (different) server's pane.
+func (s *Session) AttachCommand() *exec.Cmd {
+	if InsideServer(s.SocketName) {
+		return s.tmuxCmd("switch-client", "-t", s.Name)
+	}
+	cmd := s.tmuxCmd("attach-session", "-t", s.Name)
+	env := os.Environ()
+	filtered := env[:0]
+	for _, kv := range env {
+		if !strings.HasPrefix(kv, "TMUX=") {
+			filtered = append(filtered, kv)
+		}
+	}
+	cmd.Env = filtered
+	return cmd
+}
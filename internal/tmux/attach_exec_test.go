@@ -0,0 +1,57 @@
+package tmux
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInsideServer(t *testing.T) {
+	t.Setenv("TMUX", "")
+	if InsideServer("") {
+		t.Fatal("InsideServer should be false outside tmux")
+	}
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	if !InsideServer("") {
+		t.Error("default socket should match empty socket name")
+	}
+	if InsideServer("agentdeck") {
+		t.Error("default socket should not match -L agentdeck")
+	}
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/agentdeck,1234,0")
+	if !InsideServer("agentdeck") {
+		t.Error("agentdeck socket should match -L agentdeck")
+	}
+}
+
+func TestSessionAttachCommand(t *testing.T) {
+	s := &Session{Name: "agentdeck_demo_1234", SocketName: "agentdeck"}
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/agentdeck,1234,0")
+	cmd := s.AttachCommand()
+	if want := []string{"tmux", "-L", "agentdeck", "switch-client", "-t", "agentdeck_demo_1234"}; !slices.Equal(cmd.Args, want) {
+		t.Errorf("same server: Args = %v, want %v", cmd.Args, want)
+	}
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	cmd = s.AttachCommand()
+	if want := []string{"tmux", "-L", "agentdeck", "attach-session", "-t", "agentdeck_demo_1234"}; !slices.Equal(cmd.Args, want) {
+		t.Errorf("other server: Args = %v, want %v", cmd.Args, want)
+	}
+	for _, kv := range cmd.Env {
+		if len(kv) >= 5 && kv[:5] == "TMUX=" {
+			t.Errorf("attach-session env must drop $TMUX, found %q", kv)
+		}
+	}
+}
+
+func TestInsideServer_SocketPath(t *testing.T) {
+	t.Setenv("TMUX", "/run/user/1000/agent-deck.sock,1234,0")
+	if !InsideServer("/run/user/1000/agent-deck.sock") {
+		t.Error("matching socket path should be inside")
+	}
+	if InsideServer("/run/user/1000/other.sock") {
+		t.Error("different socket path should not match")
+	}
+}
@@ -95,28 +95,45 @@ func tmuxFmt(fields ...string) string {
 }
 
 // tmuxArgs builds the full `tmux …` argv for a command, inserting the
-// `-L <name>` socket selector at the front when socketName is non-empty
-// and non-whitespace. An empty socket name is the pre-v1.7.50 default and
-// produces an unmodified argv — zero behavior change for users who do not
-// opt in to socket isolation (scope decision 1: empty default).
+// socket selector (`-L <name>`, or `-S <path>` for a socket path, see
+// SocketArgs) at the front when socketName is non-empty and non-whitespace.
+// An empty socket name is the pre-v1.7.50 default and produces an
+// unmodified argv — zero behavior change for users who do not opt in to
+// socket isolation (scope decision 1: empty default).
 //
 // The returned slice is always freshly allocated; the caller's args slice
 // is never mutated or aliased.
 //
 // See CHANGELOG v1.7.50 and docs/README socket-isolation section.
 func tmuxArgs(socketName string, args ...string) []string {
-	name := strings.TrimSpace(socketName)
-	if name == "" {
-		out := make([]string, len(args))
-		copy(out, args)
-		return out
-	}
-	out := make([]string, 0, len(args)+2)
-	out = append(out, "-L", name)
+	sel := SocketArgs(socketName)
+	out := make([]string, 0, len(args)+len(sel))
+	out = append(out, sel...)
 	out = append(out, args...)
 	return out
 }
 
+// IsSocketPath reports whether a socket selector is a filesystem path (used
+// with tmux -S) rather than a server name (tmux -L). Names never contain a
+// path separator.
+func IsSocketPath(socket string) bool {
+	return strings.ContainsRune(strings.TrimSpace(socket), '/')
+}
+
+// SocketArgs returns the tmux server selector flags for socket: nil for the
+// default server, `-S <path>` for a socket path, `-L <name>` otherwise.
+func SocketArgs(socket string) []string {
+	socket = strings.TrimSpace(socket)
+	switch {
+	case socket == "":
+		return nil
+	case IsSocketPath(socket):
+		return []string{"-S", socket}
+	default:
+		return []string{"-L", socket}
+	}
+}
+
 // tmuxExec constructs an *exec.Cmd that invokes `tmux` with the given
 // subcommand, honoring the configured socket name. It is the package-level
 // counterpart to (*Session).tmuxCmd — use this when there is no Session
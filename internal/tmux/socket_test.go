@@ -118,3 +118,28 @@ func TestDefaultSocketName_TrimsWhitespace(t *testing.T) {
 		t.Fatalf("default socket must be trimmed; got %q want %q", got, "agent-deck")
 	}
 }
+
+// TestTmuxArgs_WithSocketPath_PrependsDashS: a selector containing a path
+// separator is a socket path and must be passed as `-S <path>`, not `-L`.
+func TestTmuxArgs_WithSocketPath_PrependsDashS(t *testing.T) {
+	got := tmuxArgs("/run/user/1000/agent-deck.sock", "has-session", "-t", "foo")
+	want := []string{"-S", "/run/user/1000/agent-deck.sock", "has-session", "-t", "foo"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("socket path must be injected as leading -S <path>\n got:  %v\n want: %v", got, want)
+	}
+}
+
+func TestSocketArgs(t *testing.T) {
+	cases := map[string][]string{
+		"":              nil,
+		"   ":           nil,
+		"agent-deck":    {"-L", "agent-deck"},
+		" /tmp/x/sock ": {"-S", "/tmp/x/sock"},
+		"relative/sock": {"-S", "relative/sock"},
+	}
+	for in, want := range cases {
+		if got := SocketArgs(in); !reflect.DeepEqual(got, want) {
+			t.Errorf("SocketArgs(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
@@ -2843,6 +2843,18 @@ func (s *Session) HasUpdated() (bool, error) {
 	return false, nil
 }
 
+// CurrentCommand returns the name of the foreground process in the
+// session's active pane (tmux pane_current_command), e.g. "claude" or "zsh".
+func (s *Session) CurrentCommand() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	output, err := s.tmuxCmdContext(ctx, "display-message", "-t", s.Name, "-p", "#{pane_current_command}").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get pane command: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // DetectTool detects which AI coding tool is running in the session
 // Uses caching to avoid re-detection on every call
 func (s *Session) DetectTool() string {
@@ -5366,6 +5378,7 @@ func DiscoverAllTmuxSessions() ([]*Session, error) {
 			Name:        sessionName,
 			DisplayName: sessionName,
 			WorkDir:     workDir,
+			SocketName:  DefaultSocketName(), // the server listed above
 		}
 
 		// If it's an agent-deck session, clean up the display name
@@ -232,6 +232,51 @@ func (p *AnalyticsPanel) renderGeminiTokens() string {
 		valueStyle.Render(outputStr),
 	))
 
+	// Live rate (only while the session is producing tokens)
+	if rate := p.geminiAnalytics.TokenRate(); rate > 0 {
+		b.WriteString(fmt.Sprintf("  %s %s\n",
+			dimStyle.Render("Rate:"),
+			valueStyle.Render(formatNumber(int(rate+0.5))+"/min"),
+		))
+	}
+
+	// Per-turn sparkline (needs at least two turns to show a trend)
+	if spark := p.renderGeminiTimeline(); spark != "" {
+		b.WriteString(fmt.Sprintf("  %s %s\n",
+			dimStyle.Render("Trend:"),
+			valueStyle.Render(spark),
+		))
+	}
+
+	// Cached row (only when context caching was used)
+	if p.geminiAnalytics.CachedTokens > 0 {
+		b.WriteString(fmt.Sprintf("  %s %s\n",
+			dimStyle.Render("Cached:"),
+			valueStyle.Render(formatNumber(p.geminiAnalytics.CachedTokens)),
+		))
+	}
+
+	// Per-model split (only when the session switched models)
+	if models := p.geminiAnalytics.ModelsUsed(); len(models) > 1 {
+		parts := make([]string, len(models))
+		for i, m := range models {
+			total := p.geminiAnalytics.ModelBreakdown[m].Total()
+			parts[i] = strings.TrimPrefix(m, "gemini-") + ": " + formatTokens(int64(total))
+		}
+		b.WriteString(fmt.Sprintf("  %s %s\n",
+			dimStyle.Render("Models:"),
+			valueStyle.Render(strings.Join(parts, ", ")),
+		))
+	}
+
+	// Prompt row (only when the session file records user-side counts)
+	if p.geminiAnalytics.PromptTokens > 0 {
+		b.WriteString(fmt.Sprintf("  %s %s\n",
+			dimStyle.Render("Prompts:"),
+			valueStyle.Render(formatNumber(p.geminiAnalytics.PromptTokens)),
+		))
+	}
+
 	// Total row
 	totalStyle := lipgloss.NewStyle().Foreground(ColorCyan).Bold(true)
 	b.WriteString(fmt.Sprintf("  %s %s\n",
@@ -242,6 +287,47 @@ func (p *AnalyticsPanel) renderGeminiTokens() string {
 	return b.String()
 }
 
+// renderGeminiTimeline renders per-turn token totals as a sparkline sized to
+// the panel width. Returns "" for sessions with fewer than two turns.
+func (p *AnalyticsPanel) renderGeminiTimeline() string {
+	maxPoints := p.width - 11 // indent + "Trend: " label + padding
+	if maxPoints > 40 {
+		maxPoints = 40
+	}
+	if maxPoints < 2 {
+		return ""
+	}
+	points := p.geminiAnalytics.Timeline(maxPoints)
+	if len(points) < 2 {
+		return ""
+	}
+	values := make([]int, len(points))
+	for i, pt := range points {
+		values[i] = pt.Total()
+	}
+	return sparkline(values)
+}
+
+// sparklineBlocks are the eight block heights used by sparkline
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline maps values onto block characters scaled to the largest value
+func sparkline(values []int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if peak > 0 && v > 0 {
+			idx = v * (len(sparklineBlocks) - 1) / peak
+		}
+		b.WriteRune(sparklineBlocks[idx])
+	}
+	return b.String()
+}
+
 // renderGeminiSessionInfo renders Gemini session info
 func (p *AnalyticsPanel) renderGeminiSessionInfo() string {
 	labelStyle := lipgloss.NewStyle().Foreground(ColorText).Bold(true)
@@ -252,8 +338,11 @@ func (p *AnalyticsPanel) renderGeminiSessionInfo() string {
 	b.WriteString(labelStyle.Render("Session"))
 	b.WriteString("\n")
 
-	// Duration
-	durationStr := formatDuration(p.geminiAnalytics.Duration)
+	// Duration (live elapsed time while the file has no usable span yet)
+	durationStr := formatDuration(p.geminiAnalytics.ElapsedAt(time.Now()))
+	if p.geminiAnalytics.InProgress() {
+		durationStr += "+"
+	}
 	b.WriteString(fmt.Sprintf("  %s %s",
 		dimStyle.Render("Duration:"),
 		valueStyle.Render(durationStr),
@@ -274,6 +363,13 @@ func (p *AnalyticsPanel) renderGeminiSessionInfo() string {
 		))
 	}
 
+	if !p.geminiAnalytics.LastActive.IsZero() {
+		b.WriteString(fmt.Sprintf("  %s %s\n",
+			dimStyle.Render("Last active:"),
+			valueStyle.Render(formatRelativeTime(p.geminiAnalytics.LastActive)),
+		))
+	}
+
 	return b.String()
 }
 
@@ -287,12 +383,13 @@ func (p *AnalyticsPanel) renderGeminiCost() string {
 	b.WriteString(labelStyle.Render("Cost"))
 	b.WriteString("\n")
 
-	// Use detected model from session, fall back to default pricing
+	// Price each model's share at its own rate; turns without a model use
+	// the detected one, else default pricing
 	model := p.geminiAnalytics.Model
 	if model == "" {
 		model = "default"
 	}
-	cost := p.geminiAnalytics.CalculateCost(model)
+	cost := p.geminiAnalytics.EstimateCost(model)
 
 	if cost > 0 {
 		costStr := fmt.Sprintf("$%.4f", cost)
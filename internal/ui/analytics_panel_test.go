@@ -404,6 +404,18 @@ func TestFormatDuration(t *testing.T) {
 	}
 }
 
+func TestFormatRelativeTime(t *testing.T) {
+	if got := formatRelativeTime(time.Time{}); got != "unknown" {
+		t.Errorf("formatRelativeTime(zero) = %q, want %q", got, "unknown")
+	}
+	if got := formatRelativeTime(time.Now().Add(-2*time.Hour - time.Minute)); got != "2h ago" {
+		t.Errorf("formatRelativeTime(2h ago) = %q, want %q", got, "2h ago")
+	}
+	if got := formatRelativeTime(time.Now().Add(-10 * time.Second)); got != "just now" {
+		t.Errorf("formatRelativeTime(10s ago) = %q, want %q", got, "just now")
+	}
+}
+
 func TestAnalyticsPanel_View_SmallWidth(t *testing.T) {
 	panel := NewAnalyticsPanel()
 
@@ -607,3 +619,70 @@ func TestAnalyticsPanel_SetDisplaySettings(t *testing.T) {
 		t.Error("View should NOT show tools when disabled")
 	}
 }
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int{0, 7, 14}); got != "▁▄█" {
+		t.Errorf("sparkline = %q, want %q", got, "▁▄█")
+	}
+	if got := sparkline([]int{0, 0}); got != "▁▁" {
+		t.Errorf("all-zero sparkline = %q, want %q", got, "▁▁")
+	}
+}
+
+func TestAnalyticsPanel_GeminiTimeline(t *testing.T) {
+	panel := NewAnalyticsPanel()
+	analytics := &session.GeminiSessionAnalytics{InputTokens: 300, OutputTokens: 30}
+	for i := range 100 {
+		analytics.TurnTokens = append(analytics.TurnTokens, session.GeminiTurnTokens{Input: i, Output: 1})
+	}
+	panel.SetGeminiAnalytics(analytics)
+	panel.SetDisplaySettings(allSectionsEnabled())
+	panel.SetSize(30, 20)
+
+	view := panel.View()
+	if !strings.Contains(view, "Trend:") {
+		t.Fatalf("View should show the turn sparkline:\n%s", view)
+	}
+	if !strings.Contains(view, "█") {
+		t.Errorf("sparkline should reach the top block:\n%s", view)
+	}
+
+	// A single turn has no trend to show.
+	analytics.TurnTokens = analytics.TurnTokens[:1]
+	if view := panel.View(); strings.Contains(view, "Trend:") {
+		t.Errorf("single-turn session should not render a sparkline:\n%s", view)
+	}
+}
+
+func TestAnalyticsPanel_GeminiLastActive(t *testing.T) {
+	panel := NewAnalyticsPanel()
+	panel.SetGeminiAnalytics(&session.GeminiSessionAnalytics{
+		TotalTurns: 4,
+		LastActive: time.Now().Add(-3 * time.Hour),
+	})
+	panel.SetDisplaySettings(allSectionsEnabled())
+	panel.SetSize(60, 40)
+
+	if view := panel.View(); !strings.Contains(view, "Last active: 3h ago") {
+		t.Fatalf("View should show the relative last-active time:\n%s", view)
+	}
+}
+
+func TestAnalyticsPanel_GeminiModelBreakdown(t *testing.T) {
+	panel := NewAnalyticsPanel()
+	panel.SetGeminiAnalytics(&session.GeminiSessionAnalytics{
+		InputTokens: 50000,
+		Model:       "gemini-2.5-flash",
+		ModelBreakdown: map[string]session.GeminiTokenCount{
+			"gemini-2.5-pro":   {Input: 40000},
+			"gemini-2.5-flash": {Input: 10000},
+		},
+	})
+	panel.SetDisplaySettings(allSectionsEnabled())
+	panel.SetSize(80, 40)
+
+	view := panel.View()
+	if !strings.Contains(view, "2.5-pro: 40.0K, 2.5-flash: 10.0K") {
+		t.Fatalf("View should list per-model tokens, most first:\n%s", view)
+	}
+}
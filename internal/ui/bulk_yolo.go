@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// bulkYoloPlan is a group-wide YOLO toggle waiting on its ConfirmBulkYolo
+// dialog: the Gemini sessions to switch, and the other sessions in the group,
+// which are skipped.
+type bulkYoloPlan struct {
+	enable  bool
+	ids     []string
+	titles  []string
+	models  []string // effective model of each switched session; "" if unknown
+	skipped []string
+}
+
+// bulkYoloDoneMsg reports a confirmed bulk YOLO toggle once every running
+// session has been restarted.
+type bulkYoloDoneMsg struct {
+	enable    bool
+	ids       []string // sessions restarted or attempted
+	restarted []string
+	failed    []string // "title: error"
+	updated   []string // not running; the new mode applies on next start
+	skipped   []string
+}
+
+// geminiYoloEnabled reports a Gemini session's effective YOLO mode: its own
+// setting, else [gemini].yolo_mode.
+func geminiYoloEnabled(inst *session.Instance, cfg *session.UserConfig) bool {
+	if inst.GeminiYoloMode != nil {
+		return *inst.GeminiYoloMode
+	}
+	return cfg != nil && cfg.Gemini.YoloMode
+}
+
+// geminiEffectiveModel is the model a Gemini session runs: its own, else
+// [gemini].default_model; "" when neither is set.
+func geminiEffectiveModel(inst *session.Instance, cfg *session.UserConfig) string {
+	if inst.GeminiModel != "" {
+		return inst.GeminiModel
+	}
+	if cfg != nil {
+		return cfg.Gemini.DefaultModel
+	}
+	return ""
+}
+
+// yoloCostMinOutputPerMtok is the output price (USD per million tokens) from
+// which auto-approving a model gets a cost note: the pro tier.
+const yoloCostMinOutputPerMtok = 5.0
+
+// yoloCostNote warns that auto-approve on a high-priced model can run up
+// cost, naming those models. Models without known pricing are left out, so
+// the note is "" unless at least one priced model is expensive.
+func yoloCostNote(models []string) string {
+	var pricey []string
+	for _, m := range models {
+		if p, ok := session.LookupGeminiPricing(m); ok && p.Output >= yoloCostMinOutputPerMtok && !slices.Contains(pricey, m) {
+			pricey = append(pricey, m)
+		}
+	}
+	if len(pricey) == 0 {
+		return ""
+	}
+	return strings.Join(pricey, ", ") + " + auto-approve can get expensive"
+}
+
+// planBulkYolo collects the sessions of groupPath and its subgroups. YOLO is
+// switched on for all Gemini sessions unless every one already has it, in
+// which case it is switched off. Archived sessions are left out.
+func planBulkYolo(instances []*session.Instance, groupPath string, cfg *session.UserConfig) *bulkYoloPlan {
+	plan := &bulkYoloPlan{}
+	for _, inst := range instances {
+		if inst.IsArchived() {
+			continue
+		}
+		if g := inst.Group(); g != groupPath && !strings.HasPrefix(g, groupPath+"/") {
+			continue
+		}
+		if inst.Tool != "gemini" {
+			plan.skipped = append(plan.skipped, inst.Title)
+			continue
+		}
+		plan.ids = append(plan.ids, inst.ID)
+		plan.titles = append(plan.titles, inst.Title)
+		plan.models = append(plan.models, geminiEffectiveModel(inst, cfg))
+		if !geminiYoloEnabled(inst, cfg) {
+			plan.enable = true
+		}
+	}
+	return plan
+}
+
+// startBulkYolo asks to toggle YOLO for every Gemini session in groupPath.
+func (h *Home) startBulkYolo(groupPath string) {
+	cfg, _ := session.LoadUserConfig()
+	h.instancesMu.RLock()
+	plan := planBulkYolo(h.instances, groupPath, cfg)
+	h.instancesMu.RUnlock()
+	if len(plan.ids) == 0 {
+		h.setError(fmt.Errorf("no Gemini sessions in this group"))
+		return
+	}
+	h.pendingBulkYolo = plan
+	h.confirmDialog.ShowBulkYolo(plan.titles, plan.skipped, plan.models, plan.enable)
+}
+
+// applyBulkYolo sets the planned YOLO mode on each Gemini session and restarts
+// the running ones, one after another, reporting the outcome in a single
+// bulkYoloDoneMsg.
+func (h *Home) applyBulkYolo() tea.Cmd {
+	plan := h.pendingBulkYolo
+	h.pendingBulkYolo = nil
+	if plan == nil {
+		return nil
+	}
+
+	done := bulkYoloDoneMsg{enable: plan.enable, skipped: plan.skipped}
+	var running []string
+	for _, id := range plan.ids {
+		inst := h.getInstanceByID(id)
+		if inst == nil {
+			continue
+		}
+		inst.SetGeminiYoloMode(plan.enable)
+		switch inst.GetStatusThreadSafe() {
+		case session.StatusRunning, session.StatusWaiting:
+			h.resumingSessions[inst.ID] = time.Now()
+			running = append(running, inst.ID)
+		default:
+			done.updated = append(done.updated, inst.Title)
+		}
+	}
+	h.saveInstances()
+
+	done.ids = running
+	return func() tea.Msg {
+		for _, id := range running {
+			// Resolve by ID: a storage reload can replace the instance
+			// pointers while earlier restarts run (see restartSession).
+			h.instancesMu.RLock()
+			inst := h.instanceByID[id]
+			h.instancesMu.RUnlock()
+			if inst == nil {
+				continue
+			}
+			if err := inst.Restart(); err != nil {
+				done.failed = append(done.failed, fmt.Sprintf("%s: %v", inst.Title, err))
+			} else {
+				done.restarted = append(done.restarted, inst.Title)
+			}
+		}
+		return done
+	}
+}
+
+// handleBulkYoloDone clears the restart animations and shows the summary.
+func (h *Home) handleBulkYoloDone(msg bulkYoloDoneMsg) {
+	for _, id := range msg.ids {
+		delete(h.resumingSessions, id)
+	}
+	if len(msg.restarted) > 0 {
+		h.saveInstances()
+	}
+	h.confirmDialog.ShowNotice(bulkYoloSummary(msg))
+}
+
+// bulkYoloSummary renders the notice shown after a bulk YOLO toggle.
+func bulkYoloSummary(msg bulkYoloDoneMsg) (title, body string) {
+	state := "off"
+	if msg.enable {
+		state = "on"
+	}
+	title = "YOLO " + state
+	if len(msg.failed) > 0 {
+		title += " (with errors)"
+	}
+
+	var b strings.Builder
+	section := func(label string, titles []string) {
+		if len(titles) == 0 {
+			return
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "%s (%d):", label, len(titles))
+		for _, t := range titles {
+			b.WriteString("\n  " + t)
+		}
+	}
+	section("Restarted", msg.restarted)
+	section("Restart failed", msg.failed)
+	section("Updated, applies on next start", msg.updated)
+	section("Skipped, not Gemini", msg.skipped)
+	return title, b.String()
+}
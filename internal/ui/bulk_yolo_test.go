@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func bulkYoloInstances() []*session.Instance {
+	on := true
+	a := session.NewInstanceWithGroupAndTool("gem-a", "/tmp", "work", "gemini")
+	b := session.NewInstanceWithGroupAndTool("gem-b", "/tmp", "work/api", "gemini")
+	b.GeminiYoloMode = &on
+	c := session.NewInstanceWithGroupAndTool("claude-c", "/tmp", "work", "claude")
+	d := session.NewInstanceWithGroupAndTool("gem-other", "/tmp", "workshop", "gemini")
+	for _, inst := range []*session.Instance{a, b, c, d} {
+		inst.Status = session.StatusStopped
+	}
+	return []*session.Instance{a, b, c, d}
+}
+
+func TestPlanBulkYolo(t *testing.T) {
+	instances := bulkYoloInstances()
+
+	plan := planBulkYolo(instances, "work", nil)
+	if strings.Join(plan.titles, ",") != "gem-a,gem-b" {
+		t.Errorf("titles = %v, want the group's and subgroup's Gemini sessions only", plan.titles)
+	}
+	if strings.Join(plan.skipped, ",") != "claude-c" {
+		t.Errorf("skipped = %v, want the non-Gemini session", plan.skipped)
+	}
+	if !plan.enable {
+		t.Error("with one session off, the bulk toggle must switch YOLO on")
+	}
+
+	on := true
+	instances[0].GeminiYoloMode = &on
+	if planBulkYolo(instances, "work", nil).enable {
+		t.Error("with every session on, the bulk toggle must switch YOLO off")
+	}
+}
+
+func TestBulkYolo_ConfirmAppliesAndReports(t *testing.T) {
+	h := NewHome()
+	instances := bulkYoloInstances()
+	h.instancesMu.Lock()
+	h.instances = instances
+	for _, inst := range instances {
+		h.instanceByID[inst.ID] = inst
+	}
+	h.instancesMu.Unlock()
+
+	h.startBulkYolo("work")
+	if !h.confirmDialog.IsVisible() || h.confirmDialog.GetConfirmType() != ConfirmBulkYolo {
+		t.Fatal("expected the aggregated ConfirmBulkYolo dialog")
+	}
+	view := ansi.Strip(h.confirmDialog.View())
+	for _, want := range []string{"Enable YOLO Mode?", "2 Gemini session(s)", "gem-a", "Skipped, not Gemini: 1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("confirm view missing %q", want)
+		}
+	}
+
+	_, cmd := h.handleConfirmDialogKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("confirm must return the bulk restart command")
+	}
+	for _, inst := range instances[:2] {
+		if inst.GeminiYoloMode == nil || !*inst.GeminiYoloMode {
+			t.Errorf("%s: YOLO not switched on", inst.Title)
+		}
+	}
+	if instances[3].GeminiYoloMode != nil {
+		t.Error("a session outside the group must be left alone")
+	}
+
+	msg := cmd().(bulkYoloDoneMsg)
+	if len(msg.updated) != 2 || len(msg.restarted) != 0 || len(msg.skipped) != 1 {
+		t.Errorf("stopped sessions are updated, not restarted: %+v", msg)
+	}
+	h.handleBulkYoloDone(msg)
+	if h.confirmDialog.GetConfirmType() != ConfirmNotice {
+		t.Fatal("expected a summary notice after the bulk toggle")
+	}
+}
+
+func TestBulkYoloSummary(t *testing.T) {
+	title, body := bulkYoloSummary(bulkYoloDoneMsg{
+		enable:    false,
+		restarted: []string{"gem-a"},
+		failed:    []string{"gem-b: tmux gone"},
+		skipped:   []string{"claude-c"},
+	})
+	if title != "YOLO off (with errors)" {
+		t.Errorf("title = %q", title)
+	}
+	for _, want := range []string{"Restarted (1):\n  gem-a", "Restart failed (1):\n  gem-b: tmux gone", "Skipped, not Gemini (1):\n  claude-c"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestBulkYolo_NoGeminiSessions(t *testing.T) {
+	h := NewHome()
+	h.startBulkYolo("empty")
+	if h.confirmDialog.IsVisible() || h.pendingBulkYolo != nil {
+		t.Fatal("a group without Gemini sessions must not open the confirm")
+	}
+}
+
+func TestYoloCostNote(t *testing.T) {
+	if note := yoloCostNote([]string{"gemini-2.5-flash", "", "unknown-model"}); note != "" {
+		t.Errorf("cheap/unknown models: note = %q, want none", note)
+	}
+	note := yoloCostNote([]string{"gemini-2.5-pro", "gemini-2.5-flash", "gemini-2.5-pro"})
+	if note != "gemini-2.5-pro + auto-approve can get expensive" {
+		t.Errorf("note = %q", note)
+	}
+}
+
+func TestBulkYolo_ConfirmShowsCostNote(t *testing.T) {
+	instances := bulkYoloInstances()
+	instances[0].GeminiModel = "gemini-2.5-pro"
+	cfg := &session.UserConfig{Gemini: session.GeminiSettings{DefaultModel: "gemini-2.5-flash"}}
+
+	plan := planBulkYolo(instances, "work", cfg)
+	if strings.Join(plan.models, ",") != "gemini-2.5-pro,gemini-2.5-flash" {
+		t.Fatalf("models = %v, want own model then [gemini].default_model", plan.models)
+	}
+
+	c := NewConfirmDialog()
+	c.SetSize(120, 50)
+	c.ShowBulkYolo(plan.titles, plan.skipped, plan.models, true)
+	if view := ansi.Strip(c.View()); !strings.Contains(view, "gemini-2.5-pro + auto-approve") {
+		t.Errorf("enabling YOLO on a pro model should note the cost:\n%s", view)
+	}
+	c.ShowBulkYolo(plan.titles, plan.skipped, plan.models, false)
+	if view := ansi.Strip(c.View()); strings.Contains(view, "expensive") {
+		t.Errorf("disabling YOLO should not note the cost:\n%s", view)
+	}
+}
@@ -1,9 +1,11 @@
 package ui
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -24,12 +26,27 @@ type ClaudeOptionsPanel struct {
 	// not persisted to SQLite. Fork inherits nothing here (fork resumes an
 	// existing session; the query has already been consumed).
 	startQueryInput textinput.Model
+	// MCP server config file path, passed via --mcp-config. NewDialog only.
+	mcpConfigInput textinput.Model
+	// Text appended to Claude's system prompt (--append-system-prompt).
+	// Multi-line: Enter inserts a newline while focused. NewDialog only.
+	appendPromptInput textarea.Model
+	// Permission mode: index into session.ClaudePermissionModes (0 = default)
+	permissionMode int
+	// Allowed tools checkbox list (--allowedTools); one focus row navigated
+	// with ←/→. Nothing selected means Claude's defaults. NewDialog only.
+	allowedTools      map[string]bool
+	allowedToolCursor int
 	// Checkbox states
 	skipPermissions      bool
 	allowSkipPermissions bool
 	autoMode             bool
 	useChrome            bool
 	useTeammateMode      bool
+	// Option presets ("safe", "fast", "yolo" plus [claude.presets]) and their
+	// cycle order; "p" applies the next one.
+	presets     map[string]session.ClaudePreset
+	presetNames []string
 	// Focus tracking
 	focusIndex int
 	// Whether this panel is for fork dialog (fewer options)
@@ -38,11 +55,23 @@ type ClaudeOptionsPanel struct {
 	focusCount int
 }
 
+// claudeAllowedToolChoices are the tools offered in the allowed-tools list
+var claudeAllowedToolChoices = []string{"Bash", "Edit", "Write", "Read", "Glob", "Grep", "WebFetch", "WebSearch"}
+
 // Focus indices for NewDialog mode:
 // 0: Session mode (radio)
 // 1: Resume ID input (only when mode=resume)
-// 2: Skip permissions checkbox
-// 3: Chrome checkbox
+// 2: Permission mode selector
+// 3: Skip permissions checkbox
+// 4: Auto mode checkbox
+// 5: Chrome checkbox
+// 6: Teammate mode checkbox
+// 7: Allowed tools list (←/→ move, space toggles)
+// 8: Extra args input
+// 9: MCP config input
+// 10: System prompt textarea
+// 11: Start query input
+// (indices after 0 shift down by one when the resume ID input is hidden)
 
 // Focus indices for ForkDialog mode:
 // 0: Skip permissions checkbox
@@ -65,38 +94,138 @@ func NewClaudeOptionsPanel() *ClaudeOptionsPanel {
 	startQueryInput.CharLimit = 1024
 	startQueryInput.Width = 44
 
+	mcpConfigInput := textinput.New()
+	mcpConfigInput.Placeholder = "~/.config/mcp/servers.json"
+	mcpConfigInput.CharLimit = 512
+	mcpConfigInput.Width = 44
+
+	appendPromptInput := newAppendPromptInput()
+	appendPromptInput.Placeholder = "appended to the system prompt (Enter = newline)"
+	appendPromptInput.SetWidth(44)
+	appendPromptInput.SetHeight(3)
+
+	presets, presetNames := (*session.ClaudeSettings)(nil).GetPresets()
+
 	return &ClaudeOptionsPanel{
-		sessionMode:     0, // new
-		resumeIDInput:   resumeInput,
-		extraArgsInput:  extraArgsInput,
-		startQueryInput: startQueryInput,
-		isForkMode:      false,
-		focusCount:      7, // session, skip, auto, chrome, teammate, extra-args, start-query
+		sessionMode:       0, // new
+		resumeIDInput:     resumeInput,
+		extraArgsInput:    extraArgsInput,
+		startQueryInput:   startQueryInput,
+		mcpConfigInput:    mcpConfigInput,
+		appendPromptInput: appendPromptInput,
+		allowedTools:      make(map[string]bool),
+		presets:           presets,
+		presetNames:       presetNames,
+		isForkMode:        false,
+		focusCount:        11, // session, permission, skip, auto, chrome, teammate, allowed-tools, extra-args, mcp-config, system-prompt, start-query
 	}
 }
 
 // NewClaudeOptionsPanelForFork creates a panel for ForkDialog (fewer options)
 func NewClaudeOptionsPanelForFork() *ClaudeOptionsPanel {
 	return &ClaudeOptionsPanel{
-		sessionMode:     0,
-		resumeIDInput:   textinput.New(), // Not used in fork mode
-		extraArgsInput:  textinput.New(), // Not used in fork mode
-		startQueryInput: textinput.New(), // Not used in fork mode
-		isForkMode:      true,
-		focusCount:      3, // skip, chrome, teammate
+		sessionMode:       0,
+		resumeIDInput:     textinput.New(),        // Not used in fork mode
+		extraArgsInput:    textinput.New(),        // Not used in fork mode
+		startQueryInput:   textinput.New(),        // Not used in fork mode
+		mcpConfigInput:    textinput.New(),        // Not shown in fork mode; carries the config default
+		appendPromptInput: newAppendPromptInput(), // Not shown in fork mode; carries the config default
+		allowedTools:      make(map[string]bool),
+		isForkMode:        true,
+		focusCount:        3, // skip, chrome, teammate
 	}
 }
 
+// newAppendPromptInput creates the borderless textarea for the system prompt field
+func newAppendPromptInput() textarea.Model {
+	ta := textarea.New()
+	ta.ShowLineNumbers = false
+	ta.Prompt = ""
+	ta.CharLimit = 4096
+	ta.Blur()
+	return ta
+}
+
 // SetDefaults applies default values from config
 func (p *ClaudeOptionsPanel) SetDefaults(config *session.UserConfig) {
 	if config != nil {
 		p.skipPermissions = config.Claude.GetDangerousMode()
 		p.allowSkipPermissions = config.Claude.AllowDangerousMode
+		p.setPermissionMode(config.Claude.PermissionMode)
 		p.autoMode = config.Claude.AutoMode
 		p.SetExtraArgs(config.Claude.ExtraArgs)
 		p.useChrome = config.Claude.UseChrome
 		p.useTeammateMode = config.Claude.UseTeammateMode
+		p.mcpConfigInput.SetValue(config.Claude.MCPConfig)
+		p.appendPromptInput.SetValue(config.Claude.AppendSystemPrompt)
+		p.presets, p.presetNames = config.Claude.GetPresets()
+	}
+}
+
+// ApplyPreset sets the permission options to the named preset, leaving the
+// other fields alone. It reports whether the preset exists.
+func (p *ClaudeOptionsPanel) ApplyPreset(name string) bool {
+	preset, ok := p.presets[name]
+	if !ok {
+		return false
+	}
+	p.setPermissionMode(preset.PermissionMode)
+	p.skipPermissions = preset.SkipPermissions
+	p.autoMode = preset.AutoMode
+	p.allowedTools = make(map[string]bool)
+	for _, tool := range preset.AllowedTools {
+		p.allowedTools[tool] = true
+	}
+	return true
+}
+
+// currentPreset returns the name of the preset the panel's fields match, or
+// "" when they have been customized.
+func (p *ClaudeOptionsPanel) currentPreset() string {
+	for _, name := range p.presetNames {
+		preset := p.presets[name]
+		if p.GetPermissionMode() == normalizePermissionMode(preset.PermissionMode) &&
+			p.skipPermissions == preset.SkipPermissions &&
+			p.autoMode == preset.AutoMode &&
+			slices.Equal(p.GetAllowedTools(), allowedToolsInDisplayOrder(preset.AllowedTools)) {
+			return name
+		}
+	}
+	return ""
+}
+
+// normalizePermissionMode maps mode to what GetPermissionMode would return
+// after selecting it: "" for "default" and unknown values.
+func normalizePermissionMode(mode string) string {
+	if mode == "default" || !slices.Contains(session.ClaudePermissionModes, mode) {
+		return ""
+	}
+	return mode
+}
+
+// allowedToolsInDisplayOrder filters tools to the offered choices, in the
+// order GetAllowedTools returns them.
+func allowedToolsInDisplayOrder(tools []string) []string {
+	var ordered []string
+	for _, tool := range claudeAllowedToolChoices {
+		if slices.Contains(tools, tool) {
+			ordered = append(ordered, tool)
+		}
+	}
+	return ordered
+}
+
+// cyclePreset applies the preset after the one currently matched, or the
+// first one when the fields are customized.
+func (p *ClaudeOptionsPanel) cyclePreset() {
+	if len(p.presetNames) == 0 {
+		return
 	}
+	next := 0
+	if i := slices.Index(p.presetNames, p.currentPreset()); i >= 0 {
+		next = (i + 1) % len(p.presetNames)
+	}
+	p.ApplyPreset(p.presetNames[next])
 }
 
 // SetFromOptions applies persisted ClaudeOptions to the panel fields.
@@ -115,9 +244,16 @@ func (p *ClaudeOptionsPanel) SetFromOptions(opts *session.ClaudeOptions) {
 	}
 	p.skipPermissions = opts.SkipPermissions
 	p.allowSkipPermissions = opts.AllowSkipPermissions
+	p.setPermissionMode(opts.PermissionMode)
 	p.autoMode = opts.AutoMode
 	p.useChrome = opts.UseChrome
 	p.useTeammateMode = opts.UseTeammateMode
+	p.mcpConfigInput.SetValue(opts.MCPConfig)
+	p.appendPromptInput.SetValue(opts.AppendSystemPrompt)
+	p.allowedTools = make(map[string]bool)
+	for _, tool := range opts.AllowedTools {
+		p.allowedTools[tool] = true
+	}
 	p.updateInputFocus()
 	p.focusCount = p.getFocusCount()
 }
@@ -134,6 +270,8 @@ func (p *ClaudeOptionsPanel) Blur() {
 	p.resumeIDInput.Blur()
 	p.extraArgsInput.Blur()
 	p.startQueryInput.Blur()
+	p.mcpConfigInput.Blur()
+	p.appendPromptInput.Blur()
 }
 
 // GetExtraArgs returns the parsed extra-args tokens (whitespace-split, empties dropped).
@@ -177,6 +315,62 @@ func (p *ClaudeOptionsPanel) ResetStartQuery() {
 	p.startQueryInput.SetValue("")
 }
 
+// setPermissionMode selects mode in the cycle, falling back to "default"
+// for empty or unknown values.
+func (p *ClaudeOptionsPanel) setPermissionMode(mode string) {
+	p.permissionMode = 0
+	for i, m := range session.ClaudePermissionModes {
+		if m == mode {
+			p.permissionMode = i
+			return
+		}
+	}
+}
+
+// GetPermissionMode returns the selected permission mode, or "" for default.
+func (p *ClaudeOptionsPanel) GetPermissionMode() string {
+	if p.permissionMode <= 0 || p.permissionMode >= len(session.ClaudePermissionModes) {
+		return ""
+	}
+	return session.ClaudePermissionModes[p.permissionMode]
+}
+
+// cyclePermissionMode moves the permission mode selection by delta, wrapping.
+func (p *ClaudeOptionsPanel) cyclePermissionMode(delta int) {
+	n := len(session.ClaudePermissionModes)
+	p.permissionMode = ((p.permissionMode+delta)%n + n) % n
+}
+
+// GetAllowedTools returns the selected tools in display order, or nil when
+// none are selected (omit --allowedTools).
+func (p *ClaudeOptionsPanel) GetAllowedTools() []string {
+	var tools []string
+	for _, tool := range claudeAllowedToolChoices {
+		if p.allowedTools[tool] {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// GetMCPConfig returns the trimmed MCP config path ("" when unset).
+func (p *ClaudeOptionsPanel) GetMCPConfig() string {
+	return strings.Trim(strings.TrimSpace(p.mcpConfigInput.Value()), "'\"")
+}
+
+// GetAppendSystemPrompt returns the trimmed system prompt text ("" when unset).
+func (p *ClaudeOptionsPanel) GetAppendSystemPrompt() string {
+	return strings.TrimSpace(p.appendPromptInput.Value())
+}
+
+// Validate returns an error message for invalid panel input, or "" when valid.
+func (p *ClaudeOptionsPanel) Validate() string {
+	if err := session.ValidateMCPConfigFile(p.GetMCPConfig()); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
 // IsFocused returns true if any element in the panel has focus
 func (p *ClaudeOptionsPanel) IsFocused() bool {
 	return p.focusIndex >= 0
@@ -192,9 +386,13 @@ func (p *ClaudeOptionsPanel) GetOptions() *session.ClaudeOptions {
 	opts := &session.ClaudeOptions{
 		SkipPermissions:      p.skipPermissions,
 		AllowSkipPermissions: p.allowSkipPermissions,
+		PermissionMode:       p.GetPermissionMode(),
 		AutoMode:             p.autoMode,
 		UseChrome:            p.useChrome,
 		UseTeammateMode:      p.useTeammateMode,
+		MCPConfig:            p.GetMCPConfig(),
+		AppendSystemPrompt:   p.GetAppendSystemPrompt(),
+		AllowedTools:         p.GetAllowedTools(),
 	}
 
 	if !p.isForkMode {
@@ -243,14 +441,37 @@ func (p *ClaudeOptionsPanel) Update(msg tea.Msg) tea.Cmd {
 
 		case " ":
 			// Don't intercept space when focused on a text input
-			if p.isResumeInputFocused() || p.isExtraArgsInputFocused() || p.isStartQueryInputFocused() {
+			if p.isTextInputFocused() {
 				break // Let it fall through to text input handling
 			}
 			// Toggle checkbox or radio at current focus
 			p.handleSpaceKey()
 			return nil
 
+		case "p":
+			if !p.isForkMode && !p.isTextInputFocused() {
+				p.cyclePreset()
+				return nil
+			}
+
 		case "left", "right":
+			if p.getFocusType() == "permissionMode" {
+				if msg.String() == "left" {
+					p.cyclePermissionMode(-1)
+				} else {
+					p.cyclePermissionMode(1)
+				}
+				return nil
+			}
+			if p.getFocusType() == "allowedTools" {
+				n := len(claudeAllowedToolChoices)
+				if msg.String() == "left" {
+					p.allowedToolCursor = (p.allowedToolCursor - 1 + n) % n
+				} else {
+					p.allowedToolCursor = (p.allowedToolCursor + 1) % n
+				}
+				return nil
+			}
 			// For session mode radio buttons
 			if !p.isForkMode && p.focusIndex == 0 {
 				if msg.String() == "left" {
@@ -277,6 +498,16 @@ func (p *ClaudeOptionsPanel) Update(msg tea.Msg) tea.Cmd {
 		p.extraArgsInput, cmd = p.extraArgsInput.Update(msg)
 		return cmd
 	}
+	if p.isMCPConfigInputFocused() {
+		var cmd tea.Cmd
+		p.mcpConfigInput, cmd = p.mcpConfigInput.Update(msg)
+		return cmd
+	}
+	if p.isAppendPromptFocused() {
+		var cmd tea.Cmd
+		p.appendPromptInput, cmd = p.appendPromptInput.Update(msg)
+		return cmd
+	}
 	if p.isStartQueryInputFocused() {
 		var cmd tea.Cmd
 		p.startQueryInput, cmd = p.startQueryInput.Update(msg)
@@ -305,6 +536,8 @@ func (p *ClaudeOptionsPanel) handleSpaceKey() {
 		case "sessionMode":
 			// Cycle through modes on space
 			p.sessionMode = (p.sessionMode + 1) % 3
+		case "permissionMode":
+			p.cyclePermissionMode(1)
 		case "skipPermissions":
 			p.skipPermissions = !p.skipPermissions
 		case "autoMode":
@@ -313,107 +546,85 @@ func (p *ClaudeOptionsPanel) handleSpaceKey() {
 			p.useChrome = !p.useChrome
 		case "teammateMode":
 			p.useTeammateMode = !p.useTeammateMode
+		case "allowedTools":
+			tool := claudeAllowedToolChoices[p.allowedToolCursor]
+			p.allowedTools[tool] = !p.allowedTools[tool]
 		}
 	}
 }
 
+// focusTypes returns the focusable elements in display order
+func (p *ClaudeOptionsPanel) focusTypes() []string {
+	if p.isForkMode {
+		return []string{"skipPermissions", "autoMode", "chrome", "teammateMode"}
+	}
+	types := []string{"sessionMode"}
+	// Resume ID input is only focusable when mode == resume
+	if p.sessionMode == 2 {
+		types = append(types, "resumeInput")
+	}
+	return append(types,
+		"permissionMode",
+		"skipPermissions",
+		"autoMode",
+		"chrome",
+		"teammateMode",
+		"allowedTools",
+		"extraArgsInput",
+		"mcpConfigInput",
+		"appendPromptInput",
+		"startQueryInput", // v1.7.67
+	)
+}
+
 // getFocusType returns what type of element is currently focused
 func (p *ClaudeOptionsPanel) getFocusType() string {
-	if p.isForkMode {
-		switch p.focusIndex {
-		case 0:
-			return "skipPermissions"
-		case 1:
-			return "autoMode"
-		case 2:
-			return "chrome"
-		case 3:
-			return "teammateMode"
-		}
-	} else {
-		idx := p.focusIndex
-		// 0: session mode
-		if idx == 0 {
-			return "sessionMode"
-		}
-		// 1: resume input (only if mode == resume)
-		if p.sessionMode == 2 {
-			if idx == 1 {
-				return "resumeInput"
-			}
-			idx-- // Adjust for missing resume input
-		}
-		// 2: skip permissions
-		if idx == 1 {
-			return "skipPermissions"
-		}
-		// 3: auto mode
-		if idx == 2 {
-			return "autoMode"
-		}
-		// 4: chrome
-		if idx == 3 {
-			return "chrome"
-		}
-		// 5: teammate mode
-		if idx == 4 {
-			return "teammateMode"
-		}
-		// 6: extra-args input
-		if idx == 5 {
-			return "extraArgsInput"
-		}
-		// 7: start-query input (v1.7.67)
-		if idx == 6 {
-			return "startQueryInput"
-		}
+	types := p.focusTypes()
+	if p.focusIndex < 0 || p.focusIndex >= len(types) {
+		return ""
 	}
-	return ""
+	return types[p.focusIndex]
 }
 
 // getFocusCount returns the number of focusable elements
 func (p *ClaudeOptionsPanel) getFocusCount() int {
-	if p.isForkMode {
-		return 4 // skip, auto, chrome, teammate
-	}
-
-	count := 7 // session mode, skip, auto, chrome, teammate, extra-args, start-query
-	if p.sessionMode == 2 {
-		count++ // resume input
-	}
-	return count
+	return len(p.focusTypes())
 }
 
 // isResumeInputFocused returns true if resume input is focused
 func (p *ClaudeOptionsPanel) isResumeInputFocused() bool {
-	return !p.isForkMode && p.sessionMode == 2 && p.focusIndex == 1
+	return p.getFocusType() == "resumeInput"
 }
 
 // isExtraArgsInputFocused returns true if extra-args input is focused.
-// Index shifts by +1 when resume mode is active (resume ID input adds a row).
 func (p *ClaudeOptionsPanel) isExtraArgsInputFocused() bool {
-	if p.isForkMode {
-		return false
-	}
-	want := 5 // default: session(0) + skip(1) + auto(2) + chrome(3) + teammate(4) + extraArgs(5)
-	if p.sessionMode == 2 {
-		want = 6 // resume input inserts between session and skip
-	}
-	return p.focusIndex == want
+	return p.getFocusType() == "extraArgsInput"
+}
+
+// isMCPConfigInputFocused returns true if MCP config input is focused.
+func (p *ClaudeOptionsPanel) isMCPConfigInputFocused() bool {
+	return p.getFocusType() == "mcpConfigInput"
+}
+
+// isAppendPromptFocused returns true if the system prompt textarea is focused.
+// NewDialog routes Enter here (newline) instead of submitting while true.
+func (p *ClaudeOptionsPanel) isAppendPromptFocused() bool {
+	return p.getFocusType() == "appendPromptInput"
 }
 
 // isStartQueryInputFocused returns true if start-query input is focused.
-// Last focusable element in NewDialog mode (v1.7.67). Index shifts by +1
-// when resume mode is active.
+// Last focusable element in NewDialog mode (v1.7.67).
 func (p *ClaudeOptionsPanel) isStartQueryInputFocused() bool {
-	if p.isForkMode {
-		return false
-	}
-	want := 6 // default: after extraArgs(5)
-	if p.sessionMode == 2 {
-		want = 7 // resume input adds one
+	return p.getFocusType() == "startQueryInput"
+}
+
+// isTextInputFocused returns true if any text input in the panel is focused
+func (p *ClaudeOptionsPanel) isTextInputFocused() bool {
+	switch p.getFocusType() {
+	case "resumeInput", "extraArgsInput", "mcpConfigInput", "appendPromptInput", "startQueryInput":
+		return true
 	}
-	return p.focusIndex == want
+	return false
 }
 
 // updateInputFocus updates which text input has focus
@@ -421,6 +632,8 @@ func (p *ClaudeOptionsPanel) updateInputFocus() {
 	p.resumeIDInput.Blur()
 	p.extraArgsInput.Blur()
 	p.startQueryInput.Blur()
+	p.mcpConfigInput.Blur()
+	p.appendPromptInput.Blur()
 
 	if p.isResumeInputFocused() {
 		p.resumeIDInput.Focus()
@@ -428,6 +641,12 @@ func (p *ClaudeOptionsPanel) updateInputFocus() {
 	if p.isExtraArgsInputFocused() {
 		p.extraArgsInput.Focus()
 	}
+	if p.isMCPConfigInputFocused() {
+		p.mcpConfigInput.Focus()
+	}
+	if p.isAppendPromptFocused() {
+		p.appendPromptInput.Focus()
+	}
 	if p.isStartQueryInputFocused() {
 		p.startQueryInput.Focus()
 	}
@@ -470,6 +689,23 @@ func (p *ClaudeOptionsPanel) viewNewMode(labelStyle, activeStyle, dimStyle, head
 	var content string
 	content += headerStyle.Render("─ Claude Options ─") + "\n"
 
+	// Preset row: not focusable, "p" anywhere outside a text input cycles it.
+	if len(p.presetNames) > 0 {
+		current := p.currentPreset()
+		content += "  Preset: "
+		for i, name := range p.presetNames {
+			if i > 0 {
+				content += dimStyle.Render(" · ")
+			}
+			if name == current {
+				content += activeStyle.Render(name)
+			} else {
+				content += dimStyle.Render(name)
+			}
+		}
+		content += dimStyle.Render("  (p cycles)") + "\n"
+	}
+
 	// Session mode radio buttons
 	focusIdx := 0
 	radioLabel := "  Session: "
@@ -492,6 +728,18 @@ func (p *ClaudeOptionsPanel) viewNewMode(labelStyle, activeStyle, dimStyle, head
 		focusIdx++
 	}
 
+	// Permission mode selector (cycles with ←/→ or space)
+	mode := session.ClaudePermissionModes[p.permissionMode]
+	if p.focusIndex == focusIdx {
+		content += activeStyle.Render("▶ Permission: ") + activeStyle.Render("◀ "+mode+" ▶") + "\n"
+	} else {
+		content += "  Permission: " + labelStyle.Render(mode) + "\n"
+	}
+	if p.GetPermissionMode() != "" && (p.skipPermissions || p.autoMode) {
+		content += dimStyle.Render("    ↑ overridden by skip permissions / auto mode") + "\n"
+	}
+	focusIdx++
+
 	// Skip permissions checkbox
 	content += renderCheckboxLine("Skip permissions", p.skipPermissions, p.focusIndex == focusIdx)
 	focusIdx++
@@ -511,6 +759,31 @@ func (p *ClaudeOptionsPanel) viewNewMode(labelStyle, activeStyle, dimStyle, head
 	content += renderCheckboxLine("Teammate mode", p.useTeammateMode, p.focusIndex == focusIdx)
 	focusIdx++
 
+	// Allowed tools list: a single focus row with its own ←/→ cursor.
+	focused := p.focusIndex == focusIdx
+	switch {
+	case focused:
+		content += activeStyle.Render("▶ Allowed tools:") + dimStyle.Render(" ←/→ move, space toggle") + "\n"
+	case len(p.GetAllowedTools()) == 0:
+		content += "  Allowed tools: " + dimStyle.Render("(Claude defaults)") + "\n"
+	default:
+		content += "  Allowed tools:\n"
+	}
+	if focused || len(p.GetAllowedTools()) > 0 {
+		for i, tool := range claudeAllowedToolChoices {
+			if i%4 == 0 {
+				content += "    "
+			}
+			content += renderCheckboxMark(p.allowedTools[tool], focused && i == p.allowedToolCursor) + " " + labelStyle.Render(tool)
+			if i%4 == 3 || i == len(claudeAllowedToolChoices)-1 {
+				content += "\n"
+			} else {
+				content += "  "
+			}
+		}
+	}
+	focusIdx++
+
 	// Extra args input (free-form space-separated claude CLI tokens).
 	if p.focusIndex == focusIdx {
 		content += activeStyle.Render("  ▶ Extra args: ") + p.extraArgsInput.View() + "\n"
@@ -519,6 +792,25 @@ func (p *ClaudeOptionsPanel) viewNewMode(labelStyle, activeStyle, dimStyle, head
 	}
 	focusIdx++
 
+	// MCP config file input, passed via --mcp-config.
+	if p.focusIndex == focusIdx {
+		content += activeStyle.Render("  ▶ MCP config: ") + p.mcpConfigInput.View() + "\n"
+	} else {
+		content += "    MCP config: " + p.mcpConfigInput.View() + "\n"
+	}
+	focusIdx++
+
+	// System prompt textarea (multi-line), passed via --append-system-prompt.
+	if p.focusIndex == focusIdx {
+		content += activeStyle.Render("  ▶ System prompt:") + dimStyle.Render(" (Enter = newline, Ctrl+S = create)") + "\n"
+	} else {
+		content += "    System prompt:\n"
+	}
+	for _, line := range strings.Split(p.appendPromptInput.View(), "\n") {
+		content += "      " + line + "\n"
+	}
+	focusIdx++
+
 	// Start query input (v1.7.67, #725): single positional arg for claude.
 	// Not split on spaces; not persisted (per-session only).
 	if p.focusIndex == focusIdx {
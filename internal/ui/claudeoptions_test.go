@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// focusPanelType moves focus to the first element of the given type.
+func focusPanelType(t *testing.T, p *ClaudeOptionsPanel, focusType string) {
+	t.Helper()
+	for i := range p.getFocusCount() {
+		p.focusIndex = i
+		if p.getFocusType() == focusType {
+			p.updateInputFocus()
+			return
+		}
+	}
+	t.Fatalf("focus type %q not found in %v", focusType, p.focusTypes())
+}
+
+func TestClaudeOptionsPanel_PermissionModeCycles(t *testing.T) {
+	p := NewClaudeOptionsPanel()
+	focusPanelType(t, p, "permissionMode")
+
+	if got := p.GetOptions().PermissionMode; got != "" {
+		t.Fatalf("initial PermissionMode = %q, want empty", got)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if got := p.GetOptions().PermissionMode; got != "acceptEdits" {
+		t.Fatalf("after right, PermissionMode = %q, want acceptEdits", got)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if got := p.GetOptions().PermissionMode; got != "plan" {
+		t.Fatalf("after space, PermissionMode = %q, want plan", got)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	p.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	p.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if got := p.GetOptions().PermissionMode; got != "bypassPermissions" {
+		t.Fatalf("left should wrap, PermissionMode = %q, want bypassPermissions", got)
+	}
+}
+
+func TestClaudeOptionsPanel_PermissionModeRoundTrip(t *testing.T) {
+	p := NewClaudeOptionsPanel()
+	p.SetFromOptions(&session.ClaudeOptions{PermissionMode: "plan"})
+	if got := p.GetOptions().PermissionMode; got != "plan" {
+		t.Fatalf("PermissionMode = %q, want plan", got)
+	}
+	p.SetFromOptions(&session.ClaudeOptions{PermissionMode: "bogus"})
+	if got := p.GetOptions().PermissionMode; got != "" {
+		t.Fatalf("unknown mode should fall back to default, got %q", got)
+	}
+}
+
+func TestClaudeOptionsPanel_MCPConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "mcp.json")
+	if err := os.WriteFile(cfgPath, []byte(`{"mcpServers":{}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewClaudeOptionsPanel()
+	p.SetDefaults(&session.UserConfig{Claude: session.ClaudeSettings{MCPConfig: cfgPath}})
+	if got := p.GetOptions().MCPConfig; got != cfgPath {
+		t.Fatalf("MCPConfig = %q, want config default %q", got, cfgPath)
+	}
+	if msg := p.Validate(); msg != "" {
+		t.Fatalf("Validate() = %q, want valid", msg)
+	}
+
+	focusPanelType(t, p, "mcpConfigInput")
+	if !p.isTextInputFocused() {
+		t.Fatal("MCP config input should be a text input")
+	}
+	p.mcpConfigInput.SetValue(filepath.Join(dir, "missing.json"))
+	if msg := p.Validate(); msg == "" {
+		t.Fatal("Validate() should reject a missing MCP config file")
+	}
+}
+
+func TestClaudeOptionsPanel_AppendSystemPromptMultiline(t *testing.T) {
+	d := NewNewDialog()
+	d.SetDefaultTool("claude")
+	d.SetSize(100, 50)
+	d.Show()
+	d.focusIndex = d.indexOf(focusOptions)
+	if d.focusIndex < 0 {
+		t.Fatal("focusOptions should be present for claude")
+	}
+	d.updateFocus()
+	focusPanelType(t, d.claudeOptions, "appendPromptInput")
+
+	if !d.shouldHandleEnterLocally() {
+		t.Fatal("Enter in the system prompt must stay local (newline), not submit")
+	}
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("be")},
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyRunes, Runes: []rune("terse")},
+	} {
+		d, _ = d.Update(key)
+	}
+
+	opts := d.GetClaudeOptions()
+	if opts.AppendSystemPrompt != "be\nterse" {
+		t.Fatalf("AppendSystemPrompt = %q, want %q", opts.AppendSystemPrompt, "be\nterse")
+	}
+}
+
+func TestClaudeOptionsPanel_AppendSystemPromptDefault(t *testing.T) {
+	p := NewClaudeOptionsPanel()
+	p.SetDefaults(&session.UserConfig{Claude: session.ClaudeSettings{AppendSystemPrompt: "standard prompt"}})
+	if got := p.GetOptions().AppendSystemPrompt; got != "standard prompt" {
+		t.Fatalf("AppendSystemPrompt = %q, want config default", got)
+	}
+}
+
+func TestClaudeOptionsPanel_AllowedTools(t *testing.T) {
+	p := NewClaudeOptionsPanel()
+	if got := p.GetOptions().AllowedTools; got != nil {
+		t.Fatalf("AllowedTools = %v, want nil so the flag is omitted", got)
+	}
+
+	focusPanelType(t, p, "allowedTools")
+	p.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}) // Bash
+	p.Update(tea.KeyMsg{Type: tea.KeyLeft})                      // wraps to WebSearch
+	p.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	p.Update(tea.KeyMsg{Type: tea.KeyRight}) // Bash
+	p.Update(tea.KeyMsg{Type: tea.KeyRight}) // Edit
+	p.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+
+	got := p.GetOptions().AllowedTools
+	want := []string{"Bash", "Edit", "WebSearch"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("AllowedTools = %v, want %v", got, want)
+	}
+
+	// Persisted selections are restored when re-opening from options.
+	p.SetFromOptions(&session.ClaudeOptions{AllowedTools: []string{"Read"}})
+	if got := p.GetAllowedTools(); len(got) != 1 || got[0] != "Read" {
+		t.Fatalf("SetFromOptions AllowedTools = %v, want [Read]", got)
+	}
+}
+
+func TestClaudeOptionsPanel_ApplyPreset(t *testing.T) {
+	p := NewClaudeOptionsPanel()
+
+	if !p.ApplyPreset("yolo") {
+		t.Fatal("built-in yolo preset missing")
+	}
+	if opts := p.GetOptions(); !opts.SkipPermissions || opts.PermissionMode != "" {
+		t.Fatalf("yolo: got %+v", opts)
+	}
+	if !p.ApplyPreset("fast") {
+		t.Fatal("built-in fast preset missing")
+	}
+	if opts := p.GetOptions(); opts.SkipPermissions || opts.PermissionMode != "acceptEdits" {
+		t.Fatalf("fast: got %+v", opts)
+	}
+	if p.ApplyPreset("nope") {
+		t.Fatal("unknown preset must report false")
+	}
+}
+
+func TestClaudeOptionsPanel_PresetCycleIncludesConfigured(t *testing.T) {
+	p := NewClaudeOptionsPanel()
+	p.SetDefaults(&session.UserConfig{Claude: session.ClaudeSettings{
+		DangerousMode: new(bool),
+		Presets: map[string]session.ClaudePreset{
+			"review": {PermissionMode: "plan", AllowedTools: []string{"Read", "Grep"}},
+		},
+	}})
+	focusPanelType(t, p, "skipPermissions")
+
+	press := func() string {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+		return p.currentPreset()
+	}
+	if got := p.currentPreset(); got != "safe" {
+		t.Fatalf("defaults should match safe, got %q", got)
+	}
+	for _, want := range []string{"fast", "yolo", "review", "safe"} {
+		if got := press(); got != want {
+			t.Fatalf("p cycled to %q, want %q", got, want)
+		}
+	}
+
+	p.ApplyPreset("review")
+	if got := p.GetAllowedTools(); strings.Join(got, ",") != "Read,Grep" {
+		t.Fatalf("review allowed tools = %v", got)
+	}
+	if !strings.Contains(p.View(), "review") {
+		t.Fatal("preset row should list configured presets")
+	}
+}
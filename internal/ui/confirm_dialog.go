@@ -3,10 +3,13 @@ package ui
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
 // ConfirmType indicates what action is being confirmed
@@ -25,7 +28,11 @@ const (
 	ConfirmBulkRemoveErrored // bulk remove of all errored sessions (TUI Ctrl+X)
 	ConfirmArchiveSession
 	ConfirmUnarchiveSession
-	ConfirmNotice // acknowledge-only message (single OK button), e.g. protected-action blocks
+	ConfirmNotice           // acknowledge-only message (single OK button), e.g. protected-action blocks
+	ConfirmOccupiedWorktree // computed worktree path already holds another worktree
+	ConfirmBulkYolo         // toggle YOLO for every Gemini session in a group
+	ConfirmGitInit          // run `git init` so worktree mode can be enabled on a plain directory
+	ConfirmRestart          // kill a session and relaunch it with another tool
 )
 
 // ConfirmDialog handles confirmation for destructive actions
@@ -52,6 +59,23 @@ type ConfirmDialog struct {
 	focusedButton int
 	// buttonCount is the number of selectable buttons for the current dialog.
 	buttonCount int
+	// scrollOffset is the first body line shown when the body is taller than
+	// the screen (see View); the buttons stay pinned below it.
+	scrollOffset int
+	// bulkTitles lists the sessions a ConfirmBulkRemoveErrored would remove
+	// or a ConfirmBulkYolo would switch.
+	bulkTitles []string
+	// bulkSkipped lists the sessions a ConfirmBulkYolo leaves alone, and
+	// bulkYoloOn is the mode it switches to.
+	bulkSkipped []string
+	bulkYoloOn  bool
+	// bulkYoloModels are the models of the sessions a ConfirmBulkYolo
+	// switches, for the cost note (see yoloCostNote).
+	bulkYoloModels []string
+	// restartFromTool and restartTool are a ConfirmRestart's current tool
+	// and the preset it relaunches with ("" is shell).
+	restartFromTool string
+	restartTool     string
 
 	// Pending session creation data (for ConfirmCreateDirectory)
 	pendingSessionName       string
@@ -66,6 +90,9 @@ type ConfirmDialog struct {
 	pendingParentProjectPath string
 }
 
+// confirmDialogPaddingX is the dialog box's horizontal padding on each side.
+const confirmDialogPaddingX = 2
+
 // NewConfirmDialog creates a new confirmation dialog
 func NewConfirmDialog() *ConfirmDialog {
 	return &ConfirmDialog{}
@@ -151,12 +178,50 @@ func (c *ConfirmDialog) ShowRemoveSession(sessionID string, sessionName string)
 
 // ShowBulkRemoveErrored shows confirmation for removing all errored sessions
 // (TUI Ctrl+X). count is the number of errored sessions that will be removed.
-func (c *ConfirmDialog) ShowBulkRemoveErrored(count int) {
+func (c *ConfirmDialog) ShowBulkRemoveErrored(titles []string) {
 	c.visible = true
 	c.confirmType = ConfirmBulkRemoveErrored
 	c.targetID = ""
 	c.targetName = ""
-	c.mcpCount = count // reuse mcpCount as a generic integer carrier
+	c.mcpCount = len(titles) // reuse mcpCount as a generic integer carrier
+	c.bulkTitles = titles
+	c.scrollOffset = 0
+	c.buttonCount = 2
+	c.focusedButton = 1
+}
+
+// ShowRestartWithTool shows confirmation for killing a session and
+// relaunching it with the tool preset toPreset ("" is shell).
+func (c *ConfirmDialog) ShowRestartWithTool(sessionID, sessionName, fromTool, toPreset string) {
+	c.visible = true
+	c.confirmType = ConfirmRestart
+	c.targetID = sessionID
+	c.targetName = sessionName
+	c.restartFromTool = fromTool
+	c.restartTool = toPreset
+	c.scrollOffset = 0
+	c.buttonCount = 2
+	c.focusedButton = 1
+}
+
+// GetRestartTool returns the tool preset a ConfirmRestart relaunches with.
+func (c *ConfirmDialog) GetRestartTool() string {
+	return c.restartTool
+}
+
+// ShowBulkYolo shows confirmation for switching YOLO on or off for the
+// Gemini sessions of a group (y on a group row); skipped are the group's
+// other sessions, and models the switched sessions' models.
+func (c *ConfirmDialog) ShowBulkYolo(titles, skipped, models []string, enable bool) {
+	c.visible = true
+	c.confirmType = ConfirmBulkYolo
+	c.targetID = ""
+	c.targetName = ""
+	c.bulkTitles = titles
+	c.bulkSkipped = skipped
+	c.bulkYoloOn = enable
+	c.bulkYoloModels = models
+	c.scrollOffset = 0
 	c.buttonCount = 2
 	c.focusedButton = 1
 }
@@ -229,6 +294,28 @@ func (c *ConfirmDialog) ShowCreateDirectory(
 	c.focusedButton = 1
 }
 
+// ShowOccupiedWorktree asks what to do with an existing worktree at the path
+// a new session's worktree would be created at.
+func (c *ConfirmDialog) ShowOccupiedWorktree(path string) {
+	c.visible = true
+	c.confirmType = ConfirmOccupiedWorktree
+	c.targetID = path
+	c.targetName = path
+	c.buttonCount = 3
+	c.focusedButton = 2 // default to Cancel
+}
+
+// ShowGitInit offers to make path a git repository after worktree mode was
+// refused for it in the new-session dialog.
+func (c *ConfirmDialog) ShowGitInit(path string) {
+	c.visible = true
+	c.confirmType = ConfirmGitInit
+	c.targetID = path
+	c.targetName = path
+	c.buttonCount = 2
+	c.focusedButton = 1
+}
+
 // ShowInstallHooks shows confirmation for installing Claude Code hooks
 func (c *ConfirmDialog) ShowInstallHooks() {
 	c.visible = true
@@ -253,6 +340,8 @@ func (c *ConfirmDialog) Hide() {
 	c.remoteName = ""
 	c.noticeTitle = ""
 	c.noticeBody = ""
+	c.bulkTitles = nil
+	c.scrollOffset = 0
 }
 
 // IsVisible returns whether the dialog is visible
@@ -286,9 +375,18 @@ func (c *ConfirmDialog) GetFocusedButton() int {
 	return c.focusedButton
 }
 
-// Update handles key events for arrow-key navigation between buttons.
+// Update handles key events for arrow-key navigation between buttons and
+// up/down scrolling of a body taller than the screen.
 func (c *ConfirmDialog) Update(msg tea.KeyMsg) (*ConfirmDialog, tea.Cmd) {
 	switch msg.String() {
+	case "up":
+		c.scrollOffset = max(c.scrollOffset-1, 0)
+	case "down":
+		c.scrollOffset = min(c.scrollOffset+1, c.maxScrollOffset())
+	case "pgup":
+		c.scrollOffset = max(c.scrollOffset-c.visibleBodyLines(c.layout()), 0)
+	case "pgdown":
+		c.scrollOffset = min(c.scrollOffset+c.visibleBodyLines(c.layout()), c.maxScrollOffset())
 	case "left", "h":
 		if c.focusedButton > 0 {
 			c.focusedButton--
@@ -301,12 +399,102 @@ func (c *ConfirmDialog) Update(msg tea.KeyMsg) (*ConfirmDialog, tea.Cmd) {
 	return c, nil
 }
 
+// confirmDialogLayout is the dialog content split into the scrollable body
+// (title, warning, details) and the footer (buttons and key hint), which
+// stays pinned at the bottom.
+type confirmDialogLayout struct {
+	body        []string
+	footer      string
+	borderColor lipgloss.Color
+	dialogWidth int
+}
+
+// visibleBodyLines returns how many body lines fit on screen with the
+// footer, border and padding, or 0 when the whole body fits (or the height
+// is unknown). One of the lines is taken by the scroll indicator.
+func (c *ConfirmDialog) visibleBodyLines(l confirmDialogLayout) int {
+	if c.height <= 0 {
+		return 0
+	}
+	// Border (2) + vertical padding (2) + blank line above the footer (1).
+	maxLines := c.height - 5 - lipgloss.Height(l.footer)
+	if len(l.body) <= maxLines {
+		return 0
+	}
+	return max(maxLines-1, 1)
+}
+
+// maxScrollOffset returns the largest useful scrollOffset.
+func (c *ConfirmDialog) maxScrollOffset() int {
+	l := c.layout()
+	visible := c.visibleBodyLines(l)
+	if visible == 0 {
+		return 0
+	}
+	return len(l.body) - visible
+}
+
 // View renders the confirmation dialog
 func (c *ConfirmDialog) View() string {
 	if !c.visible {
 		return ""
 	}
 
+	l := c.layout()
+	body := l.body
+	if visible := c.visibleBodyLines(l); visible > 0 {
+		offset := min(c.scrollOffset, len(body)-visible)
+		indicator := lipgloss.NewStyle().Foreground(ColorTextDim).Render(
+			fmt.Sprintf("↑/↓ scroll · %d–%d of %d", offset+1, offset+visible, len(body)))
+		body = append(slices.Clone(body[offset:offset+visible]), indicator)
+	}
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		strings.Join(body, "\n"),
+		"",
+		l.footer,
+	)
+
+	dialogBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(l.borderColor).
+		Padding(1, confirmDialogPaddingX).
+		Width(l.dialogWidth).
+		Render(content)
+
+	// Center in screen
+	if c.width > 0 && c.height > 0 {
+		// Create full-screen overlay with centered dialog
+		dialogHeight := lipgloss.Height(dialogBox)
+		dialogWidth := lipgloss.Width(dialogBox)
+
+		padLeft := (c.width - dialogWidth) / 2
+		if padLeft < 0 {
+			padLeft = 0
+		}
+		padTop := (c.height - dialogHeight) / 2
+		if padTop < 0 {
+			padTop = 0
+		}
+
+		// Build centered dialog
+		var b strings.Builder
+		for i := 0; i < padTop; i++ {
+			b.WriteString("\n")
+		}
+		for _, line := range strings.Split(dialogBox, "\n") {
+			b.WriteString(strings.Repeat(" ", padLeft))
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+
+		return b.String()
+	}
+
+	return dialogBox
+}
+
+// layout builds the dialog content for the current confirmation type.
+func (c *ConfirmDialog) layout() confirmDialogLayout {
 	// Build warning message and buttons based on action type
 	var title, warning, details string
 	var buttons string
@@ -336,11 +524,22 @@ func (c *ConfirmDialog) View() string {
 
 	hintStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
 
+	// Dialog box width; the text inside is wrapped to it, less the padding.
+	dialogWidth := 50
+	if c.width > 0 && c.width < dialogWidth+10 {
+		dialogWidth = c.width - 10
+	}
+	contentWidth := max(dialogWidth-confirmDialogPaddingX*2, 1)
+
+	// Session and group names are quoted and indented on their own line;
+	// truncate rather than wrap them so a very long name stays one line.
+	name := cellTruncate(c.targetName, max(contentWidth-4, 1), "…")
+
 	switch c.confirmType {
 	case ConfirmDeleteSession:
 		title = "⚠  Delete Session?"
-		warning = fmt.Sprintf("This will permanently delete the session:\n\n  \"%s\"", c.targetName)
-		details = "• The tmux session will be terminated\n• Any running processes will be killed\n• Terminal history will be lost"
+		warning = fmt.Sprintf("This will permanently delete the session:\n\n  \"%s\"", name)
+		details = "• The agent is asked to exit so it can save its session\n• The tmux session is force-killed if it has not exited in time\n• Terminal history will be lost"
 		if c.worktree {
 			details += "\n• The git worktree directory will be removed"
 		}
@@ -355,9 +554,24 @@ func (c *ConfirmDialog) View() string {
 		buttons = lipgloss.JoinVertical(lipgloss.Left, buttonRow,
 			hintStyle.Render("y delete · n cancel · ←/→ navigate · Enter select · Esc"))
 
+	case ConfirmRestart:
+		to := toolShell
+		if c.restartTool != "" {
+			to = displayCommandPreset(c.restartTool)
+		}
+		title = "Restart With " + to + "?"
+		warning = fmt.Sprintf("Restart this session with %s:\n\n  \"%s\"", to, name)
+		details = fmt.Sprintf("• The running %s process will be killed\n• Path, group and title are kept\n• The %s conversation is not resumed", c.restartFromTool, c.restartFromTool)
+		borderColor = ColorYellow
+		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
+			renderButton("Restart", ColorYellow, c.focusedButton == 0), "  ",
+			renderButton("Cancel", ColorAccent, c.focusedButton == 1))
+		buttons = lipgloss.JoinVertical(lipgloss.Left, buttonRow,
+			hintStyle.Render("y restart · n cancel · ←/→ navigate · Enter select · Esc"))
+
 	case ConfirmArchiveSession:
 		title = "Archive Session?"
-		warning = fmt.Sprintf("Archive this session:\n\n  \"%s\"", c.targetName)
+		warning = fmt.Sprintf("Archive this session:\n\n  \"%s\"", name)
 		details = "• The tmux process will be stopped\n• The session will move to the archived list\n• You can unarchive later (^ view, Shift+U restore)"
 		borderColor = ColorYellow
 		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
@@ -368,7 +582,7 @@ func (c *ConfirmDialog) View() string {
 
 	case ConfirmUnarchiveSession:
 		title = "Unarchive Session?"
-		warning = fmt.Sprintf("Restore this session to the active list:\n\n  \"%s\"", c.targetName)
+		warning = fmt.Sprintf("Restore this session to the active list:\n\n  \"%s\"", name)
 		details = "• Metadata returns to the main session list\n• The process is not started automatically"
 		borderColor = ColorGreen
 		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
@@ -379,7 +593,7 @@ func (c *ConfirmDialog) View() string {
 
 	case ConfirmCloseSession:
 		title = "Close Session?"
-		warning = fmt.Sprintf("This will close the running process for:\n\n  \"%s\"", c.targetName)
+		warning = fmt.Sprintf("This will close the running process for:\n\n  \"%s\"", name)
 		details = "• The tmux session will be terminated\n• Session metadata will be kept in the list\n• You can restart later from the session list"
 		if c.sandboxed {
 			details += "\n• The Docker container will be removed"
@@ -393,7 +607,7 @@ func (c *ConfirmDialog) View() string {
 
 	case ConfirmDeleteRemoteSession:
 		title = "⚠  Delete Remote Session?"
-		warning = fmt.Sprintf("This will permanently delete the remote session:\n\n  \"%s\" on %s", c.targetName, c.remoteName)
+		warning = fmt.Sprintf("This will permanently delete the remote session:\n\n  \"%s\" on %s", name, c.remoteName)
 		details = "• The remote tmux session will be terminated\n• Any running processes on the remote will be killed\n• Terminal history will be lost"
 		borderColor = ColorRed
 		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
@@ -404,7 +618,7 @@ func (c *ConfirmDialog) View() string {
 
 	case ConfirmCloseRemoteSession:
 		title = "Close Remote Session?"
-		warning = fmt.Sprintf("This will close the running process for:\n\n  \"%s\" on %s", c.targetName, c.remoteName)
+		warning = fmt.Sprintf("This will close the running process for:\n\n  \"%s\" on %s", name, c.remoteName)
 		details = "• The remote tmux session will be terminated\n• Session metadata will be kept on the remote\n• You can restart later"
 		borderColor = ColorYellow
 		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
@@ -415,7 +629,7 @@ func (c *ConfirmDialog) View() string {
 
 	case ConfirmRemoveSession:
 		title = "Remove Session?"
-		warning = fmt.Sprintf("Remove this session from the registry:\n\n  \"%s\"", c.targetName)
+		warning = fmt.Sprintf("Remove this session from the registry:\n\n  \"%s\"", name)
 		details = "• The session record will be deleted from agent-deck\n• Claude transcripts (~/.claude/projects/) are preserved\n• Git worktrees are preserved (use 'd' to destroy them)"
 		borderColor = ColorYellow
 		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
@@ -428,6 +642,12 @@ func (c *ConfirmDialog) View() string {
 		title = "Remove All Errored Sessions?"
 		warning = fmt.Sprintf("Remove %d errored session(s) from the registry.", c.mcpCount)
 		details = "• Only sessions currently in the 'error' state are affected\n• Claude transcripts are preserved\n• Git worktrees are preserved"
+		if len(c.bulkTitles) > 0 {
+			details += "\n"
+			for _, t := range c.bulkTitles {
+				details += "\n  " + cellTruncate(t, max(contentWidth-2, 1), "…")
+			}
+		}
 		borderColor = ColorYellow
 		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
 			renderButton("Remove All", ColorYellow, c.focusedButton == 0), "  ",
@@ -435,10 +655,38 @@ func (c *ConfirmDialog) View() string {
 		buttons = lipgloss.JoinVertical(lipgloss.Left, buttonRow,
 			hintStyle.Render("y remove · n cancel · ←/→ navigate · Enter select · Esc"))
 
+	case ConfirmBulkYolo:
+		state, verb := "off", "Disable"
+		if c.bulkYoloOn {
+			state, verb = "on", "Enable"
+		}
+		title = verb + " YOLO Mode?"
+		warning = fmt.Sprintf("Switch YOLO %s for %d Gemini session(s).", state, len(c.bulkTitles))
+		details = "• Running sessions restart with the new mode\n• Stopped sessions use it on their next start\n"
+		for _, t := range c.bulkTitles {
+			details += "\n  " + cellTruncate(t, max(contentWidth-2, 1), "…")
+		}
+		if len(c.bulkSkipped) > 0 {
+			details += fmt.Sprintf("\n\nSkipped, not Gemini: %d session(s)", len(c.bulkSkipped))
+		}
+		if note := yoloCostNote(c.bulkYoloModels); c.bulkYoloOn && note != "" {
+			details += "\n\n⚠ " + note
+		}
+		borderColor = ColorYellow
+		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
+			renderButton(verb, ColorYellow, c.focusedButton == 0), "  ",
+			renderButton("Cancel", ColorAccent, c.focusedButton == 1))
+		buttons = lipgloss.JoinVertical(lipgloss.Left, buttonRow,
+			hintStyle.Render("y apply · n cancel · ←/→ navigate · Enter select · Esc"))
+
 	case ConfirmDeleteGroup:
 		title = "⚠  Delete Group?"
-		warning = fmt.Sprintf("This will delete the group:\n\n  \"%s\"", c.targetName)
-		details = "• All sessions will be MOVED to 'default' group\n• Sessions will NOT be killed\n• The group structure will be lost"
+		warning = fmt.Sprintf("This will delete the group:\n\n  \"%s\"", name)
+		moveTo := session.DeleteGroupTarget(c.targetID)
+		if moveTo == session.DefaultGroupPath {
+			moveTo = session.DefaultGroupName
+		}
+		details = fmt.Sprintf("• All sessions will be MOVED to '%s'\n• Sessions will NOT be killed\n• Subgroups are deleted too", moveTo)
 		borderColor = ColorRed
 		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
 			renderButton("Delete", ColorRed, c.focusedButton == 0), "  ",
@@ -468,6 +716,29 @@ func (c *ConfirmDialog) View() string {
 		buttons = lipgloss.JoinVertical(lipgloss.Left, buttonRow,
 			hintStyle.Render("y create · n cancel · ←/→ navigate · Enter select · Esc"))
 
+	case ConfirmOccupiedWorktree:
+		title = "Worktree Path In Use"
+		warning = fmt.Sprintf("A worktree already exists at:\n\n  %s", c.targetName)
+		details = "• Reuse starts the session in it as is\n• Recreate removes it (refused with uncommitted changes) and creates a fresh one"
+		borderColor = ColorYellow
+		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
+			renderButton("Reuse", ColorGreen, c.focusedButton == 0), " ",
+			renderButton("Recreate", ColorYellow, c.focusedButton == 1), " ",
+			renderButton("Cancel", ColorAccent, c.focusedButton == 2))
+		buttons = lipgloss.JoinVertical(lipgloss.Left, buttonRow,
+			hintStyle.Render("u reuse · r recreate · n cancel · ←/→ navigate · Enter select · Esc"))
+
+	case ConfirmGitInit:
+		title = "Not a Git Repository"
+		warning = fmt.Sprintf("Worktree mode needs a repository, and this is a plain directory:\n\n  %s", c.targetName)
+		details = "Run git init there (with an empty initial commit) and enable worktree?"
+		borderColor = ColorAccent
+		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
+			renderButton("git init", ColorGreen, c.focusedButton == 0), "  ",
+			renderButton("Cancel", ColorRed, c.focusedButton == 1))
+		buttons = lipgloss.JoinVertical(lipgloss.Left, buttonRow,
+			hintStyle.Render("y init · n cancel · ←/→ navigate · Enter select · Esc"))
+
 	case ConfirmNotice:
 		title = c.noticeTitle
 		warning = c.noticeBody
@@ -499,56 +770,19 @@ func (c *ConfirmDialog) View() string {
 		Foreground(ColorYellow).
 		MarginBottom(1)
 
-	// Build content
-	content := lipgloss.JoinVertical(lipgloss.Left,
-		titleStyle.Render(title),
-		warningStyle.Render(warning),
-		detailsStyle.Render(details),
-		"",
-		buttons,
+	// Warning and details word-wrap to the content width (a path or remote
+	// name can still be long); the title stays on one line.
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render(cellTruncate(title, contentWidth, "…")),
+		warningStyle.Width(contentWidth).Render(warning),
+		detailsStyle.Width(contentWidth).Render(details),
 	)
-
-	// Dialog box
-	dialogWidth := 50
-	if c.width > 0 && c.width < dialogWidth+10 {
-		dialogWidth = c.width - 10
-	}
-
-	dialogBox := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(borderColor).
-		Padding(1, 2).
-		Width(dialogWidth).
-		Render(content)
-
-	// Center in screen
-	if c.width > 0 && c.height > 0 {
-		// Create full-screen overlay with centered dialog
-		dialogHeight := lipgloss.Height(dialogBox)
-		dialogWidth := lipgloss.Width(dialogBox)
-
-		padLeft := (c.width - dialogWidth) / 2
-		if padLeft < 0 {
-			padLeft = 0
-		}
-		padTop := (c.height - dialogHeight) / 2
-		if padTop < 0 {
-			padTop = 0
-		}
-
-		// Build centered dialog
-		var b strings.Builder
-		for i := 0; i < padTop; i++ {
-			b.WriteString("\n")
-		}
-		for _, line := range strings.Split(dialogBox, "\n") {
-			b.WriteString(strings.Repeat(" ", padLeft))
-			b.WriteString(line)
-			b.WriteString("\n")
-		}
-
-		return b.String()
+	// The footer is pre-wrapped as the box would wrap it, so its measured
+	// height is what actually ends up on screen.
+	return confirmDialogLayout{
+		body:        strings.Split(body, "\n"),
+		footer:      lipgloss.NewStyle().Width(contentWidth).Render(buttons),
+		borderColor: borderColor,
+		dialogWidth: dialogWidth,
 	}
-
-	return dialogBox
 }
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// assertBoxFits checks every line of a rendered dialog box has the same
+// width, i.e. no text spilled past the rounded border.
+func assertBoxFits(t *testing.T, view string, wantWidth int) {
+	t.Helper()
+	for i, line := range strings.Split(strings.TrimRight(view, "\n"), "\n") {
+		if w := ansi.StringWidth(line); w != wantWidth {
+			t.Errorf("line %d width = %d, want %d: %q", i, w, wantWidth, ansi.Strip(line))
+		}
+	}
+}
+
+func TestConfirmDialog_LongNameStaysInBox(t *testing.T) {
+	longName := strings.Repeat("x", 200)
+
+	c := NewConfirmDialog()
+	c.ShowDeleteSession("id", longName, false, false)
+	view := c.View()
+	assertBoxFits(t, view, 50+2) // dialog width + border
+	if !strings.Contains(ansi.Strip(view), "…") {
+		t.Error("long session name should be truncated with an ellipsis")
+	}
+
+	// Narrow terminal: the box shrinks and the text still fits.
+	c.SetSize(40, 30)
+	c.ShowDeleteGroup("g", strings.Repeat("word ", 40))
+	for _, line := range strings.Split(strings.TrimRight(c.View(), "\n"), "\n") {
+		if w := ansi.StringWidth(strings.TrimSpace(ansi.Strip(line))); w > 30+2 {
+			t.Errorf("line wider than the box: %d: %q", w, ansi.Strip(line))
+		}
+	}
+}
+
+func TestConfirmDialog_LongPathWraps(t *testing.T) {
+	path := "/home/user/" + strings.Repeat("deeply/nested/", 12) + "project"
+
+	c := NewConfirmDialog()
+	c.ShowCreateDirectory(path, "name", "claude", "", nil, nil, "", "", "", "")
+	view := c.View()
+	assertBoxFits(t, view, 50+2)
+	if strings.Contains(ansi.Strip(view), "…") {
+		t.Error("a path must be wrapped, not truncated")
+	}
+}
+
+func TestConfirmDialog_ScrollsLongBodyWithPinnedButtons(t *testing.T) {
+	titles := make([]string, 60)
+	for i := range titles {
+		titles[i] = fmt.Sprintf("session-%02d", i)
+	}
+	c := NewConfirmDialog()
+	c.SetSize(100, 30)
+	c.ShowBulkRemoveErrored(titles)
+
+	check := func(wantFirst, wantLast bool) {
+		t.Helper()
+		view := ansi.Strip(c.View())
+		if lines := strings.Count(strings.TrimRight(view, "\n"), "\n") + 1; lines > 30 {
+			t.Errorf("view is %d lines, taller than the 30-line screen", lines)
+		}
+		if !strings.Contains(view, "Remove All") || !strings.Contains(view, "Cancel") {
+			t.Error("buttons must stay visible while scrolling")
+		}
+		if strings.Contains(view, "Remove All Errored Sessions?") != wantFirst {
+			t.Errorf("title visible = %v, want %v", !wantFirst, wantFirst)
+		}
+		if strings.Contains(view, "session-59") != wantLast {
+			t.Errorf("last session visible = %v, want %v", !wantLast, wantLast)
+		}
+	}
+
+	check(true, false)
+	for range 200 {
+		c.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	check(false, true)
+	if c.scrollOffset != c.maxScrollOffset() {
+		t.Errorf("scrollOffset = %d, want clamped to %d", c.scrollOffset, c.maxScrollOffset())
+	}
+	c.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	c.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	c.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	check(true, false)
+
+	// Short bodies never scroll.
+	c.ShowDeleteSession("id", "short", false, false)
+	c.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if strings.Contains(ansi.Strip(c.View()), "scroll") {
+		t.Error("a dialog that fits should not show a scroll indicator")
+	}
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Steps reported by createSessionInGroupWithWorktreeAndOptions while a
+// creating placeholder is shown. createStepLaunch takes the tool name.
+const (
+	createStepWorktree  = "Creating worktree"
+	createStepWorktrees = "Creating worktrees"
+	createStepLaunch    = "Starting tmux and launching %s"
+
+	// createProgressBuffer holds every step a single create can report, so
+	// the create command never waits on the UI.
+	createProgressBuffer = 4
+)
+
+// withCreateProgress pairs a create command with the command that relays its
+// progress to the placeholder for tempID. Without a placeholder (tempID is
+// empty for non-worktree creates) the create command is returned as is.
+func (h *Home) withCreateProgress(tempID string, create tea.Cmd) tea.Cmd {
+	creating, ok := h.creatingSessions[tempID]
+	if !ok || creating.progress == nil {
+		return create
+	}
+	return tea.Batch(create, waitForCreateProgress(tempID, creating.progress))
+}
+
+// waitForCreateProgress turns the next step sent on progress into a
+// sessionCreateProgressMsg. It returns nil once the create command closes
+// the channel; the Update handler re-arms it after each step.
+func waitForCreateProgress(tempID string, progress <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		step, ok := <-progress
+		if !ok {
+			return nil
+		}
+		return sessionCreateProgressMsg{tempID: tempID, step: step, progress: progress}
+	}
+}
+
+// createStepError names the step a session create failed at, so the user can
+// tell a worktree failure from a launch failure.
+func createStepError(step string, err error) error {
+	if step == "" {
+		return err
+	}
+	return fmt.Errorf("%s failed: %w", step, err)
+}
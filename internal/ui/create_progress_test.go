@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateProgress_UpdatesPlaceholderStep(t *testing.T) {
+	h := NewHome()
+	progress := make(chan string, createProgressBuffer)
+	h.creatingSessions["tmp"] = &CreatingSession{ID: "tmp", Title: "demo", StartTime: time.Now(), progress: progress}
+
+	progress <- createStepWorktree
+	msg := waitForCreateProgress("tmp", progress)()
+	if _, cmd := h.Update(msg); cmd == nil {
+		t.Fatal("progress handler must re-arm the wait command")
+	}
+	if got := h.creatingSessions["tmp"].Step; got != createStepWorktree {
+		t.Errorf("Step = %q, want %q", got, createStepWorktree)
+	}
+	if out := h.renderCreatingPreview(h.creatingSessions["tmp"], 80, 20); !strings.Contains(out, createStepWorktree+"...") {
+		t.Errorf("preview does not show the current step:\n%s", out)
+	}
+
+	close(progress)
+	if msg := waitForCreateProgress("tmp", progress)(); msg != nil {
+		t.Errorf("closed channel: msg = %#v, want nil", msg)
+	}
+}
+
+func TestCreateProgress_FailureNamesStep(t *testing.T) {
+	h := NewHome()
+	h.creatingSessions["tmp"] = &CreatingSession{ID: "tmp", Title: "demo", StartTime: time.Now()}
+
+	h.Update(sessionCreatedMsg{err: errors.New("boom"), tempID: "tmp", step: createStepWorktree})
+	if _, ok := h.creatingSessions["tmp"]; ok {
+		t.Error("placeholder must be removed on failure")
+	}
+	if h.err == nil || !strings.Contains(h.err.Error(), "Creating worktree failed: boom") {
+		t.Errorf("err = %v, want it to name the failed step", h.err)
+	}
+}
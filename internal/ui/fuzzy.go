@@ -0,0 +1,24 @@
+package ui
+
+import "github.com/sahilm/fuzzy"
+
+// fuzzyRank returns the indexes of the candidates that contain query as a
+// case-insensitive subsequence, best match first. Consecutive runs and
+// matches at word starts score higher, so "deck" ranks "agent-deck" above
+// "dev/eu/clock". An empty query keeps every candidate in its original order.
+// Shared by the overlays that filter a list as you type.
+func fuzzyRank(query string, candidates []string) []int {
+	if query == "" {
+		idx := make([]int, len(candidates))
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx
+	}
+	matches := fuzzy.Find(query, candidates)
+	idx := make([]int, len(matches))
+	for i, m := range matches {
+		idx[i] = m.Index
+	}
+	return idx
+}
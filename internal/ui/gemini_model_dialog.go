@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"context"
+	"errors"
+	"slices"
 	"strings"
 
 	"github.com/asheshgoplani/agent-deck/internal/session"
@@ -10,8 +13,10 @@ import (
 
 // modelsFetchedMsg is sent when async model list fetch completes
 type modelsFetchedMsg struct {
-	models []string
-	err    error
+	instanceID string
+	models     []string
+	err        error
+	cred       session.CredStatus
 }
 
 // modelSelectedMsg is sent when user selects a model
@@ -29,8 +34,35 @@ type GeminiModelDialog struct {
 	models     []string
 	loading    bool
 	err        error
+	cred       session.CredStatus
 	instanceID string // ID of the session to change model for
 	current    string // Currently active model
+	favorites  []string
+	// cancelFetch aborts the model fetch started by Show; nil once it is done
+	// or the dialog is hidden.
+	cancelFetch context.CancelFunc
+}
+
+// loadGeminiFavorites returns the persisted favorite models.
+// Overridable for tests; production wiring reads via session.LoadUserConfig.
+var loadGeminiFavorites = func() []string {
+	cfg, err := session.LoadUserConfig()
+	if err != nil || cfg == nil {
+		return nil
+	}
+	return append([]string(nil), cfg.Gemini.Favorites...)
+}
+
+// persistGeminiFavorites writes the favorite models to config.toml.
+// Errors are swallowed: the in-memory list still applies for this dialog.
+// Overridable for tests; production wiring writes via session.SaveUserConfig.
+var persistGeminiFavorites = func(favorites []string) {
+	cfg, err := session.LoadUserConfig()
+	if err != nil || cfg == nil {
+		return
+	}
+	cfg.Gemini.Favorites = favorites
+	_ = session.SaveUserConfig(cfg)
 }
 
 // NewGeminiModelDialog creates a new model selection dialog
@@ -47,17 +79,30 @@ func (d *GeminiModelDialog) Show(instanceID, currentModel string) tea.Cmd {
 	d.err = nil
 	d.instanceID = instanceID
 	d.current = currentModel
+	d.favorites = loadGeminiFavorites()
 
+	if d.cancelFetch != nil {
+		d.cancelFetch()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancelFetch = cancel
 	return func() tea.Msg {
-		models, err := session.GetAvailableGeminiModels()
-		return modelsFetchedMsg{models: models, err: err}
+		models, err := session.GetAvailableGeminiModelsContext(ctx)
+		if ctx.Err() != nil {
+			return modelsFetchedMsg{instanceID: instanceID, err: ctx.Err()}
+		}
+		return modelsFetchedMsg{instanceID: instanceID, models: models, err: err, cred: geminiCredStatusAfterFetch(err)}
 	}
 }
 
-// Hide closes the dialog
+// Hide closes the dialog and cancels a model fetch still in flight.
 func (d *GeminiModelDialog) Hide() {
 	d.visible = false
 	d.loading = false
+	if d.cancelFetch != nil {
+		d.cancelFetch()
+		d.cancelFetch = nil
+	}
 }
 
 // IsVisible returns whether the dialog is visible
@@ -71,14 +116,68 @@ func (d *GeminiModelDialog) SetSize(width, height int) {
 	d.height = height
 }
 
-// HandleModelsFetched processes the async model fetch result
+// geminiCredStatusAfterFetch derives the credential status from a model
+// fetch. Only a list served without a live call (cache, override) needs its
+// own check; a network error or rate limit would just fail that check too.
+func geminiCredStatusAfterFetch(err error) session.CredStatus {
+	switch {
+	case errors.Is(err, session.ErrNoAPIKey):
+		return session.CredMissing
+	case errors.Is(err, session.ErrAPIKeyRejected):
+		return session.CredRejected
+	case err != nil:
+		return session.CredUnverified
+	default:
+		return session.GeminiCredentialStatus()
+	}
+}
+
+// credHeader is the credential line under the dialog title.
+func (d *GeminiModelDialog) credHeader() string {
+	if d.cred == session.CredMissing {
+		return "No API key — showing defaults"
+	}
+	return d.cred.String()
+}
+
+// fetchNotice explains why the dialog shows the default model list instead
+// of the live one; empty when the fetch succeeded.
+func (d *GeminiModelDialog) fetchNotice() string {
+	switch {
+	case d.err == nil:
+		return ""
+	case errors.Is(d.err, session.ErrNoAPIKey):
+		return "Set GOOGLE_API_KEY to see your models"
+	case errors.Is(d.err, session.ErrAPIKeyRejected):
+		return "API key rejected, showing defaults"
+	case errors.Is(d.err, session.ErrModelRateLimited):
+		return "Rate limited by the API, try again later"
+	case errors.Is(d.err, session.ErrModelFetchFailed):
+		return "Network error, showing defaults"
+	default:
+		return "Error: " + d.err.Error() + ", showing defaults"
+	}
+}
+
+// HandleModelsFetched processes the async model fetch result. Results that
+// arrive after the dialog was dismissed, reopened for another session, or
+// whose fetch was cancelled are dropped.
 func (d *GeminiModelDialog) HandleModelsFetched(msg modelsFetchedMsg) {
+	if !d.visible || msg.instanceID != d.instanceID ||
+		errors.Is(msg.err, context.Canceled) {
+		return
+	}
+	if d.cancelFetch != nil {
+		d.cancelFetch()
+		d.cancelFetch = nil
+	}
 	d.loading = false
 	d.err = msg.err
+	d.cred = msg.cred
 	d.models = msg.models
 
 	// Position cursor on current model
-	for i, m := range d.models {
+	for i, m := range d.ordered() {
 		if m == d.current {
 			d.cursor = i
 			break
@@ -86,6 +185,64 @@ func (d *GeminiModelDialog) HandleModelsFetched(msg modelsFetchedMsg) {
 	}
 }
 
+// isFavorite reports whether model is pinned as a favorite.
+func (d *GeminiModelDialog) isFavorite(model string) bool {
+	return slices.Contains(d.favorites, model)
+}
+
+// pinnedCount returns how many fetched models are favorites. Favorites that
+// are missing from the fetched list are ignored.
+func (d *GeminiModelDialog) pinnedCount() int {
+	n := 0
+	for _, m := range d.models {
+		if d.isFavorite(m) {
+			n++
+		}
+	}
+	return n
+}
+
+// ordered returns the fetched models with favorites pinned to the top,
+// preserving the fetched order within each section. Cursor navigation
+// indexes into this combined sequence.
+func (d *GeminiModelDialog) ordered() []string {
+	out := make([]string, 0, len(d.models))
+	for _, m := range d.models {
+		if d.isFavorite(m) {
+			out = append(out, m)
+		}
+	}
+	for _, m := range d.models {
+		if !d.isFavorite(m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// toggleFavorite pins or unpins the model under the cursor, keeping the
+// cursor on the same model after it moves between sections.
+func (d *GeminiModelDialog) toggleFavorite() {
+	models := d.ordered()
+	if d.cursor < 0 || d.cursor >= len(models) {
+		return
+	}
+	model := models[d.cursor]
+	if i := slices.Index(d.favorites, model); i >= 0 {
+		d.favorites = slices.Delete(d.favorites, i, i+1)
+	} else {
+		d.favorites = append(d.favorites, model)
+	}
+	persistGeminiFavorites(append([]string(nil), d.favorites...))
+
+	for i, m := range d.ordered() {
+		if m == model {
+			d.cursor = i
+			break
+		}
+	}
+}
+
 // Update handles input for the dialog
 func (d *GeminiModelDialog) Update(msg tea.KeyMsg) (*GeminiModelDialog, tea.Cmd) {
 	if !d.visible {
@@ -108,9 +265,15 @@ func (d *GeminiModelDialog) Update(msg tea.KeyMsg) (*GeminiModelDialog, tea.Cmd)
 			d.cursor++
 		}
 
+	case "f":
+		if !d.loading {
+			d.toggleFavorite()
+		}
+
 	case "enter":
-		if len(d.models) > 0 && d.cursor >= 0 && d.cursor < len(d.models) {
-			selected := d.models[d.cursor]
+		models := d.ordered()
+		if len(models) > 0 && d.cursor >= 0 && d.cursor < len(models) {
+			selected := models[d.cursor]
 			instanceID := d.instanceID
 			d.Hide()
 			return d, func() tea.Msg {
@@ -157,22 +320,35 @@ func (d *GeminiModelDialog) View() string {
 	content.WriteString(dimStyle.Render("            [Esc] Cancel"))
 	content.WriteString("\n")
 	content.WriteString(strings.Repeat("-", dialogWidth-4))
-	content.WriteString("\n\n")
+	content.WriteString("\n")
+	if !d.loading {
+		credStyle := dimStyle
+		switch d.cred {
+		case session.CredValid:
+			credStyle = currentStyle
+		case session.CredRejected:
+			credStyle = errorStyle
+		}
+		content.WriteString(credStyle.Render("  " + d.credHeader()))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
 
 	if d.loading {
 		content.WriteString(dimStyle.Render("  Loading models..."))
 		content.WriteString("\n")
+	} else if errors.Is(d.err, session.ErrNoAPIKey) {
+		// Not a failure: the defaults are the expected list without a key.
+		content.WriteString(dimStyle.Render("  " + d.fetchNotice()))
+		content.WriteString("\n\n")
 	} else if d.err != nil {
-		content.WriteString(errorStyle.Render("  Error: " + d.err.Error()))
+		content.WriteString(errorStyle.Render("  " + d.fetchNotice()))
 		content.WriteString("\n\n")
-		// Still show models if we have fallback
-		if len(d.models) > 0 {
-			content.WriteString(dimStyle.Render("  Showing fallback models:"))
-			content.WriteString("\n\n")
-		}
 	}
 
 	// Model list
+	models := d.ordered()
+	pinned := d.pinnedCount()
 	maxVisible := 15
 	if d.height > 0 {
 		maxVisible = d.height/2 - 6
@@ -187,18 +363,26 @@ func (d *GeminiModelDialog) View() string {
 		start = d.cursor - maxVisible + 1
 	}
 	end := start + maxVisible
-	if end > len(d.models) {
-		end = len(d.models)
+	if end > len(models) {
+		end = len(models)
 	}
 
 	for i := start; i < end; i++ {
-		model := d.models[i]
+		model := models[i]
+		if pinned > 0 && i == pinned && pinned < len(models) {
+			content.WriteString(dimStyle.Render("  " + strings.Repeat("-", 12)))
+			content.WriteString("\n")
+		}
 		prefix := "  "
 		if i == d.cursor {
 			prefix = "> "
 		}
 
-		line := prefix + model
+		star := "  "
+		if i < pinned {
+			star = "★ "
+		}
+		line := prefix + star + model
 		if model == d.current {
 			line += " (current)"
 		}
@@ -213,14 +397,14 @@ func (d *GeminiModelDialog) View() string {
 		content.WriteString("\n")
 	}
 
-	if len(d.models) > maxVisible {
+	if len(models) > maxVisible {
 		content.WriteString("\n")
 		content.WriteString(dimStyle.Render("  " + strings.Repeat(".", 3) + " scroll for more"))
 		content.WriteString("\n")
 	}
 
 	content.WriteString("\n")
-	content.WriteString(dimStyle.Render("j/k Navigate  Enter Select  Esc Cancel"))
+	content.WriteString(dimStyle.Render("j/k Navigate  f Favorite  Enter Select  Esc Cancel"))
 
 	// Wrap in dialog box
 	dialogStyle := lipgloss.NewStyle().
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func stubGeminiFavorites(t *testing.T, initial []string) *[]string {
+	t.Helper()
+	saved := append([]string(nil), initial...)
+	origLoad, origPersist := loadGeminiFavorites, persistGeminiFavorites
+	loadGeminiFavorites = func() []string { return append([]string(nil), saved...) }
+	persistGeminiFavorites = func(favs []string) { saved = favs }
+	t.Cleanup(func() {
+		loadGeminiFavorites, persistGeminiFavorites = origLoad, origPersist
+	})
+	return &saved
+}
+
+func TestGeminiModelDialog_FavoritesPinnedFirst(t *testing.T) {
+	stubGeminiFavorites(t, []string{"gemini-c", "gemini-missing"})
+
+	d := NewGeminiModelDialog()
+	d.Show("inst", "")
+	d.HandleModelsFetched(modelsFetchedMsg{instanceID: "inst", models: []string{"gemini-a", "gemini-b", "gemini-c"}})
+
+	got := d.ordered()
+	want := []string{"gemini-c", "gemini-a", "gemini-b"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("ordered() = %v, want %v", got, want)
+	}
+	if d.pinnedCount() != 1 {
+		t.Fatalf("pinnedCount() = %d, want 1 (missing favorites ignored)", d.pinnedCount())
+	}
+	if !strings.Contains(d.View(), "★ gemini-c") {
+		t.Fatalf("expected starred favorite in view")
+	}
+}
+
+func TestGeminiModelDialog_ToggleFavoritePersistsAndFollowsCursor(t *testing.T) {
+	saved := stubGeminiFavorites(t, nil)
+
+	d := NewGeminiModelDialog()
+	d.Show("inst", "")
+	d.HandleModelsFetched(modelsFetchedMsg{instanceID: "inst", models: []string{"gemini-a", "gemini-b", "gemini-c"}})
+
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+
+	if len(*saved) != 1 || (*saved)[0] != "gemini-c" {
+		t.Fatalf("persisted favorites = %v, want [gemini-c]", *saved)
+	}
+	if d.cursor != 0 {
+		t.Fatalf("cursor = %d, want 0 (follows pinned model)", d.cursor)
+	}
+
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected selection command")
+	}
+	if msg, ok := cmd().(modelSelectedMsg); !ok || msg.model != "gemini-c" {
+		t.Fatalf("selected %+v, want gemini-c", msg)
+	}
+
+	d.Show("inst", "")
+	d.HandleModelsFetched(modelsFetchedMsg{instanceID: "inst", models: []string{"gemini-a", "gemini-b", "gemini-c"}})
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if len(*saved) != 0 {
+		t.Fatalf("expected favorite removed, got %v", *saved)
+	}
+}
+
+func TestGeminiModelDialog_FetchErrorNotice(t *testing.T) {
+	stubGeminiFavorites(t, nil)
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{session.ErrNoAPIKey, "Set GOOGLE_API_KEY to see your models"},
+		{fmt.Errorf("%w: %w", session.ErrModelFetchFailed, errors.New("dial tcp: timeout")), "Network error, showing defaults"},
+		{fmt.Errorf("%w: API returned status 400", session.ErrAPIKeyRejected), "API key rejected, showing defaults"},
+		{fmt.Errorf("%w: API returned status 429", session.ErrModelRateLimited), "Rate limited by the API, try again later"},
+	}
+	for _, tt := range tests {
+		d := NewGeminiModelDialog()
+		d.Show("inst", "")
+		d.HandleModelsFetched(modelsFetchedMsg{instanceID: "inst", models: []string{"gemini-a"}, err: tt.err})
+		view := d.View()
+		if !strings.Contains(view, tt.want) {
+			t.Errorf("err %v: view missing %q", tt.err, tt.want)
+		}
+		if !strings.Contains(view, "gemini-a") {
+			t.Errorf("err %v: expected the default models to still be listed", tt.err)
+		}
+	}
+}
+
+func TestGeminiModelDialog_CredentialHeader(t *testing.T) {
+	stubGeminiFavorites(t, nil)
+
+	tests := []struct {
+		cred session.CredStatus
+		want string
+	}{
+		{session.CredMissing, "No API key — showing defaults"},
+		{session.CredValid, "API key OK"},
+		{session.CredRejected, "API key rejected"},
+		{session.CredUnverified, "API key not verified"},
+	}
+	for _, tt := range tests {
+		d := NewGeminiModelDialog()
+		d.Show("inst", "")
+		if strings.Contains(d.View(), tt.want) {
+			t.Errorf("%v: header shown while loading", tt.cred)
+		}
+		d.HandleModelsFetched(modelsFetchedMsg{instanceID: "inst", models: []string{"gemini-a"}, cred: tt.cred})
+		if view := d.View(); !strings.Contains(view, tt.want) {
+			t.Errorf("%v: view missing %q", tt.cred, tt.want)
+		}
+	}
+}
+
+func TestGeminiCredStatusAfterFetch(t *testing.T) {
+	if got := geminiCredStatusAfterFetch(session.ErrNoAPIKey); got != session.CredMissing {
+		t.Errorf("no key: %v", got)
+	}
+	if got := geminiCredStatusAfterFetch(fmt.Errorf("%w: status 400", session.ErrAPIKeyRejected)); got != session.CredRejected {
+		t.Errorf("rejected: %v", got)
+	}
+	if got := geminiCredStatusAfterFetch(fmt.Errorf("%w: timeout", session.ErrModelFetchFailed)); got != session.CredUnverified {
+		t.Errorf("network error: %v", got)
+	}
+}
+
+func TestGeminiModelDialog_DropsStaleFetchResults(t *testing.T) {
+	stubGeminiFavorites(t, nil)
+	t.Setenv("GEMINI_MODELS_OVERRIDE", "gemini-live")
+
+	d := NewGeminiModelDialog()
+	fetch := d.Show("inst-a", "")
+	d.Hide()
+	msg := fetch().(modelsFetchedMsg)
+	if !errors.Is(msg.err, context.Canceled) {
+		t.Fatalf("fetch after Hide: err = %v, want context.Canceled", msg.err)
+	}
+	d.HandleModelsFetched(modelsFetchedMsg{instanceID: "inst-a", models: []string{"gemini-late"}})
+	if d.IsVisible() || len(d.models) != 0 {
+		t.Fatalf("a result arriving after Hide must be ignored")
+	}
+
+	d.Show("inst-b", "")
+	d.HandleModelsFetched(modelsFetchedMsg{instanceID: "inst-a", models: []string{"gemini-late"}})
+	if !d.loading || len(d.models) != 0 {
+		t.Fatalf("a result for another session must be ignored")
+	}
+	d.HandleModelsFetched(modelsFetchedMsg{instanceID: "inst-b", models: []string{"gemini-b"}})
+	if d.loading || !slices.Equal(d.models, []string{"gemini-b"}) {
+		t.Fatalf("models = %v, want the current session's result", d.models)
+	}
+}
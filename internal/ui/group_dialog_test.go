@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
 // TestGroupDialog_NameInput_AcceptsUnderscore verifies that typing '_' into the
@@ -94,3 +96,36 @@ func TestGroupDialog_ShowRenameSession_FreshCursor_Issue604(t *testing.T) {
 			pos, len(name))
 	}
 }
+
+func TestHandleGroupDialogKey_RejectsDuplicatePaths(t *testing.T) {
+	h := NewHome()
+	h.width, h.height = 100, 30
+	h.groupTree = session.NewGroupTree([]*session.Instance{
+		{ID: "1", Title: "a", GroupPath: "alpha"},
+		{ID: "2", Title: "b", GroupPath: "beta"},
+	})
+	h.rebuildFlatItems()
+
+	h.groupDialog.Show()
+	h.groupDialog.nameInput.SetValue("alpha")
+	h.handleGroupDialogKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if !h.groupDialog.IsVisible() || h.groupDialog.validationErr == "" {
+		t.Fatal("creating a duplicate group should keep the dialog open with an error")
+	}
+
+	h.groupDialog.ShowRename("alpha", "alpha")
+	h.groupDialog.nameInput.SetValue("beta")
+	h.handleGroupDialogKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if !h.groupDialog.IsVisible() || h.groupDialog.validationErr == "" {
+		t.Fatal("renaming onto an existing group should keep the dialog open with an error")
+	}
+	if h.groupTree.Groups["alpha"] == nil {
+		t.Error("rejected rename must leave the group in place")
+	}
+
+	h.groupDialog.nameInput.SetValue("gamma")
+	h.handleGroupDialogKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if h.groupDialog.IsVisible() || h.groupTree.Groups["gamma"] == nil {
+		t.Error("a valid rename should close the dialog and apply")
+	}
+}
@@ -43,13 +43,22 @@ func wrapWithHangingIndent(text string, width int, indent string) string {
 	return strings.Join(lines, "\n")
 }
 
-// HelpOverlay shows keyboard shortcuts in a modal
+// helpSection is one titled group of [key, description] rows.
+type helpSection struct {
+	title string
+	items [][2]string // [key, description]
+}
+
+// HelpOverlay shows keyboard shortcuts in a modal. Show lists the main
+// screen's keys; dialogs embed their own overlay and call ShowSections.
 type HelpOverlay struct {
 	visible      bool
 	width        int
 	height       int
 	scrollOffset int // Current scroll position for small screens
 	hotkeys      map[string]string
+	title        string        // set by ShowSections
+	sections     []helpSection // set by ShowSections; nil = main screen keys
 }
 
 // NewHelpOverlay creates a new help overlay
@@ -61,6 +70,16 @@ func NewHelpOverlay() *HelpOverlay {
 func (h *HelpOverlay) Show() {
 	h.visible = true
 	h.scrollOffset = 0
+	h.title = ""
+	h.sections = nil
+}
+
+// ShowSections shows the given sections under title instead of the main
+// screen's keys. Rows with an empty key are dropped, as in Show.
+func (h *HelpOverlay) ShowSections(title string, sections []helpSection) {
+	h.Show()
+	h.title = title
+	h.sections = sections
 }
 
 // Hide hides the help overlay
@@ -163,13 +182,25 @@ func (h *HelpOverlay) View() string {
 		return ""
 	}
 
-	// Define help sections
+	title, sections := "KEYBOARD SHORTCUTS", h.sections
+	if sections == nil {
+		sections = h.mainSections()
+	} else {
+		title = h.title
+	}
+	return h.render(title, sections)
+}
+
+// mainSections returns the main screen's keys, labelled with the user's
+// hotkey bindings.
+func (h *HelpOverlay) mainSections() []helpSection {
 	newKeys := h.keyPair(hotkeyNewSession, hotkeyQuickCreate, "n/N")
 	forkKeys := h.keyPair(hotkeyQuickFork, hotkeyForkWithOptions, "f/F")
 	reorderUpKeys := "+ / K / Shift+↑"
 	reorderDownKeys := "- / J / Shift+↓"
 	indentKeys := "Shift+→/←"
 	searchKey := h.key(hotkeySearch, "/")
+	paletteKey := h.key(hotkeySessionPalette, "Ctrl+T")
 	settingsKey := h.key(hotkeySettings, "S")
 	helpKey := h.key(hotkeyHelp, "?")
 	quitKey := h.key(hotkeyQuit, "q")
@@ -179,6 +210,7 @@ func (h *HelpOverlay) View() string {
 	closeKey := h.key(hotkeyCloseSession, "D")
 	restartKey := h.key(hotkeyRestart, "Shift+R")
 	restartFreshKey := h.key(hotkeyRestartFresh, "Shift+T")
+	restartToolKey := h.key(hotkeyRestartWithTool, "Alt+R")
 	renameKey := h.key(hotkeyRename, "r")
 	moveKey := h.key(hotkeyMoveToGroup, "M")
 	mcpKey := h.key(hotkeyMCPManager, "m")
@@ -195,6 +227,7 @@ func (h *HelpOverlay) View() string {
 	sendKey := h.key(hotkeySendOutput, "x")
 	execShellKey := h.key(hotkeyExecShell, "E")
 	notesKey := h.key(hotkeyEditNotes, "e")
+	duplicateKey := h.key(hotkeyDuplicate, "V")
 	if cfg, _ := session.LoadUserConfig(); cfg != nil && !cfg.GetShowNotes() {
 		notesKey = ""
 	}
@@ -209,10 +242,7 @@ func (h *HelpOverlay) View() string {
 	unarchiveKey := h.key(hotkeyUnarchiveSession, "Shift+U")
 	viewArchivedKey := h.key(hotkeyViewArchived, "^")
 
-	sections := []struct {
-		title string
-		items [][2]string // [key, description]
-	}{
+	return []helpSection{
 		{
 			title: "NAVIGATION",
 			items: [][2]string{
@@ -244,12 +274,14 @@ func (h *HelpOverlay) View() string {
 			title: "SESSIONS",
 			items: [][2]string{
 				{newKeys, "New / quick create"},
+				{"Alt+N/P/C", "New dialog: jump to name / path / command"},
 				{renameKey, "Rename session"},
 				{restartKey, "Restart session"},
 				{restartFreshKey, "Restart with new session ID"},
+				{restartToolKey, "Restart with another tool"},
 				{deleteKey, "Delete session"},
 				{closeKey, "Close session process"},
-				{undoKey, "Undo delete"},
+				{undoKey, "Undo delete/archive"},
 				{archiveKey, "Archive session"},
 				{unarchiveKey, "Unarchive session"},
 				{viewArchivedKey, "Toggle archived view"},
@@ -267,9 +299,11 @@ func (h *HelpOverlay) View() string {
 				{reorderDownKeys, "Reorder down (auto-promote at edge)"},
 				{indentKeys, "Indent / outdent (in group)"},
 				{forkKeys, "Fork session (Claude/Pi)"},
+				{duplicateKey, "Duplicate session (new-session dialog pre-filled)"},
 				{copyKey, "Copy output to clipboard"},
 				{"C", "Copy preview info (Repo / Path / Branch)"},
 				{"Y", "Copy a code block from output"},
+				{"Ctrl+Y", "Copy project path"},
 				{sendKey, "Send output to session"},
 				{execShellKey, "Exec shell in sandbox container"},
 				{editPathsKey, "Edit multi-repo paths"},
@@ -304,11 +338,14 @@ func (h *HelpOverlay) View() string {
 			title: "SEARCH & FILTER",
 			items: [][2]string{
 				{searchKey, "Open search"},
+				{paletteKey, "Jump to session (fuzzy)"},
 				{FilterKeyActive, "Filter open (hide errors)"},
 				{"/waiting", "Filter waiting"},
 				{"/running", "Filter running"},
 				{"/idle", "Filter idle"},
+				{FilterKeyTool, "Cycle tool filter"},
 				{groupViewKey, "Cycle view: active-on-top / populated-on-top"},
+				{"O", "Cycle sort: last active / name / tool"},
 			},
 		},
 		{
@@ -331,7 +368,10 @@ func (h *HelpOverlay) View() string {
 			},
 		},
 	}
+}
 
+// render draws sections in the scrollable help box.
+func (h *HelpOverlay) render(title string, sections []helpSection) string {
 	for i := range sections {
 		filtered := sections[i].items[:0]
 		for _, item := range sections[i].items {
@@ -396,7 +436,7 @@ func (h *HelpOverlay) View() string {
 	// Build content as lines for scrolling support
 	var lines []string
 
-	lines = append(lines, titleStyle.Render("KEYBOARD SHORTCUTS"))
+	lines = append(lines, titleStyle.Render(title))
 	lines = append(lines, "")
 
 	for i, section := range sections {
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -67,6 +68,9 @@ type CreatingSession struct {
 	Tool      string
 	GroupPath string
 	StartTime time.Time
+	Step      string // current creation step, e.g. "Creating worktree"; updated by sessionCreateProgressMsg
+
+	progress chan string // fed by the create command, drained by waitForCreateProgress
 }
 
 // Structured loggers for UI components
@@ -149,6 +153,9 @@ const FilterKeyActive = "%"
 // FilterKeyArchived toggles the archived-sessions list view.
 const FilterKeyArchived = "^"
 
+// FilterKeyTool cycles the tool filter through the tools in use.
+const FilterKeyTool = "&"
+
 // FilterModeActive is the filter value for "open" sessions: excludes the
 // configured set of statuses (see DisplaySettings.ActiveFilterExcludes; default
 // {error}). This is NOT a session status (never assigned to a session), just a
@@ -227,9 +234,12 @@ type Home struct {
 
 	// Components
 	search               *Search
+	sessionPalette       *SessionPalette
+	restartToolDialog    *RestartToolDialog
 	globalSearch         *GlobalSearch              // Global session search across all Claude conversations
 	globalSearchIndex    *session.GlobalSearchIndex // Search index (nil if disabled)
 	newDialog            *NewDialog
+	claudeModelsFetching bool                  // Claude model list requested (once, on first new-session dialog)
 	pendingRemoteName    string                // #1353: remote target for the open new-session dialog ("" = local)
 	groupDialog          *GroupDialog          // For creating/renaming groups
 	forkDialog           *ForkDialog           // For forking sessions
@@ -278,16 +288,18 @@ type Home struct {
 	analyticsCacheTime     map[string]time.Time                       // TTL cache: sessionID -> cache timestamp
 
 	// State
-	cursor              int                   // Selected item index in flatItems
-	viewOffset          int                   // First visible item index (for scrolling)
-	previewScrollOffset int                   // Lines scrolled up from tail in the preview pane (#574). 0 = tail (default). Reset on cursor move.
-	isAttaching         atomic.Bool           // Prevents View() output during attach (fixes Bubble Tea Issue #431) - atomic for thread safety
-	statusFilter        session.Status        // Filter sessions by status ("" = all, or specific status)
-	groupScope          string                // Limit TUI to a specific group path ("" = all groups)
-	initialSelect       string                // Session ID or title to preselect on first load (#709). Does NOT scope groups.
-	initialSelectDone   bool                  // Guard so preselection only fires once
-	previewMode         PreviewMode           // What to show in preview pane (both, output-only, analytics-only)
-	groupViewMode       session.GroupViewMode // List partition: normal, active-on-top, populated-on-top (cycled by hotkey 't')
+	cursor              int                    // Selected item index in flatItems
+	viewOffset          int                    // First visible item index (for scrolling)
+	previewScrollOffset int                    // Lines scrolled up from tail in the preview pane (#574). 0 = tail (default). Reset on cursor move.
+	isAttaching         atomic.Bool            // Prevents View() output during attach (fixes Bubble Tea Issue #431) - atomic for thread safety
+	statusFilter        session.Status         // Filter sessions by status ("" = all, or specific status)
+	groupScope          string                 // Limit TUI to a specific group path ("" = all groups)
+	initialSelect       string                 // Session ID or title to preselect on first load (#709). Does NOT scope groups.
+	initialSelectDone   bool                   // Guard so preselection only fires once
+	previewMode         PreviewMode            // What to show in preview pane (both, output-only, analytics-only)
+	groupViewMode       session.GroupViewMode  // List partition: normal, active-on-top, populated-on-top (cycled by hotkey 't')
+	sessionSort         session.SessionSortKey // Within-group display sort (cycled by 'O'); "" keeps stored order
+	toolFilter          string                 // Show only sessions of this tool ("" = all; cycled by FilterKeyTool)
 	err                 error
 	errTime             time.Time  // When error occurred (for auto-dismiss)
 	isReloading         bool       // Visual feedback during auto-reload
@@ -356,6 +368,14 @@ type Home struct {
 	hookWatcher        *session.StatusFileWatcher
 	pendingHooksPrompt bool // True if user should be prompted to install hooks
 
+	// pendingOccupiedWorktree is the new-session create waiting on the
+	// ConfirmOccupiedWorktree dialog.
+	pendingOccupiedWorktree *occupiedWorktreeLaunch
+
+	// pendingBulkYolo is the group-wide YOLO toggle awaiting its
+	// ConfirmBulkYolo dialog.
+	pendingBulkYolo *bulkYoloPlan
+
 	// Context-% based /clear for conductor sessions with clear_on_compact
 	clearOnCompactSent map[string]time.Time // instanceID -> last /clear send time (debounce)
 
@@ -637,6 +657,7 @@ type uiState struct {
 	PreviewMode     int    `json:"preview_mode"`
 	StatusFilter    string `json:"status_filter,omitempty"`
 	GroupViewMode   int    `json:"group_view_mode,omitempty"`
+	SessionSort     string `json:"session_sort,omitempty"`
 }
 
 type selectedItemIdentity struct {
@@ -831,12 +852,22 @@ func (h *Home) actionKey(action string) string {
 	return actionHotkey(h.hotkeys, action)
 }
 
-// deletedSessionEntry holds a deleted session for undo restore
+// deletedSessionEntry holds a deleted or archived session for undo restore
 type deletedSessionEntry struct {
 	instance  *session.Instance
 	deletedAt time.Time
+	// archived marks a soft delete: undo unarchives the session in place.
+	// A real delete killed the process, so undo can only re-create it.
+	archived bool
 }
 
+// undoWindow is how long a delete or archive stays undoable.
+const undoWindow = 10 * time.Second
+
+// undoExpiredMsg fires undoWindow after an undoable action to drop entries
+// that have aged out of the undo stack.
+type undoExpiredMsg struct{}
+
 // getLayoutMode returns the current layout mode based on terminal width
 func (h *Home) getLayoutMode() string {
 	switch {
@@ -860,10 +891,44 @@ type loadSessionsMsg struct {
 	loadMtime    time.Time    // File mtime at load time (for external change detection)
 }
 
+// claudeModelsFetchedMsg carries session.GetAvailableClaudeModels' answer
+// for the new-session model picker.
+type claudeModelsFetchedMsg struct {
+	models []string
+	err    error
+}
+
+// fetchClaudeModels loads the Claude model list in the background.
+func fetchClaudeModels() tea.Msg {
+	models, err := session.GetAvailableClaudeModels()
+	return claudeModelsFetchedMsg{models: models, err: err}
+}
+
+// claudeModelsCmd fetches the Claude model list the first time the
+// new-session dialog opens, so users who never open it, or run no Claude,
+// do not pay for the lookup at startup.
+func (h *Home) claudeModelsCmd() tea.Cmd {
+	if h.claudeModelsFetching {
+		return nil
+	}
+	h.claudeModelsFetching = true
+	return fetchClaudeModels
+}
+
 type sessionCreatedMsg struct {
 	instance *session.Instance
 	err      error
 	tempID   string // matches creatingSessions key for placeholder removal
+	notice   string // non-fatal notice shown after a successful create, e.g. an auto-created initial commit
+	step     string // creation step that failed, when err is set
+}
+
+// sessionCreateProgressMsg reports that an async session create moved on to
+// a new step, so the creating placeholder can show it.
+type sessionCreateProgressMsg struct {
+	tempID   string
+	step     string
+	progress <-chan string
 }
 
 type sessionForkedMsg struct {
@@ -959,6 +1024,7 @@ type clearMaintenanceMsg struct{}
 type copyResultMsg struct {
 	sessionTitle string
 	lineCount    int
+	what         string // e.g. "path"; empty reports lineCount
 	err          error
 }
 
@@ -1033,6 +1099,7 @@ func newNotesEditor() textarea.Model {
 	editor.ShowLineNumbers = false
 	editor.Placeholder = "Write notes for this session..."
 	editor.Prompt = ""
+	editor.CharLimit = session.MaxNotesLength
 	editor.Blur()
 	return editor
 }
@@ -1082,6 +1149,8 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		storage:                   storage,
 		storageWarning:            storageWarning,
 		search:                    NewSearch(),
+		sessionPalette:            NewSessionPalette(),
+		restartToolDialog:         NewRestartToolDialog(),
 		newDialog:                 NewNewDialog(),
 		groupDialog:               NewGroupDialog(),
 		forkDialog:                NewForkDialog(),
@@ -1267,6 +1336,11 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	// Start background status worker (Priority 1C)
 	go h.statusWorker()
 
+	// Forward session lifecycle events to the configured webhook, if any.
+	if cfg, err := session.LoadUserConfig(); err == nil && cfg != nil {
+		session.StartSessionEventWebhook(h.ctx, cfg.Events)
+	}
+
 	// Start log worker pool (Priority 2)
 	h.startLogWorkers()
 
@@ -1857,6 +1931,8 @@ func (h *Home) rebuildFlatItems() {
 	h.jumpMode = false
 	h.jumpBuffer = ""
 
+	h.groupTree.SortBy = h.sessionSort
+	h.groupTree.SortOrder = h.sessionSort.DefaultOrder()
 	allItems := h.groupTree.Flatten()
 
 	// Partition archived vs active before status filters. Group membership is
@@ -1887,13 +1963,21 @@ func (h *Home) rebuildFlatItems() {
 		allItems = partitioned
 	}
 
-	// Apply status filter if active (skip when browsing archived list).
-	if h.statusFilter != "" && h.statusFilter != FilterModeArchived {
+	// Apply status filter if active (skip when browsing archived list) and the
+	// tool filter (applies to either list).
+	statusFiltered := h.statusFilter != "" && h.statusFilter != FilterModeArchived
+	if statusFiltered || h.toolFilter != "" {
+		matches := func(inst *session.Instance) bool {
+			if statusFiltered && !h.matchesStatusFilter(h.statusFilter, inst.Status) {
+				return false
+			}
+			return h.toolFilter == "" || session.ByTool(h.toolFilter)(inst)
+		}
 		// First pass: identify groups that have matching sessions
 		groupsWithMatches := make(map[string]bool)
 		for _, item := range allItems {
 			if item.Type == session.ItemTypeSession && item.Session != nil {
-				if h.matchesStatusFilter(h.statusFilter, item.Session.Status) {
+				if matches(item.Session) {
 					// Mark this session's group and all parent groups as having matches
 					groupsWithMatches[item.Path] = true
 					// Also mark parent paths
@@ -1916,14 +2000,17 @@ func (h *Home) rebuildFlatItems() {
 				}
 			} else if item.Type == session.ItemTypeSession && item.Session != nil {
 				// Keep session if it matches the filter
-				if h.matchesStatusFilter(h.statusFilter, item.Session.Status) {
+				if matches(item.Session) {
 					filtered = append(filtered, item)
 				}
 			}
 		}
-		// Auto-clear filter if it matches nothing but sessions exist
+		// Auto-clear filters if they match nothing but sessions exist
 		if len(filtered) == 0 && len(allItems) > 0 {
-			h.statusFilter = ""
+			if statusFiltered {
+				h.statusFilter = ""
+			}
+			h.toolFilter = ""
 			h.flatItems = allItems
 		} else {
 			h.flatItems = filtered
@@ -2644,9 +2731,9 @@ func (h *Home) propagateThemeToSessions() {
 
 	safego.Go(uiLog, "apply_theme_to_sessions", func() {
 		for _, inst := range instances {
-			if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil && tmuxSess.Exists() {
-				_ = tmuxSess.SetEnvironment("COLORFGBG", colorfgbg)
-				_ = tmuxSess.ApplyThemeOptions()
+			if inst.Exists() {
+				_ = inst.SetEnv("COLORFGBG", colorfgbg)
+				_ = inst.GetTmuxSession().ApplyThemeOptions()
 			}
 		}
 	})
@@ -3364,19 +3451,55 @@ func (h *Home) fetchAnalytics(inst *session.Instance) tea.Cmd {
 				err:       nil,
 			}
 		}
-	case "gemini":
+	default:
+		// Other tools go through their analytics provider.
+		if inst.AnalyticsProvider() == nil {
+			return nil
+		}
 		return func() tea.Msg {
-			// Gemini analytics are updated via UpdateGeminiSession which is called in background
-			// during UpdateStatus(). We just return the current snapshot.
-			return analyticsFetchedMsg{
-				sessionID:       sessionID,
-				geminiAnalytics: inst.GeminiAnalytics,
-				err:             nil,
+			// UpdateStatus() also refreshes tool analytics, but only for
+			// running/waiting sessions; re-read here (Gemini rate-limits
+			// this by the refresh interval) so the panel is current for
+			// idle ones too. The panel renders Gemini's analytics only.
+			msg := analyticsFetchedMsg{sessionID: sessionID}
+			if a, ok := inst.RefreshToolAnalytics().(*session.GeminiSessionAnalytics); ok {
+				msg.geminiAnalytics = a
 			}
+			return msg
 		}
 	}
+}
 
-	return nil
+// geminiAnalyticsRefreshInterval returns the configured
+// [preview].analytics_refresh_seconds, used as the Gemini analytics cache TTL.
+func geminiAnalyticsRefreshInterval() time.Duration {
+	if config, _ := session.LoadUserConfig(); config != nil {
+		return config.GetAnalyticsRefreshInterval()
+	}
+	return analyticsCacheTTL
+}
+
+// refreshSelectedGeminiAnalytics re-fetches analytics for the selected Gemini
+// session once its cached copy is older than the refresh interval, so the
+// panel keeps updating while the cursor stays put. Returns nil when nothing
+// is due.
+func (h *Home) refreshSelectedGeminiAnalytics() tea.Cmd {
+	inst := h.getSelectedSession()
+	if inst == nil || inst.GetToolThreadSafe() != "gemini" || h.analyticsFetchingID == inst.ID {
+		return nil
+	}
+	config, _ := session.LoadUserConfig()
+	if config == nil || !config.GetShowAnalytics() {
+		return nil
+	}
+	h.analyticsCacheMu.RLock()
+	fetchedAt, ok := h.analyticsCacheTime[inst.ID]
+	h.analyticsCacheMu.RUnlock()
+	if ok && time.Since(fetchedAt) < config.GetAnalyticsRefreshInterval() {
+		return nil
+	}
+	h.analyticsFetchingID = inst.ID
+	return h.fetchAnalytics(inst)
 }
 
 // getSelectedSession returns the currently selected session, or nil if a group is selected
@@ -3459,6 +3582,17 @@ func sessionDisplayLabels(inst *session.Instance, paneTitle string) (title, subt
 	return title, subtitle
 }
 
+// sessionNoteSummary returns the first non-blank line of a session's notes,
+// for the one-line list row.
+func sessionNoteSummary(notes string) string {
+	for _, line := range strings.Split(notes, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
 // cleanPaneTitle strips spinner/done marker characters from a tmux pane title
 // and returns the task description. Returns "" for default/generic titles.
 func cleanPaneTitle(title string) string {
@@ -3575,16 +3709,24 @@ func (h *Home) getInstanceByID(id string) *session.Instance {
 	return h.instanceByID[id]
 }
 
-// pushUndoStack adds a deleted session to the undo stack (LIFO, capped at 10)
-func (h *Home) pushUndoStack(inst *session.Instance) {
-	entry := deletedSessionEntry{
-		instance:  inst,
-		deletedAt: time.Now(),
-	}
+// pushUndoEntry adds entry to the undo stack (LIFO, capped at 10) and returns
+// the tick that expires it after undoWindow.
+func (h *Home) pushUndoEntry(entry deletedSessionEntry) tea.Cmd {
 	h.undoStack = append(h.undoStack, entry)
 	if len(h.undoStack) > 10 {
 		h.undoStack = h.undoStack[len(h.undoStack)-10:]
 	}
+	return tea.Tick(undoWindow, func(time.Time) tea.Msg { return undoExpiredMsg{} })
+}
+
+// pruneUndoStack drops entries older than undoWindow. Entries are pushed in
+// time order, so the expired ones are a prefix.
+func (h *Home) pruneUndoStack(now time.Time) {
+	keep := 0
+	for keep < len(h.undoStack) && now.Sub(h.undoStack[keep].deletedAt) >= undoWindow {
+		keep++
+	}
+	h.undoStack = h.undoStack[keep:]
 }
 
 // getDefaultPathForGroup returns the default path for a group
@@ -4786,6 +4928,18 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case occupiedWorktreeRemovedMsg:
+		pending := h.pendingOccupiedWorktree
+		h.pendingOccupiedWorktree = nil
+		if msg.err != nil {
+			h.setError(msg.err)
+			return h, nil
+		}
+		if pending == nil {
+			return h, nil
+		}
+		return h, pending.launch(pending.branch)
+
 	case sessionCreatedMsg:
 		uiLog.Info("session_created_msg",
 			slog.Bool("has_err", msg.err != nil),
@@ -4817,7 +4971,7 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return h, nil
 		}
 		if msg.err != nil {
-			h.setError(msg.err)
+			h.setError(createStepError(msg.step, msg.err))
 			if msg.tempID != "" {
 				h.rebuildFlatItems() // Remove placeholder from list
 			}
@@ -4834,6 +4988,16 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Track as launching for animation
 			h.launchingSessions[msg.instance.ID] = time.Now()
 
+			// Reopening the dialog on this group starts from this path.
+			rememberPath(h.stateDB(), msg.instance)
+			// Record the config in the recent sessions history so it can be
+			// relaunched from the new-session dialog (Ctrl+R), even once deleted.
+			if h.storage != nil {
+				if err := h.storage.SaveRecentSession(msg.instance, false); err != nil {
+					uiLog.Warn("save_recent_session_err", slog.String("id", msg.instance.ID), slog.String("err", err.Error()))
+				}
+			}
+
 			// Expand the group so the session is visible
 			if msg.instance.GroupPath != "" {
 				h.groupTree.ExpandGroupWithParents(msg.instance.GroupPath)
@@ -4856,12 +5020,23 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Save both instances AND groups (critical fix: was losing groups!)
 			// Use forceSave to bypass mtime check - new session creation MUST persist
 			h.forceSaveInstances()
+			if msg.notice != "" {
+				h.setError(noticeError(h.err, msg.notice))
+			}
 
 			// Start fetching preview for the new session
 			return h, h.fetchPreview(msg.instance, msg.instance.ID, -1)
 		}
 		return h, nil
 
+	case sessionCreateProgressMsg:
+		// The placeholder may already be gone if creation finished first;
+		// keep draining until the create command closes the channel.
+		if creating, ok := h.creatingSessions[msg.tempID]; ok {
+			creating.Step = msg.step
+		}
+		return h, waitForCreateProgress(msg.tempID, msg.progress)
+
 	case sessionForkedMsg:
 		// Clean up forking state for source session
 		if msg.sourceID != "" {
@@ -4965,10 +5140,11 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.instancesMu.Unlock()
 
 		// Push to undo stack before removing from group tree
+		var expireUndo tea.Cmd
 		if deletedInstance != nil {
-			h.pushUndoStack(deletedInstance)
+			expireUndo = h.pushUndoEntry(deletedSessionEntry{instance: deletedInstance, deletedAt: time.Now()})
 			// Save to recent sessions for quick re-creation
-			if err := h.storage.SaveRecentSession(deletedInstance); err != nil {
+			if err := h.storage.SaveRecentSession(deletedInstance, true); err != nil {
 				uiLog.Warn("save_recent_session_err", slog.String("id", msg.deletedID), slog.String("err", err.Error()))
 			}
 		}
@@ -5009,6 +5185,10 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 				h.setError(fmt.Errorf("deleted '%s'", deletedInstance.Title))
 			}
 		}
+		return h, expireUndo
+
+	case undoExpiredMsg:
+		h.pruneUndoStack(time.Now())
 		return h, nil
 
 	case sessionClosedMsg:
@@ -5050,7 +5230,12 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 				h.setError(fmt.Errorf("failed to persist archive: %w", err))
 				return h, nil
 			}
-			h.setError(fmt.Errorf("archived '%s' (^ to view)", inst.Title))
+			undoHint := ""
+			if undoKey := h.actionKey(hotkeyUndoDelete); undoKey != "" {
+				undoHint = ", " + undoKey + " to undo"
+			}
+			h.setError(fmt.Errorf("archived '%s' (^ to view%s)", inst.Title, undoHint))
+			return h, h.pushUndoEntry(deletedSessionEntry{instance: inst, deletedAt: time.Now(), archived: true})
 		}
 		return h, nil
 
@@ -5176,6 +5361,10 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		delete(h.resumingSessions, msg.sessionID)
 		return h, nil
 
+	case bulkYoloDoneMsg:
+		h.handleBulkYoloDone(msg)
+		return h, nil
+
 	case mcpRestartedMsg:
 		if msg.err != nil {
 			h.setError(fmt.Errorf("failed to restart session for MCP changes: %w", msg.err))
@@ -5340,8 +5529,16 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case claudeModelsFetchedMsg:
+		h.newDialog.SetClaudeModels(msg.models, msg.err == nil)
+		return h, nil
+
+	case dirCompletionsMsg:
+		h.newDialog.setDirCompletions(msg)
+		return h, nil
+
 	case modelsFetchedMsg:
-		if h.geminiModelDialog != nil && h.geminiModelDialog.IsVisible() {
+		if h.geminiModelDialog != nil {
 			h.geminiModelDialog.HandleModelsFetched(msg)
 		}
 		return h, nil
@@ -5638,7 +5835,7 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 					var cached *session.GeminiSessionAnalytics
 					h.analyticsCacheMu.RLock()
 					if c, ok := h.geminiAnalyticsCache[inst.ID]; ok {
-						if time.Since(h.analyticsCacheTime[inst.ID]) < analyticsCacheTTL {
+						if time.Since(h.analyticsCacheTime[inst.ID]) < geminiAnalyticsRefreshInterval() {
 							cached = c
 						}
 					}
@@ -5827,6 +6024,8 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case copyResultMsg:
 		if msg.err != nil {
 			h.setError(msg.err)
+		} else if msg.what != "" {
+			h.setError(fmt.Errorf("Copied %s to clipboard (%s)", msg.what, msg.sessionTitle))
 		} else {
 			h.setError(fmt.Errorf("Copied %d lines to clipboard (%s)", msg.lineCount, msg.sessionTitle))
 		}
@@ -6066,7 +6265,7 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 				h.previewCacheMu.Unlock()
 			}
 		}
-		cmds := []tea.Cmd{h.tick(), previewCmd, remoteFetchCmd, remoteLatencyCmd}
+		cmds := []tea.Cmd{h.tick(), previewCmd, remoteFetchCmd, remoteLatencyCmd, h.refreshSelectedGeminiAnalytics()}
 		if h.fullRepaint {
 			cmds = append(cmds, tea.ClearScreen)
 		}
@@ -6208,6 +6407,12 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if h.search.IsVisible() {
 			return h.handleSearchKey(msg)
 		}
+		if h.sessionPalette.IsVisible() {
+			return h.handleSessionPaletteKey(msg)
+		}
+		if h.restartToolDialog.IsVisible() {
+			return h.handleRestartToolDialogKey(msg)
+		}
 		if h.globalSearch.IsVisible() {
 			return h.handleGlobalSearchKey(msg)
 		}
@@ -6329,6 +6534,63 @@ func (h *Home) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return h, cmd
 }
 
+// handleSessionPaletteKey handles keys when the session palette is visible.
+// Enter moves the cursor to the chosen session; Alt+Enter also attaches when
+// its tmux pane is alive (otherwise it only focuses, and Enter on the list
+// restarts it as usual).
+func (h *Home) handleSessionPaletteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "alt+enter":
+		selected := h.sessionPalette.Selected()
+		h.sessionPalette.Hide()
+		if selected == nil {
+			return h, nil
+		}
+		h.jumpToSession(selected)
+		if msg.String() == "alt+enter" && selected.Exists() {
+			if tmuxSess := selected.GetTmuxSession(); tmuxSess != nil && !tmuxSess.IsPaneDead() {
+				return h, h.attachSession(selected)
+			}
+		}
+		return h, nil
+	case "esc":
+		h.sessionPalette.Hide()
+		return h, nil
+	}
+
+	var cmd tea.Cmd
+	h.sessionPalette, cmd = h.sessionPalette.Update(msg)
+	return h, cmd
+}
+
+// handleRestartToolDialogKey handles keys when the restart-with-tool picker
+// is visible. Enter asks to confirm the restart (ConfirmRestart), since it
+// kills the running process.
+func (h *Home) handleRestartToolDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		preset := h.restartToolDialog.Selected()
+		h.restartToolDialog.Hide()
+		inst := h.getInstanceByID(h.restartToolDialog.SessionID())
+		if inst == nil {
+			return h, nil
+		}
+		if tool, _ := createSessionTool(preset); tool == inst.Tool {
+			h.setError(fmt.Errorf("%q already runs %s", inst.Title, tool))
+			return h, nil
+		}
+		h.confirmDialog.ShowRestartWithTool(inst.ID, inst.Title, inst.Tool, preset)
+		return h, nil
+	case "esc":
+		h.restartToolDialog.Hide()
+		return h, nil
+	}
+
+	var cmd tea.Cmd
+	h.restartToolDialog, cmd = h.restartToolDialog.Update(msg)
+	return h, cmd
+}
+
 // handleGlobalSearchKey handles keys when global search is visible
 func (h *Home) handleGlobalSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -6496,8 +6758,9 @@ func (h *Home) resolveNewSessionGroup() string {
 
 // handleNewDialogKey handles keys when new dialog is visible
 func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// When the recent sessions picker is open, let the dialog handle all keys first.
-	if h.newDialog.IsRecentPickerOpen() {
+	// When the recent sessions picker or the key help is open, let the dialog
+	// handle all keys first.
+	if h.newDialog.IsRecentPickerOpen() || h.newDialog.IsHelpVisible() {
 		var cmd tea.Cmd
 		h.newDialog, cmd = h.newDialog.Update(msg)
 		return h, cmd
@@ -6559,7 +6822,8 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			name, path, command := h.newDialog.GetRemoteValues()
 			groupPath := h.newDialog.GetSelectedGroup()
 			// Remember the submitted tool for the next dialog open (UX top-3 #2).
-			rememberTool(h.stateDB(), command)
+			rememberTool(h.stateDB(), h.newDialog.submittedTool(command))
+			h.newDialog.ClearDraft()
 			h.newDialog.Hide()
 			h.pendingRemoteName = ""
 			h.clearError()
@@ -6572,7 +6836,7 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Remember the submitted tool so the next new-session dialog preselects
 		// it (UX top-3 #2). Best-effort: persisted in the profile StateDB, never
 		// config.toml. An explicit [default_tool] still wins on the next open.
-		rememberTool(h.stateDB(), command)
+		rememberTool(h.stateDB(), h.newDialog.submittedTool(command))
 
 		groupPath := h.newDialog.GetSelectedGroup()
 		claudeOpts := h.newDialog.GetClaudeOptions() // Get Claude options if applicable.
@@ -6623,15 +6887,18 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		parentSessionID := h.newDialog.GetParentSessionID()
 		parentProjectPath := h.newDialog.GetParentProjectPath()
 
-		// Only non-worktree sessions may need interactive "create directory" confirmation.
+		// Only non-worktree sessions may need interactive "create directory"
+		// confirmation; allow_missing_paths skips it (e.g. a lazy mount).
 		if !worktreeEnabled {
-			if _, err := os.Stat(path); os.IsNotExist(err) {
+			if _, err := os.Stat(path); os.IsNotExist(err) && !session.MissingPathAllowed(path) {
+				h.newDialog.SaveDraft() // kept if the user declines; cleared on create
 				h.newDialog.Hide()
 				h.confirmDialog.ShowCreateDirectory(path, name, command, groupPath, toolOptionsJSON, claudeExtraArgs, claudeStartQuery, launchModelID, parentSessionID, parentProjectPath)
 				return h, nil
 			}
 		}
 
+		h.newDialog.ClearDraft()
 		h.newDialog.Hide()
 		h.clearError()
 
@@ -6645,49 +6912,66 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			additionalPaths = multiRepoPaths[1:]
 		}
 
-		// Show immediate placeholder in UI while worktree + session is created async
-		var tempID string
-		if worktreeEnabled && branchName != "" {
-			tempID = session.GenerateID()
-			h.creatingSessions[tempID] = &CreatingSession{
-				ID:        tempID,
-				Title:     name,
-				Tool:      command,
-				GroupPath: groupPath,
-				StartTime: time.Now(),
-			}
-			h.rebuildFlatItems()
-			// Auto-select the placeholder
-			for i, item := range h.flatItems {
-				if item.CreatingID == tempID {
-					h.cursor = i
-					h.syncViewport()
-					break
+		launch := func(branchName string) tea.Cmd {
+			// Show immediate placeholder in UI while worktree + session is created async
+			var tempID string
+			if worktreeEnabled && branchName != "" {
+				tempID = session.GenerateID()
+				h.creatingSessions[tempID] = &CreatingSession{
+					ID:        tempID,
+					Title:     name,
+					Tool:      command,
+					GroupPath: groupPath,
+					StartTime: time.Now(),
+					progress:  make(chan string, createProgressBuffer),
+				}
+				h.rebuildFlatItems()
+				// Auto-select the placeholder
+				for i, item := range h.flatItems {
+					if item.CreatingID == tempID {
+						h.cursor = i
+						h.syncViewport()
+						break
+					}
 				}
 			}
-		}
 
-		return h, h.createSessionInGroupWithWorktreeAndOptions(
-			name,
-			path,
-			command,
-			groupPath,
-			worktreePath,
-			worktreeRepoRoot,
-			branchName,
-			geminiYoloMode,
-			sandboxMode,
-			toolOptionsJSON,
-			claudeExtraArgs,
-			claudeStartQuery,
-			launchModelID,
-			multiRepoEnabled,
-			additionalPaths,
-			parentSessionID,
-			parentProjectPath,
-			tempID,
-			false, // not auto-named — user went through the full create dialog
-		)
+			return h.withCreateProgress(tempID, h.createSessionInGroupWithWorktreeAndOptions(
+				name,
+				path,
+				command,
+				groupPath,
+				worktreePath,
+				worktreeRepoRoot,
+				branchName,
+				geminiYoloMode,
+				sandboxMode,
+				toolOptionsJSON,
+				claudeExtraArgs,
+				claudeStartQuery,
+				launchModelID,
+				multiRepoEnabled,
+				additionalPaths,
+				parentSessionID,
+				parentProjectPath,
+				tempID,
+				false, // not auto-named — user went through the full create dialog
+			))
+		}
+
+		// A worktree left at the computed path (e.g. by a failed run) would
+		// make `git worktree add` fail; ask whether to reuse or recreate it.
+		if worktreePath != "" && !multiRepoEnabled && worktreeTargetOccupied(worktreePath, worktreeRepoRoot, branchName) {
+			h.pendingOccupiedWorktree = &occupiedWorktreeLaunch{
+				path:     worktreePath,
+				repoRoot: worktreeRepoRoot,
+				branch:   branchName,
+				launch:   launch,
+			}
+			h.confirmDialog.ShowOccupiedWorktree(worktreePath)
+			return h, nil
+		}
+		return h, launch(branchName)
 
 	case msg.String() == "esc":
 		// #1162: when the model picker dropdown is open, Esc dismisses only the
@@ -6699,6 +6983,7 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			h.newDialog, cmd = h.newDialog.Update(msg)
 			return h, cmd
 		}
+		h.newDialog.SaveDraft() // reopening with n restores it
 		h.newDialog.Hide()
 		h.clearError()           // Clear any validation error
 		h.pendingRemoteName = "" // #1353: drop the remote target on cancel
@@ -6707,9 +6992,25 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	h.newDialog, cmd = h.newDialog.Update(msg)
+	if path := h.newDialog.TakeGitInitOffer(); path != "" {
+		h.newDialog.Hide()
+		h.confirmDialog.ShowGitInit(path)
+	}
 	return h, cmd
 }
 
+// showDuplicateSessionDialog opens the new-session dialog in inst's group,
+// pre-filled as a copy of inst (see NewDialog.LoadFromInstance).
+func (h *Home) showDuplicateSessionDialog(inst *session.Instance) {
+	groupName := inst.GroupPath
+	if group, exists := h.groupTree.Groups[inst.GroupPath]; exists {
+		groupName = group.Name
+	}
+	h.newDialog.SetRecentSessions(nil)
+	h.newDialog.ShowInGroup(inst.GroupPath, groupName, inst.ProjectPath, h.activeConductorSessions(), inst.ParentSessionID)
+	h.newDialog.LoadFromInstance(inst, h.instances)
+}
+
 func (h *Home) showRemoteNewSessionDialog(item session.Item) {
 	remoteName := item.RemoteName
 	if remoteName == "" {
@@ -6717,7 +7018,8 @@ func (h *Home) showRemoteNewSessionDialog(item session.Item) {
 	}
 
 	paths := h.remotePathSuggestions(remoteName)
-	h.newDialog.SetPathSuggestions(paths)
+	h.newDialog.SetRemotePathSuggestions(paths)
+	h.newDialog.SetBookmarks(nil) // bookmarks are local directories
 	h.newDialog.SetRecentSessions(nil)
 	// Preselect the last-used tool (UX top-3 #2); explicit [default_tool] wins.
 	h.newDialog.SetDefaultTool(resolveInitialTool(session.GetDefaultTool(), rememberedTool(h.stateDB())))
@@ -6744,6 +7046,7 @@ func (h *Home) showRemoteNewSessionDialog(item session.Item) {
 	}
 
 	h.newDialog.ShowInGroup(groupPath, groupName, defaultPath, nil, "")
+	h.newDialog.remote = true
 	if defaultPath == "" {
 		h.newDialog.pathInput.SetValue(".")
 		h.newDialog.pathSoftSelected = true
@@ -6799,8 +7102,11 @@ func persistClaudeDialogDefaults(opts *session.ClaudeOptions, args []string) {
 	cfg.Claude.DangerousMode = &opts.SkipPermissions
 	cfg.Claude.AllowDangerousMode = opts.AllowSkipPermissions
 	cfg.Claude.AutoMode = opts.AutoMode
+	cfg.Claude.PermissionMode = opts.PermissionMode
 	cfg.Claude.UseChrome = opts.UseChrome
 	cfg.Claude.UseTeammateMode = opts.UseTeammateMode
+	cfg.Claude.MCPConfig = opts.MCPConfig
+	cfg.Claude.AppendSystemPrompt = opts.AppendSystemPrompt
 	_ = session.SaveUserConfig(cfg)
 }
 
@@ -6959,8 +7265,8 @@ func (h *Home) hasModalVisible() bool {
 		h.setupWizard.IsVisible() || h.settingsPanel.IsVisible() ||
 		(h.toolVisibilityPanel != nil && h.toolVisibilityPanel.IsVisible()) ||
 		h.watcherPanel.IsVisible() || // hotkeyWatcherPanel overlay
-		h.helpOverlay.IsVisible() || h.search.IsVisible() || h.globalSearch.IsVisible() ||
-		h.newDialog.IsVisible() || h.groupDialog.IsVisible() || h.forkDialog.IsVisible() ||
+		h.helpOverlay.IsVisible() || h.search.IsVisible() || h.sessionPalette.IsVisible() || h.globalSearch.IsVisible() ||
+		h.restartToolDialog.IsVisible() || h.newDialog.IsVisible() || h.groupDialog.IsVisible() || h.forkDialog.IsVisible() ||
 		h.confirmDialog.IsVisible() || h.mcpDialog.IsVisible() || h.pluginDialog.IsVisible() || h.skillDialog.IsVisible() ||
 		h.geminiModelDialog.IsVisible() || h.promptInputDialog.IsVisible() || h.sessionPickerDialog.IsVisible() ||
 		h.codeBlockDialog.IsVisible() ||
@@ -7711,6 +8017,17 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case "V":
+		// Duplicate: open the new-session dialog pre-filled from this session
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				h.showDuplicateSessionDialog(item.Session)
+				return h, h.claudeModelsCmd()
+			}
+		}
+		return h, nil
+
 	case "s":
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
@@ -7983,6 +8300,11 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			paths[i] = info.path
 		}
 		h.newDialog.SetPathSuggestions(paths)
+		var bookmarks []string
+		if cfg, _ := session.LoadUserConfig(); cfg != nil {
+			bookmarks = cfg.GetPathBookmarks()
+		}
+		h.newDialog.SetBookmarks(bookmarks)
 
 		// Load recent sessions for the picker
 		if recents, err := h.storage.LoadRecentSessions(); err == nil {
@@ -8020,10 +8342,18 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		defaultPath := h.getDefaultPathForGroup(groupPath)
+		// The last path used in the group beats one derived from its
+		// sessions, but not an explicit group default path.
+		if g, ok := h.groupTree.Groups[groupPath]; !ok || g.DefaultPath == "" {
+			if p := rememberedPath(h.stateDB(), groupPath); p != "" {
+				defaultPath = p
+			}
+		}
 		conductors := h.activeConductorSessions()
 		suggestedParentID := h.suggestConductorParent()
 		h.newDialog.ShowInGroup(groupPath, groupName, defaultPath, conductors, suggestedParentID)
-		return h, nil
+		h.newDialog.RestoreDraft()
+		return h, h.claudeModelsCmd()
 
 	case "N":
 		// Check if cursor is on a remote group/session — create on remote instead
@@ -8119,19 +8449,19 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "ctrl+x":
 		// Bulk remove all errored sessions from the registry.
-		count := 0
+		var errored []string
 		h.instancesMu.RLock()
 		for _, inst := range h.instances {
 			if inst.Status == session.StatusError {
-				count++
+				errored = append(errored, inst.Title)
 			}
 		}
 		h.instancesMu.RUnlock()
-		if count == 0 {
+		if len(errored) == 0 {
 			h.setError(fmt.Errorf("no errored sessions to remove"))
 			return h, nil
 		}
-		h.confirmDialog.ShowBulkRemoveErrored(count)
+		h.confirmDialog.ShowBulkRemoveErrored(errored)
 		return h, nil
 
 	case "i":
@@ -8242,10 +8572,24 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		h.saveUIState()
 		return h, h.fetchSelectedPreview()
 
+	case "O":
+		// Cycle within-group sort: default → last active → name → tool.
+		selectedBefore := h.captureSelectedItemIdentity()
+		h.sessionSort = nextSessionSort(h.sessionSort)
+		h.rebuildFlatItemsPreservingSelection(selectedBefore)
+		h.syncViewport()
+		h.saveUIState()
+		return h, h.fetchSelectedPreview()
+
 	case "y":
-		// Toggle YOLO mode for Gemini or Codex sessions (requires restart)
+		// Toggle YOLO mode for Gemini or Codex sessions (requires restart);
+		// on a group row, for all of the group's Gemini sessions at once.
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeGroup {
+				h.startBulkYolo(item.Path)
+				return h, nil
+			}
 			if item.Type == session.ItemTypeSession && item.Session != nil {
 				inst := item.Session
 				toggled := false
@@ -8354,6 +8698,21 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case "alt+r":
+		// Restart the session with another tool (picker, then ConfirmRestart)
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				if h.hasActiveAnimation(item.Session.ID) {
+					h.setError(fmt.Errorf("session is starting, please wait..."))
+					return h, nil
+				}
+				h.restartToolDialog.SetSize(h.width, h.height)
+				h.restartToolDialog.Show(item.Session)
+			}
+		}
+		return h, nil
+
 	case "c":
 		// Copy last AI response to system clipboard
 		if h.cursor < len(h.flatItems) {
@@ -8375,6 +8734,17 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case "ctrl+y":
+		// Copy the session's expanded project path (worktree path for
+		// worktree sessions) to the clipboard. OSC52 fallback works over SSH.
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				return h, h.copySessionPath(item.Session)
+			}
+		}
+		return h, nil
+
 	case "Y", "shift+y":
 		// Extract fenced code blocks from this session's recent output and
 		// copy one (OSC52, SSH-safe). Single block -> copy directly; multiple
@@ -8429,13 +8799,23 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return h, nil
 
 	case "ctrl+z":
-		// Undo last session delete (Chrome-style: restores in reverse order)
+		// Undo last session delete or archive (Chrome-style: restores in
+		// reverse order, within undoWindow)
+		h.pruneUndoStack(time.Now())
 		if len(h.undoStack) == 0 {
 			h.setError(fmt.Errorf("nothing to undo"))
 			return h, nil
 		}
 		entry := h.undoStack[len(h.undoStack)-1]
 		h.undoStack = h.undoStack[:len(h.undoStack)-1]
+		if entry.archived {
+			// Look up by ID: a storage reload may have replaced the pointer.
+			if inst := h.getInstanceByID(entry.instance.ID); inst != nil && inst.IsArchived() {
+				return h, h.unarchiveSession(inst)
+			}
+			h.setError(fmt.Errorf("'%s' is no longer archived", entry.instance.Title))
+			return h, nil
+		}
 		inst := entry.instance
 		return h, func() tea.Msg {
 			err := inst.Restart()
@@ -8446,6 +8826,15 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case "ctrl+t":
+		// Session palette: fuzzy jump by title, group, or path
+		h.instancesMu.RLock()
+		h.sessionPalette.SetItems(h.instances)
+		h.instancesMu.RUnlock()
+		h.sessionPalette.SetSize(h.width, h.height)
+		h.sessionPalette.Show()
+		return h, nil
+
 	case "ctrl+r":
 		// Manual refresh (useful if watcher fails or for user preference)
 		state := h.preserveState()
@@ -8515,8 +8904,9 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return h, nil
 
 	case "0":
-		// Clear status filter (show all)
+		// Clear status and tool filters (show all)
 		h.statusFilter = ""
+		h.toolFilter = ""
 		h.rebuildFlatItems()
 		return h, nil
 
@@ -8584,6 +8974,12 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		h.rebuildFlatItems()
 		return h, nil
+
+	case FilterKeyTool, "shift+7":
+		// Cycle tool filter: all → each tool in use (alphabetical) → all
+		h.toolFilter = nextToolFilter(h.instances, h.toolFilter)
+		h.rebuildFlatItems()
+		return h, nil
 	}
 
 	return h, nil
@@ -8634,6 +9030,23 @@ func (h *Home) handleConfirmDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case ConfirmOccupiedWorktree:
+		choice := -1
+		switch msg.String() {
+		case "u", "U":
+			choice = 0
+		case "r", "R":
+			choice = 1
+		case "enter":
+			choice = h.confirmDialog.GetFocusedButton()
+		case "n", "N", "esc":
+			choice = 2
+		}
+		if choice < 0 {
+			return h, nil
+		}
+		return h, h.resolveOccupiedWorktree(choice)
+
 	case ConfirmNotice:
 		// Acknowledge-only: any of the usual dismiss keys closes it.
 		switch msg.String() {
@@ -8643,6 +9056,21 @@ func (h *Home) handleConfirmDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case ConfirmGitInit:
+		switch msg.String() {
+		case "y", "Y":
+			h.confirmGitInit()
+		case "enter":
+			if h.confirmDialog.GetFocusedButton() == 0 {
+				h.confirmGitInit()
+			} else {
+				h.declineGitInit()
+			}
+		case "n", "N", "esc":
+			h.declineGitInit()
+		}
+		return h, nil
+
 	case ConfirmInstallHooks:
 		switch msg.String() {
 		case "y", "Y":
@@ -8733,6 +9161,22 @@ func (h *Home) confirmAction() tea.Cmd {
 	case ConfirmBulkRemoveErrored:
 		h.confirmDialog.Hide()
 		return h.bulkRemoveErrored()
+	case ConfirmBulkYolo:
+		h.confirmDialog.Hide()
+		return h.applyBulkYolo()
+	case ConfirmRestart:
+		sessionID := h.confirmDialog.GetTargetID()
+		preset := h.confirmDialog.GetRestartTool()
+		h.confirmDialog.Hide()
+		if inst := h.getInstanceByID(sessionID); inst != nil {
+			if h.hasActiveAnimation(inst.ID) {
+				h.setError(fmt.Errorf("session is starting, please wait..."))
+				return nil
+			}
+			h.resumingSessions[inst.ID] = time.Now()
+			return h.restartSessionWithTool(inst, preset)
+		}
+		return nil
 	}
 	h.confirmDialog.Hide()
 	return nil
@@ -8746,6 +9190,7 @@ func (h *Home) confirmCreateDirectory() tea.Cmd {
 		h.setError(fmt.Errorf("failed to create directory: %w", err))
 		return nil
 	}
+	h.newDialog.ClearDraft()
 	return h.createSessionInGroupWithWorktreeAndOptions(
 		name,
 		path,
@@ -8769,6 +9214,32 @@ func (h *Home) confirmCreateDirectory() tea.Cmd {
 	)
 }
 
+// resolveOccupiedWorktree applies the ConfirmOccupiedWorktree choice:
+// 0 reuses the existing worktree, 1 removes and recreates it, anything else
+// cancels the create.
+func (h *Home) resolveOccupiedWorktree(choice int) tea.Cmd {
+	h.confirmDialog.Hide()
+	pending := h.pendingOccupiedWorktree
+	if pending == nil {
+		return nil
+	}
+	switch choice {
+	case 0:
+		h.pendingOccupiedWorktree = nil
+		cmd, err := pending.reuse()
+		if err != nil {
+			h.setError(err)
+			return nil
+		}
+		return cmd
+	case 1:
+		// Keep the pending launch until occupiedWorktreeRemovedMsg arrives.
+		return removeOccupiedWorktree(pending)
+	}
+	h.pendingOccupiedWorktree = nil
+	return nil
+}
+
 // confirmInstallHooks handles the "yes" action for ConfirmInstallHooks.
 func (h *Home) confirmInstallHooks() tea.Cmd {
 	h.confirmDialog.Hide()
@@ -9598,15 +10069,22 @@ func (h *Home) handleGroupDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		switch h.groupDialog.Mode() {
 		case GroupDialogCreate:
 			name := h.groupDialog.GetValue()
+			parentPath := ""
+			if h.groupDialog.HasParent() {
+				parentPath = h.groupDialog.GetParentPath()
+			}
+			if err := h.groupTree.ValidateNewGroup(parentPath, name); err != nil {
+				h.groupDialog.SetError(err.Error())
+				return h, nil
+			}
 			if name != "" {
 				// Seed the new-group default from [group_defaults].max_concurrent.
 				if cfg, _ := session.LoadUserConfig(); cfg != nil {
 					h.groupTree.DefaultMaxConcurrent = cfg.GroupDefaults.MaxConcurrent
 				}
 				var created *session.Group
-				if h.groupDialog.HasParent() {
+				if parentPath != "" {
 					// Create subgroup under parent
-					parentPath := h.groupDialog.GetParentPath()
 					created = h.groupTree.CreateSubgroup(parentPath, name)
 				} else {
 					// Create root-level group
@@ -9623,6 +10101,10 @@ func (h *Home) handleGroupDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		case GroupDialogRename:
 			name := h.groupDialog.GetValue()
+			if err := h.groupTree.ValidateRenameGroup(h.groupDialog.GetGroupPath(), name); err != nil {
+				h.groupDialog.SetError(err.Error())
+				return h, nil
+			}
 			if name != "" {
 				h.groupTree.RenameGroup(h.groupDialog.GetGroupPath(), name)
 				h.instancesMu.Lock()
@@ -9959,6 +10441,7 @@ func (h *Home) saveUIState() {
 		PreviewMode:   int(h.previewMode),
 		StatusFilter:  string(h.statusFilter),
 		GroupViewMode: int(h.groupViewMode),
+		SessionSort:   string(h.sessionSort),
 	}
 
 	// Capture cursor position
@@ -10016,6 +10499,10 @@ func (h *Home) loadUIState() {
 	if h.groupViewMode < session.GroupViewNormal || h.groupViewMode >= session.GroupViewModeCount {
 		h.groupViewMode = session.GroupViewNormal
 	}
+	h.sessionSort = session.SessionSortKey(state.SessionSort)
+	if !slices.Contains(session.SessionSortKeys, h.sessionSort) {
+		h.sessionSort = session.SortByDefault
+	}
 
 	// Defer cursor restoration until flatItems are populated
 	h.pendingCursorRestore = &state
@@ -10036,7 +10523,33 @@ func (h *Home) createSessionInGroupWithWorktreeAndOptions(
 	tempID string,
 	autoName bool,
 ) tea.Cmd {
+	// Read on the Update goroutine; the command below only sends on it.
+	var progress chan<- string
+	if creating, ok := h.creatingSessions[tempID]; ok {
+		progress = creating.progress
+	}
 	return func() tea.Msg {
+		step := ""
+		setStep := func(s string) {
+			step = s
+			if progress != nil {
+				select {
+				case progress <- s:
+				default: // never block creation on a slow UI
+				}
+			}
+		}
+		if progress != nil {
+			defer close(progress)
+		}
+		// Worktrees and multi-repo dirs this call created are removed again
+		// if a later step fails, so a failed create leaves nothing behind.
+		var txn session.CreateTxn
+		fail := func(err error) tea.Msg {
+			_ = txn.Rollback()
+			return sessionCreatedMsg{err: err, tempID: tempID, step: step}
+		}
+
 		uiLog.Info("create_session_start",
 			slog.String("name", name),
 			slog.String("path", path),
@@ -10045,17 +10558,18 @@ func (h *Home) createSessionInGroupWithWorktreeAndOptions(
 
 		// Check tmux availability before creating session
 		if err := tmux.IsTmuxAvailable(); err != nil {
-			return sessionCreatedMsg{err: fmt.Errorf("cannot create session: %w", err), tempID: tempID}
+			return fail(fmt.Errorf("cannot create session: %w", err))
 		}
 
 		var worktreeBackend vcs.Backend
+		var notice string
 		if worktreePath != "" && worktreeRepoRoot != "" && worktreeBranch != "" && !multiRepoEnabled {
 			// Single-repo worktree: create here. Multi-repo worktrees are handled below.
 			//
 			// Detect the VCS so jj repos get `jj workspace add` instead of `git worktree add`.
 			backend, err := vcsbackend.Detect(worktreeRepoRoot)
 			if err != nil {
-				return sessionCreatedMsg{err: fmt.Errorf("failed to detect VCS: %w", err), tempID: tempID}
+				return fail(fmt.Errorf("failed to detect VCS: %w", err))
 			}
 			worktreeBackend = backend
 
@@ -10064,12 +10578,17 @@ func (h *Home) createSessionInGroupWithWorktreeAndOptions(
 				uiLog.Info("worktree_reuse", slog.String("branch", worktreeBranch), slog.String("path", existingPath))
 				worktreePath = existingPath
 			} else {
+				setStep(createStepWorktree)
 				if err := os.MkdirAll(filepath.Dir(worktreePath), 0o755); err != nil {
-					return sessionCreatedMsg{err: fmt.Errorf("failed to create parent directory: %w", err), tempID: tempID}
+					return fail(fmt.Errorf("failed to create parent directory: %w", err))
 				}
-				if err := createWorktreeWithSetupAndLog(backend, worktreePath, worktreeBranch); err != nil {
-					return sessionCreatedMsg{err: fmt.Errorf("failed to create worktree: %w", err), tempID: tempID}
+				createdBranch := !backend.BranchExists(worktreeBranch)
+				wtNotice, err := createWorktreeWithSetupAndLog(backend, worktreePath, worktreeBranch)
+				if err != nil {
+					return fail(fmt.Errorf("failed to create worktree: %w", err))
 				}
+				notice = wtNotice
+				txn.TrackWorktree(backend, worktreePath, worktreeBranch, createdBranch)
 			}
 			path = worktreePath
 		}
@@ -10104,7 +10623,7 @@ func (h *Home) createSessionInGroupWithWorktreeAndOptions(
 
 		if launchModelID != "" {
 			if err := inst.ApplyLaunchModel(launchModelID); err != nil {
-				return sessionCreatedMsg{err: fmt.Errorf("failed to apply model override: %w", err), tempID: tempID}
+				return fail(fmt.Errorf("failed to apply model override: %w", err))
 			}
 		}
 
@@ -10134,26 +10653,27 @@ func (h *Home) createSessionInGroupWithWorktreeAndOptions(
 			if worktreeBranch != "" {
 				// Multi-repo + worktree: create a persistent parent dir with all worktrees inside.
 				// Layout: <effective-data-dir>/multi-repo-worktrees/<branch>-<id>/<repo-name>/
-				sanitizedBranch := strings.ReplaceAll(worktreeBranch, "/", "-")
-				sanitizedBranch = strings.ReplaceAll(sanitizedBranch, " ", "-")
+				sanitizedBranch := git.SanitizeBranchForPath(worktreeBranch)
 				worktreesRoot, rootErr := multiRepoWorktreesRoot()
 				if rootErr != nil {
-					return sessionCreatedMsg{err: fmt.Errorf("failed to resolve multi-repo worktree dir: %w", rootErr), tempID: tempID}
+					return fail(fmt.Errorf("failed to resolve multi-repo worktree dir: %w", rootErr))
 				}
 				parentDir := filepath.Join(worktreesRoot,
 					fmt.Sprintf("%s-%s", sanitizedBranch, inst.ID[:8]))
 				if mkErr := os.MkdirAll(parentDir, 0o755); mkErr != nil {
-					return sessionCreatedMsg{err: fmt.Errorf("failed to create multi-repo worktree dir: %w", mkErr), tempID: tempID}
+					return fail(fmt.Errorf("failed to create multi-repo worktree dir: %w", mkErr))
 				}
 				if resolved, evalErr := filepath.EvalSymlinks(parentDir); evalErr == nil {
 					parentDir = resolved
 				}
 				inst.MultiRepoTempDir = parentDir
 
+				setStep(createStepWorktrees)
 				wtResult := session.CreateMultiRepoWorktrees(allPaths, parentDir, worktreeBranch, session.GetWorktreeSettings().SetupTimeout())
 				for _, w := range wtResult.Warnings {
 					uiLog.Warn("multi_repo_worktree", slog.String("detail", w))
 				}
+				txn.TrackMultiRepo(parentDir, wtResult.Worktrees)
 				inst.MultiRepoWorktrees = wtResult.Worktrees
 				inst.ProjectPath = wtResult.MappedPaths[0]
 				inst.AdditionalPaths = wtResult.MappedPaths[1:]
@@ -10161,16 +10681,17 @@ func (h *Home) createSessionInGroupWithWorktreeAndOptions(
 				// Multi-repo without worktree: create a persistent parent dir with symlinks.
 				worktreesRoot, rootErr := multiRepoWorktreesRoot()
 				if rootErr != nil {
-					return sessionCreatedMsg{err: fmt.Errorf("failed to resolve multi-repo dir: %w", rootErr), tempID: tempID}
+					return fail(fmt.Errorf("failed to resolve multi-repo dir: %w", rootErr))
 				}
 				parentDir := filepath.Join(worktreesRoot, inst.ID[:8])
 				if mkErr := os.MkdirAll(parentDir, 0o755); mkErr != nil {
-					return sessionCreatedMsg{err: fmt.Errorf("failed to create multi-repo dir: %w", mkErr), tempID: tempID}
+					return fail(fmt.Errorf("failed to create multi-repo dir: %w", mkErr))
 				}
 				if resolved, evalErr := filepath.EvalSymlinks(parentDir); evalErr == nil {
 					parentDir = resolved
 				}
 				inst.MultiRepoTempDir = parentDir
+				txn.TrackMultiRepo(parentDir, nil)
 
 				// Create symlinks for all paths
 				dirnames := session.DeduplicateDirnames(allPaths)
@@ -10215,6 +10736,7 @@ func (h *Home) createSessionInGroupWithWorktreeAndOptions(
 			inst.SetParentWithPath(parentSessionID, parentProjectPath)
 		}
 
+		setStep(fmt.Sprintf(createStepLaunch, inst.Tool))
 		uiLog.Info("session_create_starting",
 			slog.String("tool", inst.Tool),
 			slog.String("path", inst.ProjectPath),
@@ -10222,28 +10744,33 @@ func (h *Home) createSessionInGroupWithWorktreeAndOptions(
 		)
 		if err := inst.Start(); err != nil {
 			uiLog.Error("session_create_failed", slog.String("error", err.Error()))
-			return sessionCreatedMsg{err: err, tempID: tempID}
+			return fail(err)
 		}
 		uiLog.Info("session_create_succeeded", slog.String("id", inst.ID))
-		return sessionCreatedMsg{instance: inst, tempID: tempID}
+		return sessionCreatedMsg{instance: inst, tempID: tempID, notice: notice}
 	}
 }
 
 // createWorktreeWithSetupAndLog creates a worktree via the supplied backend.
 // For git backends it also runs .worktreeinclude and worktree-setup.sh; for
 // jujutsu backends only the workspace is created (setup-script behavior is
-// git-only per the vcsbackend convention). Returns only the creation error;
-// setup failures are non-fatal and logged to uiLog.
-func createWorktreeWithSetupAndLog(backend vcs.Backend, wtPath, branch string) error {
+// git-only per the vcsbackend convention). Returns the creation error plus a
+// notice for the user when git had to give an empty repository its initial
+// commit; setup failures are non-fatal and logged to uiLog.
+func createWorktreeWithSetupAndLog(backend vcs.Backend, wtPath, branch string) (notice string, err error) {
+	unborn := backend.Type() == vcs.TypeGit && !git.HasCommits(backend.RepoDir())
 	var buf bytes.Buffer
 	setupErr, err := vcsbackend.CreateWorktreeWithSetup(backend, wtPath, branch, &buf, &buf, session.GetWorktreeSettings().SetupTimeout())
 	if err != nil {
-		return err
+		return "", err
 	}
 	if setupErr != nil {
 		uiLog.Warn("worktree_setup_script_failed", slog.String("error", setupErr.Error()), slog.String("output", buf.String()))
 	}
-	return nil
+	if unborn {
+		notice = "repository had no commits: created an empty initial commit to branch from"
+	}
+	return notice, nil
 }
 
 // createSessionTool maps a free-form command to (tool, command). Built-in
@@ -10252,7 +10779,7 @@ func createWorktreeWithSetupAndLog(backend vcs.Backend, wtPath, branch string) e
 // identity (so [tools.<name>] config lookup works). Anything unrecognised
 // falls back to a "shell" session running `command` verbatim.
 func createSessionTool(command string) (string, string) {
-	tool := "shell"
+	tool := toolShell
 	switch command {
 	case "claude":
 		tool = "claude"
@@ -10919,6 +11446,9 @@ func (h *Home) buildForkCmd(
 		if errMsg != "" {
 			return forkBuildResult{errMsg: errMsg}
 		}
+		if !fallback && worktreeTargetOccupied(worktreePath, repoRoot, branchName) {
+			return forkBuildResult{errMsg: "Worktree path " + worktreePath + " is already used by another worktree"}
+		}
 		if !fallback {
 			if opts == nil {
 				opts = &session.ClaudeOptions{}
@@ -11065,9 +11595,11 @@ func (h *Home) forkSessionCmdWithOptions(
 				if err := os.MkdirAll(filepath.Dir(opts.WorktreePath), 0o755); err != nil {
 					return sessionForkedMsg{err: fmt.Errorf("failed to create directory: %w", err), sourceID: sourceID}
 				}
-				if err := createWorktreeWithSetupAndLog(backend, opts.WorktreePath, opts.WorktreeBranch); err != nil {
+				wtNotice, err := createWorktreeWithSetupAndLog(backend, opts.WorktreePath, opts.WorktreeBranch)
+				if err != nil {
 					return sessionForkedMsg{err: fmt.Errorf("worktree creation failed: %w", err), sourceID: sourceID}
 				}
+				forkNotice = joinForkNotices(forkNotice, wtNotice)
 			}
 		}
 
@@ -11317,7 +11849,9 @@ func (h *Home) deleteSession(inst *session.Instance) tea.Cmd {
 		h.instancesMu.RUnlock()
 	}
 	return func() tea.Msg {
-		killErr := inst.Kill()
+		// Give the agent a chance to exit and flush its session files before
+		// the tmux session is force-killed.
+		killErr := inst.Stop(session.DefaultStopTimeout)
 		if isWorktree && sharedWorktree {
 			// #1449: another live session still references this worktree; skip
 			// the destructive removal + branch delete and merely drop this
@@ -11561,6 +12095,32 @@ func (h *Home) restartSessionFresh(inst *session.Instance) tea.Cmd {
 	}
 }
 
+// restartSessionWithTool relaunches inst with the tool preset ("" is shell)
+// in the same path and group; see Instance.RestartWithTool.
+func (h *Home) restartSessionWithTool(inst *session.Instance, preset string) tea.Cmd {
+	id := inst.ID
+	tool, command := createSessionTool(preset)
+	mcpUILog.Debug(
+		"restart_session_with_tool_called",
+		slog.String("id", inst.ID),
+		slog.String("title", inst.Title),
+		slog.String("from", inst.Tool),
+		slog.String("to", tool),
+	)
+	return func() tea.Msg {
+		h.instancesMu.RLock()
+		current := h.instanceByID[id]
+		h.instancesMu.RUnlock()
+		if current == nil {
+			return sessionRestartedMsg{sessionID: id, err: fmt.Errorf("session no longer exists")}
+		}
+
+		err := current.RestartWithTool(tool, session.WithRestartCommand(command))
+		mcpUILog.Debug("restart_session_with_tool_result", slog.String("id", id), slog.Any("error", err))
+		return sessionRestartedMsg{sessionID: id, err: err}
+	}
+}
+
 type remoteSessionDeletedMsg struct {
 	remoteName string
 	sessionID  string
@@ -11734,7 +12294,7 @@ func (h *Home) attachSession(inst *session.Instance) tea.Cmd {
 	// which would lose the tmux session state)
 	h.isAttaching.Store(true) // Prevent View() output only during actual attach transition
 	res := &attachResult{}
-	return tea.Exec(attachCmd{session: tmuxSess, opts: h.attachOptions(), result: res}, func(err error) tea.Msg {
+	onDetach := func(err error) tea.Msg {
 		// CRITICAL: Set isAttaching to false BEFORE returning the message
 		// This prevents a race condition where View() could be called with
 		// isAttaching=true before Update() processes statusUpdateMsg,
@@ -11792,7 +12352,22 @@ func (h *Home) attachSession(inst *session.Instance) tea.Cmd {
 		}
 
 		return statusUpdateMsg{attachedSessionID: inst.ID, attachedWorkDir: currentWorkDir}
-	})
+	}
+
+	opts := h.attachOptions()
+	if session.InsideTmux() {
+		// Running inside a client of the session's own tmux server: nesting
+		// tmux in tmux is confusing, so move the existing client with
+		// switch-client and let Bubble Tea release/restore the terminal.
+		if tmux.InsideServer(tmuxSess.SocketName) {
+			return tea.ExecProcess(tmuxSess.AttachCommand(), onDetach)
+		}
+		// A different tmux server can't be switched to; the PTY attach
+		// below nests it. Leave a hint for when the user comes back.
+		h.setError(fmt.Errorf("warning: agent-deck runs inside another tmux server, so attach nests tmux (%s detaches)",
+			DetachByteLabel(opts.DetachByte)))
+	}
+	return tea.Exec(attachCmd{session: tmuxSess, opts: opts, result: res}, onDetach)
 }
 
 func (h *Home) followAttachReturnCwd(msg statusUpdateMsg) {
@@ -12026,6 +12601,7 @@ func (h *Home) importSessions() tea.Msg {
 	}
 	// Save both instances AND groups (critical fix: was losing groups!)
 	h.saveInstances()
+	session.ConfigureAdoptedSessions(discovered)
 	state := h.preserveState()
 	return loadSessionsMsg{instances: instancesCopy, restoreState: &state}
 }
@@ -12258,6 +12834,12 @@ func (h *Home) renderFilterBar() string {
 // updateSizes updates component sizes
 func (h *Home) updateSizes() {
 	h.search.SetSize(h.width, h.height)
+	if h.sessionPalette != nil {
+		h.sessionPalette.SetSize(h.width, h.height)
+	}
+	if h.restartToolDialog != nil {
+		h.restartToolDialog.SetSize(h.width, h.height)
+	}
 	h.newDialog.SetSize(h.width, h.height)
 	h.groupDialog.SetSize(h.width, h.height)
 	h.confirmDialog.SetSize(h.width, h.height)
@@ -12342,6 +12924,12 @@ func (h *Home) View() string {
 	if h.search.IsVisible() {
 		return h.search.View()
 	}
+	if h.sessionPalette.IsVisible() {
+		return h.sessionPalette.View()
+	}
+	if h.restartToolDialog.IsVisible() {
+		return h.restartToolDialog.View()
+	}
 	if h.globalSearch.IsVisible() {
 		return h.globalSearch.View()
 	}
@@ -14595,6 +15183,14 @@ func (h *Home) renderCreatingPreview(creating *CreatingSession, width, height in
 	b.WriteString(centerStyle.Render(titleStyle.Render("🔨 Creating Worktree")))
 	b.WriteString("\n\n")
 
+	// Current step, once the create command has reported one
+	if creating.Step != "" {
+		stepStyle := lipgloss.NewStyle().
+			Foreground(ColorAccent)
+		b.WriteString(centerStyle.Render(stepStyle.Render(creating.Step + "...")))
+		b.WriteString("\n\n")
+	}
+
 	// Description
 	descStyle := lipgloss.NewStyle().
 		Foreground(ColorText)
@@ -14649,7 +15245,11 @@ func (h *Home) renderCreatingSessionItem(
 	b.WriteString(spinnerStyle.Render(spinner))
 	b.WriteString(" ")
 	b.WriteString(titleStyle.Render(item.CreatingTitle))
-	b.WriteString(lipgloss.NewStyle().Foreground(ColorTextDim).Italic(true).Render(" (creating worktree...)"))
+	status := "creating worktree"
+	if creating, ok := h.creatingSessions[item.CreatingID]; ok && creating.Step != "" {
+		status = strings.ToLower(creating.Step[:1]) + creating.Step[1:]
+	}
+	b.WriteString(lipgloss.NewStyle().Foreground(ColorTextDim).Italic(true).Render(" (" + status + "...)"))
 	b.WriteString("\n")
 }
 
@@ -14955,6 +15555,22 @@ func (h *Home) renderSessionItem(
 		timestampBadge,
 	)
 
+	// Notes: the first line, dim, so a wall of similarly named sessions can
+	// be told apart. Comes before the pane title, which is live but noisier.
+	if note := sessionNoteSummary(inst.Notes); note != "" {
+		remaining := listWidth - cellWidth(row) - 4 // -4: " · " and trailing margin
+		if remaining > 10 {
+			if cellWidth(note) > remaining {
+				note = cellTruncate(note, remaining, "…")
+			}
+			noteStyle := DimStyle
+			if selected {
+				noteStyle = SessionStatusSelStyle
+			}
+			row += noteStyle.Render(" · " + note)
+		}
+	}
+
 	// Append pane title filling remaining row space (only for the selected item).
 	// #937 v2: cellWidth/cellTruncate (not lipgloss.Width / ansi.Truncate)
 	// for both the row budget and the pane-title fit check. pane titles
@@ -17063,31 +17679,7 @@ func formatRelativeTime(t time.Time) string {
 	if t.IsZero() {
 		return "unknown"
 	}
-
-	d := time.Since(t)
-
-	switch {
-	case d < time.Minute:
-		return "just now"
-	case d < time.Hour:
-		mins := int(d.Minutes())
-		if mins == 1 {
-			return "1m ago"
-		}
-		return fmt.Sprintf("%dm ago", mins)
-	case d < 24*time.Hour:
-		hours := int(d.Hours())
-		if hours == 1 {
-			return "1h ago"
-		}
-		return fmt.Sprintf("%dh ago", hours)
-	default:
-		days := int(d.Hours() / 24)
-		if days == 1 {
-			return "1d ago"
-		}
-		return fmt.Sprintf("%dd ago", days)
-	}
+	return session.RelativeTime(t, time.Now())
 }
 
 // renderGroupPreview renders the preview pane for a group
@@ -17894,6 +18486,37 @@ func markGroupPathAndAncestors(groupsWithMatches map[string]bool, groupPath stri
 	}
 }
 
+// nextToolFilter returns the tool filter after current in the cycle "" →
+// each distinct tool among instances (alphabetical) → "". A current value no
+// longer in use restarts the cycle at the first tool.
+func nextToolFilter(instances []*session.Instance, current string) string {
+	var tools []string
+	for _, inst := range instances {
+		if inst.Tool != "" && !slices.Contains(tools, inst.Tool) {
+			tools = append(tools, inst.Tool)
+		}
+	}
+	if len(tools) == 0 {
+		return ""
+	}
+	slices.Sort(tools)
+	idx := slices.Index(tools, current)
+	if current == "" || idx == -1 {
+		return tools[0]
+	}
+	if idx == len(tools)-1 {
+		return ""
+	}
+	return tools[idx+1]
+}
+
+// nextSessionSort returns the sort key after current in SessionSortKeys,
+// wrapping back to the default order.
+func nextSessionSort(current session.SessionSortKey) session.SessionSortKey {
+	idx := slices.Index(session.SessionSortKeys, current)
+	return session.SessionSortKeys[(idx+1)%len(session.SessionSortKeys)]
+}
+
 // matchesStatusFilter reports whether status passes the current filter.
 // FilterModeActive consults [display].active_filter_excludes; concrete
 // filters require exact match.
@@ -17931,11 +18554,22 @@ func (h *Home) renderFilterBarHint() string {
 		mark(FilterKeyArchived, h.statusFilter == FilterModeArchived) +
 		dim.Render(" archived")
 
+	if h.toolFilter != "" {
+		hint += dim.Render(" • ") + mark(FilterKeyTool, true) + dim.Render(" "+h.toolFilter)
+	} else {
+		hint += dim.Render(" • ") + mark(FilterKeyTool, false) + dim.Render(" tool")
+	}
+
 	// View-mode indicator (running-on-top / populated-on-top), only when active.
 	if h.groupViewMode != session.GroupViewNormal {
 		hint += dim.Render(" • ") + mark("t", true) + dim.Render(" "+h.groupViewMode.Label())
 	} else {
 		hint += dim.Render(" • ") + mark("t", false) + dim.Render(" view")
 	}
+	if h.sessionSort != session.SortByDefault {
+		hint += dim.Render(" • ") + mark("O", true) + dim.Render(" "+h.sessionSort.Label())
+	} else {
+		hint += dim.Render(" • ") + mark("O", false) + dim.Render(" sort")
+	}
 	return hint
 }
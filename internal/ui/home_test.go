@@ -437,6 +437,64 @@ func TestHomeRenameSessionWithR(t *testing.T) {
 	}
 }
 
+func TestHomeDuplicateSessionWithV(t *testing.T) {
+	setXDGTestHome(t)
+	home := NewHome()
+	home.width = 100
+	home.height = 30
+
+	inst := session.NewInstanceWithGroupAndTool("api", "/tmp/project", "work", "claude")
+	other := session.NewInstanceWithGroupAndTool("api-copy", "/tmp/project", "work", "shell")
+	home.instancesMu.Lock()
+	home.instances = []*session.Instance{inst, other}
+	home.instancesMu.Unlock()
+	home.groupTree = session.NewGroupTree(home.instances)
+	home.rebuildFlatItems()
+	for i, item := range home.flatItems {
+		if item.Type == session.ItemTypeSession && item.Session == inst {
+			home.cursor = i
+		}
+	}
+
+	model, _ := home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	h := model.(*Home)
+	if !h.newDialog.IsVisible() {
+		t.Fatal("new-session dialog should open on V")
+	}
+	name, path, _ := h.newDialog.GetValues()
+	if name != "api-copy-2" || path != "/tmp/project" {
+		t.Errorf("dialog name/path = %q, %q; want api-copy-2, /tmp/project", name, path)
+	}
+	if got := h.newDialog.GetSelectedCommand(); got != "claude" {
+		t.Errorf("selected tool = %q, want claude", got)
+	}
+	if got := h.newDialog.GetSelectedGroup(); got != "work" {
+		t.Errorf("group = %q, want work", got)
+	}
+}
+
+func TestNewDialog_LoadFromInstanceWorktree(t *testing.T) {
+	d := NewNewDialog()
+	inst := session.NewInstanceWithTool("feature", "/tmp/repo-wt", "shell")
+	inst.Command = "make dev"
+	inst.WorktreePath = "/tmp/repo-wt"
+	inst.WorktreeRepoRoot = "/tmp/repo"
+	inst.WorktreeBranch = "feature/feature"
+
+	d.LoadFromInstance(inst, []*session.Instance{inst})
+
+	name, path, command := d.GetValues()
+	if name != "feature-copy" || path != "/tmp/repo" || command != "make dev" {
+		t.Errorf("GetValues() = %q, %q, %q", name, path, command)
+	}
+	if !d.IsWorktreeEnabled() || !d.IsWorktreeExplicit() {
+		t.Error("a worktree session should be copied as a new worktree")
+	}
+	if branch := strings.TrimSpace(d.branchInput.Value()); branch != d.branchPrefix+"feature-copy" {
+		t.Errorf("branch = %q, want %q", branch, d.branchPrefix+"feature-copy")
+	}
+}
+
 func TestHomeRenameSessionComplete(t *testing.T) {
 	home := NewHome()
 	home.width = 100
@@ -1967,7 +2025,7 @@ func TestPushUndoStackLIFO(t *testing.T) {
 	// Push 3 sessions
 	for i := 0; i < 3; i++ {
 		inst := session.NewInstance(fmt.Sprintf("session-%d", i), "/tmp")
-		home.pushUndoStack(inst)
+		home.pushUndoEntry(deletedSessionEntry{instance: inst, deletedAt: time.Now()})
 	}
 
 	if len(home.undoStack) != 3 {
@@ -1989,7 +2047,7 @@ func TestPushUndoStackCap(t *testing.T) {
 	// Push 12 sessions (exceeds cap of 10)
 	for i := 0; i < 12; i++ {
 		inst := session.NewInstance(fmt.Sprintf("session-%d", i), "/tmp")
-		home.pushUndoStack(inst)
+		home.pushUndoEntry(deletedSessionEntry{instance: inst, deletedAt: time.Now()})
 	}
 
 	if len(home.undoStack) != 10 {
@@ -2006,6 +2064,61 @@ func TestPushUndoStackCap(t *testing.T) {
 	}
 }
 
+func TestCtrlZUndoesArchive(t *testing.T) {
+	home := NewHome()
+	home.width = 100
+	home.height = 30
+	inst := session.NewInstance("archived-one", "/tmp")
+	home.instancesMu.Lock()
+	home.instances = append(home.instances, inst)
+	home.instanceByID[inst.ID] = inst
+	home.instancesMu.Unlock()
+
+	inst.ArchivedAt = time.Now().UTC()
+	model, cmd := home.Update(sessionArchivedMsg{sessionID: inst.ID})
+	h := model.(*Home)
+	if cmd == nil || len(h.undoStack) != 1 || !h.undoStack[0].archived {
+		t.Fatalf("archive should push an archived undo entry with an expiry tick, stack=%+v", h.undoStack)
+	}
+	if h.err == nil || !strings.Contains(h.err.Error(), "ctrl+z to undo") {
+		t.Fatalf("archive message = %v, want the undo hint", h.err)
+	}
+
+	_, cmd = h.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if cmd == nil {
+		t.Fatal("ctrl+z should unarchive")
+	}
+	if msg, ok := cmd().(sessionUnarchivedMsg); !ok || msg.sessionID != inst.ID {
+		t.Fatalf("undo produced %#v, want sessionUnarchivedMsg", msg)
+	}
+	if inst.IsArchived() {
+		t.Fatal("undo should clear the archive flag")
+	}
+}
+
+func TestUndoStackExpires(t *testing.T) {
+	home := NewHome()
+	home.width = 100
+	home.height = 30
+	old := session.NewInstance("old", "/tmp")
+	fresh := session.NewInstance("fresh", "/tmp")
+	home.undoStack = append(home.undoStack,
+		deletedSessionEntry{instance: old, deletedAt: time.Now().Add(-2 * undoWindow)},
+		deletedSessionEntry{instance: fresh, deletedAt: time.Now()},
+	)
+
+	home.Update(undoExpiredMsg{})
+	if len(home.undoStack) != 1 || home.undoStack[0].instance != fresh {
+		t.Fatalf("undoStack after expiry = %+v, want only the fresh entry", home.undoStack)
+	}
+
+	home.undoStack[0].deletedAt = time.Now().Add(-undoWindow)
+	home.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if home.err == nil || !strings.Contains(home.err.Error(), "nothing to undo") {
+		t.Fatalf("expired entry should not be undoable, err = %v", home.err)
+	}
+}
+
 func TestCtrlZEmptyStack(t *testing.T) {
 	home := NewHome()
 	home.width = 100
@@ -2053,7 +2166,7 @@ func TestUndoHintInHelpBar(t *testing.T) {
 	}
 
 	// Push to undo stack: should show ^Z
-	home.pushUndoStack(session.NewInstance("deleted", "/tmp"))
+	home.pushUndoEntry(deletedSessionEntry{instance: session.NewInstance("deleted", "/tmp"), deletedAt: time.Now()})
 	result = home.renderHelpBar()
 	if !strings.Contains(result, "Undo") {
 		t.Errorf("Help bar should show Undo when undo stack is non-empty\nGot: %q", result)
@@ -2994,6 +3107,82 @@ func TestRebuildFlatItemsKeepsValidStatusFilter(t *testing.T) {
 	}
 }
 
+func TestRebuildFlatItemsToolFilterAndSort(t *testing.T) {
+	home := NewHome()
+	home.initialLoading = false
+
+	inst1 := &session.Instance{ID: "s1", Title: "zeta", Tool: "claude", Status: session.StatusRunning, Order: 0}
+	inst2 := &session.Instance{ID: "s2", Title: "beta", Tool: "gemini", Status: session.StatusIdle, Order: 1}
+	inst3 := &session.Instance{ID: "s3", Title: "alpha", Tool: "claude", Status: session.StatusIdle, Order: 2}
+
+	home.instancesMu.Lock()
+	home.instances = []*session.Instance{inst1, inst2, inst3}
+	for _, inst := range home.instances {
+		home.instanceByID[inst.ID] = inst
+	}
+	home.instancesMu.Unlock()
+	home.groupTree = session.NewGroupTree(home.instances)
+
+	sessionIDs := func() []string {
+		var ids []string
+		for _, item := range home.flatItems {
+			if item.Type == session.ItemTypeSession {
+				ids = append(ids, item.Session.ID)
+			}
+		}
+		return ids
+	}
+
+	// Pin the other list modes: NewHome may restore them from saved UI state.
+	home.groupViewMode = session.GroupViewNormal
+	home.statusFilter = ""
+	home.sessionSort = session.SortByName
+	home.rebuildFlatItems()
+	if got := sessionIDs(); len(got) != 3 || got[0] != "s3" || got[1] != "s2" || got[2] != "s1" {
+		t.Errorf("name sort = %v, want [s3 s2 s1]", got)
+	}
+
+	// Tool filter composes with the status filter.
+	home.toolFilter = "claude"
+	home.statusFilter = session.StatusIdle
+	home.rebuildFlatItems()
+	if got := sessionIDs(); len(got) != 1 || got[0] != "s3" {
+		t.Errorf("claude+idle = %v, want [s3]", got)
+	}
+
+	// A tool filter that matches nothing is auto-cleared like a status filter.
+	home.statusFilter = ""
+	home.toolFilter = "codex"
+	home.rebuildFlatItems()
+	if home.toolFilter != "" || len(sessionIDs()) != 3 {
+		t.Errorf("toolFilter = %q, sessions = %v; want cleared", home.toolFilter, sessionIDs())
+	}
+}
+
+func TestNextToolFilterAndSessionSort(t *testing.T) {
+	instances := []*session.Instance{{Tool: "gemini"}, {Tool: "claude"}, {Tool: "gemini"}}
+	var seq []string
+	filter := ""
+	for range 3 {
+		filter = nextToolFilter(instances, filter)
+		seq = append(seq, filter)
+	}
+	if seq[0] != "claude" || seq[1] != "gemini" || seq[2] != "" {
+		t.Errorf("tool filter cycle = %q", seq)
+	}
+	if got := nextToolFilter(instances, "codex"); got != "claude" {
+		t.Errorf("stale filter should restart the cycle, got %q", got)
+	}
+
+	sort := session.SortByDefault
+	for range session.SessionSortKeys {
+		sort = nextSessionSort(sort)
+	}
+	if sort != session.SortByDefault {
+		t.Errorf("sort cycle did not wrap, ended at %q", sort)
+	}
+}
+
 func TestMatchesStatusFilter(t *testing.T) {
 	// Default matches upstream's original hardcoded behavior so existing
 	// users see no change unless they opt into a narrower exclude-set.
@@ -3810,3 +3999,13 @@ func TestDeleteBindingOnNonDefaultGroupOpensDialog(t *testing.T) {
 		t.Errorf("non-default group delete must not set an error, got %v", h.err)
 	}
 }
+
+func TestClaudeModelsCmd_RequestsOnce(t *testing.T) {
+	h := &Home{}
+	if h.claudeModelsCmd() == nil {
+		t.Fatal("the first dialog open must fetch the Claude model list")
+	}
+	if h.claudeModelsCmd() != nil {
+		t.Fatal("the model list is fetched only once")
+	}
+}
@@ -14,6 +14,7 @@ const (
 	hotkeyRename           = "rename"
 	hotkeyRestart          = "restart"
 	hotkeyRestartFresh     = "restart_fresh"
+	hotkeyRestartWithTool  = "restart_with_tool"
 	hotkeyDelete           = "delete"
 	hotkeyCloseSession     = "close_session"
 	hotkeyArchiveSession   = "archive_session"
@@ -32,6 +33,7 @@ const (
 	hotkeyToggleYolo       = "toggle_yolo"
 	hotkeyQuickFork        = "quick_fork"
 	hotkeyForkWithOptions  = "fork_with_options"
+	hotkeyDuplicate        = "duplicate_session"
 	hotkeyCopyOutput       = "copy_output"
 	hotkeySendOutput       = "send_output"
 	hotkeyExecShell        = "exec_shell"
@@ -42,6 +44,7 @@ const (
 	hotkeyWorktreeFinish   = "worktree_finish"
 	hotkeyCreateGroup      = "create_group"
 	hotkeySearch           = "search"
+	hotkeySessionPalette   = "session_palette"
 	hotkeyHelp             = "help"
 	hotkeySettings         = "settings"
 	hotkeyImport           = "import"
@@ -66,6 +69,7 @@ var hotkeyActionOrder = []string{
 	hotkeyRename,
 	hotkeyRestart,
 	hotkeyRestartFresh,
+	hotkeyRestartWithTool,
 	hotkeyDelete,
 	hotkeyCloseSession,
 	hotkeyArchiveSession,
@@ -84,6 +88,7 @@ var hotkeyActionOrder = []string{
 	hotkeyToggleYolo,
 	hotkeyQuickFork,
 	hotkeyForkWithOptions,
+	hotkeyDuplicate,
 	hotkeyCopyOutput,
 	hotkeySendOutput,
 	hotkeyExecShell,
@@ -94,6 +99,7 @@ var hotkeyActionOrder = []string{
 	hotkeyWorktreeFinish,
 	hotkeyCreateGroup,
 	hotkeySearch,
+	hotkeySessionPalette,
 	hotkeyHelp,
 	hotkeySettings,
 	hotkeyImport,
@@ -110,6 +116,7 @@ var defaultHotkeyBindings = map[string]string{
 	hotkeyRename:           "r",
 	hotkeyRestart:          "R",
 	hotkeyRestartFresh:     "T",
+	hotkeyRestartWithTool:  "alt+r",
 	hotkeyDelete:           "d",
 	hotkeyCloseSession:     "D",
 	hotkeyArchiveSession:   "A",
@@ -128,6 +135,7 @@ var defaultHotkeyBindings = map[string]string{
 	hotkeyToggleYolo:       "y",
 	hotkeyQuickFork:        "f",
 	hotkeyForkWithOptions:  "F",
+	hotkeyDuplicate:        "V",
 	hotkeyCopyOutput:       "c",
 	hotkeySendOutput:       "x",
 	hotkeyExecShell:        "E",
@@ -138,6 +146,7 @@ var defaultHotkeyBindings = map[string]string{
 	hotkeyWorktreeFinish:   "W",
 	hotkeyCreateGroup:      "g",
 	hotkeySearch:           "/",
+	hotkeySessionPalette:   "ctrl+t",
 	hotkeyHelp:             "?",
 	hotkeySettings:         "S",
 	hotkeyImport:           "i",
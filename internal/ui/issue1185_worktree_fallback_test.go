@@ -156,3 +156,26 @@ func TestForkDialog_IsWorktreeExplicit_DefaultVsToggle(t *testing.T) {
 		t.Fatalf("fork worktree toggled by the user must be reported as explicit")
 	}
 }
+
+// A computed worktree path that is already a regular directory is rejected
+// in the dialog instead of failing later in `git worktree add`.
+func TestResolveWorktreeTarget_ExistingNonWorktreeDirErrors(t *testing.T) {
+	dir := t.TempDir()
+	makeGitRepo(t, dir)
+
+	wtPath, _, _, errMsg := resolveWorktreeTarget(dir, "feature/x", true)
+	if errMsg != "" || wtPath == "" {
+		t.Fatalf("first resolve: wtPath=%q errMsg=%q", wtPath, errMsg)
+	}
+	if err := os.MkdirAll(wtPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(wtPath) }) // sibling of dir, outside TempDir
+	if err := os.WriteFile(filepath.Join(wtPath, "stray.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, _, errMsg := resolveWorktreeTarget(dir, "feature/x", true); !strings.Contains(errMsg, "not a worktree") {
+		t.Fatalf("errMsg = %q, want an existing-directory error", errMsg)
+	}
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// lastPathMetaKeyPrefix, followed by a group path, is the StateDB metadata key
+// under which the project path of the last session created in that group is
+// remembered. Like the last-used tool it lives in the profile state.db, never
+// config.toml, so creating sessions does not churn the user's config.
+const lastPathMetaKeyPrefix = "last_path:"
+
+// rememberedPath returns the last path used in groupPath, or "" when nothing
+// is remembered, the directory is gone, or the store is unavailable.
+func rememberedPath(db *statedb.StateDB, groupPath string) string {
+	if db == nil {
+		return ""
+	}
+	p, _ := db.GetMeta(lastPathMetaKeyPrefix + groupPath)
+	if p == "" {
+		return ""
+	}
+	// A stale path would make the dialog offer to create the directory.
+	if _, err := os.Stat(p); err != nil {
+		return ""
+	}
+	return p
+}
+
+// rememberPath records the path inst was created on for its group. A worktree
+// session remembers its repository, which is what the user typed. Best-effort
+// like rememberTool.
+func rememberPath(db *statedb.StateDB, inst *session.Instance) {
+	if db == nil || inst == nil {
+		return
+	}
+	p := inst.ProjectPath
+	if inst.WorktreeRepoRoot != "" {
+		p = inst.WorktreeRepoRoot
+	}
+	if p == "" {
+		return
+	}
+	_ = db.SetMeta(lastPathMetaKeyPrefix+inst.Group(), p)
+}
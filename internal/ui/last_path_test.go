@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+func TestRememberPath_PerGroup(t *testing.T) {
+	db, err := statedb.Open(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("open statedb: %v", err)
+	}
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("migrate statedb: %v", err)
+	}
+	defer db.Close()
+
+	if got := rememberedPath(db, "work"); got != "" {
+		t.Fatalf("rememberedPath on fresh db = %q, want \"\"", got)
+	}
+
+	workDir, repo := t.TempDir(), t.TempDir()
+	rememberPath(db, &session.Instance{GroupPath: "work", ProjectPath: workDir})
+	rememberPath(db, &session.Instance{GroupPath: "play", ProjectPath: filepath.Join(repo, "wt"), WorktreeRepoRoot: repo})
+
+	if got := rememberedPath(db, "work"); got != workDir {
+		t.Errorf("work = %q, want %q", got, workDir)
+	}
+	if got := rememberedPath(db, "play"); got != repo {
+		t.Errorf("worktree session should remember its repo: got %q, want %q", got, repo)
+	}
+
+	gone := filepath.Join(t.TempDir(), "deleted")
+	rememberPath(db, &session.Instance{GroupPath: "work", ProjectPath: gone})
+	if got := rememberedPath(db, "work"); got != "" {
+		t.Errorf("a path that no longer exists must fall back, got %q", got)
+	}
+}
+
+func TestRememberedPath_NilDB(t *testing.T) {
+	rememberPath(nil, &session.Instance{GroupPath: "work", ProjectPath: "/tmp"})
+	if got := rememberedPath(nil, "work"); got != "" {
+		t.Fatalf("rememberedPath(nil) = %q, want \"\"", got)
+	}
+}
@@ -2,18 +2,22 @@ package ui
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
-	"github.com/asheshgoplani/agent-deck/internal/git"
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	"github.com/asheshgoplani/agent-deck/internal/statedb"
+	"github.com/asheshgoplani/agent-deck/internal/vcsbackend"
 )
 
 // overlayDropdown paints `overlay` on top of `base` starting at the given
@@ -145,8 +149,41 @@ const (
 	newDialogInputWidthPad       = 12 // outer width minus indent ≈ textinput width
 	newDialogInputMinWidth       = 28
 	newDialogInputMaxWidth       = 100
+	// newDialogCompactHeight is the terminal height below which the dialog
+	// switches to its compact layout (see NewDialog.compact).
+	newDialogCompactHeight = 50
 )
 
+// pathSuggestionSource says where a path suggestion came from; the dropdown
+// tags each entry with it.
+type pathSuggestionSource int
+
+const (
+	pathSourceRecent   pathSuggestionSource = iota // a path earlier sessions used.
+	pathSourceDir                                  // a directory completing the typed path.
+	pathSourceBookmark                             // a [path_bookmarks] entry.
+)
+
+func (s pathSuggestionSource) label() string {
+	switch s {
+	case pathSourceDir:
+		return "dir"
+	case pathSourceBookmark:
+		return "bookmark"
+	}
+	return "recent"
+}
+
+// maxDirSuggestions caps the directory completions added to the dropdown.
+const maxDirSuggestions = 10
+
+// dirCompletionsMsg carries the directories completing typed, read by
+// NewDialog.dirCompletionsCmd.
+type dirCompletionsMsg struct {
+	typed   string
+	matches []string
+}
+
 // settingDisplay pairs a label with a formatted value for read-only display.
 type settingDisplay struct {
 	label string
@@ -157,7 +194,7 @@ type settingDisplay struct {
 type NewDialog struct {
 	nameInput             textinput.Model
 	pathInput             textinput.Model
-	commandInput          textinput.Model
+	commandInput          textarea.Model // multi-line: shell setup scripts
 	modelInput            textinput.Model
 	claudeOptions         *ClaudeOptionsPanel // Claude-specific options (concrete for value extraction).
 	geminiOptions         *YoloOptionsPanel   // Gemini YOLO panel (concrete for value extraction).
@@ -186,9 +223,27 @@ type NewDialog struct {
 	modelSuggestionHidden bool     // true when the model dropdown is explicitly dismissed.
 	modelNavigated        bool     // true when the user explicitly navigated model suggestions.
 	modelLineOffset       int      // Content line where model suggestions overlay should appear.
+	// Where each path suggestion came from (parallel to pathSuggestions), and
+	// the expanded [path_bookmarks] listed before the recent paths.
+	pathSuggestionSources []pathSuggestionSource
+	bookmarks             []string
+	// dirCompletions are the directories completing dirCompletionsFor, read
+	// in the background by dirCompletionsCmd so typing never waits on disk.
+	dirCompletions    []string
+	dirCompletionsFor string
+
+	// nameEdited is set once the name holds one the user chose (typed,
+	// restored, or copied); until then the name follows the path's base name.
+	nameEdited bool
+	// claudeModels replaces the built-in Claude suggestions once
+	// session.GetAvailableClaudeModels answers; claudeModelsLive marks a list
+	// fresh from the API, the only one a typed model is validated against.
+	claudeModels     []string
+	claudeModelsLive bool
 	// Worktree support.
 	worktreeEnabled bool
-	worktreeToggled bool // true once the user explicitly toggled the worktree checkbox (vs config default_enabled); see #1185.
+	worktreeToggled bool   // true once the user explicitly toggled the worktree checkbox (vs config default_enabled); see #1185.
+	gitInitOffer    string // non-repo path the user tried to enable worktree on; Home offers `git init` (TakeGitInitOffer).
 	branchInput     textinput.Model
 	branchAutoSet   bool   // true if branch was auto-derived from session name.
 	branchPrefix    string // configured prefix for auto-generated branch names.
@@ -211,15 +266,58 @@ type NewDialog struct {
 	recentSessionCursor int
 	showRecentPicker    bool
 	recentSnapshot      *dialogSnapshot // saved state to restore on Esc
+	// Draft of a dismissed form. Unlike the rest of the state it survives
+	// ShowInGroup, so the next open can restore it (SaveDraft/RestoreDraft).
+	draft         *dialogSnapshot
+	draftBaseline [4]string // draftFields when the form was last filled in by code
+	draftRestored bool      // shows the "draft restored" hint
 	// Conducting parent selector.
 	conductorSessions []*session.Instance // nil when no conductors; populated by ShowInGroup
 	conductorCursor   int                 // 0 = "None", 1..N index into conductorSessions
 
+	// Per-project defaults (.agentdeck.json). projectConfigPath is the path
+	// the file was last loaded for, so edits are only overridden when the
+	// path changes; projectConfigNote is shown under the path field, and
+	// also warns about a missing path that allow_missing_paths lets through.
+	projectConfigPath string
+	projectConfigNote string
+	// projectOverlay is what the current path's file changed in the form,
+	// undone when the path moves to another project or to none.
+	projectOverlay *projectOverlay
+
+	// commandPreview is PreviewCommand's result as of the last change.
+	commandPreview string
+
+	// pathBase is config.toml relative_path_base: the directory relative
+	// paths resolve against ("" = agent-deck's working directory). remote is
+	// set while the dialog targets a remote host, whose paths are never
+	// resolved locally.
+	pathBase string
+	remote   bool
+
+	// shellInit is [shell].shell_init and projectShellInit the project's
+	// shell_init override: run before a plain shell session's prompt.
+	shellInit        string
+	projectShellInit string
+
+	// commandCheck is [shell].command_check. commandCheckAcked is the flagged
+	// custom command already warned about, which a second submit runs.
+	commandCheck      string
+	commandCheckAcked string
+
+	// help lists every dialog key; opened with F1, or ? outside text fields.
+	help *HelpOverlay
+
 	// enterAdvances mirrors config.toml [ui] new_session_enter_advances (PR
 	// #1295). False (default) preserves today's behavior: Enter on the free-text
 	// Name/Branch fields submits the form. True makes Enter advance focus
 	// instead, with Ctrl+S as the explicit submit. Ctrl+S submits in both modes.
 	enterAdvances bool
+
+	// compact is set by SetSize on short terminals: the tool options collapse
+	// to a "More options…" line until focused, and the command preview and
+	// jump-key hints are dropped, so the essential fields fit.
+	compact bool
 }
 
 // dialogSnapshot captures form state so the recent picker can restore on cancel.
@@ -346,10 +444,15 @@ func NewNewDialog() *NewDialog {
 		pathInput.SetValue(cwd)
 	}
 
-	// Create command input
-	commandInput := textinput.New()
-	commandInput.Placeholder = "custom command"
-	commandInput.CharLimit = 100
+	// Create command input (multi-line so a shell session can run a short
+	// setup script, e.g. a heredoc, before handing over the prompt).
+	commandInput := textarea.New()
+	commandInput.Placeholder = "custom command (Enter = newline)"
+	commandInput.ShowLineNumbers = false
+	commandInput.Prompt = ""
+	commandInput.CharLimit = 4096
+	commandInput.SetHeight(3)
+	commandInput.Blur()
 
 	// Optional per-session model/version override for supported tools.
 	modelInput := textinput.New()
@@ -381,6 +484,7 @@ func NewNewDialog() *NewDialog {
 		worktreeEnabled: false,
 		branchPrefix:    "feature/",
 		enterAdvances:   newSessionEnterAdvancesFromConfig(),
+		help:            NewHelpOverlay(),
 	}
 	dlg.syncInputWidths()
 	dlg.updateToolOptions() // Also calls rebuildFocusTargets.
@@ -401,6 +505,7 @@ func (d *NewDialog) ShowInGroup(groupPath, groupName, defaultPath string, conduc
 	d.validationErr = ""
 	d.nameInput.SetValue("")
 	d.nameInput.Focus()
+	d.nameEdited = false
 	d.suggestionNavigated = false // reset on show
 	d.pathSuggestionCursor = 0    // reset cursor too
 	d.suggestionsActive = false
@@ -439,6 +544,11 @@ func (d *NewDialog) ShowInGroup(groupPath, groupName, defaultPath string, conduc
 	d.branchInput.SetValue("")
 	d.branchAutoSet = false
 	d.branchPrefix = "feature/" // default; overridden below if config provides one.
+	d.pathBase = ""
+	d.remote = false
+	d.shellInit = ""
+	d.commandCheck = session.CommandCheckWarn
+	d.commandCheckAcked = ""
 	// Reset multi-repo fields (ephemeral, never pre-filled).
 	d.multiRepoEnabled = false
 	d.multiRepoPaths = nil
@@ -474,6 +584,9 @@ func (d *NewDialog) ShowInGroup(groupPath, groupName, defaultPath string, conduc
 		}
 		d.inheritedSettings = buildInheritedSettings(userConfig.Docker)
 		d.branchPrefix = userConfig.Worktree.Prefix()
+		d.pathBase = userConfig.RelativePathBase
+		d.shellInit = userConfig.Shell.ShellInit
+		d.commandCheck = userConfig.Shell.GetCommandCheck()
 		// #1172: preselect the configured default model so users who set
 		// [claude].default_model aren't forced to switch off Sonnet on every
 		// new session. Overrides the empty value set above; left empty when
@@ -482,8 +595,122 @@ func (d *NewDialog) ShowInGroup(groupPath, groupName, defaultPath string, conduc
 			d.modelInput.SetValue(dm)
 		}
 	}
+	d.projectConfigPath = ""
+	d.projectOverlay = nil
+	d.refreshProjectConfig()
 	d.branchInput.Placeholder = d.branchPrefix + "branch-name"
 	d.rebuildFocusTargets()
+	d.draftRestored = false
+	d.refreshCommandPreview()
+	d.markDraftBaseline()
+}
+
+// missingPathAllowed reports whether path is a local path that does not exist
+// but may be used anyway, per allow_missing_paths.
+func (d *NewDialog) missingPathAllowed(path string) bool {
+	if d.remote || path == "" {
+		return false
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return false
+	}
+	return session.MissingPathAllowed(path)
+}
+
+// projectOverlay records the form values a project's .agentdeck.json set
+// and the user-default values they replaced.
+type projectOverlay struct {
+	tool, prevTool string // tool is "" when the file did not set one
+
+	worktree           bool // the file set the worktree checkbox
+	prevWorktree       bool
+	prevBranch         string
+	prevBranchAutoSet  bool
+	claude, prevClaude *session.ClaudeOptions // nil when not overlaid
+}
+
+// undoProjectOverlay puts back the values the last project file replaced,
+// leaving alone any the user has changed since.
+func (d *NewDialog) undoProjectOverlay() {
+	o := d.projectOverlay
+	d.projectOverlay = nil
+	if o == nil {
+		return
+	}
+	if o.tool != "" && d.GetSelectedCommand() == o.tool {
+		d.SetDefaultTool(o.prevTool)
+	}
+	if o.worktree && !d.worktreeToggled {
+		d.worktreeEnabled = o.prevWorktree
+		if d.branchAutoSet {
+			d.branchInput.SetValue(o.prevBranch)
+			d.branchAutoSet = o.prevBranchAutoSet
+		}
+	}
+	if o.claude != nil && reflect.DeepEqual(d.claudeOptions.GetOptions(), o.claude) {
+		d.claudeOptions.SetFromOptions(o.prevClaude)
+	}
+}
+
+// refreshProjectConfig applies .agentdeck.json from the current path when the
+// path differs from the one last applied. Project values override the user
+// config defaults already in the dialog; a malformed file only sets a warning.
+func (d *NewDialog) refreshProjectConfig() {
+	path := d.resolvePath(d.pathInput.Value())
+	if path == d.projectConfigPath {
+		return
+	}
+	d.projectConfigPath = path
+	d.projectConfigNote = ""
+	d.projectShellInit = ""
+	d.undoProjectOverlay()
+
+	cfg, err := session.LoadProjectConfig(path)
+	if err != nil {
+		d.projectConfigNote = "⚠ " + err.Error()
+		return
+	}
+	if cfg == nil {
+		if d.missingPathAllowed(path) {
+			d.projectConfigNote = "⚠ Path does not exist yet (allowed by allow_missing_paths)"
+		}
+		return
+	}
+	overlay := &projectOverlay{}
+	d.projectOverlay = overlay
+	if cfg.Tool != "" && slices.Contains(d.presetCommands, cfg.Tool) {
+		overlay.tool, overlay.prevTool = cfg.Tool, d.GetSelectedCommand()
+		d.SetDefaultTool(cfg.Tool)
+	}
+	if cfg.Worktree != nil && !d.worktreeToggled {
+		overlay.worktree = true
+		overlay.prevWorktree = d.worktreeEnabled
+		overlay.prevBranch = d.branchInput.Value()
+		overlay.prevBranchAutoSet = d.branchAutoSet
+		d.worktreeEnabled = *cfg.Worktree
+		d.branchAutoSet = d.worktreeEnabled
+		if d.worktreeEnabled {
+			d.autoBranchFromName()
+		}
+	}
+	d.projectShellInit = cfg.TrustedShellInit()
+	if len(cfg.Claude) > 0 && cfg.Trusted {
+		prev := d.claudeOptions.GetOptions()
+		opts := d.claudeOptions.GetOptions()
+		if err := cfg.ApplyClaude(opts); err != nil {
+			d.projectConfigNote = "⚠ " + err.Error()
+			return
+		}
+		d.claudeOptions.SetFromOptions(opts)
+		overlay.claude, overlay.prevClaude = d.claudeOptions.GetOptions(), prev
+	}
+	d.projectConfigNote = "Using " + session.ProjectConfigFileName
+	if ignored := cfg.UntrustedParts(); len(ignored) > 0 {
+		d.projectConfigNote += " (untrusted: " + strings.Join(ignored, ", ") + " ignored; run agent-deck trust)"
+	} else if n := len(cfg.Env); n > 0 {
+		d.projectConfigNote += fmt.Sprintf(" (%d env vars)", n)
+	}
+	d.rebuildFocusTargets()
 }
 
 // SetDefaultTool sets the pre-selected command based on tool name
@@ -534,7 +761,7 @@ func (d *NewDialog) syncInputWidths() {
 	}
 	d.nameInput.Width = iw
 	d.pathInput.Width = iw
-	d.commandInput.Width = iw
+	d.commandInput.SetWidth(iw)
 	d.modelInput.Width = iw
 	d.branchInput.Width = iw
 }
@@ -543,19 +770,110 @@ func (d *NewDialog) syncInputWidths() {
 func (d *NewDialog) SetSize(width, height int) {
 	d.width = width
 	d.height = height
+	d.compact = height > 0 && height < newDialogCompactHeight
 	d.syncInputWidths()
 	if d.branchPicker != nil {
 		d.branchPicker.SetSize(width, height)
 	}
+	d.help.SetSize(width, height)
 }
 
-// SetPathSuggestions sets the available path suggestions for autocomplete
+// SetPathSuggestions sets the available path suggestions for autocomplete.
+// The paths are normalized first (see normalizePaths).
 func (d *NewDialog) SetPathSuggestions(paths []string) {
+	d.allPathSuggestions = normalizePaths(paths)
+	d.resetPathSuggestions()
+}
+
+// SetRemotePathSuggestions sets path suggestions that live on a remote host.
+// They are kept as given: ~ and existence only mean something over there.
+func (d *NewDialog) SetRemotePathSuggestions(paths []string) {
 	d.allPathSuggestions = paths
-	d.pathSuggestions = paths
+	d.resetPathSuggestions()
+}
+
+// pathExists reports whether a recent path is still on disk.
+// Overridable for tests, whose fixture paths are mostly made up.
+var pathExists = func(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// normalizePaths expands ~, cleans each path, and drops blanks, duplicates
+// and paths that no longer exist, keeping the first occurrence's position.
+func normalizePaths(paths []string) []string {
+	out := make([]string, 0, len(paths))
+	seen := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		p = filepath.Clean(session.ExpandPath(p))
+		if seen[p] || !pathExists(p) {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	return out
+}
+
+// SetBookmarks sets the curated directories listed first in the path
+// dropdown. Entries are stored expanded, so accepting one fills in the
+// absolute path; the dropdown shows them with ~ for the home directory.
+func (d *NewDialog) SetBookmarks(paths []string) {
+	d.bookmarks = nil
+	for _, p := range paths {
+		if p = session.ExpandPath(strings.TrimSpace(p)); p != "" && !slices.Contains(d.bookmarks, p) {
+			d.bookmarks = append(d.bookmarks, p)
+		}
+	}
+	d.resetPathSuggestions()
+}
+
+// resetPathSuggestions lists every bookmark and recent path, unfiltered.
+func (d *NewDialog) resetPathSuggestions() {
+	d.pathSuggestions = nil
+	d.pathSuggestionSources = nil
+	d.addPathSuggestions("")
 	d.pathSuggestionCursor = 0
 }
 
+// addPathSuggestions appends the bookmarks, then the recent paths, that
+// contain query (lower-case; "" matches all). A recent path that is also a
+// bookmark is listed once, as the bookmark.
+func (d *NewDialog) addPathSuggestions(query string) {
+	for _, b := range d.bookmarks {
+		if query == "" || strings.Contains(strings.ToLower(b), query) ||
+			strings.Contains(strings.ToLower(collapseHome(b)), query) {
+			d.pathSuggestions = append(d.pathSuggestions, b)
+			d.pathSuggestionSources = append(d.pathSuggestionSources, pathSourceBookmark)
+		}
+	}
+	for _, p := range d.allPathSuggestions {
+		if slices.Contains(d.bookmarks, p) {
+			continue
+		}
+		if query == "" || strings.Contains(strings.ToLower(p), query) {
+			d.pathSuggestions = append(d.pathSuggestions, p)
+			d.pathSuggestionSources = append(d.pathSuggestionSources, pathSourceRecent)
+		}
+	}
+}
+
+// collapseHome shows path with ~ for the home directory.
+func collapseHome(path string) string {
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		if path == home {
+			return "~"
+		}
+		if strings.HasPrefix(path, home+string(os.PathSeparator)) {
+			return "~" + path[len(home):]
+		}
+	}
+	return path
+}
+
 // IsRecentPickerOpen returns whether the recent sessions picker is visible.
 func (d *NewDialog) IsRecentPickerOpen() bool {
 	return d.showRecentPicker && len(d.recentSessions) > 0
@@ -640,8 +958,18 @@ func (d *NewDialog) shouldHandleEnterLocally() bool {
 	// must NOT claim it locally.
 	case focusName, focusBranch:
 		return d.enterAdvances
+	// The shell command is a textarea too: Enter inserts a newline.
+	case focusCommand:
+		return d.commandCursor == 0
 	case focusMultiRepo:
 		return d.multiRepoEnabled
+	// The Claude system prompt is a textarea: Enter inserts a newline there,
+	// so submit goes through Ctrl+S instead.
+	case focusOptions:
+		if d.isClaudeSelected() && d.claudeOptions.isAppendPromptFocused() {
+			return true
+		}
+		return d.suggestionsActive || d.modelSuggestionActive
 	default:
 		return d.suggestionsActive || d.modelSuggestionActive
 	}
@@ -715,7 +1043,8 @@ func (d *NewDialog) DismissModelSuggestions() {
 	d.modelSuggestionActive = false
 }
 
-// SetRecentSessions sets the list of recently deleted session configs.
+// SetRecentSessions sets the configs of recently created and deleted
+// sessions offered by the Ctrl+R picker.
 func (d *NewDialog) SetRecentSessions(sessions []*statedb.RecentSessionRow) {
 	d.recentSessions = sessions
 	d.recentSessionCursor = 0
@@ -754,6 +1083,7 @@ func (d *NewDialog) saveSnapshot() *dialogSnapshot {
 // restoreSnapshot restores form state from a snapshot.
 func (d *NewDialog) restoreSnapshot(s *dialogSnapshot) {
 	d.nameInput.SetValue(s.name)
+	d.nameEdited = s.name != ""
 	d.pathInput.SetValue(s.path)
 	d.commandCursor = s.commandCursor
 	d.commandInput.SetValue(s.commandInput)
@@ -778,23 +1108,138 @@ func (d *NewDialog) restoreSnapshot(s *dialogSnapshot) {
 	d.rebuildFocusTargets()
 }
 
+// draftFields are the typed values that decide whether a form is worth
+// keeping as a draft.
+func (d *NewDialog) draftFields() [4]string {
+	return [4]string{d.nameInput.Value(), d.pathInput.Value(), d.commandInput.Value(), d.branchInput.Value()}
+}
+
+// markDraftBaseline records the current values as not user-typed, so that
+// dismissing the form without edits does not save a draft.
+func (d *NewDialog) markDraftBaseline() {
+	d.draftBaseline = d.draftFields()
+}
+
+// SaveDraft keeps the in-progress form so an accidental dismissal does not
+// lose it. It is a no-op when nothing was typed since the form was filled in
+// and for remote targets, whose paths mean nothing on the next local open.
+func (d *NewDialog) SaveDraft() {
+	if d.remote || d.draftFields() == d.draftBaseline {
+		return
+	}
+	d.draft = d.saveSnapshot()
+}
+
+// RestoreDraft fills the form from the saved draft, if any, and reports
+// whether it did. Call it after ShowInGroup; the draft is kept until
+// ClearDraft so dismissing the restored form again loses nothing.
+func (d *NewDialog) RestoreDraft() bool {
+	if d.draft == nil {
+		return false
+	}
+	// Load the draft path's project config first so its note and shell_init
+	// apply, then let the draft's own values win over its defaults.
+	d.pathInput.SetValue(d.draft.path)
+	d.refreshProjectConfig()
+	d.restoreSnapshot(d.draft)
+	if d.conductorCursor > len(d.conductorSessions) {
+		d.conductorCursor = 0 // conductor list changed since the draft
+	}
+	d.pathSoftSelected = false
+	d.draftRestored = true
+	d.refreshCommandPreview()
+	d.markDraftBaseline()
+	return true
+}
+
+// ClearDraft drops the saved draft; called once a session is created.
+func (d *NewDialog) ClearDraft() {
+	d.draft = nil
+	d.draftRestored = false
+}
+
 // previewRecentSession pre-fills the dialog from a recent session row (keeps picker open).
 func (d *NewDialog) previewRecentSession(rs *statedb.RecentSessionRow) {
 	d.nameInput.SetValue(rs.Title)
+	d.nameEdited = rs.Title != ""
 	d.pathInput.SetValue(rs.ProjectPath)
 
+	d.applySavedTool(rs.Tool, rs.Command, rs.ToolOptions, rs.GeminiYoloMode)
+
+	d.sandboxEnabled = rs.SandboxEnabled
+	d.filterModelSuggestions()
+
+	// Reset worktree (ephemeral, never pre-filled)
+	d.worktreeEnabled = false
+	d.worktreeToggled = false
+	d.branchInput.SetValue("")
+	d.branchAutoSet = false
+
+	// Reset multi-repo (ephemeral, never pre-filled)
+	d.multiRepoEnabled = false
+	d.multiRepoPaths = nil
+	d.multiRepoPathCursor = 0
+	d.multiRepoEditing = false
+
+	d.rebuildFocusTargets()
+}
+
+// LoadFromInstance pre-fills the dialog as a copy of inst ("duplicate"): its
+// path, tool, custom command, tool options, YOLO and sandbox settings. The
+// name gets a "-copy" suffix made unique among siblings in inst's group. A
+// worktree session is copied as a new worktree of the same repository, with a
+// branch named after the copy.
+func (d *NewDialog) LoadFromInstance(inst *session.Instance, siblings []*session.Instance) {
+	d.nameInput.SetValue(session.GenerateCopyTitle(siblings, inst.GroupPath, inst.Title))
+	d.nameEdited = true
+	d.pathInput.SetValue(inst.ProjectPath)
+	d.pathSoftSelected = false
+
+	d.applySavedTool(inst.Tool, inst.Command, inst.ToolOptionsJSON, inst.GeminiYoloMode)
+	d.sandboxEnabled = inst.Sandbox != nil
+	d.filterModelSuggestions()
+
+	d.worktreeEnabled = inst.IsWorktree()
+	d.worktreeToggled = d.worktreeEnabled
+	d.branchInput.SetValue("")
+	d.branchAutoSet = false
+	if d.worktreeEnabled {
+		if inst.WorktreeRepoRoot != "" {
+			d.pathInput.SetValue(inst.WorktreeRepoRoot)
+		}
+		d.autoBranchFromName()
+	}
+
+	d.multiRepoEnabled = false
+	d.multiRepoPaths = nil
+	d.multiRepoPathCursor = 0
+	d.multiRepoEditing = false
+
+	d.projectConfigPath = ""
+	d.projectOverlay = nil
+	d.refreshProjectConfig()
+	d.rebuildFocusTargets()
+	d.refreshCommandPreview()
+	d.markDraftBaseline()
+}
+
+// applySavedTool selects tool (or the shell with command when the tool is
+// empty, "shell" or no longer offered) and restores its per-tool options from
+// a saved ToolOptionsWrapper payload. Shared by the recent-session picker and
+// LoadFromInstance.
+func (d *NewDialog) applySavedTool(tool, command string, toolOptions json.RawMessage, geminiYolo *bool) {
 	// Default to shell/custom command mode.
 	d.commandCursor = 0
 	d.commandInput.SetValue("")
 	d.modelInput.SetValue("")
 
 	// Set command/tool.
-	if rs.Tool == "" || rs.Tool == "shell" {
-		d.commandInput.SetValue(strings.TrimSpace(rs.Command))
+	if tool == "" || tool == "shell" {
+		d.commandInput.SetValue(strings.TrimSpace(command))
 	} else {
 		matched := false
 		for i, cmd := range d.presetCommands {
-			if cmd == rs.Tool {
+			if cmd == tool {
 				d.commandCursor = i
 				matched = true
 				break
@@ -803,17 +1248,17 @@ func (d *NewDialog) previewRecentSession(rs *statedb.RecentSessionRow) {
 		// If the saved tool no longer exists, fall back to shell/custom command.
 		if !matched {
 			d.commandCursor = 0
-			d.commandInput.SetValue(strings.TrimSpace(rs.Command))
+			d.commandInput.SetValue(strings.TrimSpace(command))
 		}
 	}
 	d.updateToolOptions()
 
 	// Apply tool-specific options
-	if len(rs.ToolOptions) > 0 && string(rs.ToolOptions) != "{}" {
+	if len(toolOptions) > 0 && string(toolOptions) != "{}" {
 		switch {
-		case session.IsClaudeCompatible(rs.Tool):
+		case session.IsClaudeCompatible(tool):
 			var wrapper session.ToolOptionsWrapper
-			if err := json.Unmarshal(rs.ToolOptions, &wrapper); err == nil && wrapper.Tool == "claude" {
+			if err := json.Unmarshal(toolOptions, &wrapper); err == nil && wrapper.Tool == "claude" {
 				var opts session.ClaudeOptions
 				if err := json.Unmarshal(wrapper.Options, &opts); err == nil {
 					d.claudeOptions.SetFromOptions(&opts)
@@ -822,13 +1267,13 @@ func (d *NewDialog) previewRecentSession(rs *statedb.RecentSessionRow) {
 					}
 				}
 			}
-		case rs.Tool == "gemini":
-			if rs.GeminiYoloMode != nil {
-				d.geminiOptions.SetDefaults(*rs.GeminiYoloMode)
+		case tool == "gemini":
+			if geminiYolo != nil {
+				d.geminiOptions.SetDefaults(*geminiYolo)
 			}
-		case rs.Tool == "codex":
+		case tool == "codex":
 			var wrapper session.ToolOptionsWrapper
-			if err := json.Unmarshal(rs.ToolOptions, &wrapper); err == nil && wrapper.Tool == "codex" {
+			if err := json.Unmarshal(toolOptions, &wrapper); err == nil && wrapper.Tool == "codex" {
 				var opts session.CodexOptions
 				if err := json.Unmarshal(wrapper.Options, &opts); err == nil {
 					if opts.YoloMode != nil {
@@ -839,9 +1284,9 @@ func (d *NewDialog) previewRecentSession(rs *statedb.RecentSessionRow) {
 					}
 				}
 			}
-		case rs.Tool == "opencode":
+		case tool == "opencode":
 			var wrapper session.ToolOptionsWrapper
-			if err := json.Unmarshal(rs.ToolOptions, &wrapper); err == nil && wrapper.Tool == "opencode" {
+			if err := json.Unmarshal(toolOptions, &wrapper); err == nil && wrapper.Tool == "opencode" {
 				var opts session.OpenCodeOptions
 				if err := json.Unmarshal(wrapper.Options, &opts); err == nil && opts.Model != "" {
 					d.modelInput.SetValue(opts.Model)
@@ -849,39 +1294,88 @@ func (d *NewDialog) previewRecentSession(rs *statedb.RecentSessionRow) {
 			}
 		}
 	}
+}
 
-	d.sandboxEnabled = rs.SandboxEnabled
-	d.filterModelSuggestions()
+// acceptNavigatedSuggestion puts the suggestion highlighted with Ctrl+N/P
+// into the path input, keeping focus there, and reports whether there was
+// one. Cursor 0 is the synthetic "Type custom" entry, which accepts nothing.
+func (d *NewDialog) acceptNavigatedSuggestion() bool {
+	idx := d.pathSuggestionCursor - 1
+	if !d.suggestionNavigated || idx < 0 || idx >= len(d.pathSuggestions) {
+		return false
+	}
+	d.pathInput.SetValue(d.pathSuggestions[idx])
+	d.pathInput.SetCursor(len(d.pathInput.Value()))
+	d.pathSoftSelected = false
+	d.suggestionNavigated = false
+	d.pathSuggestionCursor = 0
+	d.pathCycler.Reset()
+	d.filterPathSuggestions()
+	return true
+}
 
-	// Reset worktree (ephemeral, never pre-filled)
-	d.worktreeEnabled = false
-	d.worktreeToggled = false
-	d.branchInput.SetValue("")
-	d.branchAutoSet = false
+// wantsDirCompletions reports whether typed looks like a path to complete.
+// A remote target's filesystem is not this one, so it never is.
+func (d *NewDialog) wantsDirCompletions(typed string) bool {
+	return !d.remote && (strings.HasPrefix(typed, "/") || strings.HasPrefix(typed, "~") ||
+		strings.HasPrefix(typed, ".") || strings.Contains(typed, "/"))
+}
 
-	// Reset multi-repo (ephemeral, never pre-filled)
-	d.multiRepoEnabled = false
-	d.multiRepoPaths = nil
-	d.multiRepoPathCursor = 0
-	d.multiRepoEditing = false
+// appendDirSuggestions adds the directories completing typed, after the
+// recent paths, once dirCompletionsCmd has read them for this input.
+func (d *NewDialog) appendDirSuggestions(typed string) {
+	if !d.wantsDirCompletions(typed) || typed != d.dirCompletionsFor {
+		return
+	}
+	added := 0
+	for _, m := range d.dirCompletions {
+		if added == maxDirSuggestions {
+			break
+		}
+		if m == typed || slices.Contains(d.pathSuggestions, m) {
+			continue
+		}
+		d.pathSuggestions = append(d.pathSuggestions, m)
+		d.pathSuggestionSources = append(d.pathSuggestionSources, pathSourceDir)
+		added++
+	}
+}
 
-	d.rebuildFocusTargets()
+// dirCompletionsCmd reads the directories completing the path input in the
+// background, or returns nil when they are already known or not wanted.
+func (d *NewDialog) dirCompletionsCmd() tea.Cmd {
+	typed := strings.TrimSpace(d.pathInput.Value())
+	if !d.visible || typed == d.dirCompletionsFor || !d.wantsDirCompletions(typed) {
+		return nil
+	}
+	d.dirCompletionsFor = typed
+	d.dirCompletions = nil
+	return func() tea.Msg {
+		matches, _ := session.GetDirectoryCompletions(typed)
+		return dirCompletionsMsg{typed: typed, matches: matches}
+	}
+}
+
+// setDirCompletions stores a dirCompletionsCmd result and refreshes the
+// dropdown, unless the input has moved on since it was requested.
+func (d *NewDialog) setDirCompletions(msg dirCompletionsMsg) {
+	if msg.typed != d.dirCompletionsFor {
+		return
+	}
+	d.dirCompletions = msg.matches
+	if strings.TrimSpace(d.pathInput.Value()) == msg.typed {
+		d.filterPathSuggestions()
+	}
 }
 
 // filterPathSuggestions filters allPathSuggestions by the current path input value
 func (d *NewDialog) filterPathSuggestions() {
-	query := strings.ToLower(strings.TrimSpace(d.pathInput.Value()))
-	if query == "" {
-		d.pathSuggestions = d.allPathSuggestions
-	} else {
-		filtered := make([]string, 0)
-		for _, p := range d.allPathSuggestions {
-			if strings.Contains(strings.ToLower(p), query) {
-				filtered = append(filtered, p)
-			}
-		}
-		d.pathSuggestions = filtered
-	}
+	typed := strings.TrimSpace(d.pathInput.Value())
+	query := strings.ToLower(typed)
+	d.pathSuggestions = make([]string, 0, len(d.bookmarks)+len(d.allPathSuggestions))
+	d.pathSuggestionSources = d.pathSuggestionSources[:0]
+	d.addPathSuggestions(query)
+	d.appendDirSuggestions(typed)
 	// Cursor space: 0 = "Type custom", 1..N = pathSuggestions[0..N-1]
 	if d.pathSuggestionCursor > len(d.pathSuggestions) {
 		d.pathSuggestionCursor = 0
@@ -891,13 +1385,7 @@ func (d *NewDialog) filterPathSuggestions() {
 func knownModelIDsForTool(tool string) []string {
 	switch {
 	case session.IsClaudeCompatible(tool):
-		return []string{
-			"claude-sonnet-4-6",
-			"claude-opus-4-8",
-			"claude-opus-4-7",
-			"claude-haiku-4-5",
-			"claude-haiku-4-5-20251001",
-		}
+		return session.ClaudeModelFallback
 	case tool == "gemini":
 		return []string{
 			"gemini-3.1-pro-preview",
@@ -983,8 +1471,25 @@ func preselectDefaultModel(config *session.UserConfig, tool string) string {
 	return ""
 }
 
+// SetClaudeModels replaces the Claude model suggestions with the list from
+// session.GetAvailableClaudeModels; live reports that it came from the API
+// rather than a fallback, which enables model validation.
+func (d *NewDialog) SetClaudeModels(models []string, live bool) {
+	d.claudeModels = models
+	d.claudeModelsLive = live && len(models) > 0
+}
+
+// modelIDsForSelectedTool returns the model suggestions for the selected tool.
+func (d *NewDialog) modelIDsForSelectedTool() []string {
+	tool := d.GetSelectedCommand()
+	if session.IsClaudeCompatible(tool) && len(d.claudeModels) > 0 {
+		return d.claudeModels
+	}
+	return knownModelIDsForTool(tool)
+}
+
 func (d *NewDialog) filterModelSuggestions() {
-	all := knownModelIDsForTool(d.GetSelectedCommand())
+	all := d.modelIDsForSelectedTool()
 	query := strings.ToLower(strings.TrimSpace(d.modelInput.Value()))
 	if query == "" {
 		d.modelSuggestions = all
@@ -1010,6 +1515,7 @@ func (d *NewDialog) Show() {
 // Hide hides the dialog
 func (d *NewDialog) Hide() {
 	d.visible = false
+	d.help.Hide()
 	if d.branchPicker != nil {
 		d.branchPicker.Hide()
 	}
@@ -1022,10 +1528,39 @@ func (d *NewDialog) IsVisible() bool {
 
 func (d *NewDialog) sanitizePath(raw string) string {
 	path := strings.Trim(strings.TrimSpace(raw), "'\"")
-	// Fix malformed paths that have ~ in the middle (e.g., "/some/path~/actual/path")
-	// This can happen when textinput suggestion appends instead of replaces.
-	if idx := strings.Index(path, "~/"); idx > 0 {
-		path = path[idx:]
+	return stripAppendedSuggestion(path, d.allPathSuggestions)
+}
+
+// resolvePath sanitizes a typed local path and resolves it to the absolute
+// path the session will use: ~ and $VARS first, then relative paths against
+// pathBase. Remote paths are only sanitized.
+func (d *NewDialog) resolvePath(raw string) string {
+	if d.remote {
+		return d.sanitizePath(raw)
+	}
+	return session.ResolveProjectPath(d.sanitizePath(raw), d.pathBase)
+}
+
+// stripAppendedSuggestion repairs a path where a "~/..." suggestion was
+// appended to the typed text instead of replacing it, e.g.
+// "/some/dir~/projects/app" after accepting "~/projects/app". Only that exact
+// form is repaired: the tail must be one of the known suggestions and the "~"
+// must be glued to the typed text. Anything else — "/home/bob/~/weird-dir",
+// or a "notes~/x" directory that is not a suggestion — is a real path and is
+// returned unchanged. Expanded suggestions are also checked in their "~/"
+// form, since that is what the user sees.
+func stripAppendedSuggestion(path string, suggestions []string) string {
+	for _, suggestion := range suggestions {
+		if !strings.HasPrefix(suggestion, "~/") {
+			suggestion = collapseHome(suggestion)
+		}
+		if !strings.HasPrefix(suggestion, "~/") || len(path) <= len(suggestion) ||
+			!strings.HasSuffix(path, suggestion) {
+			continue
+		}
+		if prefix := path[:len(path)-len(suggestion)]; !strings.HasSuffix(prefix, "/") {
+			return suggestion
+		}
 	}
 	return path
 }
@@ -1036,24 +1571,154 @@ func (d *NewDialog) resolveCommand() string {
 			return command
 		}
 	}
-	return strings.TrimSpace(d.commandInput.Value())
+	return normalizeShellCommand(d.commandInput.Value())
+}
+
+// normalizeShellCommand trims the custom command textarea for launch:
+// trailing whitespace and blank lines at either end are dropped, inner
+// newlines are kept so multi-line scripts and heredocs reach the shell
+// intact. A blank textarea yields "", the same as an empty command.
+func normalizeShellCommand(value string) string {
+	lines := strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
 }
 
-// GetValues returns the current dialog values with expanded paths
+// commandFlagCompletion returns the partial flag at the end of a custom
+// command and the known flags of its tool that it could complete to. The tool
+// is the first word of the last line, so a multi-line setup script completes
+// against its final command. partial is "" and matches nil when the last word
+// is not an unfinished flag or the tool has no flag table.
+func commandFlagCompletion(value string) (partial string, matches []string) {
+	line := value[strings.LastIndex(value, "\n")+1:]
+	fields := strings.Fields(line)
+	if len(fields) < 2 || strings.TrimRight(line, " \t") != line {
+		return "", nil
+	}
+	last := fields[len(fields)-1]
+	if !strings.HasPrefix(last, "-") || strings.Contains(last, "=") {
+		return "", nil
+	}
+	for _, flag := range session.ToolFlags(line) {
+		if strings.HasPrefix(flag, last) {
+			matches = append(matches, flag)
+		}
+	}
+	if len(matches) == 0 {
+		return "", nil
+	}
+	return last, matches
+}
+
+// completeCommandFlag completes the flag being typed at the end of the
+// custom command (Tab): a unique match is finished with a trailing space,
+// several are extended to their common prefix. It reports whether the input
+// changed; when there is nothing to add Tab keeps moving focus.
+func (d *NewDialog) completeCommandFlag() bool {
+	value := d.commandInput.Value()
+	lastLine := value[strings.LastIndex(value, "\n")+1:]
+	info := d.commandInput.LineInfo()
+	if d.commandInput.Line() != d.commandInput.LineCount()-1 ||
+		info.StartColumn+info.CharOffset != len([]rune(lastLine)) {
+		return false
+	}
+	partial, matches := commandFlagCompletion(value)
+	if len(matches) == 0 {
+		return false
+	}
+	prefix := matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	insert := prefix[len(partial):]
+	if len(matches) == 1 {
+		insert += " "
+	}
+	if insert == "" {
+		return false
+	}
+	d.commandInput.InsertString(insert)
+	return true
+}
+
+// toolShell is the tool of a session that runs an interactive shell (or a
+// custom command) rather than an agent.
+const toolShell = "shell"
+
+// IsShellSession reports whether the dialog launches a plain interactive
+// shell: the shell entry is selected and the command textarea is blank.
+// GetValues then returns an empty command (or only the shell_init wrapper),
+// which callers must not mistake for "no tool chosen".
+func (d *NewDialog) IsShellSession() bool {
+	return d.GetSelectedCommand() == "" && d.resolveCommand() == ""
+}
+
+// submittedTool is the tool to remember for a submitted command: toolShell
+// for a plain shell, whose command alone is empty or a shell_init wrapper.
+func (d *NewDialog) submittedTool(command string) string {
+	if d.IsShellSession() {
+		return toolShell
+	}
+	return command
+}
+
+// suggestNameFromPath sets the name to the path's base name, e.g. "api" for
+// ~/code/api, while the user has not chosen a name (see nameEdited). Multi-repo
+// paths are left alone: no single directory names the session.
+func (d *NewDialog) suggestNameFromPath() {
+	if d.nameEdited || d.multiRepoEnabled {
+		return
+	}
+	name := ""
+	if raw := strings.TrimSpace(d.pathInput.Value()); raw != "" {
+		var resolved string
+		if d.remote {
+			resolved = d.sanitizePath(raw)
+		} else {
+			resolved = d.resolvePath(raw)
+		}
+		if base := filepath.Base(resolved); base != "." && base != string(filepath.Separator) {
+			name = base
+		}
+	}
+	oldName := d.nameInput.Value()
+	d.nameInput.SetValue(name)
+	d.nameInput.CursorEnd()
+	if d.worktreeEnabled && d.branchAutoSet && name != oldName {
+		d.autoBranchFromName()
+	}
+}
+
+// GetValues returns the current dialog values with the path resolved to an
+// absolute path (see resolvePath). For a plain shell command is "" or the
+// shell_init wrapper; see IsShellSession.
 func (d *NewDialog) GetValues() (name, path, command string) {
 	name = strings.TrimSpace(d.nameInput.Value())
-	// Fix: sanitize input to remove surrounding quotes that cause path issues
-	path = d.sanitizePath(d.pathInput.Value())
-
-	// Expand environment variables and ~ prefix
-	path = session.ExpandPath(path)
+	path = d.resolvePath(d.pathInput.Value())
 
 	// Get command - either from preset or custom input
 	command = d.resolveCommand()
+	if command == "" {
+		// Plain shell: run the configured shell_init, then stay interactive.
+		command = session.ShellInitCommand(d.effectiveShellInit())
+	}
 
 	return name, path, command
 }
 
+// effectiveShellInit returns the shell_init for a plain shell session: the
+// project's .agentdeck.json value, else [shell].shell_init.
+func (d *NewDialog) effectiveShellInit() string {
+	if d.projectShellInit != "" {
+		return d.projectShellInit
+	}
+	return d.shellInit
+}
+
 // GetRemoteValues returns dialog values for a remote host. Unlike GetValues,
 // it does not expand ~ or environment variables locally because those paths
 // belong to the remote machine.
@@ -1075,6 +1740,77 @@ func (d *NewDialog) ToggleWorktree() {
 	d.rebuildFocusTargets()
 }
 
+// worktreeNonRepoPath returns the current path when it is an existing local
+// directory that is not a git or jujutsu repository, else "". An empty,
+// missing or remote path cannot be checked yet and is left to the submit path.
+func (d *NewDialog) worktreeNonRepoPath() string {
+	if d.remote || d.multiRepoEnabled {
+		return ""
+	}
+	path := d.resolvePath(d.pathInput.Value())
+	if path == "" {
+		return ""
+	}
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return ""
+	}
+	if _, err := vcsbackend.Detect(path); err != nil {
+		return path
+	}
+	return ""
+}
+
+// worktreeUnavailable returns why worktree mode cannot be used with the
+// current path, or "" when it can.
+func (d *NewDialog) worktreeUnavailable() string {
+	if path := d.worktreeNonRepoPath(); path != "" {
+		return "Worktree needs a git or jujutsu repository: " + path
+	}
+	return ""
+}
+
+// toggleWorktreeChecked is ToggleWorktree for the keyboard: enabling is
+// refused with an inline error when the path is not a repository, so the
+// mistake shows up now rather than as a git failure on submit. The path is
+// kept for Home to offer `git init` on.
+func (d *NewDialog) toggleWorktreeChecked() {
+	if !d.worktreeEnabled {
+		if path := d.worktreeNonRepoPath(); path != "" {
+			d.SetError(d.worktreeUnavailable())
+			d.gitInitOffer = path
+			return
+		}
+	}
+	d.ClearError()
+	d.ToggleWorktree()
+}
+
+// TakeGitInitOffer returns, once, the path a refused worktree toggle was on.
+func (d *NewDialog) TakeGitInitOffer() string {
+	path := d.gitInitOffer
+	d.gitInitOffer = ""
+	return path
+}
+
+// Reopen shows the dialog again with its values intact, after Home hid it
+// for a confirmation.
+func (d *NewDialog) Reopen() {
+	d.visible = true
+}
+
+// EnableWorktreeAfterInit turns worktree mode on once the path has been made
+// a repository, moving focus to the branch field as the toggle keys do.
+func (d *NewDialog) EnableWorktreeAfterInit() {
+	d.ClearError()
+	if !d.worktreeEnabled {
+		d.ToggleWorktree()
+	}
+	if idx := d.indexOf(focusBranch); idx >= 0 {
+		d.focusIndex = idx
+	}
+	d.updateFocus()
+}
+
 // IsWorktreeExplicit reports whether the worktree state reflects an explicit
 // user choice (the checkbox was toggled) rather than the config default
 // (`[worktree] default_enabled`). Used by #1185 to decide whether a worktree on
@@ -1166,17 +1902,11 @@ func (d *NewDialog) GetMultiRepoPaths() ([]string, bool) {
 	if !d.multiRepoEnabled {
 		return nil, false
 	}
-	// Return non-empty, expanded paths
+	// Return non-empty, resolved paths
 	var paths []string
 	for _, p := range d.multiRepoPaths {
-		p = strings.TrimSpace(p)
-		if p != "" {
-			p = strings.Trim(p, "'\"")
-			if idx := strings.Index(p, "~/"); idx > 0 {
-				p = p[idx:]
-			}
-			p = session.ExpandPath(p)
-			paths = append(paths, p)
+		if strings.TrimSpace(p) != "" {
+			paths = append(paths, d.resolvePath(p))
 		}
 	}
 	return paths, true
@@ -1215,6 +1945,21 @@ func (d *NewDialog) updateModelPlaceholder() {
 	}
 }
 
+// resolvedPathHint returns "→ <absolute path>" when the typed path is not
+// already the absolute path the session will get (relative, ~ or $VARS), so
+// the user sees what "../sibling" actually means. Empty otherwise.
+func (d *NewDialog) resolvedPathHint() string {
+	typed := d.sanitizePath(d.pathInput.Value())
+	if typed == "" {
+		return ""
+	}
+	resolved := d.resolvePath(typed)
+	if resolved == typed {
+		return ""
+	}
+	return "→ " + resolved
+}
+
 func (d *NewDialog) modelInputHint() string {
 	switch cmd := d.GetSelectedCommand(); {
 	case session.IsClaudeCompatible(cmd):
@@ -1268,6 +2013,43 @@ func (d *NewDialog) GetClaudeStartQuery() string {
 	return d.claudeOptions.GetStartQuery()
 }
 
+// PreviewCommand returns the command line the session would launch with the
+// dialog's current values, assembled by the same builders Start uses. Empty
+// when there is nothing to run yet (a plain shell) or the values are invalid.
+func (d *NewDialog) PreviewCommand() string {
+	name, path, command := d.GetValues()
+	tool, command := createSessionTool(command)
+	inst := session.NewLaunchPreviewInstance(name, path, tool, command)
+	applyCreateSessionToolOverrides(inst, tool, d.IsGeminiYoloMode())
+
+	// Tool options mirror the submit path in handleNewDialogKey.
+	switch tool {
+	case "claude":
+		if opts := d.GetClaudeOptions(); opts != nil {
+			inst.ToolOptionsJSON, _ = session.MarshalToolOptions(opts)
+			inst.ExtraArgs = d.GetClaudeExtraArgs()
+			inst.StartupQuery = d.GetClaudeStartQuery()
+		}
+	case "codex":
+		yolo := d.GetCodexYoloMode()
+		inst.ToolOptionsJSON, _ = session.MarshalToolOptions(&session.CodexOptions{YoloMode: &yolo})
+	case "hermes":
+		yolo := d.GetHermesYoloMode()
+		inst.ToolOptionsJSON, _ = session.MarshalToolOptions(&session.HermesOptions{YoloMode: &yolo})
+	}
+	if err := inst.ApplyLaunchModel(d.GetLaunchModelID()); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(inst.LaunchCommandPreview())
+}
+
+// refreshCommandPreview recomputes the command preview View shows. The
+// builders behind PreviewCommand do real work (config, tool lookups), so this
+// runs when the values may have changed rather than on every render.
+func (d *NewDialog) refreshCommandPreview() {
+	d.commandPreview = d.PreviewCommand()
+}
+
 // isClaudeSelected returns true if the selected command is Claude or a claude-compatible custom tool
 func (d *NewDialog) isClaudeSelected() bool {
 	if d.commandCursor < 0 || d.commandCursor >= len(d.presetCommands) {
@@ -1276,60 +2058,131 @@ func (d *NewDialog) isClaudeSelected() bool {
 	return session.IsClaudeCompatible(d.presetCommands[d.commandCursor])
 }
 
-// Validate checks if the dialog values are valid and returns an error message if not
+// Spec returns the dialog's current values as a SessionSpec, with paths
+// resolved as GetValues does.
+func (d *NewDialog) Spec() session.SessionSpec {
+	spec := session.SessionSpec{
+		Title:    strings.TrimSpace(d.nameInput.Value()),
+		Path:     d.resolvePath(d.pathInput.Value()),
+		Group:    d.parentGroupPath,
+		Tool:     d.GetSelectedCommand(),
+		ModelID:  d.GetLaunchModelID(),
+		Worktree: d.worktreeEnabled,
+		Branch:   strings.TrimSpace(d.branchInput.Value()),
+	}
+	if d.commandCursor == 0 {
+		spec.Command = d.resolveCommand()
+	}
+	if paths, ok := d.GetMultiRepoPaths(); ok {
+		spec.MultiRepo = true
+		spec.Path = ""
+		if len(paths) > 0 {
+			spec.Path, spec.AdditionalPaths = paths[0], paths[1:]
+		}
+	}
+	return spec
+}
+
+// Validate checks if the dialog values are valid and returns an error message if not.
+// The value checks are session.ValidateSpec's; its feasibility checks are left
+// to the submit path, which can offer to create a missing directory.
 func (d *NewDialog) Validate() string {
-	name := strings.TrimSpace(d.nameInput.Value())
-	// Fix: sanitize input to remove surrounding quotes that cause path issues
-	path := strings.Trim(strings.TrimSpace(d.pathInput.Value()), "'\"")
+	for _, err := range session.ValidateSpec(d.Spec()) {
+		var specErr *session.SpecError
+		if errors.As(err, &specErr) && specErr.Feasibility {
+			continue
+		}
+		return err.Error()
+	}
 
-	// Check for empty name
-	if name == "" {
-		return "Session name cannot be empty"
+	if msg := d.checkConsistency(); msg != "" {
+		return msg
 	}
 
-	// Check name length
-	if len(name) > MaxNameLength {
-		return fmt.Sprintf("Session name too long (max %d characters)", MaxNameLength)
+	if msg := d.checkCustomCommand(); msg != "" {
+		return msg
 	}
 
-	// Check for empty path
-	if path == "" && !d.multiRepoEnabled {
-		return "Project path cannot be empty"
+	// A typo'd Claude model only fails once claude starts; catch it here when
+	// the live model list is known. Claude-compatible wrappers may route to
+	// other providers with their own model names, so only plain claude is
+	// checked.
+	if model := d.GetLaunchModelID(); model != "" && d.claudeModelsLive &&
+		d.GetSelectedCommand() == "claude" && !session.IsValidClaudeModel(model, d.claudeModels) {
+		return fmt.Sprintf("Unknown Claude model %q", model)
 	}
 
-	// Validate multi-repo paths
-	if d.multiRepoEnabled {
-		nonEmpty := 0
-		seen := make(map[string]bool)
-		for _, p := range d.multiRepoPaths {
-			p = strings.TrimSpace(p)
-			if p == "" {
-				continue
-			}
-			expanded := session.ExpandPath(strings.Trim(p, "'\""))
-			if seen[expanded] {
-				return "Duplicate paths in multi-repo mode"
-			}
-			seen[expanded] = true
-			nonEmpty++
+	// Validate Claude-specific inputs (e.g. MCP config file)
+	if d.isClaudeSelected() {
+		if msg := d.claudeOptions.Validate(); msg != "" {
+			return msg
 		}
-		if nonEmpty < 2 {
-			return "Multi-repo mode requires at least 2 paths"
+	}
+
+	return "" // Valid
+}
+
+// checkConsistency rejects option combinations the form should never produce
+// but would otherwise be dropped or fail late on submit. Kept in one place so
+// the key handlers do not each have to guard against them.
+func (d *NewDialog) checkConsistency() string {
+	// The YOLO checkbox on screen must belong to the selected tool; the other
+	// tools' panels keep their config defaults and are never applied.
+	if p, ok := d.toolOptions.(*YoloOptionsPanel); ok && p.GetYoloMode() &&
+		d.toolOptions != d.optionsPanelFor(d.GetSelectedCommand()) {
+		tool := displayCommandPreset(d.GetSelectedCommand())
+		if tool == "" {
+			tool = "shell"
 		}
+		return fmt.Sprintf("%s YOLO mode does not apply to %s", p.toolName, tool)
 	}
 
-	// Validate worktree branch if enabled
-	if d.worktreeEnabled {
-		branch := strings.TrimSpace(d.branchInput.Value())
-		if branch == "" {
-			return "Branch name required for worktree"
+	// Remote sessions are created by the remote's own agent-deck, which gets
+	// only name, path and tool.
+	if d.remote {
+		switch {
+		case d.worktreeEnabled:
+			return "Worktrees are not available for remote sessions"
+		case d.sandboxEnabled:
+			return "Docker sandbox is not available for remote sessions"
+		case d.multiRepoEnabled:
+			return "Multi-repo is not available for remote sessions"
 		}
-		if err := git.ValidateBranchName(branch); err != nil {
-			return err.Error()
+	}
+
+	// An explicitly requested worktree on a non-repo path fails on submit
+	// anyway (#1185); say so before anything is created. A worktree enabled
+	// only by config default falls back to a plain session instead.
+	if d.worktreeEnabled && d.worktreeToggled {
+		if msg := d.worktreeUnavailable(); msg != "" {
+			return msg
 		}
 	}
+	return ""
+}
 
-	return "" // Valid
+// checkCustomCommand flags a custom shell command matching
+// session.CheckShellCommand, typically a bad clipboard paste. Under "warn" the
+// first submit shows the findings and a second submit of the same command
+// goes through; under "block" it never does.
+func (d *NewDialog) checkCustomCommand() string {
+	if d.commandCursor != 0 || d.commandCheck == session.CommandCheckOff {
+		return ""
+	}
+	command := d.resolveCommand()
+	reasons := session.CheckShellCommand(command)
+	if len(reasons) == 0 {
+		return ""
+	}
+	msg := "Command " + strings.Join(reasons, ", ")
+	if d.commandCheck == session.CommandCheckBlock {
+		return msg + " (blocked by [shell].command_check)"
+	}
+	if d.commandCheckAcked == command {
+		return ""
+	}
+	d.commandCheckAcked = command
+	return msg + " — submit again to run it"
 }
 
 // SetError sets an inline validation error displayed inside the dialog
@@ -1410,22 +2263,28 @@ func (d *NewDialog) updateToolOptions() {
 	d.modelSuggestionHidden = false
 	d.modelNavigated = false
 	d.filterModelSuggestions()
+	d.toolOptions = d.optionsPanelFor(cmd)
+	d.rebuildFocusTargets()
+}
+
+// optionsPanelFor returns the options panel belonging to tool, or nil when
+// the tool has none.
+func (d *NewDialog) optionsPanelFor(tool string) OptionsPanel {
 	switch {
-	case session.IsClaudeCompatible(cmd):
-		d.toolOptions = d.claudeOptions
-	case cmd == "gemini":
-		d.toolOptions = d.geminiOptions
-	case cmd == "codex":
-		d.toolOptions = d.codexOptions
-	case cmd == "hermes":
-		d.toolOptions = d.hermesOptions
-	default:
-		d.toolOptions = nil
+	case session.IsClaudeCompatible(tool):
+		return d.claudeOptions
+	case tool == "gemini":
+		return d.geminiOptions
+	case tool == "codex":
+		return d.codexOptions
+	case tool == "hermes":
+		return d.hermesOptions
 	}
-	d.rebuildFocusTargets()
+	return nil
 }
 
 func (d *NewDialog) updateFocus() {
+	d.refreshProjectConfig()
 	d.nameInput.Blur()
 	d.pathInput.Blur()
 	d.commandInput.Blur()
@@ -1484,6 +2343,33 @@ func (d *NewDialog) moveFocus(delta int) {
 	d.updateFocus()
 }
 
+// newDialogJumpKeys maps direct-jump hotkeys to their fields. Alt-modified
+// so they never collide with text typed into the inputs.
+var newDialogJumpKeys = map[string]focusTarget{
+	"alt+n": focusName,
+	"alt+p": focusPath,
+	"alt+c": focusCommand,
+	"alt+m": focusModel,
+	"alt+b": focusBranch,
+	"alt+o": focusOptions,
+}
+
+// jumpToField focuses target if it is currently shown. Open dropdowns are
+// dismissed and an in-progress multi-repo path edit is committed first, as
+// when tabbing away.
+func (d *NewDialog) jumpToField(target focusTarget) {
+	idx := d.indexOf(target)
+	if idx < 0 {
+		return
+	}
+	d.CommitInFlightMultiRepoEdit()
+	d.DismissSuggestions()
+	d.DismissModelSuggestions()
+	d.suggestionNavigated = false
+	d.focusIndex = idx
+	d.updateFocus()
+}
+
 func isNewDialogTabKey(msg tea.KeyMsg) bool {
 	return msg.Type == tea.KeyTab || msg.String() == "tab"
 }
@@ -1513,17 +2399,122 @@ func (d *NewDialog) isTextInputFocused() bool {
 	}
 }
 
-func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
+// IsHelpVisible reports whether the dialog's key help is open; while it is,
+// every key belongs to the help overlay.
+func (d *NewDialog) IsHelpVisible() bool {
+	return d.help.IsVisible()
+}
+
+// opensHelp reports whether msg opens the key help. ? is typed as text in
+// the text fields, where F1 still works.
+func (d *NewDialog) opensHelp(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "f1":
+		return true
+	case "?":
+		claudeText := d.currentTarget() == focusOptions && d.isClaudeSelected() && d.claudeOptions.isTextInputFocused()
+		return !d.isTextInputFocused() && !claudeText
+	}
+	return false
+}
+
+// helpSections lists every key the dialog handles, for the help overlay.
+// Keep it in sync with Update.
+func (d *NewDialog) helpSections() []helpSection {
+	create := [][2]string{
+		{"Enter", "Create session (on the name and branch fields)"},
+		{"Ctrl+S", "Create session from any field"},
+		{"Esc", "Close the open dropdown, else cancel"},
+	}
+	if d.enterAdvances {
+		create[0] = [2]string{"Enter", "Next field (on the name and branch fields)"}
+	}
+	recentKey := ""
+	if len(d.recentSessions) > 0 {
+		recentKey = "Ctrl+R"
+	}
+	return []helpSection{
+		{
+			title: "FIELDS",
+			items: [][2]string{
+				{"Tab", "Next field"},
+				{"Shift+Tab", "Previous field"},
+				{"↑ / ↓", "Previous / next field"},
+				{recentKey, "Pick from recent sessions"},
+				{"F1 / ?", "This help (? outside text fields)"},
+			},
+		},
+		{
+			title: "PATH",
+			items: [][2]string{
+				{"Ctrl+N", "Next suggestion"},
+				{"Ctrl+P", "Previous suggestion"},
+				{"Enter", "Accept the highlighted suggestion, else browse the list"},
+				{"Tab", "Complete directory name, else next field"},
+				{"Space / →", "Browse the list from a pre-filled path"},
+				{"Ctrl+W", "Delete the last path segment"},
+			},
+		},
+		{
+			title: "COMMAND",
+			items: [][2]string{
+				{"← / →", "Choose the tool"},
+				{"w", "Toggle git worktree"},
+				{"s", "Toggle Docker sandbox"},
+				{"m", "Toggle multi-repo"},
+				{"y", "Toggle YOLO mode (Gemini, Codex, Hermes)"},
+				{"Space", "Toggle the focused checkbox"},
+			},
+		},
+		{
+			title: "BRANCH & MULTI-REPO",
+			items: [][2]string{
+				{"Ctrl+F", "Search existing branches"},
+				{"a", "Add a repository path"},
+				{"d", "Remove the selected path"},
+				{"Enter", "Edit / save the selected path"},
+			},
+		},
+		{
+			title: "CREATE",
+			items: create,
+		},
+	}
+}
+
+func (d *NewDialog) Update(msg tea.Msg) (_ *NewDialog, cmd tea.Cmd) {
 	if !d.visible {
 		return d, nil
 	}
 
-	var cmd tea.Cmd
+	// However the path changed, its directory completions are read in the
+	// background and added to the dropdown when they arrive.
+	// The command preview follows the values the same way.
+	defer func() {
+		d.refreshCommandPreview()
+		cmd = tea.Batch(cmd, d.dirCompletionsCmd())
+	}()
+
 	maxIdx := len(d.focusTargets) - 1
 	cur := d.currentTarget()
 
+	// However the path changed (typing, a suggestion, Tab completion), an
+	// untouched name follows it.
+	if pathBefore := d.pathInput.Value(); !d.nameEdited {
+		defer func() {
+			if d.pathInput.Value() != pathBefore {
+				d.suggestNameFromPath()
+			}
+		}()
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if d.help.IsVisible() {
+			d.help, cmd = d.help.Update(msg)
+			return d, cmd
+		}
+
 		if d.branchPicker != nil && d.branchPicker.IsVisible() {
 			if selected, handled := d.branchPicker.Update(msg); handled {
 				if d.branchPicker == nil || !d.branchPicker.IsVisible() {
@@ -1571,6 +2562,11 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 			return d, nil // Consume all other keys while picker is open
 		}
 
+		if d.opensHelp(msg) {
+			d.help.ShowSections("NEW SESSION KEYS", d.helpSections())
+			return d, nil
+		}
+
 		// Toggle recent sessions picker
 		if msg.String() == "ctrl+r" && len(d.recentSessions) > 0 {
 			d.recentSnapshot = d.saveSnapshot()
@@ -1580,6 +2576,12 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 			return d, nil
 		}
 
+		// Alt+letter jumps straight to a field from anywhere in the form.
+		if target, ok := newDialogJumpKeys[msg.String()]; ok {
+			d.jumpToField(target)
+			return d, nil
+		}
+
 		// Issue #896 sub-bug 4: when the path-suggestions popup is visible
 		// and the user is actively editing the path (pathInput focused,
 		// not soft-selected), arrow keys auto-activate the popup so the
@@ -1735,6 +2737,10 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 		}
 
 		if isNewDialogTabKey(msg) {
+			// On the custom command: complete a known tool flag first.
+			if cur == focusCommand && d.commandCursor == 0 && d.completeCommandFlag() {
+				return d, nil
+			}
 			// On path field (or multi-repo path editing): trigger autocomplete or cycle through matches.
 			isPathEditing := cur == focusPath || d.multiRepoEditing
 			if isPathEditing {
@@ -1759,15 +2765,8 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 				}
 			}
 
-			// On path field: apply selected suggestion ONLY if user explicitly navigated.
-			// Cursor 0 = "Type custom" (no-op); cursor 1..N maps to pathSuggestions[0..N-1].
-			if isPathEditing && d.suggestionNavigated && d.pathSuggestionCursor > 0 {
-				suggestionIdx := d.pathSuggestionCursor - 1
-				if suggestionIdx < len(d.pathSuggestions) {
-					d.pathInput.SetValue(d.pathSuggestions[suggestionIdx])
-					d.pathInput.SetCursor(len(d.pathInput.Value()))
-				}
-			}
+			// Tab never applies a Ctrl+N/P-highlighted suggestion; Enter does
+			// (acceptNavigatedSuggestion), so leaving the field keeps the typed path.
 			// When editing a multi-repo path, Tab is only for autocomplete — don't move focus.
 			if d.multiRepoEditing {
 				return d, nil
@@ -1782,11 +2781,11 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 			// that doesn't point to an existing directory. Tab should stick to
 			// the input until the user has a usable path; otherwise it silently
 			// jumps to the agent selector and the typed path is left dangling.
+			// A path allow_missing_paths covers (e.g. a lazy mount) may
+			// not exist yet; it gets a warning under the field instead.
 			if isPathEditing {
-				v := strings.Trim(strings.TrimSpace(d.pathInput.Value()), "'\"")
-				if v != "" {
-					expanded := session.ExpandPath(v)
-					if info, err := os.Stat(expanded); err != nil || !info.IsDir() {
+				if resolved := d.resolvePath(d.pathInput.Value()); resolved != "" {
+					if info, err := os.Stat(resolved); (err != nil || !info.IsDir()) && !d.missingPathAllowed(resolved) {
 						return d, nil
 					}
 				}
@@ -1912,6 +2911,10 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 			}
 
 		case "down":
+			if cur == focusCommand && d.commandCursor == 0 && d.commandInput.Line() < d.commandInput.LineCount()-1 {
+				d.commandInput, cmd = d.commandInput.Update(msg)
+				return d, cmd
+			}
 			if cur == focusConductor {
 				total := len(d.conductorSessions) + 1 // +1 for "None"
 				if d.conductorCursor < total-1 {
@@ -1934,6 +2937,10 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 			return d, nil
 
 		case "up":
+			if cur == focusCommand && d.commandCursor == 0 && d.commandInput.Line() > 0 {
+				d.commandInput, cmd = d.commandInput.Update(msg)
+				return d, cmd
+			}
 			if cur == focusConductor {
 				if d.conductorCursor > 0 {
 					d.conductorCursor--
@@ -1984,6 +2991,9 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 				d.moveFocus(1)
 				return d, nil
 			}
+			if (cur == focusPath || d.multiRepoEditing) && d.acceptNavigatedSuggestion() {
+				return d, nil
+			}
 			if cur == focusPath {
 				d.suggestionsActive = true
 				d.suggestionsHidden = false
@@ -1991,6 +3001,13 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 				d.pathInput.Blur()
 				return d, nil
 			}
+			if cur == focusOptions && d.isClaudeSelected() && d.claudeOptions.isAppendPromptFocused() {
+				return d, d.claudeOptions.Update(msg)
+			}
+			if cur == focusCommand && d.commandCursor == 0 {
+				d.commandInput, cmd = d.commandInput.Update(msg)
+				return d, cmd
+			}
 			if cur == focusModel {
 				d.filterModelSuggestions()
 				d.modelSuggestionActive = true
@@ -2049,7 +3066,7 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 
 		case "w":
 			if cur == focusCommand && !d.isTextInputFocused() {
-				d.ToggleWorktree()
+				d.toggleWorktreeChecked()
 				d.rebuildFocusTargets()
 				if d.worktreeEnabled {
 					if idx := d.indexOf(focusBranch); idx >= 0 {
@@ -2134,7 +3151,7 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 
 		case " ":
 			if cur == focusWorktree {
-				d.ToggleWorktree()
+				d.toggleWorktreeChecked()
 				d.rebuildFocusTargets()
 				if d.worktreeEnabled {
 					if idx := d.indexOf(focusBranch); idx >= 0 {
@@ -2172,6 +3189,10 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 	case focusName:
 		oldName := d.nameInput.Value()
 		d.nameInput, cmd = d.nameInput.Update(msg)
+		if d.nameInput.Value() != oldName {
+			// Clearing the name hands it back to the path.
+			d.nameEdited = d.nameInput.Value() != ""
+		}
 		if d.worktreeEnabled && d.branchAutoSet && d.nameInput.Value() != oldName {
 			d.autoBranchFromName()
 		}
@@ -2307,9 +3328,23 @@ func (d *NewDialog) renderCommandSection(content *strings.Builder, cur focusTarg
 		} else {
 			content.WriteString(labelStyle.Render("    Custom:"))
 		}
-		content.WriteString("\n    ")
-		content.WriteString(d.commandInput.View())
-		content.WriteString("\n\n")
+		content.WriteString("\n")
+		for _, line := range strings.Split(d.commandInput.View(), "\n") {
+			content.WriteString("    " + line + "\n")
+		}
+		if cur == focusCommand {
+			if _, matches := commandFlagCompletion(d.commandInput.Value()); len(matches) > 0 {
+				dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+				hint := cellTruncate("Tab: "+strings.Join(matches, "  "), d.commandInput.Width(), "…")
+				content.WriteString("    " + dimStyle.Render(hint) + "\n")
+			}
+		}
+		if init := d.effectiveShellInit(); init != "" && d.resolveCommand() == "" {
+			dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+			hint := cellTruncate("Runs first: "+strings.ReplaceAll(init, "\n", "; "), d.commandInput.Width(), "…")
+			content.WriteString("    " + dimStyle.Render(hint) + "\n")
+		}
+		content.WriteString("\n")
 	}
 }
 
@@ -2337,7 +3372,7 @@ func (d *NewDialog) renderModelSection(content *strings.Builder, cur focusTarget
 	}
 	wrapped := lipgloss.NewStyle().Width(innerWidth).Render(content.String())
 	d.modelLineOffset = lipgloss.Height(wrapped)
-	if hint := d.modelInputHint(); hint != "" {
+	if hint := d.modelInputHint(); hint != "" && (!d.compact || cur == focusModel) {
 		dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
 		content.WriteString("\n  ")
 		content.WriteString(dimStyle.Render(hint))
@@ -2381,6 +3416,18 @@ func (d *NewDialog) renderSinglePathSection(content *strings.Builder, cur focusT
 	}
 	wrapped := lipgloss.NewStyle().Width(innerWidth).Render(content.String())
 	d.suggestionsLineOffset = lipgloss.Height(wrapped)
+	if hint := d.resolvedPathHint(); hint != "" {
+		dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+		content.WriteString("  ")
+		content.WriteString(dimStyle.Render(cellTruncate(hint, innerWidth-2, "…")))
+		content.WriteString("\n")
+	}
+	if d.projectConfigNote != "" {
+		dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+		content.WriteString("  ")
+		content.WriteString(dimStyle.Render(d.projectConfigNote))
+		content.WriteString("\n")
+	}
 	content.WriteString("\n")
 }
 
@@ -2454,6 +3501,9 @@ func (d *NewDialog) View() string {
 	if !d.visible {
 		return ""
 	}
+	if d.help.IsVisible() {
+		return d.help.View()
+	}
 
 	cur := d.currentTarget()
 
@@ -2462,6 +3512,11 @@ func (d *NewDialog) View() string {
 		Bold(true).
 		Foreground(ColorCyan).
 		MarginBottom(1)
+	padTop := 2
+	if d.compact {
+		titleStyle = titleStyle.MarginBottom(0)
+		padTop = 1
+	}
 
 	labelStyle := lipgloss.NewStyle().
 		Foreground(ColorText)
@@ -2472,7 +3527,7 @@ func (d *NewDialog) View() string {
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(ColorCyan).
 		Background(ColorSurface).
-		Padding(2, 4).
+		Padding(padTop, 4).
 		Width(dialogWidth)
 
 	// Active field indicator style
@@ -2488,6 +3543,9 @@ func (d *NewDialog) View() string {
 	content.WriteString("\n")
 	groupInfoStyle := lipgloss.NewStyle().Foreground(ColorPurple) // Purple for group context
 	content.WriteString(groupInfoStyle.Render("  in group: " + d.parentGroupName))
+	if d.draftRestored {
+		content.WriteString(lipgloss.NewStyle().Foreground(ColorComment).Render("  · draft restored"))
+	}
 	content.WriteString("\n")
 
 	// Recent sessions picker
@@ -2690,10 +3748,32 @@ func (d *NewDialog) View() string {
 	content.WriteString("\n")
 	d.renderMultiRepoSection(&content, cur)
 
-	// Tool options panel
+	// Tool options panel; collapsed in the compact layout until focused.
 	if d.toolOptions != nil {
 		content.WriteString("\n")
-		content.WriteString(d.toolOptions.View())
+		if d.compact && cur != focusOptions {
+			dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+			content.WriteString("  " + dimStyle.Render("▸ More options… (Alt+O)"))
+			content.WriteString("\n")
+		} else {
+			content.WriteString(d.toolOptions.View())
+		}
+	}
+
+	// Command preview, computed in Update (see refreshCommandPreview).
+	if preview := d.commandPreview; preview != "" && !d.compact {
+		previewStyle := lipgloss.NewStyle().
+			Foreground(ColorComment).
+			Faint(true).
+			Width(dialogWidth - 8)
+		if d.worktreeEnabled {
+			if branch := strings.TrimSpace(d.branchInput.Value()); branch != "" {
+				preview = "(in worktree " + branch + ") " + preview
+			}
+		}
+		content.WriteString("\n")
+		content.WriteString(previewStyle.Render("$ " + preview))
+		content.WriteString("\n")
 	}
 
 	// Inline validation error
@@ -2720,12 +3800,14 @@ func (d *NewDialog) View() string {
 	if d.enterAdvances {
 		createHint = "^S create"
 	}
-	helpText := recentPrefix + "Tab next │ ↑↓ navigate │ " + createHint + " │ Esc cancel"
+	helpText := recentPrefix + "Tab next │ ↑↓ navigate │ " + createHint + " │ F1 help │ Esc cancel"
 	if cur == focusPath {
 		if d.suggestionsActive {
 			helpText = "↑/↓ navigate │ Space/Enter select │ Tab next │ Esc back"
 		} else if d.pathSoftSelected {
 			helpText = "Type to replace │ Enter browse list │ ← edit │ Tab next │ Esc cancel"
+		} else if d.suggestionNavigated && d.pathSuggestionCursor > 0 {
+			helpText = "Enter accept suggestion │ ^N/^P navigate │ Tab next │ Esc cancel"
 		} else {
 			helpText = "Tab autocomplete │ Enter browse list │ Esc cancel"
 		}
@@ -2739,7 +3821,9 @@ func (d *NewDialog) View() string {
 		}
 	} else if cur == focusCommand {
 		selectedCmd := d.GetSelectedCommand()
-		if selectedCmd == "gemini" || selectedCmd == "codex" || selectedCmd == "hermes" {
+		if d.commandCursor == 0 {
+			helpText = "←→ command │ Enter newline │ Tab next │ ^S create │ Esc cancel"
+		} else if selectedCmd == "gemini" || selectedCmd == "codex" || selectedCmd == "hermes" {
 			helpText = "←→ command │ w worktree │ s sandbox │ y yolo │ Tab next │ ^S create │ Esc cancel"
 		} else {
 			helpText = "←→ command │ w worktree │ s sandbox │ Tab next │ ^S create │ Esc cancel"
@@ -2760,6 +3844,10 @@ func (d *NewDialog) View() string {
 		helpText = "Space/y toggle │ ↑↓ navigate │ Enter/^S create │ Esc cancel"
 	}
 	content.WriteString(helpStyle.Render(helpText))
+	if !d.compact {
+		content.WriteString("\n")
+		content.WriteString(helpStyle.MarginTop(0).Render("Alt+ N name │ P path │ C command │ M model │ B branch │ O options"))
+	}
 
 	// Wrap in dialog box
 	dialog := dialogStyle.Render(content.String())
@@ -2782,8 +3870,8 @@ func (d *NewDialog) View() string {
 		topRow, leftCol := dialogOrigin(d.width, d.height, lipgloss.Width(dialog), lipgloss.Height(dialog))
 
 		// suggestionsLineOffset is the content line where the dropdown should appear.
-		// Add border (1) + top padding (2) to get the actual row within the dialog box.
-		overlayRow := topRow + 1 + 2 + d.suggestionsLineOffset
+		// Add border (1) + top padding to get the actual row within the dialog box.
+		overlayRow := topRow + 1 + padTop + d.suggestionsLineOffset
 		// Align with the path input: border (1) + padding (4)
 		overlayCol := leftCol + 1 + 4
 
@@ -2793,7 +3881,7 @@ func (d *NewDialog) View() string {
 	if modelOverlay := d.renderModelSuggestionsDropdown(); modelOverlay != "" {
 		topRow, leftCol := dialogOrigin(d.width, d.height, lipgloss.Width(dialog), lipgloss.Height(dialog))
 
-		overlayRow := topRow + 1 + 2 + d.modelLineOffset
+		overlayRow := topRow + 1 + padTop + d.modelLineOffset
 		overlayCol := leftCol + 1 + 4
 
 		placed = overlayDropdown(placed, modelOverlay, overlayRow, overlayCol)
@@ -2813,6 +3901,14 @@ func dropdownMenuBg() lipgloss.Color {
 	return lipgloss.Color("#292e42")
 }
 
+// pathSuggestionSource returns where pathSuggestions[i] came from.
+func (d *NewDialog) pathSuggestionSource(i int) pathSuggestionSource {
+	if i < len(d.pathSuggestionSources) {
+		return d.pathSuggestionSources[i]
+	}
+	return pathSourceRecent
+}
+
 func (d *NewDialog) renderSuggestionsDropdown() string {
 	cur := d.currentTarget()
 
@@ -2886,7 +3982,12 @@ func (d *NewDialog) renderSuggestionsDropdown() string {
 				style = selectedStyle
 				prefix = "▶ "
 			}
-			b.WriteString(style.Render(prefix + d.pathSuggestions[i]))
+			label := d.pathSuggestions[i]
+			if d.pathSuggestionSource(i) == pathSourceBookmark {
+				label = collapseHome(label)
+			}
+			b.WriteString(style.Render(prefix + label))
+			b.WriteString(suggestionStyle.Render("  " + d.pathSuggestionSource(i).label()))
 		}
 
 		if endIdx < total {
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	"github.com/asheshgoplani/agent-deck/internal/statedb"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestNewNewDialog(t *testing.T) {
@@ -93,6 +95,67 @@ func TestNewDialog_ModelInputForCodex(t *testing.T) {
 	}
 }
 
+func TestNewDialog_ClaudeModelsFromLiveList(t *testing.T) {
+	d := NewNewDialog()
+	d.SetDefaultTool("claude")
+	d.SetSize(100, 50)
+	d.Show()
+	d.nameInput.SetValue("s")
+	d.pathInput.SetValue(t.TempDir())
+
+	d.modelInput.SetValue("claude-typo")
+	if msg := d.Validate(); msg != "" {
+		t.Fatalf("without a live list a custom model must pass, got %q", msg)
+	}
+
+	d.SetClaudeModels([]string{"claude-live-1", "claude-live-2"}, true)
+	d.modelInput.SetValue("live")
+	d.filterModelSuggestions()
+	if len(d.modelSuggestions) != 2 || d.modelSuggestions[0] != "claude-live-1" {
+		t.Fatalf("model suggestions = %v, want the live list", d.modelSuggestions)
+	}
+
+	d.modelInput.SetValue("claude-typo")
+	if msg := d.Validate(); !strings.Contains(msg, "Unknown Claude model") {
+		t.Fatalf("Validate() = %q, want an unknown-model error", msg)
+	}
+	for _, ok := range []string{"claude-live-2", "opus"} {
+		d.modelInput.SetValue(ok)
+		if msg := d.Validate(); msg != "" {
+			t.Fatalf("Validate() with %q = %q, want valid", ok, msg)
+		}
+	}
+}
+
+func TestNewDialog_ClaudeCompatibleToolSkipsClaudeModelCheck(t *testing.T) {
+	setXDGTestHome(t)
+	if err := session.SaveUserConfig(&session.UserConfig{
+		Tools: map[string]session.ToolDef{
+			"glm": {Command: "glm-claude", CompatibleWith: "claude"},
+		},
+	}); err != nil {
+		t.Fatalf("SaveUserConfig: %v", err)
+	}
+	session.ClearUserConfigCache()
+	t.Cleanup(session.ClearUserConfigCache)
+
+	d := NewNewDialog()
+	d.SetDefaultTool("glm")
+	d.SetSize(100, 50)
+	d.Show()
+	d.nameInput.SetValue("s")
+	d.pathInput.SetValue(t.TempDir())
+	if got := d.GetSelectedCommand(); got != "glm" {
+		t.Fatalf("selected tool = %q, want glm", got)
+	}
+
+	d.SetClaudeModels([]string{"claude-live-1"}, true)
+	d.modelInput.SetValue("glm-4.6")
+	if msg := d.Validate(); msg != "" {
+		t.Fatalf("a wrapper's own model must not be checked against Claude's list, got %q", msg)
+	}
+}
+
 func TestNewDialog_ModelSuggestions_FilterAndSelectCodex(t *testing.T) {
 	d := NewNewDialog()
 	d.SetDefaultTool("codex")
@@ -224,6 +287,35 @@ func TestNewDialog_TabFromLastFieldCyclesToTop(t *testing.T) {
 	}
 }
 
+func TestNewDialog_TabPastMissingPathOnlyWhenAllowed(t *testing.T) {
+	setXDGTestHome(t)
+	mount := t.TempDir()
+	if err := session.SaveUserConfig(&session.UserConfig{AllowMissingPaths: []string{mount}}); err != nil {
+		t.Fatalf("SaveUserConfig: %v", err)
+	}
+	session.ClearUserConfigCache()
+	t.Cleanup(session.ClearUserConfigCache)
+
+	d := NewNewDialog()
+	d.SetSize(100, 50)
+	d.Show()
+	d.jumpToField(focusPath)
+	d.pathInput.SetValue(filepath.Join(t.TempDir(), "missing"))
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if d.currentTarget() != focusPath {
+		t.Fatalf("Tab from a missing path moved to %v, want it to stay on the path", d.currentTarget())
+	}
+
+	d.pathInput.SetValue(filepath.Join(mount, "lazy", "share"))
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if d.currentTarget() == focusPath {
+		t.Fatal("Tab must leave a missing path that allow_missing_paths covers")
+	}
+	if !strings.Contains(d.projectConfigNote, "allow_missing_paths") {
+		t.Errorf("note = %q, want the missing-path warning", d.projectConfigNote)
+	}
+}
+
 func TestNewDialog_ModelInputHiddenForShell(t *testing.T) {
 	d := NewNewDialog()
 	d.SetDefaultTool("")
@@ -402,6 +494,7 @@ func TestNewDialog_SuggestionFiltering(t *testing.T) {
 
 func TestNewDialog_MalformedPathFix(t *testing.T) {
 	home, _ := os.UserHomeDir()
+	suggestions := []string{"~/projects/myapp", "~/other/path", "/Users/ashesh/projects/myapp"}
 
 	tests := []struct {
 		name     string
@@ -414,7 +507,7 @@ func TestNewDialog_MalformedPathFix(t *testing.T) {
 			expected: home + "/projects/myapp",
 		},
 		{
-			name:     "malformed path with cwd prefix",
+			name:     "suggestion appended to typed path",
 			input:    "/Users/someone/claude-deck~/projects/myapp",
 			expected: home + "/projects/myapp",
 		},
@@ -429,15 +522,31 @@ func TestNewDialog_MalformedPathFix(t *testing.T) {
 			expected: home,
 		},
 		{
-			name:     "malformed path with different prefix",
+			name:     "different suggestion appended",
 			input:    "/some/random/path~/other/path",
 			expected: home + "/other/path",
 		},
+		{
+			name:     "literal tilde directory is kept",
+			input:    "/home/bob/~/weird-dir",
+			expected: "/home/bob/~/weird-dir",
+		},
+		{
+			name:     "tilde directory matching a suggestion tail is kept",
+			input:    "/home/bob/~/projects/myapp",
+			expected: "/home/bob/~/projects/myapp",
+		},
+		{
+			name:     "backup-style dir that is not a suggestion is kept",
+			input:    "/home/bob/notes~/drafts",
+			expected: "/home/bob/notes~/drafts",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := NewNewDialog()
+			d.SetPathSuggestions(suggestions)
 			d.pathInput.SetValue(tt.input)
 
 			_, path, _ := d.GetValues()
@@ -449,6 +558,73 @@ func TestNewDialog_MalformedPathFix(t *testing.T) {
 	}
 }
 
+func TestNewDialog_RelativePathResolvesAgainstBase(t *testing.T) {
+	d := NewNewDialog()
+	d.pathBase = "/code/app"
+	d.pathInput.SetValue("../sibling-project")
+
+	if _, path, _ := d.GetValues(); path != "/code/sibling-project" {
+		t.Errorf("GetValues() path = %q, want /code/sibling-project", path)
+	}
+	if hint := d.resolvedPathHint(); hint != "→ /code/sibling-project" {
+		t.Errorf("resolvedPathHint() = %q", hint)
+	}
+
+	d.pathInput.SetValue("/code/app")
+	if hint := d.resolvedPathHint(); hint != "" {
+		t.Errorf("absolute path should not show a hint, got %q", hint)
+	}
+
+	// Multi-repo entries resolve the same way, so a relative and an absolute
+	// spelling of one directory are duplicates.
+	d.nameInput.SetValue("multi")
+	d.multiRepoEnabled = true
+	d.multiRepoPaths = []string{"../sibling-project", "/code/sibling-project"}
+	if msg := d.Validate(); msg != "Duplicate paths in multi-repo mode" {
+		t.Errorf("Validate() = %q, want duplicate-path error", msg)
+	}
+	if paths, _ := d.GetMultiRepoPaths(); len(paths) != 2 || paths[0] != "/code/sibling-project" {
+		t.Errorf("GetMultiRepoPaths() = %v", paths)
+	}
+}
+
+func TestNewDialog_TabCompletesToolFlagInCustomCommand(t *testing.T) {
+	setXDGTestHome(t)
+
+	d := NewNewDialog()
+	d.Show()
+	d.commandCursor = 0 // shell: custom command input
+	d.rebuildFocusTargets()
+	d.focusIndex = d.indexOf(focusCommand)
+	d.updateFocus()
+
+	d.commandInput.SetValue("claude --mo")
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := d.commandInput.Value(); got != "claude --model " {
+		t.Fatalf("after Tab = %q, want %q", got, "claude --model ")
+	}
+	if d.currentTarget() != focusCommand {
+		t.Fatalf("completing Tab must keep focus, got %v", d.currentTarget())
+	}
+
+	// Ambiguous: extend to the common prefix, then list the candidates.
+	d.commandInput.SetValue("claude --s")
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := d.commandInput.Value(); got != "claude --se" {
+		t.Fatalf("after Tab = %q, want %q", got, "claude --se")
+	}
+	if _, matches := commandFlagCompletion("claude --se"); len(matches) != 2 {
+		t.Fatalf("commandFlagCompletion(claude --se) = %v, want --session-id and --settings", matches)
+	}
+
+	// Unknown tools get no completion, so Tab moves focus as before.
+	d.commandInput.SetValue("vim --mo")
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if d.commandInput.Value() != "vim --mo" || d.currentTarget() == focusCommand {
+		t.Fatalf("Tab on unknown command: value %q, target %v", d.commandInput.Value(), d.currentTarget())
+	}
+}
+
 // TestNewDialog_TabDoesNotOverwriteCustomPath tests Issue #22:
 // When user enters a new folder path and presses Tab to move to agent selection,
 // the custom path should NOT be overwritten by a suggestion.
@@ -491,9 +667,10 @@ func TestNewDialog_TabDoesNotOverwriteCustomPath(t *testing.T) {
 	}
 }
 
-// TestNewDialog_TabAppliesSuggestionWhenNavigated tests that Tab DOES apply
-// the suggestion when the user explicitly navigated to one using Ctrl+N/P.
-func TestNewDialog_TabAppliesSuggestionWhenNavigated(t *testing.T) {
+// TestNewDialog_EnterAcceptsNavigatedSuggestion tests that Enter applies the
+// suggestion highlighted with Ctrl+N/P and stays on the path field, while Tab
+// only advances and keeps the typed path.
+func TestNewDialog_EnterAcceptsNavigatedSuggestion(t *testing.T) {
 	d := NewNewDialog()
 	d.Show()
 
@@ -506,26 +683,43 @@ func TestNewDialog_TabAppliesSuggestionWhenNavigated(t *testing.T) {
 	// User is on path field
 	d.focusIndex = 2
 	d.updateFocus()
+	pathIdx := d.focusIndex
 
-	// User types something, then navigates to suggestion with Ctrl+N.
 	// Cursor convention: 0 = "Type custom path…" (synthetic), 1 = first
 	// real suggestion, 2 = second. Two presses lands on the second.
 	d.pathInput.SetValue("/some/partial")
 	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
 	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
 
-	// Now Tab should apply the selected suggestion
-	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEnter})
 
-	_, path, _ := d.GetValues()
+	if got := d.pathInput.Value(); got != "/Users/test/project-2" {
+		t.Errorf("Enter should accept the navigated suggestion\nGot: %q\nWant: %q", got, "/Users/test/project-2")
+	}
+	if d.focusIndex != pathIdx {
+		t.Errorf("Enter moved focus from the path field to %d", d.focusIndex)
+	}
+	if d.suggestionNavigated || d.pathSuggestionCursor != 0 {
+		t.Errorf("navigation not reset after accepting: navigated=%v cursor=%d", d.suggestionNavigated, d.pathSuggestionCursor)
+	}
+}
 
-	// Should be the second suggestion (cursor 0 → 1 → 2 = suggestions[1])
-	if path != "/Users/test/project-2" {
-		t.Errorf(
-			"Tab should apply suggestion after Ctrl+N navigation\nGot: %q\nWant: %q",
-			path,
-			"/Users/test/project-2",
-		)
+// TestNewDialog_TabIgnoresNavigatedSuggestion tests that Tab leaves the typed
+// path alone even with a suggestion highlighted.
+func TestNewDialog_TabIgnoresNavigatedSuggestion(t *testing.T) {
+	d := NewNewDialog()
+	d.Show()
+	d.SetPathSuggestions([]string{"/Users/test/project-1"})
+	d.focusIndex = 2
+	d.updateFocus()
+
+	typed := t.TempDir() + string(os.PathSeparator)
+	d.pathInput.SetValue(typed)
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyTab})
+
+	if got := d.pathInput.Value(); got == "/Users/test/project-1" {
+		t.Errorf("Tab applied the highlighted suggestion; path = %q", got)
 	}
 }
 
@@ -911,6 +1105,76 @@ func TestNewDialog_WorktreeToggle_ViaKeyPress(t *testing.T) {
 	}
 }
 
+func TestNewDialog_WorktreeToggle_RefusesNonRepoPath(t *testing.T) {
+	setXDGTestHome(t)
+
+	dialog := NewNewDialog()
+	dialog.Show()
+	dialog.commandCursor = 1
+	dialog.rebuildFocusTargets()
+	dialog.focusIndex = dialog.indexOf(focusCommand)
+
+	plain := t.TempDir()
+	dialog.pathInput.SetValue(plain)
+	dialog, _ = dialog.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if dialog.worktreeEnabled {
+		t.Fatal("worktree must not be enabled on a directory that is not a repository")
+	}
+	if !strings.Contains(dialog.validationErr, plain) {
+		t.Errorf("validationErr = %q, want it to name the path", dialog.validationErr)
+	}
+
+	// A path that cannot be checked yet is not refused.
+	dialog.pathInput.SetValue(filepath.Join(plain, "missing"))
+	dialog, _ = dialog.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if !dialog.worktreeEnabled || dialog.validationErr != "" {
+		t.Fatalf("missing path: enabled=%v err=%q, want enabled without error", dialog.worktreeEnabled, dialog.validationErr)
+	}
+
+	// Switching to a non-repo path afterwards is caught by Validate.
+	dialog.nameInput.SetValue("demo")
+	dialog.branchInput.SetValue("feature/demo")
+	dialog.pathInput.SetValue(plain)
+	if msg := dialog.Validate(); !strings.Contains(msg, "repository") {
+		t.Errorf("Validate() = %q, want the repository error", msg)
+	}
+
+	repo := t.TempDir()
+	makeGitRepo(t, repo)
+	dialog.pathInput.SetValue(repo)
+	if msg := dialog.Validate(); msg != "" {
+		t.Errorf("Validate() on a git repo = %q, want valid", msg)
+	}
+}
+
+func TestNewDialog_WorktreeToggle_OffersGitInit(t *testing.T) {
+	setXDGTestHome(t)
+
+	dialog := NewNewDialog()
+	dialog.Show()
+	dialog.commandCursor = 1
+	dialog.rebuildFocusTargets()
+	dialog.focusIndex = dialog.indexOf(focusCommand)
+
+	plain := t.TempDir()
+	dialog.pathInput.SetValue(plain)
+	dialog, _ = dialog.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if got := dialog.TakeGitInitOffer(); got != plain {
+		t.Fatalf("TakeGitInitOffer() = %q, want %q", got, plain)
+	}
+	if got := dialog.TakeGitInitOffer(); got != "" {
+		t.Errorf("second TakeGitInitOffer() = %q, want empty", got)
+	}
+
+	dialog.EnableWorktreeAfterInit()
+	if !dialog.worktreeEnabled || dialog.validationErr != "" {
+		t.Errorf("after init: enabled=%v err=%q, want enabled without error", dialog.worktreeEnabled, dialog.validationErr)
+	}
+	if dialog.focusTargets[dialog.focusIndex] != focusBranch {
+		t.Error("focus should move to the branch field")
+	}
+}
+
 func TestNewDialog_ShortcutsBlockedDuringTextInput(t *testing.T) {
 	setXDGTestHome(t)
 
@@ -2530,3 +2794,729 @@ func TestNewDialog_CtrlSSubmitsInBothModes(t *testing.T) {
 		}
 	}
 }
+
+func TestNewDialog_ProjectConfigOverridesUserDefaults(t *testing.T) {
+	dir := t.TempDir()
+	body := `{"tool": "claude", "worktree": true, "claude": {"permission_mode": "plan"}, "env": {"A": "1"}}`
+	if err := os.WriteFile(dir+"/"+session.ProjectConfigFileName, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewNewDialog()
+	d.SetDefaultTool("")
+	d.SetSize(100, 50)
+	d.ShowInGroup("default", "default", dir, nil, "")
+
+	if got := d.GetSelectedCommand(); got != "claude" {
+		t.Fatalf("tool = %q, want claude from project config", got)
+	}
+	if !d.IsWorktreeEnabled() || d.IsWorktreeExplicit() {
+		t.Fatal("worktree should be enabled as a (non-explicit) project default")
+	}
+	if got := d.GetClaudeOptions().PermissionMode; got == "plan" {
+		t.Fatal("an untrusted claude section must not change the Claude options")
+	}
+	if !strings.Contains(d.View(), "untrusted: claude, env ignored") {
+		t.Fatalf("expected the untrusted note in view, got %q", d.projectConfigNote)
+	}
+
+	if err := session.TrustProjectConfig(dir); err != nil {
+		t.Fatal(err)
+	}
+	d.projectConfigPath = ""
+	d.refreshProjectConfig()
+	if got := d.GetClaudeOptions().PermissionMode; got != "plan" {
+		t.Fatalf("PermissionMode = %q, want plan once trusted", got)
+	}
+	if !strings.Contains(d.View(), "Using .agentdeck.json (1 env vars)") {
+		t.Fatal("expected project config note in view")
+	}
+}
+
+func TestNewDialog_ProjectConfigUndoneWhenPathMoves(t *testing.T) {
+	projectA := t.TempDir()
+	body := `{"tool": "claude", "worktree": true, "claude": {"permission_mode": "plan"}}`
+	if err := os.WriteFile(projectA+"/"+session.ProjectConfigFileName, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.TrustProjectConfig(projectA); err != nil {
+		t.Fatal(err)
+	}
+	projectB := t.TempDir()
+
+	d := NewNewDialog()
+	d.SetDefaultTool("")
+	d.SetSize(100, 50)
+	d.ShowInGroup("default", "default", projectB, nil, "")
+	d.nameInput.SetValue("demo")
+
+	d.pathInput.SetValue(projectA)
+	d.refreshProjectConfig()
+	if d.GetSelectedCommand() != "claude" || !d.IsWorktreeEnabled() || d.GetClaudeOptions().PermissionMode != "plan" {
+		t.Fatal("project A's defaults should apply")
+	}
+
+	d.pathInput.SetValue(projectB)
+	d.refreshProjectConfig()
+	if got := d.GetSelectedCommand(); got != "" {
+		t.Errorf("tool = %q, want the user default back", got)
+	}
+	if d.IsWorktreeEnabled() || d.branchInput.Value() != "" {
+		t.Errorf("worktree = %v, branch = %q; want project A's worktree undone", d.IsWorktreeEnabled(), d.branchInput.Value())
+	}
+	if got := d.claudeOptions.GetOptions().PermissionMode; got == "plan" {
+		t.Error("project A's claude options should not carry over to project B")
+	}
+
+	// A value the user changed after the overlay is theirs and stays.
+	d.pathInput.SetValue(projectA)
+	d.refreshProjectConfig()
+	d.ToggleWorktree()
+	d.ToggleWorktree()
+	d.pathInput.SetValue(projectB)
+	d.refreshProjectConfig()
+	if !d.IsWorktreeEnabled() {
+		t.Error("an explicit worktree choice must survive the path change")
+	}
+}
+
+func TestNewDialog_ShellInitWrapsPlainShell(t *testing.T) {
+	d := NewNewDialog()
+	d.SetDefaultTool("")
+	d.SetSize(100, 50)
+	d.ShowInGroup("default", "default", t.TempDir(), nil, "")
+	d.nameInput.SetValue("venv")
+	d.shellInit = "source venv/bin/activate"
+
+	if _, _, command := d.GetValues(); command != session.ShellInitCommand("source venv/bin/activate") {
+		t.Fatalf("command = %q, want the shell_init wrapper", command)
+	}
+	if !strings.Contains(d.View(), "Runs first: source venv/bin/activate") {
+		t.Fatal("expected the shell_init hint under the custom command")
+	}
+
+	d.commandInput.SetValue("htop")
+	if _, _, command := d.GetValues(); command != "htop" {
+		t.Fatalf("command = %q, want a custom command to run as-is", command)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(dir+"/"+session.ProjectConfigFileName, []byte(`{"shell_init": "nvm use"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d.commandInput.SetValue("")
+	d.pathInput.SetValue(dir)
+	d.refreshProjectConfig()
+	if _, _, command := d.GetValues(); command != session.ShellInitCommand("source venv/bin/activate") {
+		t.Fatalf("command = %q, want an untrusted project shell_init ignored", command)
+	}
+	if !strings.Contains(d.projectConfigNote, "shell_init ignored") {
+		t.Errorf("projectConfigNote = %q, want the untrusted shell_init called out", d.projectConfigNote)
+	}
+
+	if err := session.TrustProjectConfig(dir); err != nil {
+		t.Fatal(err)
+	}
+	d.projectConfigPath = ""
+	d.refreshProjectConfig()
+	if _, _, command := d.GetValues(); command != session.ShellInitCommand("nvm use") {
+		t.Fatalf("command = %q, want the trusted project shell_init to win", command)
+	}
+}
+
+func TestNewDialog_IsShellSession(t *testing.T) {
+	d := NewNewDialog()
+	d.SetDefaultTool("")
+	d.ShowInGroup("default", "default", t.TempDir(), nil, "")
+	d.shellInit = "source venv/bin/activate"
+
+	if !d.IsShellSession() {
+		t.Fatal("shell with a blank command must be a shell session")
+	}
+	if got := d.submittedTool(session.ShellInitCommand(d.shellInit)); got != toolShell {
+		t.Errorf("submittedTool = %q, want %q rather than the shell_init wrapper", got, toolShell)
+	}
+
+	d.commandInput.SetValue("htop")
+	if d.IsShellSession() {
+		t.Error("a custom command is not a plain shell")
+	}
+	if got := d.submittedTool("htop"); got != "htop" {
+		t.Errorf("submittedTool = %q, want the custom command", got)
+	}
+
+	d.commandInput.SetValue("")
+	d.SetDefaultTool("claude")
+	if d.IsShellSession() {
+		t.Error("an agent tool is not a shell session")
+	}
+}
+
+func TestNewDialog_MalformedProjectConfigWarns(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(dir+"/"+session.ProjectConfigFileName, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewNewDialog()
+	d.SetDefaultTool("")
+	d.SetSize(100, 50)
+	d.ShowInGroup("default", "default", dir, nil, "")
+
+	if !strings.HasPrefix(d.projectConfigNote, "⚠") {
+		t.Fatalf("projectConfigNote = %q, want warning", d.projectConfigNote)
+	}
+	if d.GetSelectedCommand() != "" {
+		t.Fatal("malformed project config must not change the tool")
+	}
+}
+
+func TestNewDialog_AltJumpKeys(t *testing.T) {
+	alt := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}, Alt: true} }
+
+	d := NewNewDialog()
+	d.SetSize(100, 50)
+	d.Show()
+	d.nameInput.SetValue("abc")
+
+	d, _ = d.Update(alt('c'))
+	if d.currentTarget() != focusCommand {
+		t.Fatalf("alt+c: currentTarget = %v, want focusCommand", d.currentTarget())
+	}
+	d, _ = d.Update(alt('p'))
+	if d.currentTarget() != focusPath {
+		t.Fatalf("alt+p: currentTarget = %v, want focusPath", d.currentTarget())
+	}
+	d, _ = d.Update(alt('n'))
+	if d.currentTarget() != focusName || !d.nameInput.Focused() {
+		t.Fatalf("alt+n: currentTarget = %v, want focused name input", d.currentTarget())
+	}
+	if got := d.nameInput.Value(); got != "abc" {
+		t.Errorf("jump keys must not type into inputs: name = %q", got)
+	}
+
+	// Hidden fields are a no-op: branch only exists with worktree enabled.
+	d, _ = d.Update(alt('b'))
+	if d.currentTarget() != focusName {
+		t.Errorf("alt+b without worktree: currentTarget = %v, want focusName", d.currentTarget())
+	}
+}
+
+func TestNewDialog_PreviewCommand(t *testing.T) {
+	d := NewNewDialog()
+	d.SetSize(100, 50)
+	d.SetDefaultTool("gemini")
+	d.Show()
+	d.nameInput.SetValue("preview")
+	d.pathInput.SetValue(t.TempDir())
+
+	d.geminiOptions.SetDefaults(true)
+	d.modelInput.SetValue("gemini-2.5-pro")
+	got := d.PreviewCommand()
+	for _, want := range []string{"gemini", "--yolo", "gemini-2.5-pro"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("gemini preview %q missing %q", got, want)
+		}
+	}
+
+	// Live: dropping YOLO updates the preview.
+	d.geminiOptions.SetDefaults(false)
+	if got := d.PreviewCommand(); strings.Contains(got, "--yolo") {
+		t.Errorf("preview after disabling yolo = %q", got)
+	}
+
+	d.SetDefaultTool("claude")
+	d.Show()
+	got = d.PreviewCommand()
+	if !strings.Contains(got, "claude") || !strings.Contains(got, session.LaunchPreviewSessionID) {
+		t.Errorf("claude preview = %q, want claude with placeholder session id", got)
+	}
+	if got != d.PreviewCommand() {
+		t.Error("preview should be stable across calls")
+	}
+	if !strings.Contains(d.View(), "$ ") {
+		t.Error("View should render the command preview")
+	}
+
+	// View renders the preview from the last update, not a fresh build.
+	d.modelInput.SetValue("claude-opus-4-7")
+	d.View()
+	if strings.Contains(d.commandPreview, "claude-opus-4-7") {
+		t.Error("rendering must not rebuild the preview")
+	}
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	if !strings.Contains(d.commandPreview, "claude-opus-4-7") {
+		t.Errorf("preview after an update = %q, want the new model", d.commandPreview)
+	}
+}
+
+func TestNormalizeShellCommand(t *testing.T) {
+	tests := map[string]string{
+		"":                          "",
+		"  \n \n":                   "",
+		"make dev":                  "make dev",
+		"  npm ci  \n  npm test \n": "  npm ci\n  npm test",
+		"cat <<EOF\nhi\nEOF\r\n":    "cat <<EOF\nhi\nEOF",
+	}
+	for in, want := range tests {
+		if got := normalizeShellCommand(in); got != want {
+			t.Errorf("normalizeShellCommand(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNewDialog_ShellCommandMultiline(t *testing.T) {
+	d := NewNewDialog()
+	d.SetSize(100, 50)
+	d.Show()
+	d.commandCursor = 0 // shell
+	d.focusIndex = d.indexOf(focusCommand)
+	d.updateFocus()
+
+	if !d.shouldHandleEnterLocally() {
+		t.Fatal("Enter on the shell command should stay in the dialog (newline)")
+	}
+	for _, r := range "echo one" {
+		d, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, r := range "echo two" {
+		d, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if d.currentTarget() != focusCommand {
+		t.Fatalf("focus moved to %v while typing the command", d.currentTarget())
+	}
+
+	// Up moves within the script before it leaves the field.
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if d.currentTarget() != focusCommand || d.commandInput.Line() != 0 {
+		t.Fatalf("up on line 2: target %v line %d, want command line 0", d.currentTarget(), d.commandInput.Line())
+	}
+
+	_, _, command := d.GetValues()
+	if command != "echo one\necho two" {
+		t.Errorf("command = %q, want newline-joined script", command)
+	}
+
+	d.commandInput.SetValue("  \n  ")
+	if _, _, command := d.GetValues(); command != "" {
+		t.Errorf("blank textarea command = %q, want empty", command)
+	}
+}
+
+func TestNewDialog_DraftSurvivesDismissal(t *testing.T) {
+	d := NewNewDialog()
+	d.ShowInGroup("work", "work", "/tmp/a", nil, "")
+	d.nameInput.SetValue("half-typed")
+	d.branchInput.SetValue("feature/half")
+	d.SaveDraft()
+	d.Hide()
+
+	d.ShowInGroup("work", "work", "/tmp/b", nil, "")
+	if d.nameInput.Value() != "" {
+		t.Fatalf("ShowInGroup restored the draft on its own: name %q", d.nameInput.Value())
+	}
+	if !d.RestoreDraft() {
+		t.Fatal("RestoreDraft() = false, want a saved draft")
+	}
+	if d.nameInput.Value() != "half-typed" || d.pathInput.Value() != "/tmp/a" || d.branchInput.Value() != "feature/half" {
+		t.Errorf("restored name/path/branch = %q/%q/%q", d.nameInput.Value(), d.pathInput.Value(), d.branchInput.Value())
+	}
+	d.SetSize(100, 50)
+	if !strings.Contains(d.View(), "draft restored") {
+		t.Error("View() does not show the draft restored hint")
+	}
+
+	// Dismissing the restored form unchanged keeps the draft.
+	d.SaveDraft()
+	d.ShowInGroup("work", "work", "", nil, "")
+	if strings.Contains(d.View(), "draft restored") {
+		t.Error("hint still shown after reopening without restoring")
+	}
+	if !d.RestoreDraft() || d.nameInput.Value() != "half-typed" {
+		t.Error("draft lost after dismissing the restored form")
+	}
+
+	d.ClearDraft()
+	d.ShowInGroup("work", "work", "", nil, "")
+	if d.RestoreDraft() {
+		t.Error("RestoreDraft() = true after ClearDraft")
+	}
+}
+
+func TestNewDialog_SaveDraft_SkipsUntouchedForm(t *testing.T) {
+	d := NewNewDialog()
+	d.ShowInGroup("work", "work", "/tmp/a", nil, "")
+	d.SaveDraft()
+	if d.RestoreDraft() {
+		t.Error("dismissing an untouched form saved a draft")
+	}
+
+	d.ShowInGroup("work", "work", "/tmp/a", nil, "")
+	d.remote = true
+	d.nameInput.SetValue("remote-one")
+	d.SaveDraft()
+	if d.draft != nil {
+		t.Error("saved a draft for a remote target")
+	}
+}
+
+func TestNewDialog_CheckConsistency_YoloPanelForOtherTool(t *testing.T) {
+	dialog := NewNewDialog()
+	dialog.nameInput.SetValue("test-session")
+	dialog.pathInput.SetValue(t.TempDir())
+	dialog.commandCursor = 0 // shell
+	dialog.toolOptions = dialog.geminiOptions
+	dialog.geminiOptions.SetDefaults(true)
+
+	if got, want := dialog.Validate(), "Gemini YOLO mode does not apply to shell"; got != want {
+		t.Errorf("Validate() = %q, want %q", got, want)
+	}
+
+	// The matching tool, or YOLO off, is fine.
+	dialog.geminiOptions.SetDefaults(false)
+	if got := dialog.Validate(); got != "" {
+		t.Errorf("Validate() with YOLO off = %q, want valid", got)
+	}
+	dialog.geminiOptions.SetDefaults(true)
+	dialog.SetDefaultTool("gemini")
+	if got := dialog.Validate(); got != "" {
+		t.Errorf("Validate() with gemini selected = %q, want valid", got)
+	}
+}
+
+func TestNewDialog_CheckConsistency_RemoteRejectsLocalOnlyOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(d *NewDialog)
+		want string
+	}{
+		{"worktree", func(d *NewDialog) {
+			d.worktreeEnabled = true
+			d.branchInput.SetValue("feature/x")
+		}, "Worktrees are not available for remote sessions"},
+		{"sandbox", func(d *NewDialog) { d.sandboxEnabled = true }, "Docker sandbox is not available for remote sessions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialog := NewNewDialog()
+			dialog.nameInput.SetValue("test-session")
+			dialog.pathInput.SetValue(t.TempDir())
+			dialog.remote = true
+			tt.set(dialog)
+			if got := dialog.Validate(); got != tt.want {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDialog_HelpOverlay(t *testing.T) {
+	d := NewNewDialog()
+	d.SetSize(120, 60)
+	d.Show()
+
+	// ? is text in the name field; F1 opens the help anywhere.
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	if d.IsHelpVisible() {
+		t.Fatal("? in the name field opened the help")
+	}
+	if d.nameInput.Value() != "?" {
+		t.Errorf("name = %q, want the typed ?", d.nameInput.Value())
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyF1})
+	if !d.IsHelpVisible() {
+		t.Fatal("F1 did not open the help")
+	}
+	view := d.View()
+	for _, want := range []string{"NEW SESSION KEYS", "Ctrl+N", "Shift+Tab", "Toggle git worktree", "Toggle YOLO mode"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("help view missing %q", want)
+		}
+	}
+
+	// Any other key closes it without reaching the form.
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if d.IsHelpVisible() {
+		t.Fatal("help still open after a key")
+	}
+	if d.nameInput.Value() != "?" {
+		t.Errorf("closing key reached the name field: %q", d.nameInput.Value())
+	}
+
+	// Outside text fields, ? opens it.
+	d.jumpToField(focusCommand)
+	d.commandCursor = 1
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	if !d.IsHelpVisible() {
+		t.Error("? on the command selector did not open the help")
+	}
+}
+
+func TestHelpOverlay_ShowSections(t *testing.T) {
+	h := NewHelpOverlay()
+	h.SetSize(100, 40)
+	h.ShowSections("DIALOG KEYS", []helpSection{
+		{title: "FIELDS", items: [][2]string{{"Tab", "Next field"}, {"", "dropped row"}}},
+	})
+	view := h.View()
+	if !strings.Contains(view, "DIALOG KEYS") || !strings.Contains(view, "Next field") {
+		t.Errorf("custom sections not rendered:\n%s", view)
+	}
+	if strings.Contains(view, "dropped row") || strings.Contains(view, "KEYBOARD SHORTCUTS") {
+		t.Errorf("unexpected content in custom help:\n%s", view)
+	}
+
+	h.Show()
+	if !strings.Contains(h.View(), "KEYBOARD SHORTCUTS") {
+		t.Error("Show() did not go back to the main screen keys")
+	}
+}
+
+func TestNewDialog_PathSuggestions_TagsRecentAndDirSources(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"alpha", "alpine", "beta"} {
+		if err := os.Mkdir(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	recent := filepath.Join(root, "alpha")
+
+	d := NewNewDialog()
+	d.SetSize(120, 50)
+	d.Show()
+	d.jumpToField(focusPath)
+	d.SetPathSuggestions([]string{recent, "/elsewhere/alps"})
+	d.pathInput.SetValue(filepath.Join(root, "al"))
+	d.filterPathSuggestions()
+	if len(d.pathSuggestions) != 1 {
+		t.Fatalf("pathSuggestions = %v, want only the recent until the directory read returns", d.pathSuggestions)
+	}
+	cmd := d.dirCompletionsCmd()
+	if cmd == nil {
+		t.Fatal("a path-like input must request its directory completions")
+	}
+	if d.dirCompletionsCmd() != nil {
+		t.Error("the same input is read only once")
+	}
+	d.setDirCompletions(cmd().(dirCompletionsMsg))
+
+	want := []string{recent, filepath.Join(root, "alpine")}
+	if !reflect.DeepEqual(d.pathSuggestions, want) {
+		t.Fatalf("pathSuggestions = %v, want %v (recent first, no duplicate dir)", d.pathSuggestions, want)
+	}
+	if d.pathSuggestionSource(0) != pathSourceRecent || d.pathSuggestionSource(1) != pathSourceDir {
+		t.Errorf("sources = %v, want [recent dir]", d.pathSuggestionSources)
+	}
+
+	view := d.renderSuggestionsDropdown()
+	if !strings.Contains(view, "recent") || !strings.Contains(view, "dir") {
+		t.Errorf("dropdown does not tag sources:\n%s", view)
+	}
+
+	// Plain words are matched against recents only, and remote targets
+	// never read the local filesystem.
+	d.pathInput.SetValue("alp")
+	d.filterPathSuggestions()
+	if len(d.pathSuggestions) != 2 {
+		t.Errorf("plain query suggestions = %v, want the two recents", d.pathSuggestions)
+	}
+	// A stale result for input the user has moved on from is dropped.
+	d.setDirCompletions(dirCompletionsMsg{typed: filepath.Join(root, "a"), matches: []string{filepath.Join(root, "alpha")}})
+	if len(d.pathSuggestions) != 2 {
+		t.Errorf("stale completions were applied: %v", d.pathSuggestions)
+	}
+	d.remote = true
+	d.pathInput.SetValue(filepath.Join(root, "be"))
+	d.filterPathSuggestions()
+	if len(d.pathSuggestions) != 0 || d.dirCompletionsCmd() != nil {
+		t.Errorf("remote suggestions = %v, want none and no local read", d.pathSuggestions)
+	}
+}
+
+func TestNewDialog_Bookmarks_ListedFirstAndStoredExpanded(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	code := filepath.Join(home, "code")
+	work := filepath.Join(home, "work")
+
+	d := NewNewDialog()
+	d.SetPathSuggestions([]string{"/tmp/recent", code})
+	d.SetBookmarks([]string{"~/code", "~/work", code})
+
+	want := []string{code, work, "/tmp/recent"}
+	if !reflect.DeepEqual(d.pathSuggestions, want) {
+		t.Fatalf("pathSuggestions = %v, want %v (bookmarks first, deduped)", d.pathSuggestions, want)
+	}
+	if d.pathSuggestionSource(0) != pathSourceBookmark || d.pathSuggestionSource(2) != pathSourceRecent {
+		t.Errorf("sources = %v, want [bookmark bookmark recent]", d.pathSuggestionSources)
+	}
+
+	// The "~" form matches too, and the dropdown shows it.
+	d.SetSize(120, 50)
+	d.Show()
+	d.jumpToField(focusPath)
+	d.pathInput.SetValue("~/wo")
+	d.filterPathSuggestions()
+	if len(d.pathSuggestions) == 0 || d.pathSuggestions[0] != work {
+		t.Fatalf("pathSuggestions for ~/wo = %v, want %s first", d.pathSuggestions, work)
+	}
+	view := d.renderSuggestionsDropdown()
+	if !strings.Contains(view, "~/work") || !strings.Contains(view, "bookmark") {
+		t.Errorf("dropdown should show ~/work tagged bookmark:\n%s", view)
+	}
+
+	// Accepting a bookmark fills in the expanded path.
+	d.pathSuggestionCursor = 1
+	d.suggestionNavigated = true
+	d.acceptNavigatedSuggestion()
+	if got := d.pathInput.Value(); got != work {
+		t.Errorf("accepted path = %q, want %q", got, work)
+	}
+}
+
+func TestNormalizePaths(t *testing.T) {
+	orig := pathExists
+	pathExists = func(path string) bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}
+	t.Cleanup(func() { pathExists = orig })
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	root := t.TempDir()
+	proj := filepath.Join(root, "proj")
+	if err := os.Mkdir(proj, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got := normalizePaths([]string{
+		proj + "/", "", proj, filepath.Join(root, "gone"), "~", home, filepath.Join(root, ".", "proj"),
+	})
+	want := []string{proj, home}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizePaths() = %v, want %v", got, want)
+	}
+
+	d := NewNewDialog()
+	d.SetPathSuggestions([]string{proj + "/", proj})
+	if !reflect.DeepEqual(d.allPathSuggestions, []string{proj}) {
+		t.Errorf("SetPathSuggestions kept %v, want just %s", d.allPathSuggestions, proj)
+	}
+}
+
+func TestNewDialog_NameFollowsPathUntilEdited(t *testing.T) {
+	d := NewNewDialog()
+	d.ShowInGroup("default", "default", "", nil, "")
+	d.jumpToField(focusPath)
+	d.pathInput.SetValue("")
+
+	typeInto := func(s string) {
+		for _, r := range s {
+			d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	typeInto("/src/api")
+	if got := d.nameInput.Value(); got != "api" {
+		t.Fatalf("name = %q, want the path's base name %q", got, "api")
+	}
+	typeInto("-v2")
+	if got := d.nameInput.Value(); got != "api-v2" {
+		t.Errorf("name = %q, want it to follow the path to %q", got, "api-v2")
+	}
+
+	// Once the user types a name, path changes leave it alone.
+	d.jumpToField(focusName)
+	typeInto("x")
+	d.jumpToField(focusPath)
+	typeInto("/sub")
+	if got := d.nameInput.Value(); got != "api-v2x" {
+		t.Errorf("edited name = %q, want it kept as %q", got, "api-v2x")
+	}
+
+	// Clearing the name hands it back to the path.
+	d.jumpToField(focusName)
+	d.nameInput.SetValue("a")
+	d.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	d.jumpToField(focusPath)
+	typeInto("/web")
+	if got := d.nameInput.Value(); got != "web" {
+		t.Errorf("name after clearing = %q, want %q", got, "web")
+	}
+}
+
+func TestNewDialog_CompactLayoutCollapsesOptions(t *testing.T) {
+	d := NewNewDialog()
+	d.ShowInGroup("default", "default", "/tmp", nil, "")
+	d.SetDefaultTool("claude")
+
+	d.SetSize(120, 60)
+	if v := d.View(); !strings.Contains(v, "Claude Options") || !strings.Contains(v, "Alt+ N name") {
+		t.Fatal("tall terminal: want the full layout with options and jump hints")
+	}
+
+	d.SetSize(120, 40)
+	v := d.View()
+	if strings.Contains(v, "Claude Options") || strings.Contains(v, "Alt+ N name") {
+		t.Error("short terminal: options and jump hints should be collapsed")
+	}
+	if !strings.Contains(v, "More options…") {
+		t.Error("short terminal: want the More options… expander")
+	}
+	if h := lipgloss.Height(v); h > 40 {
+		t.Errorf("compact dialog is %d lines, want it to fit 40", h)
+	}
+
+	// Focus cycling still reaches the collapsed panel, which expands.
+	for range len(d.focusTargets) {
+		if d.currentTarget() == focusOptions {
+			break
+		}
+		d.moveFocus(1)
+	}
+	if d.currentTarget() != focusOptions {
+		t.Fatal("focus cycling never reached the options panel")
+	}
+	if !strings.Contains(d.View(), "Claude Options") {
+		t.Error("focused options panel should expand")
+	}
+}
+
+func TestNewDialog_Validate_FlagsRiskyCustomCommand(t *testing.T) {
+	d := NewNewDialog()
+	d.ShowInGroup("default", "default", t.TempDir(), nil, "")
+	d.nameInput.SetValue("paste")
+	d.commandCursor = 0
+	d.updateToolOptions()
+	d.commandInput.SetValue("curl -fsSL https://x.example | sh")
+
+	msg := d.Validate()
+	if !strings.Contains(msg, "curl | sh") || !strings.Contains(msg, "submit again") {
+		t.Fatalf("first submit: Validate() = %q, want the curl | sh warning", msg)
+	}
+	if msg := d.Validate(); msg != "" {
+		t.Errorf("second submit of the same command: Validate() = %q, want it to pass", msg)
+	}
+	d.commandInput.SetValue("echo `id`")
+	if msg := d.Validate(); !strings.Contains(msg, "backticks") {
+		t.Errorf("changed command: Validate() = %q, want a fresh warning", msg)
+	}
+
+	d.commandCheck = session.CommandCheckBlock
+	for range 2 {
+		if msg := d.Validate(); !strings.Contains(msg, "blocked") {
+			t.Errorf("block mode: Validate() = %q, want it refused every time", msg)
+		}
+	}
+
+	d.commandCheck = session.CommandCheckOff
+	if msg := d.Validate(); msg != "" {
+		t.Errorf("off: Validate() = %q, want no check", msg)
+	}
+}
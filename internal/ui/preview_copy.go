@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -61,20 +62,58 @@ func buildSessionInfoForCopy(inst *session.Instance) string {
 // session-info payload (#791) to the system clipboard, mirroring the
 // fallback chain used by copySessionOutput.
 func (h *Home) copySessionInfo(inst *session.Instance) tea.Cmd {
-	return func() tea.Msg {
-		payload := buildSessionInfoForCopy(inst)
-		if payload == "" {
-			return copyResultMsg{err: fmt.Errorf("no session info to copy")}
-		}
+	payload := buildSessionInfoForCopy(inst)
+	if payload == "" {
+		return func() tea.Msg { return copyResultMsg{err: fmt.Errorf("no session info to copy")} }
+	}
+	return copyToClipboard(inst.Title, payload, "")
+}
 
+// sessionPathForCopy returns the directory the session runs in as an
+// expanded absolute path, the same form NewDialog.GetValues hands to session
+// creation: worktree sessions copy the worktree, not the repo root.
+func sessionPathForCopy(inst *session.Instance) string {
+	if inst == nil {
+		return ""
+	}
+	path := inst.WorktreePath
+	if path == "" {
+		path = inst.ProjectPath
+	}
+	if path == "" {
+		return ""
+	}
+	path = session.ExpandPath(path)
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return path
+}
+
+// copySessionPath returns a tea.Cmd that copies the session's project path
+// to the clipboard, e.g. for a `cd` in another terminal.
+func (h *Home) copySessionPath(inst *session.Instance) tea.Cmd {
+	path := sessionPathForCopy(inst)
+	if path == "" {
+		return func() tea.Msg { return copyResultMsg{err: fmt.Errorf("session has no project path")} }
+	}
+	return copyToClipboard(inst.Title, path, "path")
+}
+
+// copyToClipboard copies payload via the shared fallback chain (native
+// clipboard tools, then OSC52 so it also works over SSH). what names the
+// payload in the status message; empty reports a line count instead.
+func copyToClipboard(sessionTitle, payload, what string) tea.Cmd {
+	return func() tea.Msg {
 		termInfo := tmux.GetTerminalInfo()
 		result, err := clipboard.Copy(payload, termInfo.SupportsOSC52)
 		if err != nil {
 			return copyResultMsg{err: fmt.Errorf("clipboard: %w", err)}
 		}
 		return copyResultMsg{
-			sessionTitle: inst.Title,
+			sessionTitle: sessionTitle,
 			lineCount:    result.LineCount,
+			what:         what,
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -108,3 +109,30 @@ func TestBuildSessionInfoForCopy_MultiRepo(t *testing.T) {
 		}
 	}
 }
+
+// TestSessionPathForCopy verifies the copied path is the expanded absolute
+// path (as NewDialog.GetValues produces), preferring the worktree directory.
+func TestSessionPathForCopy(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name string
+		inst *session.Instance
+		want string
+	}{
+		{"nil", nil, ""},
+		{"empty", &session.Instance{}, ""},
+		{"absolute", &session.Instance{ProjectPath: "/srv/app"}, "/srv/app"},
+		{"tilde", &session.Instance{ProjectPath: "~/code/app"}, filepath.Join(home, "code", "app")},
+		{"env var", &session.Instance{ProjectPath: "$HOME/app"}, filepath.Join(home, "app")},
+		{"worktree", &session.Instance{ProjectPath: "/srv/app", WorktreePath: "/srv/app/.worktrees/x"}, "/srv/app/.worktrees/x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sessionPathForCopy(tt.inst); got != tt.want {
+				t.Errorf("sessionPathForCopy = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RestartToolDialog picks the tool to relaunch a session with, in the same
+// path and group. It offers the presets of the new-session dialog's command
+// selector; the restart itself is confirmed with ConfirmRestart.
+type RestartToolDialog struct {
+	sessionID string
+	title     string
+	current   string // the session's tool when the dialog opened
+	presets   []string
+	cursor    int
+	width     int
+	height    int
+	visible   bool
+}
+
+// NewRestartToolDialog creates a new restart-with-tool picker
+func NewRestartToolDialog() *RestartToolDialog {
+	return &RestartToolDialog{}
+}
+
+// SetSize sets the dimensions of the overlay
+func (d *RestartToolDialog) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+}
+
+// Show opens the picker for inst with its current tool highlighted.
+func (d *RestartToolDialog) Show(inst *session.Instance) {
+	d.visible = true
+	d.sessionID = inst.ID
+	d.title = inst.Title
+	d.current = inst.Tool
+	d.presets = buildPresetCommands()
+	d.cursor = 0
+	for i, preset := range d.presets {
+		if tool, _ := createSessionTool(preset); tool == inst.Tool {
+			d.cursor = i
+			break
+		}
+	}
+}
+
+// Hide closes the picker
+func (d *RestartToolDialog) Hide() {
+	d.visible = false
+}
+
+// IsVisible returns whether the picker is visible. Nil-safe for Homes built
+// field by field in tests.
+func (d *RestartToolDialog) IsVisible() bool {
+	if d == nil {
+		return false
+	}
+	return d.visible
+}
+
+// SessionID returns the session the picker was opened for
+func (d *RestartToolDialog) SessionID() string {
+	return d.sessionID
+}
+
+// Selected returns the highlighted preset ("" is shell).
+func (d *RestartToolDialog) Selected() string {
+	if d.cursor < 0 || d.cursor >= len(d.presets) {
+		return ""
+	}
+	return d.presets[d.cursor]
+}
+
+// Update moves the highlight; Enter and Esc are handled by Home.
+func (d *RestartToolDialog) Update(msg tea.KeyMsg) (*RestartToolDialog, tea.Cmd) {
+	if len(d.presets) == 0 {
+		return d, nil
+	}
+	switch msg.String() {
+	case "left", "h", "shift+tab", "up", "k":
+		d.cursor = (d.cursor - 1 + len(d.presets)) % len(d.presets)
+	case "right", "l", "tab", "down", "j":
+		d.cursor = (d.cursor + 1) % len(d.presets)
+	}
+	return d, nil
+}
+
+// View renders the picker
+func (d *RestartToolDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	header := lipgloss.NewStyle().
+		Foreground(ColorAccent).
+		Bold(true).
+		Render("Restart with tool")
+
+	dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	current := d.current
+	if current == "" {
+		current = toolShell
+	}
+	info := dimStyle.Render(fmt.Sprintf("%q runs %s. Path, group and title are kept.", d.title, current))
+	keysHint := dimStyle.Render("[←/→] Choose  [Enter] Restart  [Esc] Cancel")
+
+	pills := renderToolPills(d.presets, d.cursor)
+	content := header + "\n\n" + info + "\n\n" + pills + "\n\n" + keysHint
+
+	// Wide enough for the pill row, which must not wrap.
+	overlayWidth := max(70, lipgloss.Width(pills)+6)
+	if d.width > 0 && d.width < overlayWidth+4 {
+		overlayWidth = max(d.width-4, 30)
+	}
+	return centerInScreen(overlayStyle.Width(overlayWidth).Render(content), d.width, d.height)
+}
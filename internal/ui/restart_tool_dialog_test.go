@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func restartToolHome(t *testing.T) (*Home, *session.Instance) {
+	t.Helper()
+	h := NewHome()
+	h.width, h.height = 160, 40
+	inst := &session.Instance{ID: "s1", Title: "api", GroupPath: "my-sessions", Tool: "shell"}
+	h.instancesMu.Lock()
+	h.instances = []*session.Instance{inst}
+	h.instanceByID[inst.ID] = inst
+	h.instancesMu.Unlock()
+	h.groupTree = session.NewGroupTree(h.instances)
+	h.rebuildFlatItems()
+	for i, item := range h.flatItems {
+		if item.Session == inst {
+			h.cursor = i
+		}
+	}
+	return h, inst
+}
+
+func TestRestartToolDialog_PicksToolAndAsksToConfirm(t *testing.T) {
+	h, inst := restartToolHome(t)
+
+	h.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}, Alt: true})
+	if !h.restartToolDialog.IsVisible() {
+		t.Fatal("alt+r did not open the restart-with-tool picker")
+	}
+	if got := h.restartToolDialog.Selected(); got != "" {
+		t.Errorf("picker opened on %q, want the current tool (shell)", got)
+	}
+	if view := h.restartToolDialog.View(); !strings.Contains(view, "claude") {
+		t.Errorf("picker does not list the tool presets:\n%s", view)
+	}
+
+	h.handleRestartToolDialogKey(tea.KeyMsg{Type: tea.KeyRight})
+	want := h.restartToolDialog.Selected()
+	h.handleRestartToolDialogKey(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if h.restartToolDialog.IsVisible() {
+		t.Error("picker still visible after enter")
+	}
+	if !h.confirmDialog.IsVisible() || h.confirmDialog.GetConfirmType() != ConfirmRestart {
+		t.Fatal("enter should ask to confirm the restart")
+	}
+	if h.confirmDialog.GetTargetID() != inst.ID || h.confirmDialog.GetRestartTool() != want {
+		t.Errorf("confirm for %q/%q, want %q/%q", h.confirmDialog.GetTargetID(), h.confirmDialog.GetRestartTool(), inst.ID, want)
+	}
+	if inst.Tool != "shell" {
+		t.Errorf("tool changed to %q before confirming", inst.Tool)
+	}
+}
+
+func TestRestartToolDialog_SameToolIsRejected(t *testing.T) {
+	h, _ := restartToolHome(t)
+
+	h.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}, Alt: true})
+	h.handleRestartToolDialogKey(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if h.confirmDialog.IsVisible() {
+		t.Error("restarting with the current tool should not ask to confirm")
+	}
+	if h.err == nil {
+		t.Error("expected an error explaining the session already runs that tool")
+	}
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// renderRowWithNotes renders one unselected session row carrying notes.
+func renderRowWithNotes(t *testing.T, width int, notes string) string {
+	t.Helper()
+	forceTrueColorProfile()
+
+	h := &Home{width: width}
+	inst := &session.Instance{ID: "sess-notes", Title: "api", Notes: notes}
+	item := session.Item{
+		Type:          session.ItemTypeSession,
+		Session:       inst,
+		Level:         1,
+		Path:          "test",
+		IsLastInGroup: true,
+	}
+	snapshot := map[string]sessionRenderState{
+		inst.ID: {status: session.StatusIdle, tool: "claude"},
+	}
+
+	var b strings.Builder
+	h.renderSessionItem(&b, item, false, snapshot, h.width)
+	return b.String()
+}
+
+func TestSessionRow_ShowsFirstLineOfNotes(t *testing.T) {
+	row := renderRowWithNotes(t, 140, "\n  debugging the flaky test \nsecond line")
+	if !strings.Contains(row, "debugging the flaky test") {
+		t.Fatalf("row must show the first line of the notes, got %q", row)
+	}
+	if strings.Contains(row, "second line") {
+		t.Fatalf("row must show only one line of the notes, got %q", row)
+	}
+}
+
+func TestSessionRow_TruncatesNotesToWidth(t *testing.T) {
+	row := renderRowWithNotes(t, 60, strings.Repeat("word ", 40))
+	if cellWidth(strings.TrimRight(row, "\n")) > 60 {
+		t.Fatalf("notes overflow the list width: %q", row)
+	}
+	if !strings.Contains(row, "…") {
+		t.Fatalf("long notes must be truncated with an ellipsis, got %q", row)
+	}
+}
+
+func TestSessionNoteSummary(t *testing.T) {
+	for notes, want := range map[string]string{
+		"":                 "",
+		"  \n\t\n":         "",
+		"one":              "one",
+		"\n  two  \nthree": "two",
+	} {
+		if got := sessionNoteSummary(notes); got != want {
+			t.Errorf("sessionNoteSummary(%q) = %q, want %q", notes, got, want)
+		}
+	}
+}
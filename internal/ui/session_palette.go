@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sessionPaletteMaxRows is how many matches the palette shows at once.
+const sessionPaletteMaxRows = 10
+
+// SessionPalette is a command-palette overlay for jumping to a session by
+// fuzzy-matching its title, group, or path. Unlike Search it ranks by match
+// quality and can attach directly; transcript search stays in GlobalSearch.
+type SessionPalette struct {
+	input   textinput.Model
+	items   []*session.Instance
+	results []*session.Instance
+	cursor  int
+	width   int
+	height  int
+	visible bool
+}
+
+// NewSessionPalette creates a new session palette
+func NewSessionPalette() *SessionPalette {
+	ti := textinput.New()
+	ti.Placeholder = "Jump to session (title, group, path)..."
+	ti.CharLimit = 100
+	ti.Width = 50
+	return &SessionPalette{input: ti}
+}
+
+// SetItems sets the sessions to match against
+func (p *SessionPalette) SetItems(items []*session.Instance) {
+	p.items = items
+	p.updateResults()
+}
+
+// SetSize sets the dimensions of the overlay
+func (p *SessionPalette) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// Show opens the palette with an empty query
+func (p *SessionPalette) Show() {
+	p.visible = true
+	p.input.SetValue("")
+	p.input.Focus()
+	p.updateResults()
+}
+
+// Hide closes the palette
+func (p *SessionPalette) Hide() {
+	p.visible = false
+	p.input.Blur()
+}
+
+// IsVisible returns whether the palette is visible. Nil-safe for Homes built
+// field by field in tests.
+func (p *SessionPalette) IsVisible() bool {
+	if p == nil {
+		return false
+	}
+	return p.visible
+}
+
+// Selected returns the highlighted session, or nil when nothing matches
+func (p *SessionPalette) Selected() *session.Instance {
+	if p.cursor < 0 || p.cursor >= len(p.results) {
+		return nil
+	}
+	return p.results[p.cursor]
+}
+
+// paletteMatchText is what the query is matched against for inst.
+func paletteMatchText(inst *session.Instance) string {
+	return inst.Title + " " + inst.GroupPath + " " + inst.ProjectPath
+}
+
+// updateResults re-ranks the sessions for the current query
+func (p *SessionPalette) updateResults() {
+	texts := make([]string, len(p.items))
+	for i, inst := range p.items {
+		texts[i] = paletteMatchText(inst)
+	}
+	p.results = p.results[:0]
+	for _, i := range fuzzyRank(strings.TrimSpace(p.input.Value()), texts) {
+		p.results = append(p.results, p.items[i])
+	}
+	p.cursor = 0
+}
+
+// Update handles navigation and typing. Enter and Alt+Enter are left to the
+// parent, which reads Selected.
+func (p *SessionPalette) Update(msg tea.KeyMsg) (*SessionPalette, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+	switch msg.String() {
+	case "up", "ctrl+k", "ctrl+p":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return p, nil
+	case "down", "ctrl+j", "ctrl+n":
+		if p.cursor < len(p.results)-1 {
+			p.cursor++
+		}
+		return p, nil
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	p.updateResults()
+	return p, cmd
+}
+
+// View renders the palette
+func (p *SessionPalette) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	header := lipgloss.NewStyle().
+		Foreground(ColorAccent).
+		Bold(true).
+		Render("Jump to session")
+
+	dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+
+	// Keep the cursor inside the visible window of rows.
+	start := 0
+	if p.cursor >= sessionPaletteMaxRows {
+		start = p.cursor - sessionPaletteMaxRows + 1
+	}
+	end := min(start+sessionPaletteMaxRows, len(p.results))
+
+	var rows strings.Builder
+	for i := start; i < end; i++ {
+		inst := p.results[i]
+		where := inst.GroupPath
+		if where == "" {
+			where = inst.ProjectPath
+		}
+		if i == p.cursor {
+			rows.WriteString(selectedResultStyle.Render("› " + inst.Title + "  " + where))
+		} else {
+			rows.WriteString(resultItemStyle.Render("  "+inst.Title) + "  " + dimStyle.Render(where))
+		}
+		if i < end-1 {
+			rows.WriteString("\n")
+		}
+	}
+
+	countStr := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render("  " + formatCount(len(p.results)))
+	keysHint := dimStyle.Render("  [Enter] Focus  [Alt+Enter] Attach  [↑↓] Navigate  [Esc] Cancel")
+
+	content := header + "\n\n" + searchBoxStyle.Render(p.input.View()) + "\n\n" +
+		rows.String() + "\n" + countStr + "\n" + keysHint
+
+	overlayWidth := 70
+	if p.width > 0 && p.width < overlayWidth+10 {
+		overlayWidth = max(p.width-10, 30)
+	}
+	return centerInScreen(overlayStyle.Width(overlayWidth).Render(content), p.width, p.height)
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typePalette(p *SessionPalette, text string) {
+	for _, r := range text {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestFuzzyRank_EmptyQueryKeepsOrder(t *testing.T) {
+	got := fuzzyRank("", []string{"b", "a", "c"})
+	if len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("fuzzyRank(\"\") = %v, want [0 1 2]", got)
+	}
+}
+
+func TestFuzzyRank_SubsequenceAndRanking(t *testing.T) {
+	got := fuzzyRank("deck", []string{"dev/eu/clock", "nothing", "agent-deck"})
+	if len(got) != 2 {
+		t.Fatalf("fuzzyRank matched %v, want two candidates", got)
+	}
+	if got[0] != 2 {
+		t.Errorf("best match = %d, want 2 (agent-deck)", got[0])
+	}
+}
+
+func TestSessionPalette_MatchesTitleGroupAndPath(t *testing.T) {
+	p := NewSessionPalette()
+	p.SetItems([]*session.Instance{
+		{Title: "api", GroupPath: "work/backend", ProjectPath: "/src/api"},
+		{Title: "blog", GroupPath: "personal", ProjectPath: "/src/site"},
+		{Title: "notes", GroupPath: "personal", ProjectPath: "/home/me/notes"},
+	})
+	p.Show()
+
+	if len(p.results) != 3 {
+		t.Fatalf("empty query: %d results, want 3", len(p.results))
+	}
+
+	typePalette(p, "backend")
+	if sel := p.Selected(); sel == nil || sel.Title != "api" {
+		t.Errorf("group match selected %v, want api", sel)
+	}
+
+	p.Show()
+	typePalette(p, "site")
+	if sel := p.Selected(); sel == nil || sel.Title != "blog" {
+		t.Errorf("path match selected %v, want blog", sel)
+	}
+
+	p.Show()
+	typePalette(p, "zzz")
+	if sel := p.Selected(); sel != nil {
+		t.Errorf("no match selected %v, want nil", sel)
+	}
+}
+
+func TestSessionPalette_Navigation(t *testing.T) {
+	p := NewSessionPalette()
+	p.SetItems([]*session.Instance{{Title: "one"}, {Title: "two"}})
+	p.Show()
+
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if sel := p.Selected(); sel == nil || sel.Title != "two" {
+		t.Errorf("after down selected %v, want two", sel)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if p.cursor != 1 {
+		t.Errorf("cursor moved past the last result: %d", p.cursor)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if sel := p.Selected(); sel == nil || sel.Title != "one" {
+		t.Errorf("after up selected %v, want one", sel)
+	}
+}
+
+func TestSessionPalette_EnterJumpsToSession(t *testing.T) {
+	h := NewHome()
+	h.width, h.height = 120, 40
+	target := &session.Instance{ID: "s2", Title: "target", GroupPath: "my-sessions"}
+	h.instances = []*session.Instance{
+		{ID: "s1", Title: "other", GroupPath: "my-sessions"},
+		target,
+	}
+	h.groupTree = session.NewGroupTree(h.instances)
+	h.rebuildFlatItems()
+
+	h.handleMainKey(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if !h.sessionPalette.IsVisible() {
+		t.Fatal("ctrl+t did not open the session palette")
+	}
+	typePalette(h.sessionPalette, "target")
+	h.handleSessionPaletteKey(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if h.sessionPalette.IsVisible() {
+		t.Error("palette still visible after enter")
+	}
+	item := h.flatItems[h.cursor]
+	if item.Session == nil || item.Session.ID != "s2" {
+		t.Errorf("cursor on %+v, want session s2", item)
+	}
+}
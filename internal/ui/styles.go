@@ -5,6 +5,8 @@ import (
 	"sync"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
 // Theme represents the current color scheme
@@ -196,7 +198,8 @@ const (
 
 // MaxNameLength is the maximum allowed length for session and group names.
 // Used by dialog CharLimits and Validate() methods to ensure consistency.
-const MaxNameLength = 50
+// It is session.MaxTitleLength so the dialogs and ValidateSpec agree.
+const MaxNameLength = session.MaxTitleLength
 
 // List Item Styles (used by legacy list.go component in tests)
 var (
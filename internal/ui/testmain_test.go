@@ -47,6 +47,11 @@ func runTestMain(m *testing.M) int {
 	// via stubSyncOptOut(t).
 	syncOptOutToConfig = func() {}
 
+	// Most path-suggestion fixtures are made-up paths; keep them all so
+	// SetPathSuggestions only dedupes and cleans. TestNormalizePaths
+	// restores the real check.
+	pathExists = func(string) bool { return true }
+
 	// Run tests
 	code := m.Run()
 
@@ -1,6 +1,11 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
 	"github.com/asheshgoplani/agent-deck/internal/git"
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	"github.com/asheshgoplani/agent-deck/internal/vcs"
@@ -39,5 +44,93 @@ func resolveWorktreeTarget(path, branch string, explicit bool) (worktreePath, re
 		SessionID: git.GeneratePathID(),
 		Template:  wtSettings.Template(),
 	})
+	// An existing worktree for the branch is reused at creation time, so the
+	// computed path only matters when there is none.
+	if existing, err := backend.GetWorktreeForBranch(branch); err != nil || existing == "" {
+		if git.WorktreePathState(worktreePath) == git.WorktreePathForeign {
+			return "", "", false, "Worktree path " + worktreePath + " already exists and is not a worktree"
+		}
+	}
 	return worktreePath, root, false, ""
 }
+
+// worktreeTargetOccupied reports whether a linked worktree already sits at the
+// computed worktreePath while branch has none of its own, typically left
+// behind by an earlier failed run. `git worktree add` would fail on it, so the
+// caller asks whether to reuse or recreate it (ConfirmOccupiedWorktree).
+func worktreeTargetOccupied(worktreePath, repoRoot, branch string) bool {
+	if git.WorktreePathState(worktreePath) != git.WorktreePathWorktree {
+		return false
+	}
+	existing, err := git.GetWorktreeForBranch(repoRoot, branch)
+	return err != nil || existing == ""
+}
+
+// occupiedWorktreeLaunch is a new-session submit suspended by the
+// ConfirmOccupiedWorktree dialog. launch finishes the create for the given
+// branch once the occupied path has been reused or removed.
+type occupiedWorktreeLaunch struct {
+	path     string
+	repoRoot string
+	branch   string
+	launch   func(branch string) tea.Cmd
+}
+
+// occupiedWorktreeRemovedMsg reports the outcome of removeOccupiedWorktree.
+type occupiedWorktreeRemovedMsg struct {
+	err error
+}
+
+// reuse starts the session in the worktree already at
+// p.path. The create step reuses an existing worktree by branch, so the
+// session takes over whatever branch that worktree has checked out.
+func (p *occupiedWorktreeLaunch) reuse() (tea.Cmd, error) {
+	branch, err := git.GetCurrentBranch(p.path)
+	if err != nil || branch == "" || branch == "HEAD" {
+		return nil, fmt.Errorf("cannot reuse %s: no branch checked out", p.path)
+	}
+	return p.launch(branch), nil
+}
+
+// removeOccupiedWorktree removes the worktree at p.path so the suspended
+// create can make a fresh one. Uncommitted work is never discarded: a dirty
+// worktree is refused, and RemoveWorktree runs without force.
+func removeOccupiedWorktree(p *occupiedWorktreeLaunch) tea.Cmd {
+	return func() tea.Msg {
+		dirty, err := git.HasUncommittedChanges(p.path)
+		if err != nil {
+			return occupiedWorktreeRemovedMsg{err: fmt.Errorf("failed to check %s: %w", p.path, err)}
+		}
+		if dirty {
+			return occupiedWorktreeRemovedMsg{err: fmt.Errorf("%s has uncommitted changes; not removing it", p.path)}
+		}
+		if err := git.RemoveWorktree(p.repoRoot, p.path, false); err != nil {
+			return occupiedWorktreeRemovedMsg{err: fmt.Errorf("failed to remove worktree: %w", err)}
+		}
+		return occupiedWorktreeRemovedMsg{}
+	}
+}
+
+// confirmGitInit runs `git init` on the path of a ConfirmGitInit and returns
+// to the new-session dialog, with worktree mode on if it worked. Init is quick
+// and local, so it runs inline like confirmCreateDirectory's MkdirAll.
+func (h *Home) confirmGitInit() {
+	path := h.confirmDialog.GetTargetID()
+	h.confirmDialog.Hide()
+	h.newDialog.Reopen()
+	if err := git.InitRepo(path); err != nil {
+		if errors.Is(err, git.ErrInsideRepo) {
+			h.newDialog.SetError(err.Error())
+		} else {
+			h.newDialog.SetError(fmt.Sprintf("git init failed: %v", err))
+		}
+		return
+	}
+	h.newDialog.EnableWorktreeAfterInit()
+}
+
+// declineGitInit returns to the new-session dialog with worktree mode off.
+func (h *Home) declineGitInit() {
+	h.confirmDialog.Hide()
+	h.newDialog.Reopen()
+}
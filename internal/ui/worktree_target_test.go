@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// occupiedWorktreeFixture creates a repo with a linked worktree on branch
+// "old", standing in for one left behind by an earlier failed run.
+func occupiedWorktreeFixture(t *testing.T) (repo, wt string) {
+	t.Helper()
+	repo = t.TempDir()
+	makeGitRepo(t, repo)
+	wt = filepath.Join(t.TempDir(), "wt")
+	if err := git.CreateWorktree(repo, wt, "old"); err != nil {
+		t.Fatalf("create worktree: %v", err)
+	}
+	return repo, wt
+}
+
+func TestWorktreeTargetOccupied(t *testing.T) {
+	repo, wt := occupiedWorktreeFixture(t)
+
+	if !worktreeTargetOccupied(wt, repo, "new") {
+		t.Errorf("another branch's worktree at the target must count as occupied")
+	}
+	if worktreeTargetOccupied(wt, repo, "old") {
+		t.Errorf("the branch's own worktree is reused, not occupied")
+	}
+	if worktreeTargetOccupied(filepath.Join(t.TempDir(), "missing"), repo, "new") {
+		t.Errorf("a missing path is not occupied")
+	}
+}
+
+func TestOccupiedWorktreeDialog_ReuseLaunchesOnExistingBranch(t *testing.T) {
+	repo, wt := occupiedWorktreeFixture(t)
+
+	h := NewHome()
+	var launched string
+	h.pendingOccupiedWorktree = &occupiedWorktreeLaunch{
+		path: wt, repoRoot: repo, branch: "new",
+		launch: func(branch string) tea.Cmd { launched = branch; return nil },
+	}
+	h.confirmDialog.ShowOccupiedWorktree(wt)
+
+	h.handleConfirmDialogKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+
+	if launched != "old" {
+		t.Fatalf("reuse launched branch %q, want the worktree's branch %q", launched, "old")
+	}
+	if h.confirmDialog.IsVisible() || h.pendingOccupiedWorktree != nil {
+		t.Fatalf("dialog and pending launch must be cleared after reuse")
+	}
+}
+
+func TestOccupiedWorktreeDialog_CancelDropsPendingLaunch(t *testing.T) {
+	h := NewHome()
+	h.pendingOccupiedWorktree = &occupiedWorktreeLaunch{
+		path: "/tmp/wt", branch: "new",
+		launch: func(string) tea.Cmd { t.Fatal("cancel must not launch"); return nil },
+	}
+	h.confirmDialog.ShowOccupiedWorktree("/tmp/wt")
+
+	h.handleConfirmDialogKey(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if h.confirmDialog.IsVisible() || h.pendingOccupiedWorktree != nil {
+		t.Fatalf("cancel must hide the dialog and drop the pending launch")
+	}
+}
+
+func TestRemoveOccupiedWorktree_RefusesDirtyWorktree(t *testing.T) {
+	repo, wt := occupiedWorktreeFixture(t)
+	pending := &occupiedWorktreeLaunch{path: wt, repoRoot: repo, branch: "new"}
+
+	if err := os.WriteFile(filepath.Join(wt, "wip.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	msg := removeOccupiedWorktree(pending)().(occupiedWorktreeRemovedMsg)
+	if msg.err == nil {
+		t.Fatalf("a dirty worktree must not be removed")
+	}
+	if _, err := os.Stat(wt); err != nil {
+		t.Fatalf("dirty worktree was touched: %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(wt, "wip.txt")); err != nil {
+		t.Fatal(err)
+	}
+	msg = removeOccupiedWorktree(pending)().(occupiedWorktreeRemovedMsg)
+	if msg.err != nil {
+		t.Fatalf("clean worktree removal failed: %v", msg.err)
+	}
+	if git.WorktreePathState(wt) != git.WorktreePathEmpty {
+		t.Fatalf("worktree path still occupied after removal")
+	}
+}
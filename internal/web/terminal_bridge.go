@@ -11,6 +11,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
 	"github.com/creack/pty"
 	"github.com/gorilla/websocket"
 )
@@ -244,15 +245,16 @@ func tmuxSessionExists(name, socketName string) (bool, error) {
 // tmuxCommand assembles an `exec.Cmd` for tmux, selecting the server in the
 // following precedence order: (1) explicit socketName from the caller — the
 // session's stored TmuxSocketName captured at creation time, passed through
-// as tmux `-L <name>`; (2) TMUX env var's socket path (legacy web-in-tmux
-// behavior), passed through as `-S <path>`; (3) tmux's default server. The
-// legacy env-based fallback is preserved so running `agent-deck web` inside
-// an existing tmux pane keeps working for users who haven't opted into the
-// new per-session socket config (issue #687 phase 1).
+// as tmux `-L <name>` (or `-S <path>` for a socket path); (2) TMUX env var's
+// socket path (legacy web-in-tmux behavior), passed through as `-S <path>`;
+// (3) tmux's default server. The legacy env-based fallback is preserved so
+// running `agent-deck web` inside an existing tmux pane keeps working for
+// users who haven't opted into the new per-session socket config (issue
+// #687 phase 1).
 func tmuxCommand(socketName string, args ...string) *exec.Cmd {
 	// Explicit per-session socket name wins — this is the v1.7.50 path.
 	if trimmed := strings.TrimSpace(socketName); trimmed != "" {
-		finalArgs := append([]string{"-L", trimmed}, args...)
+		finalArgs := append(tmux.SocketArgs(trimmed), args...)
 		cmd := exec.Command("tmux", finalArgs...)
 		// Unset TMUX so tmux-in-tmux guards don't trip: we are explicitly
 		// directing this to a different server than the one we're in.